@@ -1,6 +1,8 @@
 package authmethods
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/boundary/api"
 )
 
@@ -17,6 +19,7 @@ type options struct {
 	postMap                 map[string]interface{}
 	queryMap                map[string]string
 	withAutomaticVersioning bool
+	withAll                 bool
 }
 
 func getDefaultOptions() options {
@@ -45,6 +48,39 @@ func WithAutomaticVersioning(enable bool) Option {
 	}
 }
 
+// WithFilter restricts the items returned by a List call to those matching the
+// given filter expression, e.g. name contains "prod" and type == "tcp".
+func WithFilter(filter string) Option {
+	return func(o *options) {
+		o.queryMap["filter"] = filter
+	}
+}
+
+// WithPageSize limits the number of items returned by a List call. The next
+// page can be requested by passing the returned NextPageToken to
+// WithPageToken.
+func WithPageSize(pageSize uint32) Option {
+	return func(o *options) {
+		o.queryMap["page_size"] = fmt.Sprintf("%d", pageSize)
+	}
+}
+
+// WithPageToken requests the page of a List call following the one that
+// returned the token.
+func WithPageToken(pageToken string) Option {
+	return func(o *options) {
+		o.queryMap["page_token"] = pageToken
+	}
+}
+
+// WithAll makes a List call request every page in turn and return the items
+// of all of them.
+func WithAll(all bool) Option {
+	return func(o *options) {
+		o.withAll = all
+	}
+}
+
 func WithAttributes(inAttributes map[string]interface{}) Option {
 	return func(o *options) {
 		o.postMap["attributes"] = inAttributes
@@ -128,3 +164,9 @@ func DefaultName() Option {
 		o.postMap["name"] = nil
 	}
 }
+
+func WithRecursive(inRecursive bool) Option {
+	return func(o *options) {
+		o.queryMap["recursive"] = fmt.Sprintf("%v", inRecursive)
+	}
+}
@@ -0,0 +1,141 @@
+package hostcatalogs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type ImportHostsResult struct {
+	Items        []*HostImportResult
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n ImportHostsResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n ImportHostsResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n ImportHostsResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+type ExportHostsResult struct {
+	Items        []*BulkHost
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n ExportHostsResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n ExportHostsResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n ExportHostsResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// ImportHosts creates or updates the given hosts in a static host catalog,
+// matching them with existing hosts by name, and adds them to the listed
+// host sets. All changes are made in a single transaction. If dryRun is
+// true, the changes are returned but not made.
+func (c *Client) ImportHosts(ctx context.Context, hostCatalogId string, hosts []*BulkHost, dryRun bool, opt ...Option) (*ImportHostsResult, error) {
+	if hostCatalogId == "" {
+		return nil, fmt.Errorf("empty hostCatalogId value passed into ImportHosts request")
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("empty hosts passed into ImportHosts request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	opts.postMap["hosts"] = hosts
+	if dryRun {
+		opts.postMap["dry_run"] = true
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("host-catalogs/%s:import-hosts", hostCatalogId), opts.postMap, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ImportHosts request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ImportHosts call: %w", err)
+	}
+
+	target := new(ImportHostsResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ImportHosts response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+// ExportHosts returns every host in a static host catalog along with the
+// IDs of the host sets each host is a member of. The result can be passed
+// to ImportHosts.
+func (c *Client) ExportHosts(ctx context.Context, hostCatalogId string, opt ...Option) (*ExportHostsResult, error) {
+	if hostCatalogId == "" {
+		return nil, fmt.Errorf("empty hostCatalogId value passed into ExportHosts request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("host-catalogs/%s:export-hosts", hostCatalogId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ExportHosts request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ExportHosts call: %w", err)
+	}
+
+	target := new(ExportHostsResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ExportHosts response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
@@ -0,0 +1,10 @@
+// Code generated by "make api"; DO NOT EDIT.
+package hostcatalogs
+
+type BulkHost struct {
+	Id          string   `json:"id,omitempty"`
+	Name        string   `json:"name,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Address     string   `json:"address,omitempty"`
+	HostSetIds  []string `json:"host_set_ids,omitempty"`
+}
@@ -0,0 +1,9 @@
+// Code generated by "make api"; DO NOT EDIT.
+package hostcatalogs
+
+type HostImportResult struct {
+	Action          string    `json:"action,omitempty"`
+	Host            *BulkHost `json:"host,omitempty"`
+	ChangedFields   []string  `json:"changed_fields,omitempty"`
+	AddedHostSetIds []string  `json:"added_host_set_ids,omitempty"`
+}
@@ -2,5 +2,6 @@
 package hosts
 
 type StaticHostAttributes struct {
-	Address string `json:"address,omitempty"`
+	Address string            `json:"address,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
 }
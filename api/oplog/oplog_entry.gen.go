@@ -0,0 +1,37 @@
+// Code generated by "make api"; DO NOT EDIT.
+package oplog
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type OplogEntry struct {
+	Id            uint64                 `json:"id,omitempty"`
+	ScopeId       string                 `json:"scope_id,omitempty"`
+	AggregateName string                 `json:"aggregate_name,omitempty"`
+	Version       string                 `json:"version,omitempty"`
+	CreatedTime   time.Time              `json:"created_time,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	Messages      []*OplogMessage        `json:"messages,omitempty"`
+	DataError     string                 `json:"data_error,omitempty"`
+}
+
+// Client is a client for this collection
+type Client struct {
+	client *api.Client
+}
+
+// Creates a new client for this collection. The submitted API client is cloned;
+// modifications to it after generating this client will not have effect. If you
+// need to make changes to the underlying API client, use ApiClient() to access
+// it.
+func NewClient(c *api.Client) *Client {
+	return &Client{client: c.Clone()}
+}
+
+// ApiClient returns the underlying API client
+func (c *Client) ApiClient() *api.Client {
+	return c.client
+}
@@ -0,0 +1,10 @@
+// Code generated by "make api"; DO NOT EDIT.
+package oplog
+
+type OplogMessage struct {
+	TypeName       string                 `json:"type_name,omitempty"`
+	OpType         string                 `json:"op_type,omitempty"`
+	FieldMaskPaths []string               `json:"field_mask_paths,omitempty"`
+	SetToNullPaths []string               `json:"set_to_null_paths,omitempty"`
+	Value          map[string]interface{} `json:"value,omitempty"`
+}
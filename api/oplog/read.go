@@ -0,0 +1,99 @@
+package oplog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type OplogEntryReadResult struct {
+	Items         []*OplogEntry
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
+}
+
+func (n OplogEntryReadResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n OplogEntryReadResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n OplogEntryReadResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// WithStartTime restricts the entries returned by Read to those written at or
+// after t.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.queryMap["start_time"] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// WithEndTime restricts the entries returned by Read to those written before
+// t.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.queryMap["end_time"] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// Read returns the decrypted oplog entries of the scope with scopeId, oldest
+// first, and of its descendants with WithRecursive. Fields of the entries
+// holding secrets are cleared by the controller. The entries can be filtered
+// with WithAggregateName, WithResourceType, WithResourceId, WithOpType,
+// WithStartTime and WithEndTime, and paged through like lists.
+func (c *Client) Read(ctx context.Context, scopeId string, opt ...Option) (*OplogEntryReadResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into Read request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	// With WithAll, pages are requested until the server returns no further
+	// page token and the entries of all pages are returned together.
+	target := new(OplogEntryReadResult)
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:read-oplog", url.PathEscape(scopeId)), nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating Read request: %w", err)
+		}
+		if len(opts.queryMap) > 0 {
+			q := url.Values{}
+			for k, v := range opts.queryMap {
+				q.Add(k, v)
+			}
+			req.URL.RawQuery = q.Encode()
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during Read call: %w", err)
+		}
+
+		page := new(OplogEntryReadResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding Read response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.NextPageToken = page.NextPageToken
+		target.responseBody = resp.Body
+		target.responseMap = resp.Map
+		if !opts.withAll || page.NextPageToken == "" {
+			return target, nil
+		}
+		opts.queryMap["page_token"] = page.NextPageToken
+	}
+}
@@ -0,0 +1,40 @@
+// Code generated by "make api"; DO NOT EDIT.
+package reports
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+)
+
+type SessionReport struct {
+	PeriodStart            time.Time `json:"period_start,omitempty"`
+	Type                   string    `json:"type,omitempty"`
+	Id                     string    `json:"id,omitempty"`
+	Name                   string    `json:"name,omitempty"`
+	ScopeId                string    `json:"scope_id,omitempty"`
+	SessionCount           uint64    `json:"session_count,omitempty"`
+	ConnectionCount        uint64    `json:"connection_count,omitempty"`
+	TotalDurationSeconds   float64   `json:"total_duration_seconds,omitempty"`
+	AverageDurationSeconds float64   `json:"average_duration_seconds,omitempty"`
+	BytesUp                uint64    `json:"bytes_up,omitempty"`
+	BytesDown              uint64    `json:"bytes_down,omitempty"`
+}
+
+// Client is a client for this collection
+type Client struct {
+	client *api.Client
+}
+
+// Creates a new client for this collection. The submitted API client is cloned;
+// modifications to it after generating this client will not have effect. If you
+// need to make changes to the underlying API client, use ApiClient() to access
+// it.
+func NewClient(c *api.Client) *Client {
+	return &Client{client: c.Clone()}
+}
+
+// ApiClient returns the underlying API client
+func (c *Client) ApiClient() *api.Client {
+	return c.client
+}
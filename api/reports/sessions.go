@@ -0,0 +1,90 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type SessionReportResult struct {
+	Items        []*SessionReport
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n SessionReportResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n SessionReportResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n SessionReportResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// WithStartTime restricts the sessions reported by Sessions to those started
+// at or after t.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.queryMap["start_time"] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// WithEndTime restricts the sessions reported by Sessions to those started
+// before t.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.queryMap["end_time"] = t.UTC().Format(time.RFC3339Nano)
+	}
+}
+
+// Sessions returns the number of sessions, connections, session durations and
+// bytes transferred of the targets of the project with scopeId, per day in
+// which the sessions were started. With WithRecursive, the sessions of the
+// projects below scopeId which the caller can read are reported. The sessions
+// can be reported per "user", "target" or "host" with WithGroupBy, per "day",
+// "week" or "month" with WithPeriod, and restricted to a time range with
+// WithStartTime and WithEndTime.
+func (c *Client) Sessions(ctx context.Context, scopeId string, opt ...Option) (*SessionReportResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into Sessions request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:report-sessions", url.PathEscape(scopeId)), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Sessions request: %w", err)
+	}
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Sessions call: %w", err)
+	}
+
+	target := new(SessionReportResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Sessions response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
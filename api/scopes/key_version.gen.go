@@ -0,0 +1,15 @@
+// Code generated by "make api"; DO NOT EDIT.
+package scopes
+
+import (
+	"time"
+)
+
+type KeyVersion struct {
+	Id             string    `json:"id,omitempty"`
+	Purpose        string    `json:"purpose,omitempty"`
+	Version        uint32    `json:"version,omitempty"`
+	CreatedTime    time.Time `json:"created_time,omitempty"`
+	EncryptedCount uint64    `json:"encrypted_count,omitempty"`
+	IssuedCount    uint64    `json:"issued_count,omitempty"`
+}
@@ -0,0 +1,247 @@
+package scopes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type RewrapJobReadResult struct {
+	Item         *RewrapJob
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n RewrapJobReadResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n RewrapJobReadResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n RewrapJobReadResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// RotateKeys creates new versions of the keys of a scope, which encrypt
+// everything written from then on, and queues a job re-encrypting the
+// existing data of the scope with them. The returned job reports its
+// progress.
+func (c *Client) RotateKeys(ctx context.Context, scopeId string, opt ...Option) (*RewrapJobReadResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into RotateKeys request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:rotate-keys", scopeId), map[string]interface{}{}, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating RotateKeys request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during RotateKeys call: %w", err)
+	}
+
+	target := new(RewrapJobReadResult)
+	target.Item = new(RewrapJob)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding RotateKeys response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+// ReadRewrapJob returns the job re-encrypting the data of a scope after its
+// keys were last rotated. It returns a not found error if the keys of the
+// scope have never been rotated.
+func (c *Client) ReadRewrapJob(ctx context.Context, scopeId string, opt ...Option) (*RewrapJobReadResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ReadRewrapJob request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:rewrap-status", scopeId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ReadRewrapJob request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ReadRewrapJob call: %w", err)
+	}
+
+	target := new(RewrapJobReadResult)
+	target.Item = new(RewrapJob)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ReadRewrapJob response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+type KeyVersionListResult struct {
+	Items        []*KeyVersion
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n KeyVersionListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n KeyVersionListResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n KeyVersionListResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+type KeyVersionDestroyResult struct {
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n KeyVersionDestroyResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n KeyVersionDestroyResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// ListKeyVersions returns the versions of the keys of a scope, newest first,
+// with the number of values which still depend on each of them. If purpose is
+// not empty only the versions of the key for that purpose ("database",
+// "oplog", "tokens" or "sessions") are returned.
+func (c *Client) ListKeyVersions(ctx context.Context, scopeId string, purpose string, opt ...Option) (*KeyVersionListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into ListKeyVersions request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("scopes/%s:list-key-versions", scopeId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListKeyVersions request: %w", err)
+	}
+
+	if purpose != "" {
+		opts.queryMap["purpose"] = purpose
+	}
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListKeyVersions call: %w", err)
+	}
+
+	target := new(KeyVersionListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListKeyVersions response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+// DestroyKeyVersion destroys a version of a key of a scope. The controller
+// refuses to destroy the newest version of a key, or a version which values
+// still depend on.
+func (c *Client) DestroyKeyVersion(ctx context.Context, scopeId string, keyVersionId string, opt ...Option) (*KeyVersionDestroyResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into DestroyKeyVersion request")
+	}
+	if keyVersionId == "" {
+		return nil, fmt.Errorf("empty keyVersionId value passed into DestroyKeyVersion request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	reqBody := map[string]interface{}{
+		"key_version_id": keyVersionId,
+	}
+
+	req, err := c.client.NewRequest(ctx, "POST", fmt.Sprintf("scopes/%s:destroy-key-version", scopeId), reqBody, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating DestroyKeyVersion request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during DestroyKeyVersion call: %w", err)
+	}
+
+	apiErr, err := resp.Decode(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding DestroyKeyVersion response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	return &KeyVersionDestroyResult{
+		responseBody: resp.Body,
+		responseMap:  resp.Map,
+	}, nil
+}
@@ -0,0 +1,17 @@
+// Code generated by "make api"; DO NOT EDIT.
+package scopes
+
+import (
+	"time"
+)
+
+type RewrapJob struct {
+	ScopeId        string    `json:"scope_id,omitempty"`
+	State          string    `json:"state,omitempty"`
+	RewrappedCount uint64    `json:"rewrapped_count,omitempty"`
+	RemainingCount uint64    `json:"remaining_count,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	RotatedTime    time.Time `json:"rotated_time,omitempty"`
+	UpdatedTime    time.Time `json:"updated_time,omitempty"`
+	EndedTime      time.Time `json:"ended_time,omitempty"`
+}
@@ -0,0 +1,177 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WatchEvent is a change to a resource, or a state transition of a session or
+// session connection, sent on a watch stream.
+type WatchEvent struct {
+	// Type is the resource type, such as "target" or "session", or
+	// "connection" for session connections.
+	Type string `json:"type,omitempty"`
+	// Op is "create", "update" or "delete". Session and connection state
+	// transitions are updates, except for their initial state.
+	Op        string    `json:"op,omitempty"`
+	Id        string    `json:"id,omitempty"`
+	ScopeId   string    `json:"scope_id,omitempty"`
+	SessionId string    `json:"session_id,omitempty"`
+	UserId    string    `json:"user_id,omitempty"`
+	TargetId  string    `json:"target_id,omitempty"`
+	State     string    `json:"state,omitempty"`
+	Time      time.Time `json:"time,omitempty"`
+}
+
+// WatchOption is an option for Watch.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	withScopeId   string
+	withRecursive bool
+	withTypes     []string
+}
+
+// WithWatchScopeId sets the scope to watch. Defaults to the global scope.
+func WithWatchScopeId(id string) WatchOption {
+	return func(o *watchOptions) {
+		o.withScopeId = id
+	}
+}
+
+// WithWatchRecursive includes changes in the scopes below the watched scope.
+func WithWatchRecursive(recurse bool) WatchOption {
+	return func(o *watchOptions) {
+		o.withRecursive = recurse
+	}
+}
+
+// WithWatchTypes restricts the events to the given types.
+func WithWatchTypes(types ...string) WatchOption {
+	return func(o *watchOptions) {
+		o.withTypes = types
+	}
+}
+
+// Watch streams changes to resources the token of the client can read, and
+// session and connection state transitions, calling fn with each event until
+// ctx is done or fn returns an error, which is returned. Events are only sent
+// for changes made after the stream is opened.
+//
+// The controller closes the stream with an error if the events may be
+// incomplete, such as when the stream isn't read fast enough; callers which
+// need a consistent view should list the resources again and reopen the
+// stream.
+func (c *Client) Watch(ctx context.Context, fn func(*WatchEvent) error, opt ...WatchOption) error {
+	if c == nil {
+		return fmt.Errorf("client is nil")
+	}
+	if fn == nil {
+		return fmt.Errorf("nil function passed into Watch request")
+	}
+	opts := watchOptions{}
+	for _, o := range opt {
+		o(&opts)
+	}
+
+	// Streams are open indefinitely
+	client := c.Clone()
+	client.SetClientTimeout(0)
+
+	req, err := client.NewRequest(ctx, "GET", "watch", nil)
+	if err != nil {
+		return fmt.Errorf("error creating Watch request: %w", err)
+	}
+	q := url.Values{}
+	if opts.withScopeId != "" {
+		q.Set("scope_id", opts.withScopeId)
+	}
+	if opts.withRecursive {
+		q.Set("recursive", strconv.FormatBool(opts.withRecursive))
+	}
+	if len(opts.withTypes) > 0 {
+		q.Set("types", strings.Join(opts.withTypes, ","))
+	}
+	req.URL.RawQuery = q.Encode()
+	req.Header.Set("accept", "text/event-stream")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error performing client request during Watch call: %w", err)
+	}
+	if resp.HttpResponse().StatusCode != http.StatusOK {
+		apiErr, err := resp.Decode(nil)
+		if err != nil {
+			return fmt.Errorf("error decoding Watch response: %w", err)
+		}
+		if apiErr != nil {
+			return apiErr
+		}
+		return fmt.Errorf("unexpected status %d from Watch request", resp.HttpResponse().StatusCode)
+	}
+	body := resp.HttpResponse().Body
+	defer body.Close()
+
+	err = readWatchEvents(body, fn)
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+// readWatchEvents reads server-sent events from r, calling fn with each
+// watch event. An error event ends the stream with its message.
+func readWatchEvents(r io.Reader, fn func(*WatchEvent) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var name string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if len(data) == 0 {
+				name = ""
+				continue
+			}
+			payload := strings.Join(data, "\n")
+			data = data[:0]
+			if name == "error" {
+				var e struct {
+					Message string `json:"message"`
+				}
+				if err := json.Unmarshal([]byte(payload), &e); err != nil {
+					return fmt.Errorf("error decoding watch stream error: %w", err)
+				}
+				return fmt.Errorf("watch stream closed by controller: %s", e.Message)
+			}
+			name = ""
+			e := new(WatchEvent)
+			if err := json.Unmarshal([]byte(payload), e); err != nil {
+				return fmt.Errorf("error decoding watch event: %w", err)
+			}
+			if err := fn(e); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, ":"):
+			// Comment, such as a keepalive
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading watch stream: %w", err)
+	}
+	return errors.New("watch stream closed by controller")
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadWatchEvents(t *testing.T) {
+	stream := strings.Join([]string{
+		": keepalive",
+		"",
+		"event: session",
+		`data: {"type":"session","op":"create","id":"s_1234567890","scope_id":"p_1234567890","state":"pending","time":"2020-11-02T10:00:00Z"}`,
+		"",
+		"event: target",
+		`data: {"type":"target","op":"delete","id":"ttcp_1234567890","scope_id":"p_1234567890","time":"2020-11-02T10:00:01Z"}`,
+		"",
+		"event: error",
+		`data: {"message":"stream is not being read fast enough"}`,
+		"",
+		"",
+	}, "\n")
+
+	var got []*WatchEvent
+	err := readWatchEvents(strings.NewReader(stream), func(e *WatchEvent) error {
+		got = append(got, e)
+		return nil
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stream is not being read fast enough")
+	require.Len(t, got, 2)
+	assert.Equal(t, "session", got[0].Type)
+	assert.Equal(t, "create", got[0].Op)
+	assert.Equal(t, "s_1234567890", got[0].Id)
+	assert.Equal(t, "pending", got[0].State)
+	assert.Equal(t, "target", got[1].Type)
+	assert.Equal(t, "delete", got[1].Op)
+
+	// Errors from the callback end the stream
+	stop := errors.New("stop")
+	err = readWatchEvents(strings.NewReader(stream), func(*WatchEvent) error {
+		return stop
+	})
+	assert.Equal(t, stop, err)
+
+	// The stream ending without an error event is an error too
+	err = readWatchEvents(strings.NewReader(": keepalive\n\n"), func(*WatchEvent) error {
+		return nil
+	})
+	assert.EqualError(t, err, "watch stream closed by controller")
+}
@@ -0,0 +1,73 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+)
+
+type DeliveryListResult struct {
+	Items        []*Delivery
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n DeliveryListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n DeliveryListResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n DeliveryListResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// ListDeliveries returns the most recent deliveries of a webhook, newest
+// first. If state is not empty only deliveries in that state ("pending",
+// "succeeded" or "failed") are returned.
+func (c *Client) ListDeliveries(ctx context.Context, webhookId string, state string, opt ...Option) (*DeliveryListResult, error) {
+	if webhookId == "" {
+		return nil, fmt.Errorf("empty webhookId value passed into ListDeliveries request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("webhooks/%s:list-deliveries", webhookId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ListDeliveries request: %w", err)
+	}
+
+	if state != "" {
+		opts.queryMap["state"] = state
+	}
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during ListDeliveries call: %w", err)
+	}
+
+	target := new(DeliveryListResult)
+	apiErr, err := resp.Decode(target)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ListDeliveries response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
@@ -0,0 +1,20 @@
+// Code generated by "make api"; DO NOT EDIT.
+package webhooks
+
+import (
+	"time"
+)
+
+type Delivery struct {
+	Id              string    `json:"id,omitempty"`
+	WebhookId       string    `json:"webhook_id,omitempty"`
+	EventType       string    `json:"event_type,omitempty"`
+	Payload         string    `json:"payload,omitempty"`
+	State           string    `json:"state,omitempty"`
+	Attempts        uint32    `json:"attempts,omitempty"`
+	NextAttemptTime time.Time `json:"next_attempt_time,omitempty"`
+	LastAttemptTime time.Time `json:"last_attempt_time,omitempty"`
+	LastStatusCode  int32     `json:"last_status_code,omitempty"`
+	LastError       string    `json:"last_error,omitempty"`
+	CreatedTime     time.Time `json:"created_time,omitempty"`
+}
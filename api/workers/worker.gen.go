@@ -0,0 +1,200 @@
+// Code generated by "make api"; DO NOT EDIT.
+package workers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/scopes"
+)
+
+type Worker struct {
+	Id             string            `json:"id,omitempty"`
+	Scope          *scopes.ScopeInfo `json:"scope,omitempty"`
+	Name           string            `json:"name,omitempty"`
+	Description    string            `json:"description,omitempty"`
+	Address        string            `json:"address,omitempty"`
+	CreatedTime    time.Time         `json:"created_time,omitempty"`
+	UpdatedTime    time.Time         `json:"updated_time,omitempty"`
+	Draining       bool              `json:"draining,omitempty"`
+	DrainRequested bool              `json:"drain_requested,omitempty"`
+
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n Worker) ResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n Worker) ResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+type WorkerReadResult struct {
+	Item         *Worker
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n WorkerReadResult) GetItem() interface{} {
+	return n.Item
+}
+
+func (n WorkerReadResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n WorkerReadResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+type WorkerCreateResult = WorkerReadResult
+type WorkerUpdateResult = WorkerReadResult
+
+type WorkerDeleteResult struct {
+	responseBody *bytes.Buffer
+	responseMap  map[string]interface{}
+}
+
+func (n WorkerDeleteResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n WorkerDeleteResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+type WorkerListResult struct {
+	Items         []*Worker
+	NextPageToken string `json:"next_page_token,omitempty"`
+	responseBody  *bytes.Buffer
+	responseMap   map[string]interface{}
+}
+
+func (n WorkerListResult) GetItems() interface{} {
+	return n.Items
+}
+
+func (n WorkerListResult) GetResponseBody() *bytes.Buffer {
+	return n.responseBody
+}
+
+func (n WorkerListResult) GetResponseMap() map[string]interface{} {
+	return n.responseMap
+}
+
+// Client is a client for this collection
+type Client struct {
+	client *api.Client
+}
+
+// Creates a new client for this collection. The submitted API client is cloned;
+// modifications to it after generating this client will not have effect. If you
+// need to make changes to the underlying API client, use ApiClient() to access
+// it.
+func NewClient(c *api.Client) *Client {
+	return &Client{client: c.Clone()}
+}
+
+// ApiClient returns the underlying API client
+func (c *Client) ApiClient() *api.Client {
+	return c.client
+}
+
+func (c *Client) Read(ctx context.Context, workerId string, opt ...Option) (*WorkerReadResult, error) {
+	if workerId == "" {
+		return nil, fmt.Errorf("empty workerId value passed into Read request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+
+	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("workers/%s", workerId), nil, apiOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Read request: %w", err)
+	}
+
+	if len(opts.queryMap) > 0 {
+		q := url.Values{}
+		for k, v := range opts.queryMap {
+			q.Add(k, v)
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error performing client request during Read call: %w", err)
+	}
+
+	target := new(WorkerReadResult)
+	target.Item = new(Worker)
+	apiErr, err := resp.Decode(target.Item)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding Read response: %w", err)
+	}
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	target.responseBody = resp.Body
+	target.responseMap = resp.Map
+	return target, nil
+}
+
+func (c *Client) List(ctx context.Context, scopeId string, opt ...Option) (*WorkerListResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("empty scopeId value passed into List request")
+	}
+	if c.client == nil {
+		return nil, fmt.Errorf("nil client")
+	}
+
+	opts, apiOpts := getOpts(opt...)
+	opts.queryMap["scope_id"] = scopeId
+
+	// With WithAll, pages are requested until the server returns no further
+	// page token and the items of all pages are returned together.
+	target := new(WorkerListResult)
+	for {
+		req, err := c.client.NewRequest(ctx, "GET", "workers", nil, apiOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("error creating List request: %w", err)
+		}
+		if len(opts.queryMap) > 0 {
+			q := url.Values{}
+			for k, v := range opts.queryMap {
+				q.Add(k, v)
+			}
+			req.URL.RawQuery = q.Encode()
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error performing client request during List call: %w", err)
+		}
+
+		page := new(WorkerListResult)
+		apiErr, err := resp.Decode(page)
+		if err != nil {
+			return nil, fmt.Errorf("error decoding List response: %w", err)
+		}
+		if apiErr != nil {
+			return nil, apiErr
+		}
+		target.Items = append(target.Items, page.Items...)
+		target.NextPageToken = page.NextPageToken
+		target.responseBody = resp.Body
+		target.responseMap = resp.Map
+		if !opts.withAll || page.NextPageToken == "" {
+			return target, nil
+		}
+		opts.queryMap["page_token"] = page.NextPageToken
+	}
+}
@@ -11,11 +11,15 @@ import (
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hosts"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostsets"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/oplog"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/reports"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/roles"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/sessions"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/users"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/webhooks"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/workers"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -35,6 +39,28 @@ type fieldInfo struct {
 	SkipDefault       bool
 }
 
+// recursiveOption is the extra option of collections that can be listed
+// recursively across scopes
+var recursiveOption = fieldInfo{
+	Name:        "Recursive",
+	ProtoName:   "recursive",
+	FieldType:   "bool",
+	Query:       true,
+	SkipDefault: true,
+}
+
+// oplogFilterOption returns the option filtering the oplog entries read from a
+// scope by the given query parameter.
+func oplogFilterOption(name, protoName string) fieldInfo {
+	return fieldInfo{
+		Name:        name,
+		ProtoName:   protoName,
+		FieldType:   "string",
+		Query:       true,
+		SkipDefault: true,
+	}
+}
+
 type structInfo struct {
 	inProto            proto.Message
 	outFile            string
@@ -138,10 +164,66 @@ var inputStructs = []*structInfo{
 				FieldType: "bool",
 				Query:     true,
 			},
+			recursiveOption,
 		},
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
+	{
+		inProto:    &scopes.RewrapJob{},
+		outFile:    "scopes/rewrap_job.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &scopes.KeyVersion{},
+		outFile:    "scopes/key_version.gen.go",
+		outputOnly: true,
+	},
+	// Oplog related resources
+	{
+		inProto: &oplog.OplogEntry{},
+		outFile: "oplog/oplog_entry.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+		},
+		extraOptions: []fieldInfo{
+			recursiveOption,
+			oplogFilterOption("AggregateName", "aggregate_name"),
+			oplogFilterOption("ResourceType", "resource_type"),
+			oplogFilterOption("ResourceId", "resource_id"),
+			oplogFilterOption("OpType", "op_type"),
+		},
+	},
+	{
+		inProto:    &oplog.OplogMessage{},
+		outFile:    "oplog/oplog_message.gen.go",
+		outputOnly: true,
+	},
+	// Report related resources
+	{
+		inProto: &reports.SessionReport{},
+		outFile: "reports/session_report.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+		},
+		extraOptions: []fieldInfo{
+			recursiveOption,
+			{
+				Name:        "GroupBy",
+				ProtoName:   "group_by",
+				FieldType:   "string",
+				Query:       true,
+				SkipDefault: true,
+			},
+			{
+				Name:        "Period",
+				ProtoName:   "period",
+				FieldType:   "string",
+				Query:       true,
+				SkipDefault: true,
+			},
+		},
+	},
 	// User related resources
 	{
 		inProto:    &users.Account{},
@@ -165,6 +247,7 @@ var inputStructs = []*structInfo{
 		pathArgs:            []string{"user"},
 		versionEnabled:      true,
 		createResponseTypes: true,
+		extraOptions:        []fieldInfo{recursiveOption},
 	},
 	// Group related resources
 	{
@@ -189,6 +272,7 @@ var inputStructs = []*structInfo{
 		pathArgs:            []string{"group"},
 		versionEnabled:      true,
 		createResponseTypes: true,
+		extraOptions:        []fieldInfo{recursiveOption},
 	},
 	// Role related resources
 	{
@@ -224,6 +308,7 @@ var inputStructs = []*structInfo{
 		pathArgs:            []string{"role"},
 		versionEnabled:      true,
 		createResponseTypes: true,
+		extraOptions:        []fieldInfo{recursiveOption},
 	},
 	// Auth Methods related resources
 	{
@@ -241,6 +326,7 @@ var inputStructs = []*structInfo{
 		typeOnCreate:        true,
 		versionEnabled:      true,
 		createResponseTypes: true,
+		extraOptions:        []fieldInfo{recursiveOption},
 	},
 	{
 		inProto:     &authmethods.PasswordAuthMethodAttributes{},
@@ -281,6 +367,7 @@ var inputStructs = []*structInfo{
 		},
 		pathArgs:            []string{"auth-token"},
 		createResponseTypes: true,
+		extraOptions:        []fieldInfo{recursiveOption},
 	},
 	// Host related resources
 	{
@@ -298,6 +385,17 @@ var inputStructs = []*structInfo{
 		typeOnCreate:        true,
 		versionEnabled:      true,
 		createResponseTypes: true,
+		extraOptions:        []fieldInfo{recursiveOption},
+	},
+	{
+		inProto:    &hostcatalogs.BulkHost{},
+		outFile:    "hostcatalogs/bulk_host.gen.go",
+		outputOnly: true,
+	},
+	{
+		inProto:    &hostcatalogs.HostImportResult{},
+		outFile:    "hostcatalogs/host_import_result.gen.go",
+		outputOnly: true,
 	},
 	{
 		inProto: &hosts.Host{},
@@ -339,6 +437,11 @@ var inputStructs = []*structInfo{
 		versionEnabled:      true,
 		createResponseTypes: true,
 	},
+	{
+		inProto:     &hostsets.StaticHostSetAttributes{},
+		outFile:     "hostsets/static_host_set_attributes.gen.go",
+		subtypeName: "StaticHostSet",
+	},
 	{
 		inProto: &targets.HostSet{},
 		outFile: "targets/host_set.gen.go",
@@ -377,6 +480,7 @@ var inputStructs = []*structInfo{
 				FieldType:   "string",
 				SkipDefault: true,
 			},
+			recursiveOption,
 		},
 		versionEnabled:      true,
 		typeOnCreate:        true,
@@ -398,6 +502,7 @@ var inputStructs = []*structInfo{
 		pathArgs:            []string{"session"},
 		createResponseTypes: true,
 		fieldFilter:         []string{"private_key"},
+		extraOptions:        []fieldInfo{recursiveOption},
 	},
 	{
 		inProto: &sessions.SessionState{},
@@ -417,4 +522,36 @@ var inputStructs = []*structInfo{
 		outFile:     "targets/worker_info.gen.go",
 		subtypeName: "WorkerInfo",
 	},
+	{
+		inProto: &workers.Worker{},
+		outFile: "workers/worker.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			readTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"worker"},
+		createResponseTypes: true,
+	},
+	{
+		inProto: &webhooks.Webhook{},
+		outFile: "webhooks/webhook.gen.go",
+		templates: []*template.Template{
+			clientTemplate,
+			createTemplate,
+			readTemplate,
+			updateTemplate,
+			deleteTemplate,
+			listTemplate,
+		},
+		pathArgs:            []string{"webhook"},
+		versionEnabled:      true,
+		createResponseTypes: true,
+		extraOptions:        []fieldInfo{recursiveOption},
+	},
+	{
+		inProto:    &webhooks.Delivery{},
+		outFile:    "webhooks/delivery.gen.go",
+		outputOnly: true,
+	},
 }
@@ -59,15 +59,19 @@ func parsePBs() {
 			if proto.GetExtension(opts, protooptions.E_GenerateSdkOption).(bool) {
 				fi.GenerateSdkOption = true
 			}
-			switch k := fd.Kind(); k {
-			case protoreflect.MessageKind:
+			switch k := fd.Kind(); {
+			case fd.IsMap():
+				fi.FieldType = fmt.Sprintf("map[%s]%s", fd.MapKey().Kind(), fd.MapValue().Kind())
+			case k == protoreflect.MessageKind:
 				ptr, pkg, name := messageKind(fd)
 				if pkg != "" && pkg != in.generatedStructure.pkg {
 					name = fmt.Sprintf("%s.%s", pkg, name)
 				}
 				fi.FieldType = sliceText + ptr + name
-			case protoreflect.BytesKind:
+			case k == protoreflect.BytesKind:
 				fi.FieldType = "[]byte"
+			case k == protoreflect.DoubleKind:
+				fi.FieldType = sliceText + "float64"
 			default:
 				fi.FieldType = sliceText + k.String()
 			}
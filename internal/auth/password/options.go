@@ -22,6 +22,7 @@ type options struct {
 	withPublicId    string
 	password        string
 	withPassword    bool
+	withAfterId     string
 }
 
 func getDefaultOptions() options {
@@ -81,3 +82,12 @@ func WithConfiguration(config Configuration) Option {
 		o.withConfig = config
 	}
 }
+
+// WithAfterId provides an option to list only the items whose id sorts after
+// id. Lists are ordered by id so it can be used to list items a page at a
+// time.
+func WithAfterId(id string) Option {
+	return func(o *options) {
+		o.withAfterId = id
+	}
+}
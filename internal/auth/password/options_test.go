@@ -57,4 +57,10 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withConfig = c
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithAfterId", func(t *testing.T) {
+		opts := getOpts(WithAfterId("apw_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withAfterId = "apw_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
 }
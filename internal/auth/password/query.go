@@ -41,4 +41,12 @@ select *
         where public_id = $1
     );
 `
+
+	rewrapArgon2CredentialQuery = `
+update auth_password_argon2_cred
+   set salt = $1,
+       key_id = $2
+ where private_id = $3
+   and key_id = $4;
+`
 )
@@ -143,7 +143,11 @@ func (r *Repository) ListAccounts(ctx context.Context, withAuthMethodId string,
 		limit = opts.withLimit
 	}
 	var accts []*Account
-	err := r.reader.SearchWhere(ctx, &accts, "auth_method_id = ?", []interface{}{withAuthMethodId}, db.WithLimit(limit))
+	where, args := "auth_method_id = ?", []interface{}{withAuthMethodId}
+	if opts.withAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withAfterId)
+	}
+	err := r.reader.SearchWhere(ctx, &accts, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
 	if err != nil {
 		return nil, fmt.Errorf("list: password account: %w", err)
 	}
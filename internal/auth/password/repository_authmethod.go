@@ -124,7 +124,11 @@ func (r *Repository) ListAuthMethods(ctx context.Context, scopeId string, opt ..
 		limit = opts.withLimit
 	}
 	var authMethods []*AuthMethod
-	err := r.reader.SearchWhere(ctx, &authMethods, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(limit))
+	where, args := "scope_id = ?", []interface{}{scopeId}
+	if opts.withAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withAfterId)
+	}
+	err := r.reader.SearchWhere(ctx, &authMethods, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
 	if err != nil {
 		return nil, fmt.Errorf("list: password auth method: %w", err)
 	}
@@ -0,0 +1,38 @@
+package password
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn("auth_password_argon2_cred", kms.KeyPurposeDatabase, argon2CredentialRewrapFn)
+}
+
+// argon2CredentialRewrapFn re-encrypts the salts of argon2 credentials.
+func argon2CredentialRewrapFn(ctx context.Context, keyVersionIds []string, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, limit int) (int, error) {
+	var creds []*Argon2Credential
+	if err := r.SearchWhere(ctx, &creds, "key_id in (?)", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap argon2 credentials: %w", err)
+	}
+	var updated int
+	for _, c := range creds {
+		oldKeyId := c.KeyId
+		if err := c.decrypt(ctx, wrapper); err != nil {
+			return updated, fmt.Errorf("rewrap argon2 credentials: %s: %w", c.PrivateId, err)
+		}
+		if err := c.encrypt(ctx, wrapper); err != nil {
+			return updated, fmt.Errorf("rewrap argon2 credentials: %s: %w", c.PrivateId, err)
+		}
+		rowsUpdated, err := w.Exec(ctx, rewrapArgon2CredentialQuery, []interface{}{c.CtSalt, c.KeyId, c.PrivateId, oldKeyId})
+		if err != nil {
+			return updated, fmt.Errorf("rewrap argon2 credentials: unable to update %s: %w", c.PrivateId, err)
+		}
+		updated += rowsUpdated
+	}
+	return updated, nil
+}
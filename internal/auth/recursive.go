@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// Allowed reports whether the caller verified in r is allowed to perform act
+// on res according to the ACL computed by Verify, regardless of the scope the
+// request was verified in. It is false if r was not returned by Verify.
+func (r *VerifyResults) Allowed(res perms.Resource, act action.Type) bool {
+	v := r.v
+	if v == nil {
+		return false
+	}
+	switch {
+	case v.requestInfo.DisableAuthEntirely,
+		v.requestInfo.DisableAuthzFailures,
+		v.requestInfo.TokenFormat == AuthTokenTypeRecoveryKms:
+		return true
+	}
+	return v.acl.Allowed(res, act).Allowed
+}
+
+// RecursiveScopes returns the scopes a recursive request rooted at the scope
+// verified in r should cover: the root scope and its descendants, restricted to
+// scopes of the given types in which the caller is allowed to perform act on
+// resources of type typ. Each scope is checked once against the ACL computed
+// by Verify; the root scope is included only if r has no error. If the caller
+// is allowed in none of the scopes, the error of r is returned, or a forbidden
+// error if r has none. Errors of r other than authorization failures are
+// returned as is.
+func (r *VerifyResults) RecursiveScopes(ctx context.Context, typ resource.Type, act action.Type, scopeTypes ...scope.Type) ([]*scopes.ScopeInfo, error) {
+	// Only authorization failures in the root scope allow continuing into
+	// its descendants
+	switch {
+	case r.Error == nil,
+		errors.Is(r.Error, handlers.ForbiddenError()),
+		errors.Is(r.Error, handlers.UnauthenticatedError()):
+	default:
+		return nil, r.Error
+	}
+	v := r.v
+	if v == nil || r.Scope == nil {
+		return nil, fmt.Errorf("recursive scopes: results were not returned by verify")
+	}
+	wantType := func(t string) bool {
+		for _, st := range scopeTypes {
+			if st.String() == t {
+				return true
+			}
+		}
+		return false
+	}
+	allowed := func(scopeId string) bool {
+		return r.Allowed(perms.Resource{ScopeId: scopeId, Type: typ}, act)
+	}
+
+	var ret []*scopes.ScopeInfo
+	if r.Error == nil && wantType(r.Scope.GetType()) {
+		ret = append(ret, r.Scope)
+	}
+
+	iamRepo, err := v.iamRepoFn()
+	if err != nil {
+		return nil, fmt.Errorf("recursive scopes: %w", err)
+	}
+	var orgIds []string
+	switch r.Scope.GetType() {
+	case scope.Global.String():
+		orgs, err := iamRepo.ListOrgs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("recursive scopes: %w", err)
+		}
+		for _, org := range orgs {
+			if wantType(scope.Org.String()) && allowed(org.GetPublicId()) {
+				ret = append(ret, toScopeInfo(org))
+			}
+			orgIds = append(orgIds, org.GetPublicId())
+		}
+	case scope.Org.String():
+		orgIds = []string{r.Scope.GetId()}
+	}
+	if wantType(scope.Project.String()) {
+		for _, orgId := range orgIds {
+			projects, err := iamRepo.ListProjects(ctx, orgId)
+			if err != nil {
+				return nil, fmt.Errorf("recursive scopes: %w", err)
+			}
+			for _, proj := range projects {
+				if allowed(proj.GetPublicId()) {
+					ret = append(ret, toScopeInfo(proj))
+				}
+			}
+		}
+	}
+
+	if len(ret) == 0 {
+		if r.Error != nil {
+			return nil, r.Error
+		}
+		return nil, handlers.ForbiddenError()
+	}
+	return ret, nil
+}
+
+func toScopeInfo(s *iam.Scope) *scopes.ScopeInfo {
+	return &scopes.ScopeInfo{
+		Id:            s.GetPublicId(),
+		Type:          s.GetType(),
+		Name:          s.GetName(),
+		Description:   s.GetDescription(),
+		ParentScopeId: s.GetParentId(),
+	}
+}
@@ -0,0 +1,85 @@
+package auth_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecursiveScopes(t *testing.T) {
+	tc := controller.NewTestController(t, nil)
+	defer tc.Shutdown()
+
+	conn := tc.DbConn()
+	token := tc.Token()
+	opts := []iam.Option{iam.WithUserId(token.UserId), iam.WithSkipAdminRoleCreation(true), iam.WithSkipDefaultRoleCreation(true)}
+	org1, proj1 := iam.TestScopes(t, tc.IamRepo(), opts...)
+	_, proj2 := iam.TestScopes(t, tc.IamRepo(), opts...)
+
+	iamRepoFn := func() (*iam.Repository, error) {
+		return tc.IamRepo(), nil
+	}
+	serversRepoFn := func() (*servers.Repository, error) {
+		return tc.ServersRepo(), nil
+	}
+	authTokenRepoFn := func() (*authtoken.Repository, error) {
+		return tc.AuthTokenRepo(), nil
+	}
+
+	role := iam.TestRole(t, conn, proj1.GetPublicId())
+	iam.TestUserRole(t, conn, role.PublicId, token.UserId)
+	iam.TestRoleGrant(t, conn, role.PublicId, "id=*;type=target;actions=list")
+
+	verify := func(scopeId string) auth.VerifyResults {
+		ctx := auth.NewVerifierContext(
+			context.Background(),
+			tc.Logger(),
+			iamRepoFn,
+			authTokenRepoFn,
+			serversRepoFn,
+			tc.Kms(),
+			auth.RequestInfo{
+				PublicId:       token.Id,
+				EncryptedToken: strings.Split(token.Token, "_")[2],
+				TokenFormat:    auth.AuthTokenTypeBearer,
+			})
+		return auth.Verify(ctx, auth.WithType(resource.Target), auth.WithAction(action.List), auth.WithScopeId(scopeId))
+	}
+	ids := func(t *testing.T, scopeId string) []string {
+		res := verify(scopeId)
+		scps, err := res.RecursiveScopes(context.Background(), resource.Target, action.List, scope.Project)
+		require.NoError(t, err)
+		var ret []string
+		for _, s := range scps {
+			ret = append(ret, s.GetId())
+		}
+		return ret
+	}
+
+	t.Run("global", func(t *testing.T) {
+		assert.Equal(t, []string{proj1.GetPublicId()}, ids(t, scope.Global.String()))
+	})
+	t.Run("org", func(t *testing.T) {
+		assert.Equal(t, []string{proj1.GetPublicId()}, ids(t, org1.GetPublicId()))
+	})
+	t.Run("project", func(t *testing.T) {
+		assert.Equal(t, []string{proj1.GetPublicId()}, ids(t, proj1.GetPublicId()))
+	})
+	t.Run("forbidden", func(t *testing.T) {
+		res := verify(proj2.GetPublicId())
+		require.Error(t, res.Error)
+		_, err := res.RecursiveScopes(context.Background(), resource.Target, action.List, scope.Project)
+		assert.Error(t, err)
+	})
+}
@@ -29,6 +29,7 @@ type options struct {
 	withTokenTimeToLiveDuration  time.Duration
 	withTokenTimeToStaleDuration time.Duration
 	withLimit                    int
+	withAfterId                  string
 }
 
 func getDefaultOptions() options {
@@ -75,3 +76,12 @@ func WithLimit(limit int) Option {
 		}
 	}
 }
+
+// WithAfterId provides an option to list only the items whose id sorts after
+// id. Lists are ordered by id so it can be used to list items a page at a
+// time.
+func WithAfterId(id string) Option {
+	return func(o *options) {
+		o.withAfterId = id
+	}
+}
@@ -34,4 +34,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withTokenTimeToStaleDuration = 1 * time.Hour
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAfterId("at_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withAfterId = "at_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -274,8 +274,12 @@ func (r *Repository) ListAuthTokens(ctx context.Context, withOrgId string, opt .
 	}
 	opts := getOpts(opt...)
 
+	where, args := "auth_account_id in (select public_id from auth_account where scope_id = ?)", []interface{}{withOrgId}
+	if opts.withAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withAfterId)
+	}
 	var authTokens []*AuthToken
-	if err := r.reader.SearchWhere(ctx, &authTokens, "auth_account_id in (select public_id from auth_account where scope_id = ?)", []interface{}{withOrgId}, db.WithLimit(opts.withLimit)); err != nil {
+	if err := r.reader.SearchWhere(ctx, &authTokens, where, args, db.WithLimit(opts.withLimit), db.WithOrder("public_id")); err != nil {
 		return nil, fmt.Errorf("list users: %w", err)
 	}
 	for _, at := range authTokens {
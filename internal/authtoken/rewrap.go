@@ -0,0 +1,87 @@
+package authtoken
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+const rewrapAuthTokenQuery = `
+update auth_token
+   set token = $1,
+       key_id = $2
+ where public_id = $3
+   and key_id = $4;
+`
+
+const countUnexpiredAuthTokensQuery = `
+select count(*)
+  from auth_token at
+  join auth_account aa
+    on aa.public_id = at.auth_account_id
+ where aa.scope_id = $1
+   and at.create_time >= $2
+   and at.create_time < $3
+   and at.expiration_time > now();
+`
+
+func init() {
+	// The stored values are encrypted with the database key, while the
+	// values issued to clients are encrypted with the tokens key
+	kms.RegisterTableRewrapFn(defaultWritableAuthTokenTableName, kms.KeyPurposeDatabase, authTokenRewrapFn)
+	kms.RegisterIssuedCountFn("auth tokens", kms.KeyPurposeTokens, authTokenCountFn)
+}
+
+// authTokenRewrapFn re-encrypts the stored values of auth tokens. The tokens
+// held by clients are encrypted separately, when they are issued, and keep
+// being decrypted with the token key version which was current then.
+func authTokenRewrapFn(ctx context.Context, keyVersionIds []string, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, limit int) (int, error) {
+	var tokens []*writableAuthToken
+	if err := r.SearchWhere(ctx, &tokens, "key_id in (?)", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap auth tokens: %w", err)
+	}
+	var updated int
+	for _, t := range tokens {
+		oldKeyId := t.KeyId
+		at := t.toAuthToken()
+		if err := at.decrypt(ctx, wrapper); err != nil {
+			return updated, fmt.Errorf("rewrap auth tokens: %s: %w", t.PublicId, err)
+		}
+		t = at.toWritableAuthToken()
+		if err := t.encrypt(ctx, wrapper); err != nil {
+			return updated, fmt.Errorf("rewrap auth tokens: %s: %w", t.PublicId, err)
+		}
+		rowsUpdated, err := w.Exec(ctx, rewrapAuthTokenQuery, []interface{}{t.CtToken, t.KeyId, t.PublicId, oldKeyId})
+		if err != nil {
+			return updated, fmt.Errorf("rewrap auth tokens: unable to update %s: %w", t.PublicId, err)
+		}
+		updated += rowsUpdated
+	}
+	return updated, nil
+}
+
+// authTokenCountFn counts the auth tokens which were issued in the time window
+// and have not expired. The tokens held by their clients, see EncryptToken,
+// are only decryptable with the token key version which was current when they
+// were issued.
+func authTokenCountFn(ctx context.Context, r db.Reader, scopeId string, issuedAfter, issuedBefore time.Time) (int64, error) {
+	rows, err := r.Query(ctx, countUnexpiredAuthTokensQuery, []interface{}{scopeId, issuedAfter, issuedBefore})
+	if err != nil {
+		return 0, fmt.Errorf("count auth tokens: %w", err)
+	}
+	defer rows.Close()
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, fmt.Errorf("count auth tokens: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("count auth tokens: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,50 @@
+package authtoken
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthToken_RewrapDatabaseKey(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	require.NoError(err)
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithKeyVersionGracePeriod(time.Nanosecond))
+	require.NoError(err)
+	require.NoError(kmsCache.AddExternalWrappers(kms.WithRootWrapper(wrapper)))
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	at := TestAuthToken(t, conn, kmsCache, org.GetPublicId())
+	repo, err := NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+
+	kvs, err := kmsCache.ListKeyVersions(ctx, org.GetPublicId(), kms.KeyPurposeDatabase)
+	require.NoError(err)
+	require.Len(kvs, 1)
+	oldVersion := kvs[0]
+	// The stored auth token depends on the database key version
+	assert.GreaterOrEqual(oldVersion.EncryptedCount, int64(1))
+
+	_, err = kmsCache.RotateKeys(ctx, org.GetPublicId(), rand.Reader)
+	require.NoError(err)
+	_, err = kmsCache.RunRewrapJobs(ctx)
+	require.NoError(err)
+	require.NoError(kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldVersion.Id))
+
+	got, err := repo.ValidateToken(ctx, at.GetPublicId(), at.GetToken())
+	require.NoError(err)
+	require.NotNil(got)
+	assert.Equal(at.GetPublicId(), got.GetPublicId())
+}
@@ -76,6 +76,10 @@ type Command struct {
 	FlagAuthMethodId  string
 	FlagHostCatalogId string
 	FlagVersion       int
+	FlagFilter        string
+	FlagPageSize      uint
+	FlagAll           bool
+	FlagRecursive     bool
 
 	client *api.Client
 }
@@ -31,6 +31,12 @@ type ServerListener struct {
 	ALPNListener net.Listener
 }
 
+// IsOps returns whether the listener has the "ops" purpose. These listeners
+// are served by the server command rather than by controllers or workers.
+func (l *ServerListener) IsOps() bool {
+	return len(l.Config.Purpose) == 1 && l.Config.Purpose[0] == "ops"
+}
+
 type WorkerAuthInfo struct {
 	CertPEM         []byte `json:"cert"`
 	KeyPEM          []byte `json:"key"`
@@ -123,6 +129,8 @@ func tcpListenerFactory(purpose string, l *configutil.Listener, logger hclog.Log
 			l.Address = "127.0.0.1:9201"
 		case "proxy":
 			l.Address = "127.0.0.1:9202"
+		case "ops":
+			l.Address = "127.0.0.1:9203"
 		default:
 			l.Address = "127.0.0.1:9200"
 		}
@@ -136,6 +144,8 @@ func tcpListenerFactory(purpose string, l *configutil.Listener, logger hclog.Log
 				port = "9201"
 			case "proxy":
 				port = "9202"
+			case "ops":
+				port = "9203"
 			default:
 				port = "9200"
 			}
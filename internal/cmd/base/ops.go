@@ -0,0 +1,157 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/libs/alpnmux"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// readyTimeout bounds the time the readiness checks of a /ready request take
+const readyTimeout = 5 * time.Second
+
+const (
+	statusOk          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+// ReadinessCheck returns an error describing why a component of the server
+// is not ready to handle requests, or nil if it is.
+type ReadinessCheck func(ctx context.Context) error
+
+type readinessChecks struct {
+	l      sync.RWMutex
+	checks map[string]ReadinessCheck
+}
+
+// AddReadinessCheck registers a check whose result is reported by /ready for
+// the named component. Registering a check under the name of an existing
+// one replaces it.
+func (b *Server) AddReadinessCheck(name string, check ReadinessCheck) {
+	b.readiness.l.Lock()
+	defer b.readiness.l.Unlock()
+	if b.readiness.checks == nil {
+		b.readiness.checks = make(map[string]ReadinessCheck)
+	}
+	b.readiness.checks[name] = check
+}
+
+type componentStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type opsStatus struct {
+	Status     string                      `json:"status"`
+	Components map[string]*componentStatus `json:"components,omitempty"`
+}
+
+func writeOpsStatus(w http.ResponseWriter, s *opsStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if s.Status != statusOk {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(s)
+}
+
+// handleHealth reports that the server process is alive.
+func (b *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeOpsStatus(w, &opsStatus{Status: statusOk})
+}
+
+// handleReady runs the registered readiness checks and reports the status
+// of each component. The server is ready if all of them succeed.
+func (b *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readyTimeout)
+	defer cancel()
+
+	b.readiness.l.RLock()
+	names := make([]string, 0, len(b.readiness.checks))
+	for name := range b.readiness.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	checks := make([]ReadinessCheck, 0, len(names))
+	for _, name := range names {
+		checks = append(checks, b.readiness.checks[name])
+	}
+	b.readiness.l.RUnlock()
+
+	ret := &opsStatus{
+		Status:     statusOk,
+		Components: make(map[string]*componentStatus, len(names)),
+	}
+	for i, check := range checks {
+		cs := &componentStatus{Status: statusOk}
+		if err := check(ctx); err != nil {
+			cs.Status = statusUnavailable
+			cs.Error = err.Error()
+			ret.Status = statusUnavailable
+		}
+		ret.Components[names[i]] = cs
+	}
+	writeOpsStatus(w, ret)
+}
+
+// OpsHandler returns the handler of listeners with the "ops" purpose. It
+// serves the Prometheus metrics of the server on /metrics, liveness on
+// /health, and readiness on /ready.
+func (b *Server) OpsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/health", b.handleHealth)
+	mux.HandleFunc("/ready", b.handleReady)
+	return mux
+}
+
+// StartOpsListeners starts serving OpsHandler on the listeners with the
+// "ops" purpose. Controllers and workers leave these listeners open while
+// shutting down, so that /ready can report it; the servers stop when the
+// listeners are closed by the shutdown funcs.
+func (b *Server) StartOpsListeners() error {
+	handler := b.OpsHandler()
+	for _, ln := range b.Listeners {
+		if !ln.IsOps() {
+			continue
+		}
+		server := &http.Server{
+			Handler:           handler,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			IdleTimeout:       5 * time.Minute,
+			ErrorLog:          b.Logger.StandardLogger(nil),
+		}
+
+		switch ln.Config.TLSDisable {
+		case true:
+			l, err := ln.Mux.RegisterProto(alpnmux.NoProto, nil)
+			if err != nil {
+				return fmt.Errorf("error getting non-tls listener: %w", err)
+			}
+			if l == nil {
+				return errors.New("could not get non-tls listener")
+			}
+			go server.Serve(l)
+
+		default:
+			for _, v := range []string{"", "http/1.1", "h2"} {
+				l := ln.Mux.GetListener(v)
+				if l == nil {
+					return fmt.Errorf("could not get tls proto %q listener", v)
+				}
+				go server.Serve(l)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsHandler(t *testing.T) {
+	b := NewServer(nil)
+	h := b.OpsHandler()
+
+	get := func(t *testing.T, path string) (int, *opsStatus) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		var s opsStatus
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &s))
+		return rec.Code, &s
+	}
+
+	code, s := get(t, "/health")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, &opsStatus{Status: statusOk}, s)
+
+	ready := true
+	b.AddReadinessCheck("database", func(context.Context) error { return nil })
+	b.AddReadinessCheck("controller", func(context.Context) error {
+		if !ready {
+			return errors.New("shutting down")
+		}
+		return nil
+	})
+
+	code, s = get(t, "/ready")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, &opsStatus{
+		Status: statusOk,
+		Components: map[string]*componentStatus{
+			"controller": {Status: statusOk},
+			"database":   {Status: statusOk},
+		},
+	}, s)
+
+	ready = false
+	code, s = get(t, "/ready")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, &opsStatus{
+		Status: statusUnavailable,
+		Components: map[string]*componentStatus{
+			"controller": {Status: statusUnavailable, Error: "shutting down"},
+			"database":   {Status: statusOk},
+		},
+	}, s)
+
+	// Liveness does not depend on readiness
+	code, _ = get(t, "/health")
+	assert.Equal(t, http.StatusOK, code)
+}
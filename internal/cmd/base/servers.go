@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/config"
 	"github.com/hashicorp/boundary/internal/db"
 	"github.com/hashicorp/boundary/internal/docker"
+	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/kms"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/strutil"
@@ -59,6 +60,10 @@ type Server struct {
 	InmemSink         *metrics.InmemSink
 	PrometheusEnabled bool
 
+	Eventer *event.Eventer
+
+	readiness readinessChecks
+
 	ReloadFuncsLock *sync.RWMutex
 	ReloadFuncs     map[string][]reloadutil.ReloadFunc
 
@@ -192,6 +197,14 @@ func (b *Server) RemovePidFile(pidPath string) error {
 func (b *Server) SetupMetrics(ui cli.Ui, telemetry *configutil.Telemetry) error {
 	// TODO: Figure out a user-agent we want to use for the last param
 	// TODO: Do we want different names for different components?
+	// Without a telemetry block metrics are still kept for Prometheus, to be
+	// served on ops listeners
+	if telemetry == nil {
+		telemetry = &configutil.Telemetry{
+			DisableHostname:         true,
+			PrometheusRetentionTime: configutil.PrometheusDefaultRetentionTime,
+		}
+	}
 	var err error
 	b.InmemSink, _, b.PrometheusEnabled, err = configutil.SetupTelemetry(&configutil.SetupTelemetryOpts{
 		Config:      telemetry,
@@ -207,6 +220,52 @@ func (b *Server) SetupMetrics(ui cli.Ui, telemetry *configutil.Telemetry) error
 	return nil
 }
 
+// SetupEvents creates the eventer writing audit events to the sinks in the
+// events block of the configuration. Events are attributed to the names of
+// the controller and worker in the configuration.
+func (b *Server) SetupEvents(conf *config.Config) error {
+	if conf.Events == nil || len(conf.Events.Sinks) == 0 {
+		return nil
+	}
+	var names []string
+	if conf.Controller != nil {
+		names = append(names, conf.Controller.Name)
+	}
+	if conf.Worker != nil {
+		names = append(names, conf.Worker.Name)
+	}
+	eventLogger := b.Logger.Named("events")
+	var sinks []event.Sink
+	var types []string
+	for _, s := range conf.Events.Sinks {
+		var sink event.Sink
+		var err error
+		switch s.Type {
+		case "file":
+			sink, err = event.NewFileSink(s.Path, s.RotateBytes, s.RotateMaxFiles)
+		case "stderr":
+			sink = event.NewWriterSink(os.Stderr)
+		case "webhook":
+			sink, err = event.NewWebhookSink(eventLogger, s.Url, s.Headers)
+		default:
+			err = fmt.Errorf("unknown sink type %q", s.Type)
+		}
+		if err != nil {
+			for _, created := range sinks {
+				created.Close()
+			}
+			return fmt.Errorf("Error configuring event sink: %w", err)
+		}
+		sinks = append(sinks, sink)
+		types = append(types, s.Type)
+	}
+	b.Eventer = event.NewEventer(eventLogger, strings.Join(names, ","), sinks...)
+	b.ShutdownFuncs = append(b.ShutdownFuncs, b.Eventer.Close)
+	b.InfoKeys = append(b.InfoKeys, "event sinks")
+	b.Info["event sinks"] = strings.Join(types, ", ")
+	return nil
+}
+
 func (b *Server) PrintInfo(ui cli.Ui) {
 	verInfo := version.Get()
 	if verInfo.Version != "" {
@@ -13,11 +13,14 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/config"
 	"github.com/hashicorp/boundary/internal/cmd/commands/connect"
 	"github.com/hashicorp/boundary/internal/cmd/commands/database"
+	"github.com/hashicorp/boundary/internal/cmd/commands/declarative"
 	"github.com/hashicorp/boundary/internal/cmd/commands/dev"
 	"github.com/hashicorp/boundary/internal/cmd/commands/groups"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostcatalogs"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hosts"
 	"github.com/hashicorp/boundary/internal/cmd/commands/hostsets"
+	"github.com/hashicorp/boundary/internal/cmd/commands/oplog"
+	"github.com/hashicorp/boundary/internal/cmd/commands/reports"
 	"github.com/hashicorp/boundary/internal/cmd/commands/roles"
 	"github.com/hashicorp/boundary/internal/cmd/commands/scopes"
 	"github.com/hashicorp/boundary/internal/cmd/commands/server"
@@ -25,6 +28,8 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/commands/targets"
 	"github.com/hashicorp/boundary/internal/cmd/commands/users"
 	"github.com/hashicorp/boundary/internal/cmd/commands/version"
+	"github.com/hashicorp/boundary/internal/cmd/commands/webhooks"
+	"github.com/hashicorp/boundary/internal/cmd/commands/workers"
 
 	"github.com/mitchellh/cli"
 )
@@ -42,6 +47,7 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				}),
 				SighupCh:  MakeSighupCh(),
 				SigUSR2Ch: MakeSigUSR2Ch(),
+				SigUSR1Ch: MakeSigUSR1Ch(),
 			}, nil
 		},
 		"dev": func() (cli.Command, error) {
@@ -60,6 +66,19 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"apply": func() (cli.Command, error) {
+			return &declarative.Command{
+				Command: base.NewCommand(ui),
+				Func:    "apply",
+			}, nil
+		},
+		"export": func() (cli.Command, error) {
+			return &declarative.Command{
+				Command: base.NewCommand(ui),
+				Func:    "export",
+			}, nil
+		},
+
 		"authenticate": func() (cli.Command, error) {
 			return &authenticate.Command{
 				Command: base.NewCommand(ui),
@@ -286,6 +305,31 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Command: base.NewCommand(ui),
 			}, nil
 		},
+		"database migrate": func() (cli.Command, error) {
+			return &database.MigrateCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database status": func() (cli.Command, error) {
+			return &database.StatusCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database rewrap-root": func() (cli.Command, error) {
+			return &database.RewrapRootCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database export-warehouse": func() (cli.Command, error) {
+			return &database.ExportWarehouseCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"database forget-oplog-archive": func() (cli.Command, error) {
+			return &database.ForgetOplogArchiveCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
 
 		"groups": func() (cli.Command, error) {
 			return &groups.Command{
@@ -388,6 +432,18 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "update",
 			}, nil
 		},
+		"host-catalogs import-hosts": func() (cli.Command, error) {
+			return &hostcatalogs.BulkCommand{
+				Command: base.NewCommand(ui),
+				Func:    "import-hosts",
+			}, nil
+		},
+		"host-catalogs export-hosts": func() (cli.Command, error) {
+			return &hostcatalogs.BulkCommand{
+				Command: base.NewCommand(ui),
+				Func:    "export-hosts",
+			}, nil
+		},
 
 		"host-sets": func() (cli.Command, error) {
 			return &hostsets.Command{
@@ -503,6 +559,46 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 			}, nil
 		},
 
+		"oplog": func() (cli.Command, error) {
+			return &oplog.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"oplog list": func() (cli.Command, error) {
+			return &oplog.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"oplog export": func() (cli.Command, error) {
+			return &oplog.Command{
+				Command: base.NewCommand(ui),
+				Func:    "export",
+			}, nil
+		},
+		"oplog verify": func() (cli.Command, error) {
+			return &oplog.VerifyCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"oplog replicate": func() (cli.Command, error) {
+			return &oplog.ReplicateCommand{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+
+		"reports": func() (cli.Command, error) {
+			return &reports.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"reports sessions": func() (cli.Command, error) {
+			return &reports.Command{
+				Command: base.NewCommand(ui),
+				Func:    "sessions",
+			}, nil
+		},
+
 		"roles": func() (cli.Command, error) {
 			return &roles.Command{
 				Command: base.NewCommand(ui),
@@ -610,6 +706,30 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "list",
 			}, nil
 		},
+		"scopes rotate-keys": func() (cli.Command, error) {
+			return &scopes.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rotate-keys",
+			}, nil
+		},
+		"scopes rewrap-status": func() (cli.Command, error) {
+			return &scopes.Command{
+				Command: base.NewCommand(ui),
+				Func:    "rewrap-status",
+			}, nil
+		},
+		"scopes list-key-versions": func() (cli.Command, error) {
+			return &scopes.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list-key-versions",
+			}, nil
+		},
+		"scopes destroy-key-version": func() (cli.Command, error) {
+			return &scopes.Command{
+				Command: base.NewCommand(ui),
+				Func:    "destroy-key-version",
+			}, nil
+		},
 
 		"sessions": func() (cli.Command, error) {
 			return &sessions.Command{
@@ -634,6 +754,12 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "cancel",
 			}, nil
 		},
+		"sessions watch": func() (cli.Command, error) {
+			return &sessions.Command{
+				Command: base.NewCommand(ui),
+				Func:    "watch",
+			}, nil
+		},
 
 		"targets": func() (cli.Command, error) {
 			return &targets.Command{
@@ -760,6 +886,72 @@ func initCommands(ui, serverCmdUi cli.Ui, runOpts *RunOptions) {
 				Func:    "remove-accounts",
 			}, nil
 		},
+
+		"workers": func() (cli.Command, error) {
+			return &workers.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"workers read": func() (cli.Command, error) {
+			return &workers.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"workers list": func() (cli.Command, error) {
+			return &workers.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"workers drain": func() (cli.Command, error) {
+			return &workers.Command{
+				Command: base.NewCommand(ui),
+				Func:    "drain",
+			}, nil
+		},
+
+		"webhooks": func() (cli.Command, error) {
+			return &webhooks.Command{
+				Command: base.NewCommand(ui),
+			}, nil
+		},
+		"webhooks create": func() (cli.Command, error) {
+			return &webhooks.Command{
+				Command: base.NewCommand(ui),
+				Func:    "create",
+			}, nil
+		},
+		"webhooks update": func() (cli.Command, error) {
+			return &webhooks.Command{
+				Command: base.NewCommand(ui),
+				Func:    "update",
+			}, nil
+		},
+		"webhooks read": func() (cli.Command, error) {
+			return &webhooks.Command{
+				Command: base.NewCommand(ui),
+				Func:    "read",
+			}, nil
+		},
+		"webhooks delete": func() (cli.Command, error) {
+			return &webhooks.Command{
+				Command: base.NewCommand(ui),
+				Func:    "delete",
+			}, nil
+		},
+		"webhooks list": func() (cli.Command, error) {
+			return &webhooks.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list",
+			}, nil
+		},
+		"webhooks list-deliveries": func() (cli.Command, error) {
+			return &webhooks.Command{
+				Command: base.NewCommand(ui),
+				Func:    "list-deliveries",
+			}, nil
+		},
 	}
 }
 
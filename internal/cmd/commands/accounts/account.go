@@ -44,7 +44,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":            {"id"},
 	"delete":          {"id"},
-	"list":            {"auth-method-id"},
+	"list":            {"auth-method-id", "filter", "page-size", "all"},
 	"set-password":    {"id", "password", "version"},
 	"change-password": {"id", "current-password", "new-password", "version"},
 }
@@ -255,6 +255,16 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, accounts.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, accounts.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, accounts.WithAll(true))
+	}
+
 	accountClient := accounts.NewClient(client)
 
 	existed := true
@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "filter", "page-size", "all", "recursive"},
 }
 
 func (c *Command) Help() string {
@@ -145,6 +145,19 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, authmethods.WithDescription(c.FlagDescription))
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, authmethods.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, authmethods.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, authmethods.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, authmethods.WithRecursive(true))
+	}
+
 	authmethodClient := authmethods.NewClient(client)
 
 	existed := true
@@ -227,6 +240,9 @@ func (c *Command) Run(args []string) int {
 					output = append(output,
 						fmt.Sprintf("  ID:             %s", m.Id),
 					)
+					if c.FlagRecursive && m.Scope != nil {
+						output = append(output, fmt.Sprintf("    Scope ID:     %s", m.Scope.Id))
+					}
 				}
 				if m.Description != "" {
 					output = append(output,
@@ -31,7 +31,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "filter", "page-size", "all", "recursive"},
 }
 
 func (c *Command) Help() string {
@@ -88,6 +88,21 @@ func (c *Command) Run(args []string) int {
 		return 2
 	}
 
+	var opts []authtokens.Option
+
+	if c.FlagFilter != "" {
+		opts = append(opts, authtokens.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, authtokens.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, authtokens.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, authtokens.WithRecursive(true))
+	}
+
 	authtokenClient := authtokens.NewClient(client)
 
 	existed := true
@@ -104,7 +119,7 @@ func (c *Command) Run(args []string) int {
 			err = nil
 		}
 	case "list":
-		listResult, err = authtokenClient.List(c.Context, c.FlagScopeId)
+		listResult, err = authtokenClient.List(c.Context, c.FlagScopeId, opts...)
 	}
 
 	plural := "auth token"
@@ -175,6 +190,9 @@ func (c *Command) Run(args []string) int {
 					fmt.Sprintf("    Updated Time:                %s", t.UpdatedTime.Local().Format(time.RFC1123)),
 					fmt.Sprintf("    User ID:                     %s", t.UserId),
 				)
+				if c.FlagRecursive && t.Scope != nil {
+					output = append(output, fmt.Sprintf("    Scope ID:                    %s", t.Scope.Id))
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
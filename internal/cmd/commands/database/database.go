@@ -27,6 +27,22 @@ func (c *Command) Help() string {
 		"",
 		`      $ boundary database init`,
 		"",
+		"    Upgrade the database schema:",
+		"",
+		`      $ boundary database migrate`,
+		"",
+		"    Re-encrypt the scope root keys with a new root KMS:",
+		"",
+		`      $ boundary database rewrap-root -old-config=old.hcl -new-config=new.hcl`,
+		"",
+		"    Export new and changed warehouse rows to Parquet files:",
+		"",
+		`      $ boundary database export-warehouse -config=controller.hcl -output-dir=/var/lib/boundary/warehouse`,
+		"",
+		"    Forget an oplog archive which is no longer kept:",
+		"",
+		`      $ boundary database forget-oplog-archive -config=controller.hcl -name=oplog-20201102T100000.000000000Z`,
+		"",
 		"  Please see the database subcommand help for detailed usage information.",
 	})
 }
@@ -0,0 +1,184 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/warehouse"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*ExportWarehouseCommand)(nil)
+var _ cli.CommandAutocomplete = (*ExportWarehouseCommand)(nil)
+
+type ExportWarehouseCommand struct {
+	*base.Command
+
+	flagConfig       string
+	flagConfigKms    string
+	flagMigrationUrl string
+	flagOutputDir    string
+	flagFileFormat   string
+}
+
+func (c *ExportWarehouseCommand) Synopsis() string {
+	return "Export new and changed warehouse rows to Parquet or CSV files"
+}
+
+func (c *ExportWarehouseCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database export-warehouse [options]",
+		"",
+		"  Export the rows of the data warehouse tables inserted or updated since the previous export to the output directory, so they can be loaded into an external data warehouse or analytics tool. The command reads the database configured in the controller configuration file and can be run periodically, for example from cron:",
+		"",
+		"    $ boundary database export-warehouse -config=/etc/boundary/controller.hcl -output-dir=/var/lib/boundary/warehouse",
+		"",
+		"  Each table with exported rows is written to a new file in a subdirectory named after the table. The files of each export, and the high-water mark the next export starts from, are recorded in the manifest.json file of the output directory, which is written last. The first export to a directory exports all the rows. A row updated after it was exported is exported again, so the row most recently exported for each primary key should be kept when loading the files. Rows written by transactions which are still running when the export starts are exported by the next export.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ExportWarehouseCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: &c.flagMigrationUrl,
+		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "output-dir",
+		Target:     &c.flagOutputDir,
+		Completion: complete.PredictDirs("*"),
+		Usage:      "Path to the directory the files are exported to. It is created if it does not exist.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:       "file-format",
+		Target:     &c.flagFileFormat,
+		Default:    string(warehouse.FormatParquet),
+		Completion: complete.PredictSet(string(warehouse.FormatParquet), string(warehouse.FormatCsv)),
+		Usage:      `The format of the exported files, "parquet" or "csv".`,
+	})
+
+	return set
+}
+
+func (c *ExportWarehouseCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ExportWarehouseCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ExportWarehouseCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.flagOutputDir == "" {
+		c.UI.Error("Must specify the output directory using -output-dir")
+		return 1
+	}
+	var format warehouse.Format
+	switch c.flagFileFormat {
+	case string(warehouse.FormatParquet), string(warehouse.FormatCsv):
+		format = warehouse.Format(c.flagFileFormat)
+	default:
+		c.UI.Error(fmt.Sprintf("Unknown file format %q", c.flagFileFormat))
+		return 1
+	}
+
+	cfg, configWrapper, err := common.LoadConfig(c.Context, c.flagConfig, c.flagConfigKms)
+	if configWrapper != nil {
+		defer func() {
+			if err := configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	url, err := common.MigrationUrl(cfg, c.flagMigrationUrl)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	d, err := db.Open(db.Postgres, url)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error opening database: %w", err).Error())
+		return 1
+	}
+	defer d.Close()
+
+	// Exporting depends on the latest schema
+	state, err := db.GetSchemaState(c.Context, d.DB(), "postgres")
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error reading database schema state: %w", err).Error())
+		return 1
+	}
+	if err := state.Check(); err != nil {
+		c.UI.Error(fmt.Errorf("Database schema is not up to date, run \"boundary database migrate\" first: %w", err).Error())
+		return 1
+	}
+
+	repo, err := warehouse.NewRepository(db.New(d))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating warehouse repository: %w", err).Error())
+		return 1
+	}
+	export, err := repo.Export(c.Context, c.flagOutputDir, warehouse.WithFormat(format))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error exporting warehouse: %w", err).Error())
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		if export == nil {
+			c.UI.Output("No warehouse rows to export")
+			break
+		}
+		c.UI.Output(generateExportTableOutput(export))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(export)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+	return 0
+}
@@ -0,0 +1,169 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog/retention"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*ForgetOplogArchiveCommand)(nil)
+var _ cli.CommandAutocomplete = (*ForgetOplogArchiveCommand)(nil)
+
+type ForgetOplogArchiveCommand struct {
+	*base.Command
+
+	flagConfig       string
+	flagConfigKms    string
+	flagMigrationUrl string
+	flagName         string
+}
+
+func (c *ForgetOplogArchiveCommand) Synopsis() string {
+	return "Forget an oplog archive so its key versions can be destroyed"
+}
+
+func (c *ForgetOplogArchiveCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database forget-oplog-archive [options]",
+		"",
+		"  Delete the record of an archive written by the oplog retention policy, named after its files, once the archive is no longer kept:",
+		"",
+		"    $ boundary database forget-oplog-archive -config=/etc/boundary/controller.hcl -name=oplog-20201102T100000.000000000Z",
+		"",
+		"  The oplog key versions which encrypted the entries of recorded archives can't be destroyed. Once the archive is forgotten, destroying them makes its entries unreadable.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ForgetOplogArchiveCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: &c.flagMigrationUrl,
+		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "name",
+		Target: &c.flagName,
+		Usage:  "The name of the archive, as found in its manifest.",
+	})
+
+	return set
+}
+
+func (c *ForgetOplogArchiveCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ForgetOplogArchiveCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ForgetOplogArchiveCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.flagName == "" {
+		c.UI.Error("Must specify the name of the archive using -name")
+		return 1
+	}
+
+	cfg, configWrapper, err := common.LoadConfig(c.Context, c.flagConfig, c.flagConfigKms)
+	if configWrapper != nil {
+		defer func() {
+			if err := configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	url, err := common.MigrationUrl(cfg, c.flagMigrationUrl)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	d, err := db.Open(db.Postgres, url)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error opening database: %w", err).Error())
+		return 1
+	}
+	defer d.Close()
+
+	// Archives are recorded by the latest schema
+	state, err := db.GetSchemaState(c.Context, d.DB(), "postgres")
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error reading database schema state: %w", err).Error())
+		return 1
+	}
+	if err := state.Check(); err != nil {
+		c.UI.Error(fmt.Errorf("Database schema is not up to date, run \"boundary database migrate\" first: %w", err).Error())
+		return 1
+	}
+
+	rw := db.New(d)
+	repo, err := retention.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating oplog retention repository: %w", err).Error())
+		return 1
+	}
+	forgotten, err := repo.ForgetArchive(c.Context, c.flagName)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error forgetting oplog archive: %w", err).Error())
+		return 1
+	}
+	if forgotten == 0 {
+		c.UI.Error(fmt.Sprintf("Oplog archive %q is not recorded", c.flagName))
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Info(fmt.Sprintf("Oplog archive %q forgotten.", c.flagName))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(map[string]interface{}{
+			"name": c.flagName,
+		})
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+	return 0
+}
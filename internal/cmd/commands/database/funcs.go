@@ -4,6 +4,9 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/warehouse"
+	"github.com/posener/complete"
 )
 
 type RoleInfo struct {
@@ -174,3 +177,76 @@ func generateInitialTargetTableOutput(in *TargetInfo) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func generateSchemaStateTableOutput(in *db.SchemaState) string {
+	nonAttributeMap := map[string]interface{}{
+		"Initialized":     in.Initialized,
+		"Current Version": in.CurrentVersion,
+		"Latest Version":  in.LatestVersion,
+		"Dirty":           in.Dirty,
+	}
+	status := "up to date"
+	if err := in.Check(); err != nil {
+		status = err.Error()
+	}
+	nonAttributeMap["Status"] = status
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Database schema information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+// populateSchemaFlags adds the flags shared by the commands which manage the
+// database schema.
+func populateSchemaFlags(f *base.FlagSet, configPath, configKmsPath, migrationUrl *string) {
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: configPath,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: configKmsPath,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: migrationUrl,
+		Usage:  `If set, overrides a migration URL set in config, and specifies the URL used to connect to the database. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+}
+
+func generateExportTableOutput(in *warehouse.Export) string {
+	ret := []string{
+		"",
+		"Warehouse export:",
+		fmt.Sprintf("  Format:    %s", in.Format),
+		fmt.Sprintf("  From Mark: %d", in.FromMark),
+		fmt.Sprintf("  To Mark:   %d", in.ToMark),
+		"  Files:",
+	}
+	for _, f := range in.Files {
+		ret = append(ret,
+			fmt.Sprintf("    Table:   %s", f.Table),
+			fmt.Sprintf("      Path: %s", f.Path),
+			fmt.Sprintf("      Rows: %d", f.RowCount),
+		)
+	}
+	return base.WrapForHelpText(ret)
+}
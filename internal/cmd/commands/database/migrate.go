@@ -0,0 +1,107 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*MigrateCommand)(nil)
+var _ cli.CommandAutocomplete = (*MigrateCommand)(nil)
+
+type MigrateCommand struct {
+	*base.Command
+
+	flagConfig       string
+	flagConfigKms    string
+	flagMigrationUrl string
+}
+
+func (c *MigrateCommand) Synopsis() string {
+	return "Upgrade Boundary's database schema"
+}
+
+func (c *MigrateCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database migrate [options]",
+		"",
+		"  Apply the migrations built into this binary which are missing from an initialized database:",
+		"",
+		"    $ boundary database migrate -config=/etc/boundary/controller.hcl",
+		"",
+		"  Migrations are run while holding a database lock, so running this command from several hosts at once is safe. Controllers refuse to start until the schema matches their version, so stop the controllers before upgrading and start the new version afterwards.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *MigrateCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	populateSchemaFlags(f, &c.flagConfig, &c.flagConfigKms, &c.flagMigrationUrl)
+	return set
+}
+
+func (c *MigrateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *MigrateCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *MigrateCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	cfg, configWrapper, err := common.LoadConfig(c.Context, c.flagConfig, c.flagConfigKms)
+	if configWrapper != nil {
+		defer func() {
+			if err := configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	url, err := common.MigrationUrl(cfg, c.flagMigrationUrl)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	before, after, err := db.MigrateStore(c.Context, "postgres", url)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error migrating database: %w", err).Error())
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		if before.CurrentVersion == after.CurrentVersion {
+			c.UI.Info(fmt.Sprintf("Database schema already at version %d.", after.CurrentVersion))
+			return 0
+		}
+		c.UI.Info(fmt.Sprintf("Database schema migrated from version %d to version %d.", before.CurrentVersion, after.CurrentVersion))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(map[string]interface{}{
+			"previous_version": before.CurrentVersion,
+			"current_version":  after.CurrentVersion,
+		})
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+	return 0
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*RewrapRootCommand)(nil)
+var _ cli.CommandAutocomplete = (*RewrapRootCommand)(nil)
+
+type RewrapRootCommand struct {
+	*base.Command
+
+	flagOldConfig    string
+	flagNewConfig    string
+	flagConfigKms    string
+	flagMigrationUrl string
+}
+
+func (c *RewrapRootCommand) Synopsis() string {
+	return "Re-encrypt the scope root keys with a new root KMS"
+}
+
+func (c *RewrapRootCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database rewrap-root [options]",
+		"",
+		"  Re-encrypt the root keys of all the scopes, which are encrypted with the \"root\" purpose KMS of the old configuration file, with the \"root\" purpose KMS of the new configuration file:",
+		"",
+		"    $ boundary database rewrap-root -old-config=/etc/boundary/controller.hcl -new-config=/etc/boundary/controller.new.hcl",
+		"",
+		"  All the root keys are re-encrypted in a single transaction, and each of them is decrypted with the new KMS before it is committed. The two KMSes must have different key IDs. The database is found in the new configuration file. Stop the controllers before running this command and start them with the new configuration file afterwards. Keep the old KMS key until the controllers are running with the new one.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *RewrapRootCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "old-config",
+		Target: &c.flagOldConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to the configuration file containing the "root" purpose "kms" block the root keys are currently encrypted with.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "new-config",
+		Target: &c.flagNewConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to the configuration file containing the "root" purpose "kms" block to re-encrypt the root keys with, and the "controller.database" block.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of both configuration files. If not set, will look for such a block in each configuration file.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "migration-url",
+		Target: &c.flagMigrationUrl,
+		Usage:  `If set, overrides a migration URL set in the new config, and specifies the URL used to connect to the database. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+
+	return set
+}
+
+func (c *RewrapRootCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *RewrapRootCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *RewrapRootCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	switch {
+	case c.flagOldConfig == "":
+		c.UI.Error("Must specify the old config file using -old-config")
+		return 1
+	case c.flagNewConfig == "":
+		c.UI.Error("Must specify the new config file using -new-config")
+		return 1
+	}
+
+	oldSrv, _, err := c.setupRootKms(c.flagOldConfig)
+	if oldSrv != nil {
+		defer c.runShutdownFuncs(oldSrv)
+	}
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error loading old config: %w", err).Error())
+		return 1
+	}
+	newSrv, newCfg, err := c.setupRootKms(c.flagNewConfig)
+	if newSrv != nil {
+		defer c.runShutdownFuncs(newSrv)
+	}
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error loading new config: %w", err).Error())
+		return 1
+	}
+
+	url, err := common.MigrationUrl(newCfg, c.flagMigrationUrl)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	newSrv.DatabaseUrl = url
+	if err := newSrv.ConnectToDatabase("postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return 1
+	}
+	defer newSrv.Database.Close()
+
+	// Rewrapping depends on the latest schema
+	state, err := db.GetSchemaState(c.Context, newSrv.Database.DB(), "postgres")
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error reading database schema state: %w", err).Error())
+		return 1
+	}
+	if err := state.Check(); err != nil {
+		c.UI.Error(fmt.Errorf("Database schema is not up to date, run \"boundary database migrate\" first: %w", err).Error())
+		return 1
+	}
+
+	rw := db.New(newSrv.Database)
+	repo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return 1
+	}
+	rewrapped, err := repo.RewrapRootKeyVersions(c.Context, oldSrv.RootKms, newSrv.RootKms)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error rewrapping root keys: %w", err).Error())
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Info(fmt.Sprintf("%d root key versions re-encrypted and verified with the new root KMS.", rewrapped))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(map[string]interface{}{
+			"rewrapped_count": rewrapped,
+		})
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+	return 0
+}
+
+// setupRootKms loads the config file at configPath and sets up its KMSes in
+// a new server. The returned server, if not nil, must be shut down by the
+// caller.
+func (c *RewrapRootCommand) setupRootKms(configPath string) (*base.Server, *config.Config, error) {
+	cfg, configWrapper, err := common.LoadConfig(c.Context, configPath, c.flagConfigKms)
+	if configWrapper != nil {
+		defer func() {
+			if err := configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	srv := base.NewServer(&base.Command{UI: c.UI})
+	if err := srv.SetupLogging("", "", cfg.LogLevel, cfg.LogFormat); err != nil {
+		return nil, nil, err
+	}
+	if err := srv.SetupKMSes(c.UI, cfg); err != nil {
+		return srv, nil, err
+	}
+	if srv.RootKms == nil {
+		return srv, nil, fmt.Errorf("Root KMS not found after parsing KMS blocks")
+	}
+	return srv, cfg, nil
+}
+
+func (c *RewrapRootCommand) runShutdownFuncs(srv *base.Server) {
+	if err := srv.RunShutdownFuncs(); err != nil {
+		c.UI.Warn(fmt.Errorf("Error finalizing kms: %w", err).Error())
+	}
+}
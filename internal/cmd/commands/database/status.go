@@ -0,0 +1,110 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*StatusCommand)(nil)
+var _ cli.CommandAutocomplete = (*StatusCommand)(nil)
+
+type StatusCommand struct {
+	*base.Command
+
+	flagConfig       string
+	flagConfigKms    string
+	flagMigrationUrl string
+}
+
+func (c *StatusCommand) Synopsis() string {
+	return "Show the version of Boundary's database schema"
+}
+
+func (c *StatusCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary database status [options]",
+		"",
+		"  Show the version of the database schema, the latest version known to this binary, and whether a migration failed part way through:",
+		"",
+		"    $ boundary database status -config=/etc/boundary/controller.hcl",
+		"",
+		"  The command exits with status 2 if the schema does not match this binary.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *StatusCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	populateSchemaFlags(f, &c.flagConfig, &c.flagConfigKms, &c.flagMigrationUrl)
+	return set
+}
+
+func (c *StatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *StatusCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *StatusCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	cfg, configWrapper, err := common.LoadConfig(c.Context, c.flagConfig, c.flagConfigKms)
+	if configWrapper != nil {
+		defer func() {
+			if err := configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	url, err := common.MigrationUrl(cfg, c.flagMigrationUrl)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	d, err := sql.Open("postgres", url)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error opening database: %w", err).Error())
+		return 1
+	}
+	defer d.Close()
+	state, err := db.GetSchemaState(c.Context, d, "postgres")
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error reading database schema state: %w", err).Error())
+		return 1
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateSchemaStateTableOutput(state))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(state)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+	if state.Check() != nil {
+		return 2
+	}
+	return 0
+}
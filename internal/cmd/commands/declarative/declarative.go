@@ -0,0 +1,281 @@
+package declarative
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*Command)(nil)
+var _ cli.CommandAutocomplete = (*Command)(nil)
+
+// Command applies a declarative file to a scope and exports a scope to one.
+type Command struct {
+	*base.Command
+
+	Func string
+
+	flagFile   string
+	flagDryRun bool
+	flagPrune  bool
+}
+
+func (c *Command) Synopsis() string {
+	switch c.Func {
+	case "apply":
+		return "Make the resources in a scope match a declarative file"
+	default:
+		return "Export the resources in a scope to a declarative file"
+	}
+}
+
+func (c *Command) Help() string {
+	var info string
+	switch c.Func {
+	case "apply":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary apply [options] [args]",
+			"",
+			"  Make the roles, scopes, host catalogs, hosts, host sets and targets in a scope and the scopes below it match a declarative HCL file, such as one written by the export command. Resources are matched with existing ones by name within their parent. The changes are planned against the current state of the resources and are applied in dependency order; if a resource is modified after it was read, applying its change fails. Example:",
+			"",
+			`    $ boundary apply -scope-id o_1234567890 -f resources.hcl -dry-run`,
+			"",
+			"  Existing resources which are not in the file are left alone unless -prune is set. Unnamed resources are never changed.",
+			"",
+			"",
+		})
+
+	case "export":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary export [options] [args]",
+			"",
+			"  Export the roles, scopes, host catalogs, hosts, host sets and targets in a scope and the scopes below it to a declarative HCL file which can be passed to the apply command. Unnamed resources can't be described in a file and are skipped with a warning. Example:",
+			"",
+			`    $ boundary export -scope-id o_1234567890 -f resources.hcl`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "scope", []string{"scope-id"})
+
+	switch c.Func {
+	case "apply":
+		f.StringVar(&base.StringVar{
+			Name:       "file",
+			Aliases:    []string{"f"},
+			Target:     &c.flagFile,
+			Completion: complete.PredictFiles("*.hcl"),
+			Usage:      `The declarative file to apply. If set to "-", the file is read from stdin.`,
+		})
+		f.BoolVar(&base.BoolVar{
+			Name:   "dry-run",
+			Target: &c.flagDryRun,
+			Usage:  "Show the changes the apply would make without making them.",
+		})
+		f.BoolVar(&base.BoolVar{
+			Name:   "prune",
+			Target: &c.flagPrune,
+			Usage:  "Delete resources which are not in the file.",
+		})
+	case "export":
+		f.StringVar(&base.StringVar{
+			Name:       "file",
+			Aliases:    []string{"f"},
+			Target:     &c.flagFile,
+			Completion: complete.PredictFiles("*.hcl"),
+			Usage:      "The file to write the resources to. If not set, they are written to stdout.",
+		})
+	}
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+	if c.Func == "apply" && c.flagFile == "" {
+		c.UI.Error("A declarative file must be passed in via -file")
+		return 1
+	}
+
+	var desired *Scope
+	if c.Func == "apply" {
+		var raw []byte
+		var err error
+		switch c.flagFile {
+		case "-":
+			raw, err = ioutil.ReadAll(os.Stdin)
+		default:
+			raw, err = ioutil.ReadFile(c.flagFile)
+		}
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error reading declarative file: %s", err.Error()))
+			return 1
+		}
+		desired, err = Parse(string(raw))
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing declarative file: %s", err.Error()))
+			return 1
+		}
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	r := &reader{client: client}
+	live, err := r.readScope(c.Context, c.FlagScopeId, "")
+	if err != nil {
+		return c.printError(err)
+	}
+	for _, w := range r.warnings {
+		c.UI.Warn(w)
+	}
+
+	switch c.Func {
+	case "export":
+		var buf bytes.Buffer
+		if err := Write(&buf, live); err != nil {
+			c.UI.Error(fmt.Sprintf("Error formatting declarative file: %s", err.Error()))
+			return 1
+		}
+		if c.flagFile == "" {
+			c.UI.Output(strings.TrimSuffix(buf.String(), "\n"))
+			return 0
+		}
+		if err := ioutil.WriteFile(c.flagFile, buf.Bytes(), 0o644); err != nil {
+			c.UI.Error(fmt.Sprintf("Error writing declarative file: %s", err.Error()))
+			return 1
+		}
+		c.UI.Output(fmt.Sprintf("Exported scope %s to %s.", live.id, c.flagFile))
+
+	case "apply":
+		plan, err := diff(desired, live, c.flagPrune)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error in declarative file: %s", err.Error()))
+			return 1
+		}
+		if c.flagDryRun {
+			return c.printPlan(plan, true)
+		}
+		applied := new(Plan)
+		err = plan.Apply(c.Context, client, func(ch *Change) {
+			applied.Changes = append(applied.Changes, ch)
+		})
+		if err == nil || len(applied.Changes) > 0 {
+			if ret := c.printPlan(applied, false); ret != 0 {
+				return ret
+			}
+		}
+		if err != nil {
+			failed := plan.Changes[len(applied.Changes)]
+			c.UI.Error(fmt.Sprintf("Failed to %s %s, later changes were not applied.", failed.Action, failed.Address))
+			return c.printError(err)
+		}
+	}
+
+	return 0
+}
+
+func (c *Command) printPlan(plan *Plan, dryRun bool) int {
+	switch base.Format(c.UI) {
+	case "json":
+		changes := plan.Changes
+		if changes == nil {
+			changes = []*Change{}
+		}
+		b, err := base.JsonFormatter{}.Format(changes)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	case "table":
+		c.UI.Output(generatePlanTableOutput(plan, dryRun))
+	}
+	return 0
+}
+
+func (c *Command) printError(err error) int {
+	if apiErr := api.AsServerError(err); apiErr != nil {
+		c.UI.Error(fmt.Sprintf("Error from controller when performing %s: %s", c.Func, base.PrintApiError(apiErr)))
+		return 1
+	}
+	c.UI.Error(fmt.Sprintf("Error trying to %s: %s", c.Func, err.Error()))
+	return 2
+}
+
+var actionSymbols = map[Action]string{
+	ActionCreate: "+",
+	ActionUpdate: "~",
+	ActionDelete: "-",
+}
+
+func generatePlanTableOutput(plan *Plan, dryRun bool) string {
+	if len(plan.Changes) == 0 {
+		return "No changes, the scope matches the file"
+	}
+	header := "Applied changes:"
+	if dryRun {
+		header = "Planned changes (dry run, no changes made):"
+	}
+	output := []string{
+		"",
+		header,
+	}
+	for _, ch := range plan.Changes {
+		line := fmt.Sprintf("  %s %s", actionSymbols[ch.Action], ch.Address)
+		if ch.Id != "" {
+			line += fmt.Sprintf(" (%s)", ch.Id)
+		}
+		output = append(output, line)
+		if len(ch.ChangedFields) > 0 {
+			output = append(output,
+				fmt.Sprintf("      Changed Fields: %s", strings.Join(ch.ChangedFields, ", ")),
+			)
+		}
+	}
+	summary := "%d created, %d updated, %d deleted."
+	if dryRun {
+		summary = "%d to create, %d to update, %d to delete."
+	}
+	create, update, delete := plan.Counts()
+	output = append(output,
+		"",
+		fmt.Sprintf(summary, create, update, delete),
+	)
+	return base.WrapForHelpText(output)
+}
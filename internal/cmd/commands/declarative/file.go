@@ -0,0 +1,337 @@
+package declarative
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/hcl"
+	"github.com/hashicorp/hcl/hcl/printer"
+)
+
+// targetTypeTcp is the only target type, and the default in files
+const targetTypeTcp = "tcp"
+
+// File is the declarative description of the contents of a scope: its roles
+// and child scopes and, for project scopes, its host catalogs and targets.
+type File struct {
+	Roles        []*Role        `hcl:"role"`
+	Scopes       []*Scope       `hcl:"scope"`
+	HostCatalogs []*HostCatalog `hcl:"host_catalog"`
+	Targets      []*Target      `hcl:"target"`
+}
+
+// Scope describes a scope and its contents. Resources are identified by
+// their name within their parent, so every resource in a file is named.
+type Scope struct {
+	Name         string         `hcl:",key"`
+	Description  string         `hcl:"description"`
+	Roles        []*Role        `hcl:"role"`
+	Scopes       []*Scope       `hcl:"scope"`
+	HostCatalogs []*HostCatalog `hcl:"host_catalog"`
+	Targets      []*Target      `hcl:"target"`
+
+	id      string
+	version uint32
+	typ     scope.Type
+}
+
+// Role describes a role. Principals are user or group IDs.
+type Role struct {
+	Name         string   `hcl:",key"`
+	Description  string   `hcl:"description"`
+	GrantScopeId string   `hcl:"grant_scope_id"`
+	Grants       []string `hcl:"grants"`
+	Principals   []string `hcl:"principals"`
+
+	id      string
+	version uint32
+}
+
+// HostCatalog describes a static host catalog along with its hosts and host
+// sets.
+type HostCatalog struct {
+	Name        string     `hcl:",key"`
+	Description string     `hcl:"description"`
+	Hosts       []*Host    `hcl:"host"`
+	HostSets    []*HostSet `hcl:"host_set"`
+
+	id      string
+	version uint32
+}
+
+// Host describes a static host.
+type Host struct {
+	Name        string            `hcl:",key"`
+	Description string            `hcl:"description"`
+	Address     string            `hcl:"address"`
+	Labels      map[string]string `hcl:"labels"`
+
+	id      string
+	version uint32
+}
+
+// HostSet describes a static host set. Hosts are the names of hosts in the
+// same catalog assigned to the set. Selector is an optional label selector;
+// the hosts of the catalog it selects are in the set without being listed in
+// Hosts.
+type HostSet struct {
+	Name        string   `hcl:",key"`
+	Description string   `hcl:"description"`
+	Hosts       []string `hcl:"hosts"`
+	Selector    string   `hcl:"selector"`
+
+	id      string
+	version uint32
+}
+
+// Target describes a target. Host sets are referenced as
+// "<host catalog name>/<host set name>" within the same project. Session
+// settings left at zero are not managed by the file and keep the values of
+// the controller.
+type Target struct {
+	Name                   string   `hcl:",key"`
+	Description            string   `hcl:"description"`
+	Type                   string   `hcl:"type"`
+	DefaultPort            int      `hcl:"default_port"`
+	SessionMaxSeconds      int      `hcl:"session_max_seconds"`
+	SessionConnectionLimit int      `hcl:"session_connection_limit"`
+	HostSets               []string `hcl:"host_sets"`
+
+	id      string
+	version uint32
+}
+
+// Parse parses a declarative file into the scope it describes.
+func Parse(d string) (*Scope, error) {
+	obj, err := hcl.Parse(d)
+	if err != nil {
+		return nil, err
+	}
+	f := new(File)
+	if err := hcl.DecodeObject(f, obj); err != nil {
+		return nil, err
+	}
+	return &Scope{
+		Roles:        f.Roles,
+		Scopes:       f.Scopes,
+		HostCatalogs: f.HostCatalogs,
+		Targets:      f.Targets,
+	}, nil
+}
+
+// validate checks that the contents of s, a scope of type typ, can be
+// created: names are unique and present, resources are placed in scopes of
+// the right type, and references resolve within the file.
+func validate(s *Scope, typ scope.Type, address string) error {
+	names := make(map[string]bool)
+	unique := func(kind, name string) error {
+		if name == "" {
+			return fmt.Errorf("%s: %s without a name", address, kind)
+		}
+		if names[kind+"/"+name] {
+			return fmt.Errorf("%s: duplicate %s %q", address, kind, name)
+		}
+		names[kind+"/"+name] = true
+		return nil
+	}
+
+	for _, r := range s.Roles {
+		if err := unique("role", r.Name); err != nil {
+			return err
+		}
+	}
+
+	if len(s.Scopes) > 0 && typ == scope.Project {
+		return fmt.Errorf("%s: project scopes cannot contain scopes", address)
+	}
+	for _, child := range s.Scopes {
+		if err := unique("scope", child.Name); err != nil {
+			return err
+		}
+		if err := validate(child, childType(typ), childAddress(address, "scope", child.Name)); err != nil {
+			return err
+		}
+	}
+
+	if (len(s.HostCatalogs) > 0 || len(s.Targets) > 0) && typ != scope.Project {
+		return fmt.Errorf("%s: host catalogs and targets can only be declared in project scopes", address)
+	}
+	hostSets := make(map[string]bool)
+	for _, hc := range s.HostCatalogs {
+		if err := unique("host_catalog", hc.Name); err != nil {
+			return err
+		}
+		hcAddress := childAddress(address, "host_catalog", hc.Name)
+		hosts := make(map[string]bool)
+		for _, h := range hc.Hosts {
+			if h.Name == "" {
+				return fmt.Errorf("%s: host without a name", hcAddress)
+			}
+			if hosts[h.Name] {
+				return fmt.Errorf("%s: duplicate host %q", hcAddress, h.Name)
+			}
+			if h.Address == "" {
+				return fmt.Errorf("%s: missing address", childAddress(hcAddress, "host", h.Name))
+			}
+			hosts[h.Name] = true
+		}
+		for _, hs := range hc.HostSets {
+			if hs.Name == "" {
+				return fmt.Errorf("%s: host set without a name", hcAddress)
+			}
+			ref := hc.Name + "/" + hs.Name
+			if hostSets[ref] {
+				return fmt.Errorf("%s: duplicate host set %q", hcAddress, hs.Name)
+			}
+			for _, h := range hs.Hosts {
+				if !hosts[h] {
+					return fmt.Errorf("%s: unknown host %q", childAddress(hcAddress, "host_set", hs.Name), h)
+				}
+			}
+			if hs.Selector != "" && !static.ValidSelector(hs.Selector) {
+				return fmt.Errorf("%s: invalid selector %q", childAddress(hcAddress, "host_set", hs.Name), hs.Selector)
+			}
+			hostSets[ref] = true
+		}
+	}
+	for _, t := range s.Targets {
+		if err := unique("target", t.Name); err != nil {
+			return err
+		}
+		tAddress := childAddress(address, "target", t.Name)
+		if t.Type != "" && t.Type != targetTypeTcp {
+			return fmt.Errorf("%s: unknown target type %q", tAddress, t.Type)
+		}
+		if t.DefaultPort < 0 || t.DefaultPort > 65535 {
+			return fmt.Errorf("%s: invalid default port %d", tAddress, t.DefaultPort)
+		}
+		if t.SessionMaxSeconds < 0 {
+			return fmt.Errorf("%s: invalid session max seconds %d", tAddress, t.SessionMaxSeconds)
+		}
+		if t.SessionConnectionLimit < -1 {
+			return fmt.Errorf("%s: invalid session connection limit %d", tAddress, t.SessionConnectionLimit)
+		}
+		for _, ref := range t.HostSets {
+			if !hostSets[ref] {
+				return fmt.Errorf("%s: unknown host set %q, expected <host catalog name>/<host set name>", tAddress, ref)
+			}
+		}
+	}
+	return nil
+}
+
+// childType returns the type of the scopes contained in a scope of type typ.
+func childType(typ scope.Type) scope.Type {
+	if typ == scope.Global {
+		return scope.Org
+	}
+	return scope.Project
+}
+
+// childAddress returns the address of a resource within the resource at
+// address, e.g. scope["Engineering"].target["web"].
+func childAddress(address, kind, name string) string {
+	a := fmt.Sprintf("%s[%q]", kind, name)
+	if address == "" {
+		return a
+	}
+	return address + "." + a
+}
+
+// Write writes the contents of s as a declarative file.
+func Write(w io.Writer, s *Scope) error {
+	var buf bytes.Buffer
+	writeScopeContents(&buf, s)
+	b, err := printer.Format(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
+
+func writeScopeContents(buf *bytes.Buffer, s *Scope) {
+	for _, r := range s.Roles {
+		fmt.Fprintf(buf, "role %s {\n", strconv.Quote(r.Name))
+		writeString(buf, "description", r.Description)
+		writeString(buf, "grant_scope_id", r.GrantScopeId)
+		writeList(buf, "grants", r.Grants)
+		writeList(buf, "principals", r.Principals)
+		buf.WriteString("}\n\n")
+	}
+	for _, child := range s.Scopes {
+		fmt.Fprintf(buf, "scope %s {\n", strconv.Quote(child.Name))
+		writeString(buf, "description", child.Description)
+		writeScopeContents(buf, child)
+		buf.WriteString("}\n\n")
+	}
+	for _, hc := range s.HostCatalogs {
+		fmt.Fprintf(buf, "host_catalog %s {\n", strconv.Quote(hc.Name))
+		writeString(buf, "description", hc.Description)
+		for _, h := range hc.Hosts {
+			fmt.Fprintf(buf, "host %s {\n", strconv.Quote(h.Name))
+			writeString(buf, "description", h.Description)
+			writeString(buf, "address", h.Address)
+			if len(h.Labels) > 0 {
+				keys := make([]string, 0, len(h.Labels))
+				for k := range h.Labels {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				buf.WriteString("labels {\n")
+				for _, k := range keys {
+					fmt.Fprintf(buf, "%s = %s\n", strconv.Quote(k), strconv.Quote(h.Labels[k]))
+				}
+				buf.WriteString("}\n")
+			}
+			buf.WriteString("}\n")
+		}
+		for _, hs := range hc.HostSets {
+			fmt.Fprintf(buf, "host_set %s {\n", strconv.Quote(hs.Name))
+			writeString(buf, "description", hs.Description)
+			writeList(buf, "hosts", hs.Hosts)
+			writeString(buf, "selector", hs.Selector)
+			buf.WriteString("}\n")
+		}
+		buf.WriteString("}\n\n")
+	}
+	for _, t := range s.Targets {
+		fmt.Fprintf(buf, "target %s {\n", strconv.Quote(t.Name))
+		writeString(buf, "description", t.Description)
+		writeString(buf, "type", t.Type)
+		writeInt(buf, "default_port", t.DefaultPort)
+		writeInt(buf, "session_max_seconds", t.SessionMaxSeconds)
+		writeInt(buf, "session_connection_limit", t.SessionConnectionLimit)
+		writeList(buf, "host_sets", t.HostSets)
+		buf.WriteString("}\n\n")
+	}
+}
+
+func writeString(buf *bytes.Buffer, name, value string) {
+	if value != "" {
+		fmt.Fprintf(buf, "%s = %s\n", name, strconv.Quote(value))
+	}
+}
+
+func writeInt(buf *bytes.Buffer, name string, value int) {
+	if value != 0 {
+		fmt.Fprintf(buf, "%s = %d\n", name, value)
+	}
+}
+
+func writeList(buf *bytes.Buffer, name string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, strconv.Quote(v))
+	}
+	fmt.Fprintf(buf, "%s = [%s]\n", name, strings.Join(quoted, ", "))
+}
@@ -0,0 +1,193 @@
+package declarative
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFile = `
+role "Administration" {
+  grants     = ["id=*;type=*;actions=*"]
+  principals = ["u_1234567890"]
+}
+
+scope "Engineering" {
+  description = "Engineering org"
+
+  scope "Production" {
+    host_catalog "servers" {
+      host "web-1" {
+        address = "10.0.0.1"
+        labels {
+          env = "prod"
+        }
+      }
+      host "web-2" {
+        address = "10.0.0.2"
+      }
+      host_set "web" {
+        hosts = ["web-1", "web-2"]
+      }
+    }
+
+    target "web-ssh" {
+      type                     = "tcp"
+      default_port             = 22
+      session_connection_limit = -1
+      host_sets                = ["servers/web"]
+    }
+  }
+}
+`
+
+func TestParse(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	s, err := Parse(testFile)
+	require.NoError(err)
+	require.NoError(validate(s, scope.Global, ""))
+
+	require.Len(s.Roles, 1)
+	assert.Equal("Administration", s.Roles[0].Name)
+	assert.Equal([]string{"id=*;type=*;actions=*"}, s.Roles[0].Grants)
+	assert.Equal([]string{"u_1234567890"}, s.Roles[0].Principals)
+
+	require.Len(s.Scopes, 1)
+	org := s.Scopes[0]
+	assert.Equal("Engineering", org.Name)
+	assert.Equal("Engineering org", org.Description)
+
+	require.Len(org.Scopes, 1)
+	proj := org.Scopes[0]
+	require.Len(proj.HostCatalogs, 1)
+	hc := proj.HostCatalogs[0]
+	require.Len(hc.Hosts, 2)
+	assert.Equal("10.0.0.1", hc.Hosts[0].Address)
+	assert.Equal(map[string]string{"env": "prod"}, hc.Hosts[0].Labels)
+	require.Len(hc.HostSets, 1)
+	assert.Equal([]string{"web-1", "web-2"}, hc.HostSets[0].Hosts)
+
+	require.Len(proj.Targets, 1)
+	tgt := proj.Targets[0]
+	assert.Equal(22, tgt.DefaultPort)
+	assert.Equal(-1, tgt.SessionConnectionLimit)
+	assert.Equal([]string{"servers/web"}, tgt.HostSets)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		typ     scope.Type
+		wantErr string
+	}{
+		{
+			name:    "duplicate-role",
+			in:      `role "r" {} role "r" {}`,
+			typ:     scope.Global,
+			wantErr: `duplicate role "r"`,
+		},
+		{
+			name:    "unnamed-scope",
+			in:      `scope "" {}`,
+			typ:     scope.Global,
+			wantErr: "scope without a name",
+		},
+		{
+			name:    "scope-in-project",
+			in:      `scope "p" {}`,
+			typ:     scope.Project,
+			wantErr: "project scopes cannot contain scopes",
+		},
+		{
+			name:    "target-in-org",
+			in:      `target "t" {}`,
+			typ:     scope.Org,
+			wantErr: "can only be declared in project scopes",
+		},
+		{
+			name:    "catalog-in-nested-org",
+			in:      `scope "o" { host_catalog "c" {} }`,
+			typ:     scope.Global,
+			wantErr: `scope["o"]: host catalogs and targets`,
+		},
+		{
+			name:    "host-without-address",
+			in:      `host_catalog "c" { host "h" {} }`,
+			typ:     scope.Project,
+			wantErr: `host_catalog["c"].host["h"]: missing address`,
+		},
+		{
+			name:    "unknown-host",
+			in:      `host_catalog "c" { host_set "s" { hosts = ["h"] } }`,
+			typ:     scope.Project,
+			wantErr: `unknown host "h"`,
+		},
+		{
+			name:    "invalid-selector",
+			in:      `host_catalog "c" { host_set "s" { selector = "env prod" } }`,
+			typ:     scope.Project,
+			wantErr: `host_set["s"]: invalid selector "env prod"`,
+		},
+		{
+			name:    "unknown-host-set",
+			in:      `host_catalog "c" { host_set "s" {} } target "t" { host_sets = ["s"] }`,
+			typ:     scope.Project,
+			wantErr: `unknown host set "s"`,
+		},
+		{
+			name:    "unknown-target-type",
+			in:      `target "t" { type = "ssh" }`,
+			typ:     scope.Project,
+			wantErr: `unknown target type "ssh"`,
+		},
+		{
+			name:    "invalid-port",
+			in:      `target "t" { default_port = 70000 }`,
+			typ:     scope.Project,
+			wantErr: "invalid default port 70000",
+		},
+		{
+			name: "valid",
+			in:   `host_catalog "c" { host_set "s" {} } target "t" { host_sets = ["c/s"] }`,
+			typ:  scope.Project,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.in)
+			require.NoError(t, err)
+			err = validate(s, tt.typ, "")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	in, err := Parse(testFile)
+	require.NoError(err)
+	in.Scopes[0].Scopes[0].HostCatalogs[0].HostSets[0].Selector = "env=prod"
+
+	var buf bytes.Buffer
+	require.NoError(Write(&buf, in))
+	out, err := Parse(buf.String())
+	require.NoError(err)
+	assert.Equal(in, out)
+
+	// Writing is stable
+	var again bytes.Buffer
+	require.NoError(Write(&again, out))
+	assert.Equal(buf.String(), again.String())
+}
@@ -0,0 +1,748 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/api/hosts"
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/host/static"
+)
+
+// Action is the kind of change made to a resource.
+type Action string
+
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// The types of the resources managed by declarative files, in the order
+// they are created and updated. Deletions happen in the reverse order.
+const (
+	typeScope = iota
+	typeHostCatalog
+	typeHost
+	typeHostSet
+	typeTarget
+	typeRole
+	numTypes
+)
+
+var typeNames = [numTypes]string{"scope", "host_catalog", "host", "host_set", "target", "role"}
+
+// Change is a change to a single resource. Updates carry the version of the
+// resource the change was planned against; if the resource is modified in
+// the meantime, applying the change fails.
+type Change struct {
+	Action        Action   `json:"action"`
+	Type          string   `json:"type"`
+	Address       string   `json:"address"`
+	Id            string   `json:"id,omitempty"`
+	Version       uint32   `json:"version,omitempty"`
+	ChangedFields []string `json:"changed_fields,omitempty"`
+
+	// scope is the scope containing the resource, or the parent of a scope
+	scope *Scope
+	// catalog is the host catalog containing a host or host set
+	catalog *HostCatalog
+	// resource is the desired resource; nil for deletions
+	resource interface{}
+}
+
+func (c *Change) changed(field string) bool {
+	if c.Action == ActionCreate {
+		return true
+	}
+	for _, f := range c.ChangedFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Plan is the ordered list of changes which makes the live state of a scope
+// match a declarative file.
+type Plan struct {
+	Changes []*Change
+}
+
+// Counts returns the number of creations, updates and deletions in the plan.
+func (p *Plan) Counts() (create, update, delete int) {
+	for _, c := range p.Changes {
+		switch c.Action {
+		case ActionCreate:
+			create++
+		case ActionUpdate:
+			update++
+		case ActionDelete:
+			delete++
+		}
+	}
+	return
+}
+
+type differ struct {
+	prune   bool
+	upserts [numTypes][]*Change
+	deletes [numTypes][]*Change
+}
+
+// diff plans the changes which make live, the state of a scope read from the
+// controller, match desired, the contents of the scope parsed from a file.
+// Resources are matched by name. Live resources missing from the file are
+// only deleted if prune is set. diff records the IDs and versions of the
+// matched live resources on the desired ones.
+func diff(desired, live *Scope, prune bool) (*Plan, error) {
+	if err := validate(desired, live.typ, ""); err != nil {
+		return nil, err
+	}
+	desired.id, desired.version, desired.typ = live.id, live.version, live.typ
+
+	d := &differ{prune: prune}
+	d.scopeContents(desired, live, "")
+
+	p := new(Plan)
+	for t := 0; t < numTypes; t++ {
+		p.Changes = append(p.Changes, d.upserts[t]...)
+	}
+	for t := numTypes - 1; t >= 0; t-- {
+		p.Changes = append(p.Changes, d.deletes[t]...)
+	}
+	return p, nil
+}
+
+func (d *differ) add(t int, c *Change) {
+	c.Type = typeNames[t]
+	switch c.Action {
+	case ActionDelete:
+		d.deletes[t] = append(d.deletes[t], c)
+	default:
+		d.upserts[t] = append(d.upserts[t], c)
+	}
+}
+
+// upsert records the creation of a resource without an ID or, if fields is
+// not empty, the update of an existing one.
+func (d *differ) upsert(t int, c *Change, fields []string) {
+	switch {
+	case c.Id == "":
+		c.Action = ActionCreate
+	case len(fields) > 0:
+		c.Action = ActionUpdate
+		c.ChangedFields = fields
+	default:
+		return
+	}
+	d.add(t, c)
+}
+
+// scopeContents plans the changes to the contents of desired. live is nil if
+// the scope doesn't exist yet.
+func (d *differ) scopeContents(desired, live *Scope, address string) {
+	if live == nil {
+		live = new(Scope)
+	}
+
+	liveRoles := make(map[string]*Role, len(live.Roles))
+	for _, r := range live.Roles {
+		liveRoles[r.Name] = r
+	}
+	for _, r := range desired.Roles {
+		c := &Change{Address: childAddress(address, "role", r.Name), scope: desired, resource: r}
+		var fields []string
+		if l, ok := liveRoles[r.Name]; ok {
+			delete(liveRoles, r.Name)
+			r.id, r.version = l.id, l.version
+			c.Id, c.Version = l.id, l.version
+			if r.Description != l.Description {
+				fields = append(fields, "description")
+			}
+			if grantScopeId(r, desired) != grantScopeId(l, live) {
+				fields = append(fields, "grant_scope_id")
+			}
+			if !sameSet(r.Grants, l.Grants) {
+				fields = append(fields, "grants")
+			}
+			if !sameSet(r.Principals, l.Principals) {
+				fields = append(fields, "principals")
+			}
+		}
+		d.upsert(typeRole, c, fields)
+	}
+	for _, r := range live.Roles {
+		if _, ok := liveRoles[r.Name]; ok {
+			d.prunable(typeRole, childAddress(address, "role", r.Name), r.id)
+		}
+	}
+
+	liveScopes := make(map[string]*Scope, len(live.Scopes))
+	for _, s := range live.Scopes {
+		liveScopes[s.Name] = s
+	}
+	for _, s := range desired.Scopes {
+		sAddress := childAddress(address, "scope", s.Name)
+		s.typ = childType(desired.typ)
+		c := &Change{Address: sAddress, scope: desired, resource: s}
+		var fields []string
+		l, ok := liveScopes[s.Name]
+		if ok {
+			delete(liveScopes, s.Name)
+			s.id, s.version = l.id, l.version
+			c.Id, c.Version = l.id, l.version
+			if s.Description != l.Description {
+				fields = append(fields, "description")
+			}
+		}
+		d.upsert(typeScope, c, fields)
+		d.scopeContents(s, l, sAddress)
+	}
+	for _, s := range live.Scopes {
+		if _, ok := liveScopes[s.Name]; ok {
+			d.prunable(typeScope, childAddress(address, "scope", s.Name), s.id)
+		}
+	}
+
+	liveCatalogs := make(map[string]*HostCatalog, len(live.HostCatalogs))
+	for _, hc := range live.HostCatalogs {
+		liveCatalogs[hc.Name] = hc
+	}
+	for _, hc := range desired.HostCatalogs {
+		hcAddress := childAddress(address, "host_catalog", hc.Name)
+		c := &Change{Address: hcAddress, scope: desired, resource: hc}
+		var fields []string
+		l, ok := liveCatalogs[hc.Name]
+		if ok {
+			delete(liveCatalogs, hc.Name)
+			hc.id, hc.version = l.id, l.version
+			c.Id, c.Version = l.id, l.version
+			if hc.Description != l.Description {
+				fields = append(fields, "description")
+			}
+		}
+		d.upsert(typeHostCatalog, c, fields)
+		d.catalogContents(desired, hc, l, hcAddress)
+	}
+	for _, hc := range live.HostCatalogs {
+		if _, ok := liveCatalogs[hc.Name]; ok {
+			d.prunable(typeHostCatalog, childAddress(address, "host_catalog", hc.Name), hc.id)
+		}
+	}
+
+	liveTargets := make(map[string]*Target, len(live.Targets))
+	for _, t := range live.Targets {
+		liveTargets[t.Name] = t
+	}
+	for _, t := range desired.Targets {
+		c := &Change{Address: childAddress(address, "target", t.Name), scope: desired, resource: t}
+		var fields []string
+		if l, ok := liveTargets[t.Name]; ok {
+			delete(liveTargets, t.Name)
+			t.id, t.version = l.id, l.version
+			c.Id, c.Version = l.id, l.version
+			if t.Description != l.Description {
+				fields = append(fields, "description")
+			}
+			if t.DefaultPort != 0 && t.DefaultPort != l.DefaultPort {
+				fields = append(fields, "default_port")
+			}
+			if t.SessionMaxSeconds != 0 && t.SessionMaxSeconds != l.SessionMaxSeconds {
+				fields = append(fields, "session_max_seconds")
+			}
+			if t.SessionConnectionLimit != 0 && t.SessionConnectionLimit != l.SessionConnectionLimit {
+				fields = append(fields, "session_connection_limit")
+			}
+			if !sameSet(t.HostSets, l.HostSets) {
+				fields = append(fields, "host_sets")
+			}
+		}
+		d.upsert(typeTarget, c, fields)
+	}
+	for _, t := range live.Targets {
+		if _, ok := liveTargets[t.Name]; ok {
+			d.prunable(typeTarget, childAddress(address, "target", t.Name), t.id)
+		}
+	}
+}
+
+// catalogContents plans the changes to the hosts and host sets of desired,
+// a host catalog in scope s. live is nil if the catalog doesn't exist yet.
+func (d *differ) catalogContents(s *Scope, desired, live *HostCatalog, address string) {
+	if live == nil {
+		live = new(HostCatalog)
+	}
+
+	liveHosts := make(map[string]*Host, len(live.Hosts))
+	for _, h := range live.Hosts {
+		liveHosts[h.Name] = h
+	}
+	for _, h := range desired.Hosts {
+		c := &Change{Address: childAddress(address, "host", h.Name), scope: s, catalog: desired, resource: h}
+		var fields []string
+		if l, ok := liveHosts[h.Name]; ok {
+			delete(liveHosts, h.Name)
+			h.id, h.version = l.id, l.version
+			c.Id, c.Version = l.id, l.version
+			if h.Description != l.Description {
+				fields = append(fields, "description")
+			}
+			if h.Address != l.Address {
+				fields = append(fields, "address")
+			}
+			if !sameLabels(h.Labels, l.Labels) {
+				fields = append(fields, "labels")
+			}
+		}
+		d.upsert(typeHost, c, fields)
+	}
+	for _, h := range live.Hosts {
+		if _, ok := liveHosts[h.Name]; ok {
+			d.prunable(typeHost, childAddress(address, "host", h.Name), h.id)
+		}
+	}
+
+	liveSets := make(map[string]*HostSet, len(live.HostSets))
+	for _, hs := range live.HostSets {
+		liveSets[hs.Name] = hs
+	}
+	for _, hs := range desired.HostSets {
+		c := &Change{Address: childAddress(address, "host_set", hs.Name), scope: s, catalog: desired, resource: hs}
+		var fields []string
+		if l, ok := liveSets[hs.Name]; ok {
+			delete(liveSets, hs.Name)
+			hs.id, hs.version = l.id, l.version
+			c.Id, c.Version = l.id, l.version
+			if hs.Description != l.Description {
+				fields = append(fields, "description")
+			}
+			if !sameSet(assignedHosts(desired, hs), l.Hosts) {
+				fields = append(fields, "hosts")
+			}
+			if !sameSelector(hs.Selector, l.Selector) {
+				fields = append(fields, "selector")
+			}
+		}
+		d.upsert(typeHostSet, c, fields)
+	}
+	for _, hs := range live.HostSets {
+		if _, ok := liveSets[hs.Name]; ok {
+			d.prunable(typeHostSet, childAddress(address, "host_set", hs.Name), hs.id)
+		}
+	}
+}
+
+// prunable records the deletion of a live resource missing from the file if
+// pruning is enabled. The contents of a deleted scope or host catalog are
+// deleted along with it and aren't planned separately.
+func (d *differ) prunable(t int, address, id string) {
+	if d.prune {
+		d.add(t, &Change{Action: ActionDelete, Address: address, Id: id})
+	}
+}
+
+// grantScopeId returns the effective grant scope of a role in scope s.
+func grantScopeId(r *Role, s *Scope) string {
+	if r.GrantScopeId == "" {
+		return s.id
+	}
+	return r.GrantScopeId
+}
+
+func sameSet(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as := append([]string(nil), a...)
+	bs := append([]string(nil), b...)
+	sort.Strings(as)
+	sort.Strings(bs)
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// assignedHosts returns the hosts of hs which are not selected by its
+// selector, using the labels of the hosts of hc.
+func assignedHosts(hc *HostCatalog, hs *HostSet) []string {
+	if hs.Selector == "" {
+		return hs.Hosts
+	}
+	labels := make(map[string]map[string]string, len(hc.Hosts))
+	for _, h := range hc.Hosts {
+		labels[h.Name] = h.Labels
+	}
+	var ret []string
+	for _, name := range hs.Hosts {
+		if selected, err := static.MatchSelector(hs.Selector, labels[name]); err == nil && selected {
+			continue
+		}
+		ret = append(ret, name)
+	}
+	return ret
+}
+
+// sameSelector reports whether the selectors a and b select the same hosts
+// after normalization.
+func sameSelector(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	na, err := static.NormalizeSelector(a)
+	if err != nil {
+		return false
+	}
+	nb, err := static.NormalizeSelector(b)
+	if err != nil {
+		return false
+	}
+	return na == nb
+}
+
+func sameLabels(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply makes the changes of the plan in order, calling applied after each
+// one. It stops at the first change which fails and returns its error.
+func (p *Plan) Apply(ctx context.Context, client *api.Client, applied func(*Change)) error {
+	for _, c := range p.Changes {
+		var err error
+		switch c.Action {
+		case ActionDelete:
+			err = deleteResource(ctx, client, c)
+		default:
+			switch r := c.resource.(type) {
+			case *Scope:
+				err = applyScope(ctx, client, c, r)
+			case *Role:
+				err = applyRole(ctx, client, c, r)
+			case *HostCatalog:
+				err = applyHostCatalog(ctx, client, c, r)
+			case *Host:
+				err = applyHost(ctx, client, c, r)
+			case *HostSet:
+				err = applyHostSet(ctx, client, c, r)
+			case *Target:
+				err = applyTarget(ctx, client, c, r)
+			default:
+				err = fmt.Errorf("unknown resource %T", c.resource)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if applied != nil {
+			applied(c)
+		}
+	}
+	return nil
+}
+
+func deleteResource(ctx context.Context, client *api.Client, c *Change) error {
+	var err error
+	switch c.Type {
+	case typeNames[typeScope]:
+		_, err = scopes.NewClient(client).Delete(ctx, c.Id)
+	case typeNames[typeRole]:
+		_, err = roles.NewClient(client).Delete(ctx, c.Id)
+	case typeNames[typeHostCatalog]:
+		_, err = hostcatalogs.NewClient(client).Delete(ctx, c.Id)
+	case typeNames[typeHost]:
+		_, err = hosts.NewClient(client).Delete(ctx, c.Id)
+	case typeNames[typeHostSet]:
+		_, err = hostsets.NewClient(client).Delete(ctx, c.Id)
+	case typeNames[typeTarget]:
+		_, err = targets.NewClient(client).Delete(ctx, c.Id)
+	default:
+		err = fmt.Errorf("unknown resource type %q", c.Type)
+	}
+	return err
+}
+
+func applyScope(ctx context.Context, client *api.Client, c *Change, s *Scope) error {
+	sc := scopes.NewClient(client)
+	if c.Action == ActionCreate {
+		opts := []scopes.Option{scopes.WithName(s.Name)}
+		if s.Description != "" {
+			opts = append(opts, scopes.WithDescription(s.Description))
+		}
+		// The roles declared for a scope are all of its roles
+		if len(s.Roles) > 0 {
+			opts = append(opts, scopes.WithSkipAdminRoleCreation(true), scopes.WithSkipDefaultRoleCreation(true))
+		}
+		result, err := sc.Create(ctx, c.scope.id, opts...)
+		if err != nil {
+			return err
+		}
+		s.id, s.version = result.Item.Id, result.Item.Version
+		c.Id = s.id
+		return nil
+	}
+
+	opts := []scopes.Option{scopes.DefaultDescription()}
+	if s.Description != "" {
+		opts = []scopes.Option{scopes.WithDescription(s.Description)}
+	}
+	result, err := sc.Update(ctx, s.id, s.version, opts...)
+	if err != nil {
+		return err
+	}
+	s.version = result.Item.Version
+	return nil
+}
+
+func applyRole(ctx context.Context, client *api.Client, c *Change, r *Role) error {
+	rc := roles.NewClient(client)
+	switch c.Action {
+	case ActionCreate:
+		opts := []roles.Option{roles.WithName(r.Name)}
+		if r.Description != "" {
+			opts = append(opts, roles.WithDescription(r.Description))
+		}
+		if r.GrantScopeId != "" {
+			opts = append(opts, roles.WithGrantScopeId(r.GrantScopeId))
+		}
+		result, err := rc.Create(ctx, c.scope.id, opts...)
+		if err != nil {
+			return err
+		}
+		r.id, r.version = result.Item.Id, result.Item.Version
+		c.Id = r.id
+
+	default:
+		if c.changed("description") || c.changed("grant_scope_id") {
+			opts := []roles.Option{roles.DefaultDescription(), roles.WithGrantScopeId(grantScopeId(r, c.scope))}
+			if r.Description != "" {
+				opts[0] = roles.WithDescription(r.Description)
+			}
+			result, err := rc.Update(ctx, r.id, r.version, opts...)
+			if err != nil {
+				return err
+			}
+			r.version = result.Item.Version
+		}
+	}
+
+	if c.changed("grants") && (c.Action == ActionUpdate || len(r.Grants) > 0) {
+		result, err := rc.SetGrants(ctx, r.id, r.version, r.Grants)
+		if err != nil {
+			return err
+		}
+		r.version = result.Item.Version
+	}
+	if c.changed("principals") && (c.Action == ActionUpdate || len(r.Principals) > 0) {
+		result, err := rc.SetPrincipals(ctx, r.id, r.version, r.Principals)
+		if err != nil {
+			return err
+		}
+		r.version = result.Item.Version
+	}
+	return nil
+}
+
+func applyHostCatalog(ctx context.Context, client *api.Client, c *Change, hc *HostCatalog) error {
+	hcc := hostcatalogs.NewClient(client)
+	if c.Action == ActionCreate {
+		opts := []hostcatalogs.Option{hostcatalogs.WithName(hc.Name)}
+		if hc.Description != "" {
+			opts = append(opts, hostcatalogs.WithDescription(hc.Description))
+		}
+		result, err := hcc.Create(ctx, staticType, c.scope.id, opts...)
+		if err != nil {
+			return err
+		}
+		hc.id, hc.version = result.Item.Id, result.Item.Version
+		c.Id = hc.id
+		return nil
+	}
+
+	opts := []hostcatalogs.Option{hostcatalogs.DefaultDescription()}
+	if hc.Description != "" {
+		opts = []hostcatalogs.Option{hostcatalogs.WithDescription(hc.Description)}
+	}
+	result, err := hcc.Update(ctx, hc.id, hc.version, opts...)
+	if err != nil {
+		return err
+	}
+	hc.version = result.Item.Version
+	return nil
+}
+
+func applyHost(ctx context.Context, client *api.Client, c *Change, h *Host) error {
+	hc := hosts.NewClient(client)
+	opts := []hosts.Option{hosts.WithStaticHostAddress(h.Address)}
+	switch {
+	case h.Description != "":
+		opts = append(opts, hosts.WithDescription(h.Description))
+	case c.Action == ActionUpdate:
+		opts = append(opts, hosts.DefaultDescription())
+	}
+	switch {
+	case len(h.Labels) > 0:
+		opts = append(opts, hosts.WithStaticHostLabels(h.Labels))
+	case c.Action == ActionUpdate:
+		opts = append(opts, hosts.DefaultStaticHostLabels())
+	}
+
+	if c.Action == ActionCreate {
+		result, err := hc.Create(ctx, c.catalog.id, append(opts, hosts.WithName(h.Name))...)
+		if err != nil {
+			return err
+		}
+		h.id, h.version = result.Item.Id, result.Item.Version
+		c.Id = h.id
+		return nil
+	}
+
+	result, err := hc.Update(ctx, h.id, h.version, opts...)
+	if err != nil {
+		return err
+	}
+	h.version = result.Item.Version
+	return nil
+}
+
+func applyHostSet(ctx context.Context, client *api.Client, c *Change, hs *HostSet) error {
+	hsc := hostsets.NewClient(client)
+	switch c.Action {
+	case ActionCreate:
+		opts := []hostsets.Option{hostsets.WithName(hs.Name)}
+		if hs.Description != "" {
+			opts = append(opts, hostsets.WithDescription(hs.Description))
+		}
+		if hs.Selector != "" {
+			opts = append(opts, hostsets.WithStaticHostSetSelector(hs.Selector))
+		}
+		result, err := hsc.Create(ctx, c.catalog.id, opts...)
+		if err != nil {
+			return err
+		}
+		hs.id, hs.version = result.Item.Id, result.Item.Version
+		c.Id = hs.id
+
+	default:
+		var opts []hostsets.Option
+		if c.changed("description") {
+			opt := hostsets.DefaultDescription()
+			if hs.Description != "" {
+				opt = hostsets.WithDescription(hs.Description)
+			}
+			opts = append(opts, opt)
+		}
+		if c.changed("selector") {
+			opt := hostsets.DefaultStaticHostSetSelector()
+			if hs.Selector != "" {
+				opt = hostsets.WithStaticHostSetSelector(hs.Selector)
+			}
+			opts = append(opts, opt)
+		}
+		if len(opts) > 0 {
+			result, err := hsc.Update(ctx, hs.id, hs.version, opts...)
+			if err != nil {
+				return err
+			}
+			hs.version = result.Item.Version
+		}
+	}
+
+	// Hosts selected by the selector are members without being assigned
+	assigned := assignedHosts(c.catalog, hs)
+	if c.changed("hosts") && (c.Action == ActionUpdate || len(assigned) > 0) {
+		hostIds := make([]string, 0, len(assigned))
+		for _, name := range assigned {
+			for _, h := range c.catalog.Hosts {
+				if h.Name == name {
+					hostIds = append(hostIds, h.id)
+				}
+			}
+		}
+		result, err := hsc.SetHosts(ctx, hs.id, hs.version, hostIds)
+		if err != nil {
+			return err
+		}
+		hs.version = result.Item.Version
+	}
+	return nil
+}
+
+func applyTarget(ctx context.Context, client *api.Client, c *Change, t *Target) error {
+	tc := targets.NewClient(client)
+	var opts []targets.Option
+	switch {
+	case t.Description != "":
+		opts = append(opts, targets.WithDescription(t.Description))
+	case c.Action == ActionUpdate:
+		opts = append(opts, targets.DefaultDescription())
+	}
+	if t.DefaultPort != 0 {
+		opts = append(opts, targets.WithTcpTargetDefaultPort(uint32(t.DefaultPort)))
+	}
+	if t.SessionMaxSeconds != 0 {
+		opts = append(opts, targets.WithSessionMaxSeconds(uint32(t.SessionMaxSeconds)))
+	}
+	if t.SessionConnectionLimit != 0 {
+		opts = append(opts, targets.WithSessionConnectionLimit(int32(t.SessionConnectionLimit)))
+	}
+
+	switch c.Action {
+	case ActionCreate:
+		result, err := tc.Create(ctx, targetTypeTcp, c.scope.id, append(opts, targets.WithName(t.Name))...)
+		if err != nil {
+			return err
+		}
+		t.id, t.version = result.Item.Id, result.Item.Version
+		c.Id = t.id
+
+	default:
+		if len(c.ChangedFields) > 1 || !c.changed("host_sets") {
+			result, err := tc.Update(ctx, t.id, t.version, opts...)
+			if err != nil {
+				return err
+			}
+			t.version = result.Item.Version
+		}
+	}
+
+	if c.changed("host_sets") && (c.Action == ActionUpdate || len(t.HostSets) > 0) {
+		hostSetIds := make([]string, 0, len(t.HostSets))
+		for _, ref := range t.HostSets {
+			parts := strings.SplitN(ref, "/", 2)
+			for _, hc := range c.scope.HostCatalogs {
+				if hc.Name != parts[0] {
+					continue
+				}
+				for _, hs := range hc.HostSets {
+					if hs.Name == parts[1] {
+						hostSetIds = append(hostSetIds, hs.id)
+					}
+				}
+			}
+		}
+		result, err := tc.SetHostSets(ctx, t.id, t.version, hostSetIds)
+		if err != nil {
+			return err
+		}
+		t.version = result.Item.Version
+	}
+	return nil
+}
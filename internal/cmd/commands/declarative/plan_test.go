@@ -0,0 +1,232 @@
+package declarative
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLive returns the live state of a global scope matching testFile, with
+// an extra unmanaged org and role.
+func testLive() *Scope {
+	return &Scope{
+		id:  scope.Global.String(),
+		typ: scope.Global,
+		Roles: []*Role{
+			{Name: "Administration", Grants: []string{"id=*;type=*;actions=*"}, Principals: []string{"u_1234567890"}, id: "r_admin", version: 3},
+			{Name: "Unmanaged", id: "r_other", version: 1},
+		},
+		Scopes: []*Scope{
+			{
+				Name:        "Engineering",
+				Description: "Engineering org",
+				id:          "o_eng",
+				version:     1,
+				typ:         scope.Org,
+				Scopes: []*Scope{
+					{
+						Name:    "Production",
+						id:      "p_prod",
+						version: 1,
+						typ:     scope.Project,
+						HostCatalogs: []*HostCatalog{
+							{
+								Name:    "servers",
+								id:      "hcst_servers",
+								version: 1,
+								Hosts: []*Host{
+									{Name: "web-1", Address: "10.0.0.1", Labels: map[string]string{"env": "prod"}, id: "hst_web1", version: 1},
+									{Name: "web-2", Address: "10.0.0.2", id: "hst_web2", version: 1},
+								},
+								HostSets: []*HostSet{
+									{Name: "web", Hosts: []string{"web-2", "web-1"}, id: "hsst_web", version: 4},
+								},
+							},
+						},
+						Targets: []*Target{
+							{Name: "web-ssh", Type: "tcp", DefaultPort: 22, SessionMaxSeconds: 28800, SessionConnectionLimit: -1, HostSets: []string{"servers/web"}, id: "ttcp_web", version: 2},
+						},
+					},
+				},
+			},
+			{Name: "Other", id: "o_other", version: 1, typ: scope.Org},
+		},
+	}
+}
+
+type changeSummary struct {
+	action  Action
+	address string
+	fields  []string
+}
+
+func summarize(p *Plan) []changeSummary {
+	var ret []changeSummary
+	for _, c := range p.Changes {
+		ret = append(ret, changeSummary{action: c.Action, address: c.Address, fields: c.ChangedFields})
+	}
+	return ret
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		prune bool
+		want  []changeSummary
+	}{
+		{
+			name: "no-changes",
+			file: testFile,
+		},
+		{
+			name:  "prune",
+			file:  testFile,
+			prune: true,
+			want: []changeSummary{
+				{action: ActionDelete, address: `role["Unmanaged"]`},
+				{action: ActionDelete, address: `scope["Other"]`},
+			},
+		},
+		{
+			name: "updates",
+			file: `
+role "Administration" {
+  grants     = ["id=*;type=*;actions=*"]
+  principals = ["u_1234567890", "g_1234567890"]
+}
+
+scope "Engineering" {
+  description = "Engineering"
+
+  scope "Production" {
+    host_catalog "servers" {
+      host "web-1" {
+        address = "10.0.0.11"
+      }
+      host "web-2" {
+        address = "10.0.0.2"
+      }
+      host_set "web" {
+        hosts = ["web-2"]
+      }
+    }
+
+    target "web-ssh" {
+      default_port = 2222
+      host_sets    = ["servers/web"]
+    }
+  }
+}
+`,
+			want: []changeSummary{
+				{action: ActionUpdate, address: `scope["Engineering"]`, fields: []string{"description"}},
+				{action: ActionUpdate, address: `scope["Engineering"].scope["Production"].host_catalog["servers"].host["web-1"]`, fields: []string{"address", "labels"}},
+				{action: ActionUpdate, address: `scope["Engineering"].scope["Production"].host_catalog["servers"].host_set["web"]`, fields: []string{"hosts"}},
+				{action: ActionUpdate, address: `scope["Engineering"].scope["Production"].target["web-ssh"]`, fields: []string{"default_port"}},
+				{action: ActionUpdate, address: `role["Administration"]`, fields: []string{"principals"}},
+			},
+		},
+		{
+			name: "creates-in-dependency-order",
+			file: `
+scope "Sales" {
+  role "Readers" {
+    grants = ["id=*;type=*;actions=read"]
+  }
+  scope "Demo" {
+    target "demo" {
+      host_sets = ["demo/all"]
+    }
+    host_catalog "demo" {
+      host "a" {
+        address = "10.1.0.1"
+      }
+      host_set "all" {
+        hosts = ["a"]
+      }
+    }
+  }
+}
+`,
+			prune: true,
+			want: []changeSummary{
+				{action: ActionCreate, address: `scope["Sales"]`},
+				{action: ActionCreate, address: `scope["Sales"].scope["Demo"]`},
+				{action: ActionCreate, address: `scope["Sales"].scope["Demo"].host_catalog["demo"]`},
+				{action: ActionCreate, address: `scope["Sales"].scope["Demo"].host_catalog["demo"].host["a"]`},
+				{action: ActionCreate, address: `scope["Sales"].scope["Demo"].host_catalog["demo"].host_set["all"]`},
+				{action: ActionCreate, address: `scope["Sales"].scope["Demo"].target["demo"]`},
+				{action: ActionCreate, address: `scope["Sales"].role["Readers"]`},
+				{action: ActionDelete, address: `role["Administration"]`},
+				{action: ActionDelete, address: `role["Unmanaged"]`},
+				{action: ActionDelete, address: `scope["Engineering"]`},
+				{action: ActionDelete, address: `scope["Other"]`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			desired, err := Parse(tt.file)
+			require.NoError(t, err)
+			p, err := diff(desired, testLive(), tt.prune)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, summarize(p))
+		})
+	}
+}
+
+func TestDiff_Versions(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	desired, err := Parse(`scope "Engineering" { scope "Production" { target "web-ssh" { description = "ssh" } } }`)
+	require.NoError(err)
+	p, err := diff(desired, testLive(), false)
+	require.NoError(err)
+
+	// The org lost its description
+	require.Len(p.Changes, 2)
+	c := p.Changes[1]
+	assert.Equal(ActionUpdate, c.Action)
+	assert.Equal("target", c.Type)
+	assert.Equal("ttcp_web", c.Id)
+	assert.Equal(uint32(2), c.Version)
+	// The file declares no host sets for the target
+	assert.Equal([]string{"description", "host_sets"}, c.ChangedFields)
+
+	// Matched resources carry their live IDs, so references to them resolve
+	// when the plan is applied
+	assert.Equal("p_prod", c.scope.id)
+	assert.Equal("o_eng", desired.Scopes[0].id)
+}
+
+func TestDiff_Selector(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	// Hosts selected by the selector of a live set are read back without
+	// being assigned to it
+	live := testLive()
+	set := live.Scopes[0].Scopes[0].HostCatalogs[0].HostSets[0]
+	set.Selector = "env=prod"
+	set.Hosts = []string{"web-2"}
+
+	desired, err := Parse(strings.Replace(testFile, `hosts = ["web-1", "web-2"]`, `hosts = ["web-1", "web-2"]
+        selector = "env = prod"`, 1))
+	require.NoError(err)
+	p, err := diff(desired, live, false)
+	require.NoError(err)
+	assert.Empty(summarize(p))
+
+	// Dropping the selector assigns the hosts it selected
+	desired, err = Parse(testFile)
+	require.NoError(err)
+	p, err = diff(desired, live, false)
+	require.NoError(err)
+	assert.Equal([]changeSummary{
+		{action: ActionUpdate, address: `scope["Engineering"].scope["Production"].host_catalog["servers"].host_set["web"]`, fields: []string{"hosts", "selector"}},
+	}, summarize(p))
+}
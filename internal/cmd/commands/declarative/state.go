@@ -0,0 +1,270 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/api/hosts"
+	"github.com/hashicorp/boundary/api/hostsets"
+	"github.com/hashicorp/boundary/api/roles"
+	"github.com/hashicorp/boundary/api/scopes"
+	"github.com/hashicorp/boundary/api/targets"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// staticType is the type of the host catalogs, hosts and host sets managed by
+// declarative files
+const staticType = "static"
+
+// reader reads the live state of a scope subtree through the API. Resources
+// that can't be described in a file, such as unnamed ones, are skipped and a
+// warning is recorded.
+type reader struct {
+	client   *api.Client
+	warnings []string
+}
+
+func (r *reader) warn(format string, a ...interface{}) {
+	r.warnings = append(r.warnings, fmt.Sprintf(format, a...))
+}
+
+// readScope returns the live state of the scope with the given ID and
+// everything in it.
+func (r *reader) readScope(ctx context.Context, scopeId, address string) (*Scope, error) {
+	sr, err := scopes.NewClient(r.client).Read(ctx, scopeId)
+	if err != nil {
+		return nil, err
+	}
+	s := &Scope{
+		Name:        sr.Item.Name,
+		Description: sr.Item.Description,
+		id:          sr.Item.Id,
+		version:     sr.Item.Version,
+		typ:         scope.Map[sr.Item.Type],
+	}
+	if s.typ == scope.Unknown {
+		return nil, fmt.Errorf("unknown type %q of scope %s", sr.Item.Type, scopeId)
+	}
+
+	if s.Roles, err = r.readRoles(ctx, s, address); err != nil {
+		return nil, err
+	}
+
+	if s.typ != scope.Project {
+		children, err := scopes.NewClient(r.client).List(ctx, s.id, scopes.WithAll(true))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range children.Items {
+			if item.Name == "" {
+				r.warn("%s: skipping unnamed scope %s", address, item.Id)
+				continue
+			}
+			child, err := r.readScope(ctx, item.Id, childAddress(address, "scope", item.Name))
+			if err != nil {
+				return nil, err
+			}
+			s.Scopes = append(s.Scopes, child)
+		}
+		sort.Slice(s.Scopes, func(i, j int) bool { return s.Scopes[i].Name < s.Scopes[j].Name })
+		return s, nil
+	}
+
+	hostSetRefs := make(map[string]string)
+	if s.HostCatalogs, err = r.readHostCatalogs(ctx, s, address, hostSetRefs); err != nil {
+		return nil, err
+	}
+	if s.Targets, err = r.readTargets(ctx, s, address, hostSetRefs); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (r *reader) readRoles(ctx context.Context, s *Scope, address string) ([]*Role, error) {
+	rc := roles.NewClient(r.client)
+	list, err := rc.List(ctx, s.id, roles.WithAll(true))
+	if err != nil {
+		return nil, err
+	}
+	var ret []*Role
+	for _, item := range list.Items {
+		if item.Name == "" {
+			r.warn("%s: skipping unnamed role %s", address, item.Id)
+			continue
+		}
+		// List results don't include principals and grants
+		rr, err := rc.Read(ctx, item.Id)
+		if err != nil {
+			return nil, err
+		}
+		role := &Role{
+			Name:        rr.Item.Name,
+			Description: rr.Item.Description,
+			Grants:      rr.Item.GrantStrings,
+			Principals:  rr.Item.PrincipalIds,
+			id:          rr.Item.Id,
+			version:     rr.Item.Version,
+		}
+		if rr.Item.GrantScopeId != s.id {
+			role.GrantScopeId = rr.Item.GrantScopeId
+		}
+		ret = append(ret, role)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret, nil
+}
+
+// readHostCatalogs reads the static host catalogs of a project, filling in
+// hostSetRefs with the "<catalog name>/<set name>" reference of each host
+// set ID.
+func (r *reader) readHostCatalogs(ctx context.Context, s *Scope, address string, hostSetRefs map[string]string) ([]*HostCatalog, error) {
+	list, err := hostcatalogs.NewClient(r.client).List(ctx, s.id, hostcatalogs.WithAll(true))
+	if err != nil {
+		return nil, err
+	}
+	hc := hosts.NewClient(r.client)
+	hsc := hostsets.NewClient(r.client)
+	var ret []*HostCatalog
+	for _, item := range list.Items {
+		switch {
+		case item.Name == "":
+			r.warn("%s: skipping unnamed host catalog %s", address, item.Id)
+			continue
+		case item.Type != staticType:
+			r.warn("%s: skipping %s-type host catalog %s", address, item.Type, item.Id)
+			continue
+		}
+		catalog := &HostCatalog{
+			Name:        item.Name,
+			Description: item.Description,
+			id:          item.Id,
+			version:     item.Version,
+		}
+		hcAddress := childAddress(address, "host_catalog", item.Name)
+
+		hostList, err := hc.List(ctx, item.Id, hosts.WithAll(true))
+		if err != nil {
+			return nil, err
+		}
+		hostNames := make(map[string]string)
+		for _, h := range hostList.Items {
+			if h.Name == "" {
+				r.warn("%s: skipping unnamed host %s", hcAddress, h.Id)
+				continue
+			}
+			host := &Host{
+				Name:        h.Name,
+				Description: h.Description,
+				id:          h.Id,
+				version:     h.Version,
+			}
+			if address, ok := h.Attributes["address"].(string); ok {
+				host.Address = address
+			}
+			if labels, ok := h.Attributes["labels"].(map[string]interface{}); ok && len(labels) > 0 {
+				host.Labels = make(map[string]string, len(labels))
+				for k, v := range labels {
+					host.Labels[k] = fmt.Sprint(v)
+				}
+			}
+			hostNames[h.Id] = h.Name
+			catalog.Hosts = append(catalog.Hosts, host)
+		}
+		sort.Slice(catalog.Hosts, func(i, j int) bool { return catalog.Hosts[i].Name < catalog.Hosts[j].Name })
+
+		setList, err := hsc.List(ctx, item.Id, hostsets.WithAll(true))
+		if err != nil {
+			return nil, err
+		}
+		for _, hs := range setList.Items {
+			if hs.Name == "" {
+				r.warn("%s: skipping unnamed host set %s", hcAddress, hs.Id)
+				continue
+			}
+			// List results don't include the hosts of a set
+			hsr, err := hsc.Read(ctx, hs.Id)
+			if err != nil {
+				return nil, err
+			}
+			set := &HostSet{
+				Name:        hsr.Item.Name,
+				Description: hsr.Item.Description,
+				id:          hsr.Item.Id,
+				version:     hsr.Item.Version,
+			}
+			if selector, ok := hsr.Item.Attributes["selector"].(string); ok {
+				set.Selector = selector
+			}
+			for _, id := range hsr.Item.HostIds {
+				name, ok := hostNames[id]
+				if !ok {
+					r.warn("%s: skipping unnamed host %s", childAddress(hcAddress, "host_set", hs.Name), id)
+					continue
+				}
+				set.Hosts = append(set.Hosts, name)
+			}
+			// The hosts of a set include the ones its selector selects,
+			// which are not assigned to it.
+			set.Hosts = assignedHosts(catalog, set)
+			sort.Strings(set.Hosts)
+			hostSetRefs[hs.Id] = item.Name + "/" + hs.Name
+			catalog.HostSets = append(catalog.HostSets, set)
+		}
+		sort.Slice(catalog.HostSets, func(i, j int) bool { return catalog.HostSets[i].Name < catalog.HostSets[j].Name })
+
+		ret = append(ret, catalog)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret, nil
+}
+
+func (r *reader) readTargets(ctx context.Context, s *Scope, address string, hostSetRefs map[string]string) ([]*Target, error) {
+	tc := targets.NewClient(r.client)
+	list, err := tc.List(ctx, s.id, targets.WithAll(true))
+	if err != nil {
+		return nil, err
+	}
+	var ret []*Target
+	for _, item := range list.Items {
+		switch {
+		case item.Name == "":
+			r.warn("%s: skipping unnamed target %s", address, item.Id)
+			continue
+		case item.Type != targetTypeTcp:
+			r.warn("%s: skipping %s-type target %s", address, item.Type, item.Id)
+			continue
+		}
+		// List results don't include the host sets of a target
+		tr, err := tc.Read(ctx, item.Id)
+		if err != nil {
+			return nil, err
+		}
+		t := &Target{
+			Name:                   tr.Item.Name,
+			Description:            tr.Item.Description,
+			Type:                   tr.Item.Type,
+			SessionMaxSeconds:      int(tr.Item.SessionMaxSeconds),
+			SessionConnectionLimit: int(tr.Item.SessionConnectionLimit),
+			id:                     tr.Item.Id,
+			version:                tr.Item.Version,
+		}
+		if port, ok := tr.Item.Attributes["default_port"].(float64); ok {
+			t.DefaultPort = int(port)
+		}
+		for _, id := range tr.Item.HostSetIds {
+			ref, ok := hostSetRefs[id]
+			if !ok {
+				r.warn("%s: skipping host set %s which can't be referenced by name", childAddress(address, "target", t.Name), id)
+				continue
+			}
+			t.HostSets = append(t.HostSets, ref)
+		}
+		sort.Strings(t.HostSets)
+		ret = append(ret, t)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret, nil
+}
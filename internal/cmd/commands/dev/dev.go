@@ -336,6 +336,11 @@ func (c *Command) Run(args []string) int {
 		return 1
 	}
 
+	if err := c.SetupEvents(c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
 	if c.flagRecoveryKey != "" {
 		c.Config.DevRecoveryKey = c.flagRecoveryKey
 	}
@@ -46,7 +46,7 @@ var flagsMap = map[string][]string{
 	"update":         {"id", "name", "description", "version"},
 	"read":           {"id"},
 	"delete":         {"id"},
-	"list":           {"scope-id"},
+	"list":           {"scope-id", "filter", "page-size", "all", "recursive"},
 	"add-members":    {"id", "member", "version"},
 	"set-members":    {"id", "member", "version"},
 	"remove-members": {"id", "member", "version"},
@@ -141,6 +141,19 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, groups.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, groups.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, groups.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, groups.WithRecursive(true))
+	}
+
 	groupClient := groups.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -248,6 +261,9 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("  ID:            %s", g.Id),
 						fmt.Sprintf("    Version:     %d", g.Version),
 					)
+					if c.FlagRecursive && g.Scope != nil {
+						output = append(output, fmt.Sprintf("    Scope ID:    %s", g.Scope.Id))
+					}
 				}
 				if g.Name != "" {
 					output = append(output,
@@ -0,0 +1,360 @@
+package hostcatalogs
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/hostcatalogs"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*BulkCommand)(nil)
+var _ cli.CommandAutocomplete = (*BulkCommand)(nil)
+
+// BulkCommand imports hosts into and exports hosts from a static host
+// catalog.
+type BulkCommand struct {
+	*base.Command
+
+	Func string
+
+	flagFile       string
+	flagFileFormat string
+	flagDryRun     bool
+}
+
+// csvColumns are the columns of the CSV file format. The id column is
+// ignored on import. Host set IDs are separated by semicolons.
+var csvColumns = []string{"id", "name", "address", "description", "host_set_ids"}
+
+func (c *BulkCommand) Synopsis() string {
+	switch c.Func {
+	case "import-hosts":
+		return "Import hosts into a static-type host catalog"
+	default:
+		return "Export the hosts in a static-type host catalog"
+	}
+}
+
+func (c *BulkCommand) Help() string {
+	var info string
+	switch c.Func {
+	case "import-hosts":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-catalogs import-hosts [options] [args]",
+			"",
+			"  Create or update hosts in a static-type host catalog given its ID, and add them to host sets in the same catalog. Hosts are matched with existing hosts in the catalog by name. All changes are made in a single transaction. Example:",
+			"",
+			`    $ boundary host-catalogs import-hosts -id hcst_1234567890 -file hosts.csv -dry-run`,
+			"",
+			"  The file may be CSV with a header row containing the columns name, address, description and host_set_ids, where multiple host set IDs are separated by semicolons, or a JSON list of objects with the same fields.",
+			"",
+			"",
+		})
+
+	case "export-hosts":
+		info = base.WrapForHelpText([]string{
+			"Usage: boundary host-catalogs export-hosts [options] [args]",
+			"",
+			"  Export the hosts in a static-type host catalog given its ID, along with their host set memberships, in a form which can be passed to import-hosts. Example:",
+			"",
+			`    $ boundary host-catalogs export-hosts -id hcst_1234567890 -file-format csv -file hosts.csv`,
+			"",
+			"",
+		})
+	}
+	return info + c.Flags().Help()
+}
+
+func (c *BulkCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "static-type host catalog", []string{"id"})
+
+	switch c.Func {
+	case "import-hosts":
+		f.StringVar(&base.StringVar{
+			Name:       "file",
+			Target:     &c.flagFile,
+			Completion: complete.PredictFiles("*"),
+			Usage:      `The file containing the hosts to import. If set to "-", the hosts are read from stdin.`,
+		})
+		f.BoolVar(&base.BoolVar{
+			Name:   "dry-run",
+			Target: &c.flagDryRun,
+			Usage:  "Show the changes the import would make without making them.",
+		})
+	case "export-hosts":
+		f.StringVar(&base.StringVar{
+			Name:       "file",
+			Target:     &c.flagFile,
+			Completion: complete.PredictFiles("*"),
+			Usage:      "The file to write the hosts to. If not set, the hosts are written to stdout.",
+		})
+	}
+	f.StringVar(&base.StringVar{
+		Name:       "file-format",
+		Target:     &c.flagFileFormat,
+		Completion: complete.PredictSet("csv", "json"),
+		Usage:      `The format of the hosts file. Valid formats are "csv" or "json". If not set, it is derived from the file extension, defaulting to "json".`,
+	})
+
+	return set
+}
+
+func (c *BulkCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *BulkCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *BulkCommand) Run(args []string) int {
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if c.Func == "import-hosts" && c.flagFile == "" {
+		c.UI.Error("A hosts file must be passed in via -file")
+		return 1
+	}
+
+	fileFormat := c.flagFileFormat
+	if fileFormat == "" {
+		fileFormat = "json"
+		if strings.EqualFold(filepath.Ext(c.flagFile), ".csv") {
+			fileFormat = "csv"
+		}
+	}
+	switch fileFormat {
+	case "csv", "json":
+	default:
+		c.UI.Error(fmt.Sprintf("Unknown file format %q", fileFormat))
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+	hostcatalogClient := hostcatalogs.NewClient(client)
+
+	switch c.Func {
+	case "import-hosts":
+		var r io.Reader
+		switch c.flagFile {
+		case "-":
+			r = os.Stdin
+		default:
+			file, err := os.Open(c.flagFile)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error opening hosts file: %s", err.Error()))
+				return 1
+			}
+			defer file.Close()
+			r = file
+		}
+		hosts, err := readBulkHosts(r, fileFormat)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error reading hosts file: %s", err.Error()))
+			return 1
+		}
+		result, err := hostcatalogClient.ImportHosts(c.Context, c.FlagId, hosts, c.flagDryRun)
+		if err != nil {
+			return c.printError(err)
+		}
+		switch base.Format(c.UI) {
+		case "json":
+			b, err := base.JsonFormatter{}.Format(result.Items)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+		case "table":
+			c.UI.Output(generateImportTableOutput(result.Items, c.flagDryRun))
+		}
+
+	case "export-hosts":
+		result, err := hostcatalogClient.ExportHosts(c.Context, c.FlagId)
+		if err != nil {
+			return c.printError(err)
+		}
+		var buf bytes.Buffer
+		if err := writeBulkHosts(&buf, result.Items, fileFormat); err != nil {
+			c.UI.Error(fmt.Sprintf("Error formatting hosts: %s", err.Error()))
+			return 1
+		}
+		if c.flagFile == "" {
+			c.UI.Output(strings.TrimSuffix(buf.String(), "\n"))
+			return 0
+		}
+		if err := ioutil.WriteFile(c.flagFile, buf.Bytes(), 0o644); err != nil {
+			c.UI.Error(fmt.Sprintf("Error writing hosts file: %s", err.Error()))
+			return 1
+		}
+		c.UI.Output(fmt.Sprintf("Exported %d hosts to %s.", len(result.Items), c.flagFile))
+	}
+
+	return 0
+}
+
+func (c *BulkCommand) printError(err error) int {
+	if apiErr := api.AsServerError(err); apiErr != nil {
+		c.UI.Error(fmt.Sprintf("Error from controller when performing %s on static-type host catalog: %s", c.Func, base.PrintApiError(apiErr)))
+		return 1
+	}
+	c.UI.Error(fmt.Sprintf("Error trying to %s on static-type host catalog: %s", c.Func, err.Error()))
+	return 2
+}
+
+// readBulkHosts parses hosts in the given file format.
+func readBulkHosts(r io.Reader, format string) ([]*hostcatalogs.BulkHost, error) {
+	if format == "json" {
+		var hosts []*hostcatalogs.BulkHost
+		if err := json.NewDecoder(r).Decode(&hosts); err != nil {
+			return nil, err
+		}
+		for _, h := range hosts {
+			h.Id = ""
+		}
+		return hosts, nil
+	}
+
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("missing header row")
+	}
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, c := range csvColumns {
+			if c == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"name", "address"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var hosts []*hostcatalogs.BulkHost
+	for _, record := range records[1:] {
+		h := &hostcatalogs.BulkHost{
+			Name:        field(record, "name"),
+			Address:     field(record, "address"),
+			Description: field(record, "description"),
+		}
+		for _, id := range strings.Split(field(record, "host_set_ids"), ";") {
+			if id = strings.TrimSpace(id); id != "" {
+				h.HostSetIds = append(h.HostSetIds, id)
+			}
+		}
+		hosts = append(hosts, h)
+	}
+	return hosts, nil
+}
+
+// writeBulkHosts writes hosts in the given file format.
+func writeBulkHosts(w io.Writer, hosts []*hostcatalogs.BulkHost, format string) error {
+	if format == "json" {
+		if hosts == nil {
+			hosts = []*hostcatalogs.BulkHost{}
+		}
+		b, err := json.MarshalIndent(hosts, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(b))
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return err
+	}
+	for _, h := range hosts {
+		if err := cw.Write([]string{h.Id, h.Name, h.Address, h.Description, strings.Join(h.HostSetIds, ";")}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func generateImportTableOutput(in []*hostcatalogs.HostImportResult, dryRun bool) string {
+	if len(in) == 0 {
+		return "No hosts imported"
+	}
+	header := "Host import results:"
+	if dryRun {
+		header = "Host import results (dry run, no changes made):"
+	}
+	output := []string{
+		"",
+		header,
+	}
+	for i, r := range in {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Name:                 %s", r.Host.Name),
+			fmt.Sprintf("    Action:             %s", r.Action),
+		)
+		if r.Host.Id != "" {
+			output = append(output,
+				fmt.Sprintf("    ID:                 %s", r.Host.Id),
+			)
+		}
+		if len(r.ChangedFields) > 0 {
+			output = append(output,
+				fmt.Sprintf("    Changed Fields:     %s", strings.Join(r.ChangedFields, ", ")),
+			)
+		}
+		if len(r.AddedHostSetIds) > 0 {
+			output = append(output,
+				fmt.Sprintf("    Added to Host Sets: %s", strings.Join(r.AddedHostSetIds, ", ")),
+			)
+		}
+	}
+	return base.WrapForHelpText(output)
+}
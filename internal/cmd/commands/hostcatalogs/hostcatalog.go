@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "filter", "page-size", "all", "recursive"},
 }
 
 func (c *Command) Help() string {
@@ -146,6 +146,19 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, hostcatalogs.WithDescription(c.FlagDescription))
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, hostcatalogs.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, hostcatalogs.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, hostcatalogs.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, hostcatalogs.WithRecursive(true))
+	}
+
 	hostcatalogClient := hostcatalogs.NewClient(client)
 
 	existed := true
@@ -230,6 +243,9 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:      %d", m.Version),
 						fmt.Sprintf("    Type:         %s", m.Type),
 					)
+					if c.FlagRecursive && m.Scope != nil {
+						output = append(output, fmt.Sprintf("    Scope ID:     %s", m.Scope.Id))
+					}
 				}
 				if m.Name != "" {
 					output = append(output,
@@ -30,7 +30,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"delete": {"id"},
-	"list":   {"host-catalog-id"},
+	"list":   {"host-catalog-id", "filter", "page-size", "all"},
 }
 
 func (c *Command) Help() string {
@@ -145,6 +145,16 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, hosts.WithDescription(c.FlagDescription))
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, hosts.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, hosts.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, hosts.WithAll(true))
+	}
+
 	hostClient := hosts.NewClient(client)
 
 	existed := true
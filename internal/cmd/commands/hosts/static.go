@@ -3,6 +3,7 @@ package hosts
 import (
 	"fmt"
 	"net/textproto"
+	"strings"
 
 	"github.com/hashicorp/boundary/api"
 	"github.com/hashicorp/boundary/api/hosts"
@@ -22,6 +23,7 @@ type StaticCommand struct {
 	Func string
 
 	flagAddress string
+	flagLabels  []string
 }
 
 func (c *StaticCommand) Synopsis() string {
@@ -29,8 +31,8 @@ func (c *StaticCommand) Synopsis() string {
 }
 
 var staticFlagsMap = map[string][]string{
-	"create": {"host-catalog-id", "name", "description", "address"},
-	"update": {"id", "name", "description", "version", "address"},
+	"create": {"host-catalog-id", "name", "description", "address", "label"},
+	"update": {"id", "name", "description", "version", "address", "label"},
 }
 
 func (c *StaticCommand) Help() string {
@@ -42,7 +44,7 @@ func (c *StaticCommand) Help() string {
 			"",
 			"  Create a static-type host. Example:",
 			"",
-			`    $ boundary hosts static create -name prodops -description "Static host for ProdOps" -address "127.0.0.1"`,
+			`    $ boundary hosts static create -name prodops -description "Static host for ProdOps" -address "127.0.0.1" -label env=prod -label role=db`,
 			"",
 			"",
 		})
@@ -55,6 +57,8 @@ func (c *StaticCommand) Help() string {
 			"",
 			`    $ boundary hosts update static -id hst_1234567890 -name "devops" -description "Static host for DevOps" -address "10.20.30.40"`,
 			"",
+			"  Labels passed in via -label replace all of the existing labels of the host. Use -label null to remove them.",
+			"",
 			"",
 		})
 	}
@@ -76,6 +80,12 @@ func (c *StaticCommand) Flags() *base.FlagSets {
 				Target: &c.flagAddress,
 				Usage:  "The address of the host",
 			})
+		case "label":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "label",
+				Target: &c.flagLabels,
+				Usage:  `A label for the host in the form "key=value", used to select the host with host set selectors. May be specified multiple times.`,
+			})
 		}
 	}
 
@@ -147,6 +157,23 @@ func (c *StaticCommand) Run(args []string) int {
 		opts = append(opts, hosts.WithStaticHostAddress(c.flagAddress))
 	}
 
+	switch {
+	case len(c.flagLabels) == 0:
+	case len(c.flagLabels) == 1 && c.flagLabels[0] == "null":
+		opts = append(opts, hosts.DefaultStaticHostLabels())
+	default:
+		labels := make(map[string]string, len(c.flagLabels))
+		for _, l := range c.flagLabels {
+			idx := strings.Index(l, "=")
+			if idx == -1 {
+				c.UI.Error(fmt.Sprintf("Label %q must be in the form key=value", l))
+				return 1
+			}
+			labels[l[:idx]] = l[idx+1:]
+		}
+		opts = append(opts, hosts.WithStaticHostLabels(labels))
+	}
+
 	hostClient := hosts.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -41,7 +41,7 @@ func (c *Command) Synopsis() string {
 var flagsMap = map[string][]string{
 	"read":         {"id"},
 	"delete":       {"id"},
-	"list":         {"host-catalog-id"},
+	"list":         {"host-catalog-id", "filter", "page-size", "all"},
 	"add-hosts":    {"id", "host", "version"},
 	"set-hosts":    {"id", "host", "version"},
 	"remove-hosts": {"id", "host", "version"},
@@ -234,6 +234,16 @@ func (c *Command) Run(args []string) int {
 		// file
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, hostsets.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, hostsets.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, hostsets.WithAll(true))
+	}
+
 	hostsetClient := hostsets.NewClient(client)
 
 	existed := true
@@ -20,6 +20,8 @@ type StaticCommand struct {
 	*base.Command
 
 	Func string
+
+	flagSelector string
 }
 
 func (c *StaticCommand) Synopsis() string {
@@ -27,8 +29,8 @@ func (c *StaticCommand) Synopsis() string {
 }
 
 var staticFlagsMap = map[string][]string{
-	"create": {"host-catalog-id", "name", "description"},
-	"update": {"id", "name", "description", "version"},
+	"create": {"host-catalog-id", "name", "description", "selector"},
+	"update": {"id", "name", "description", "version", "selector"},
 }
 
 func (c *StaticCommand) Help() string {
@@ -40,7 +42,7 @@ func (c *StaticCommand) Help() string {
 			"",
 			"  Create a static-type host set. Example:",
 			"",
-			`    $ boundary host-sets create static -name prodops -description "Static host-set for ProdOps"`,
+			`    $ boundary host-sets create static -name prodops -description "Static host-set for ProdOps" -selector "env=prod,role=db"`,
 			"",
 			"",
 		})
@@ -64,6 +66,19 @@ func (c *StaticCommand) Flags() *base.FlagSets {
 	f := set.NewFlagSet("Command Options")
 	common.PopulateCommonFlags(c.Command, f, "static-type host set", staticFlagsMap[c.Func])
 
+	f = set.NewFlagSet("Static Host Set Options")
+
+	for _, name := range staticFlagsMap[c.Func] {
+		switch name {
+		case "selector":
+			f.StringVar(&base.StringVar{
+				Name:   "selector",
+				Target: &c.flagSelector,
+				Usage:  `A label selector, e.g. "env=prod,role=db". Hosts in the host catalog with labels matching every comma separated "key=value", "key!=value" or "key" term are members of the host set, in addition to the hosts added to it.`,
+			})
+		}
+	}
+
 	return set
 }
 
@@ -120,6 +135,14 @@ func (c *StaticCommand) Run(args []string) int {
 		opts = append(opts, hostsets.WithDescription(c.FlagDescription))
 	}
 
+	switch c.flagSelector {
+	case "":
+	case "null":
+		opts = append(opts, hostsets.DefaultStaticHostSetSelector())
+	default:
+		opts = append(opts, hostsets.WithStaticHostSetSelector(c.flagSelector))
+	}
+
 	hostsetClient := hostsets.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -0,0 +1,151 @@
+package oplog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api/oplog"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func baseHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog [sub command] [options] [args]",
+		"",
+		"  This command allows reading the operation log of Boundary scopes: the decrypted record of every change the controllers made to their resources. Fields holding secrets are cleared. Example:",
+		"",
+		"    List the changes made to users of an org in the last day:",
+		"",
+		`      $ boundary oplog list -scope-id o_1234567890 -resource-type user -start-time 2020-10-01T00:00:00Z`,
+		"",
+		"    Verify that the oplog was not edited directly in the database:",
+		"",
+		`      $ boundary oplog verify -config=/etc/boundary/controller.hcl`,
+		"",
+		"    Replay the oplog into a standby database:",
+		"",
+		`      $ boundary oplog replicate -config=/etc/boundary/controller.hcl -standby-url=env://BOUNDARY_STANDBY_URL`,
+		"",
+		"  Please see the oplog subcommand help for detailed usage information.",
+	})
+}
+
+func listHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog list [options] [args]",
+		"",
+		"  List the entries of the operation log of a scope, oldest first. Example:",
+		"",
+		`    $ boundary oplog list -scope-id o_1234567890 -recursive -op-type delete`,
+		"",
+		"",
+	})
+}
+
+func exportHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog export [options] [args]",
+		"",
+		"  Export all the entries of the operation log of a scope matching the given filters, oldest first, as one JSON object per line. Pages are requested in turn and written as they are received. Example:",
+		"",
+		`    $ boundary oplog export -scope-id global -recursive -output oplog.json`,
+		"",
+		"",
+	})
+}
+
+func generateEntryTableOutput(entries []*oplog.OplogEntry) string {
+	output := []string{
+		"",
+		"Oplog entries:",
+	}
+	for i, e := range entries {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  ID:                 %d", e.Id),
+			fmt.Sprintf("    Scope ID:         %s", e.ScopeId),
+			fmt.Sprintf("    Aggregate Name:   %s", e.AggregateName),
+			fmt.Sprintf("    Created Time:     %s", e.CreatedTime.Local().Format(time.RFC1123)),
+		)
+		for _, k := range []string{"resource-type", "resource-public-id"} {
+			if vals, ok := e.Metadata[k].([]interface{}); ok && len(vals) > 0 {
+				strs := make([]string, 0, len(vals))
+				for _, v := range vals {
+					strs = append(strs, fmt.Sprintf("%v", v))
+				}
+				output = append(output, fmt.Sprintf("    %-18s%s", strings.Title(strings.ReplaceAll(k, "-", " "))+":", strings.Join(strs, ", ")))
+			}
+		}
+		if e.DataError != "" {
+			output = append(output, fmt.Sprintf("    Data Error:       %s", e.DataError))
+		}
+		for _, m := range e.Messages {
+			output = append(output, fmt.Sprintf("    Message:          %s %s", m.OpType, m.TypeName))
+			if len(m.FieldMaskPaths) > 0 {
+				output = append(output, fmt.Sprintf("      Fields:         %s", strings.Join(m.FieldMaskPaths, ", ")))
+			}
+			if len(m.SetToNullPaths) > 0 {
+				output = append(output, fmt.Sprintf("      Set To Null:    %s", strings.Join(m.SetToNullPaths, ", ")))
+			}
+		}
+	}
+	return base.WrapForHelpText(output)
+}
+
+func generateChainStatusTableOutput(in []*ChainStatus) string {
+	output := []string{
+		"",
+		"Oplog hash chains:",
+	}
+	for i, s := range in {
+		if i > 0 {
+			output = append(output, "")
+		}
+		status := "intact"
+		if s.BreakId != 0 {
+			status = "broken"
+		}
+		output = append(output,
+			fmt.Sprintf("  Aggregate Name:     %s", s.AggregateName),
+			fmt.Sprintf("    Status:           %s", status),
+			fmt.Sprintf("    Verified Entries: %d", s.VerifiedCount),
+		)
+		if s.UnchainedCount > 0 {
+			output = append(output, fmt.Sprintf("    Unchained:        %d", s.UnchainedCount))
+		}
+		if s.AnchorId != 0 {
+			output = append(output,
+				fmt.Sprintf("    Anchor Entry ID:  %d", s.AnchorId),
+				fmt.Sprintf("    Anchor Hash:      %s", s.AnchorHash),
+			)
+		}
+		if s.HeadId != 0 {
+			output = append(output,
+				fmt.Sprintf("    Last Entry ID:    %d", s.HeadId),
+				fmt.Sprintf("    Last Entry Hash:  %s", s.HeadHash),
+			)
+		}
+		if s.BreakId != 0 {
+			output = append(output,
+				fmt.Sprintf("    Break Entry ID:   %d", s.BreakId),
+				fmt.Sprintf("    Break Reason:     %s", s.BreakReason),
+			)
+		}
+	}
+	return base.WrapForHelpText(output)
+}
+
+func generateReplicationStatusTableOutput(in *ReplicationStatus) string {
+	return base.WrapForHelpText([]string{
+		"",
+		"Oplog replication:",
+		fmt.Sprintf("  Name:               %s", in.Name),
+		fmt.Sprintf("    Entries Replayed: %d", in.ReplayedCount),
+		fmt.Sprintf("    Last Entry ID:    %d", in.EntryId),
+		fmt.Sprintf("    Lag Entries:      %d", in.LagEntries),
+		fmt.Sprintf("    Lag:              %s", time.Duration(in.LagSeconds*float64(time.Second)).Round(time.Second)),
+	})
+}
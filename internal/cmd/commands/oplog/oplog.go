@@ -0,0 +1,256 @@
+package oplog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/oplog"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*Command)(nil)
+var _ cli.CommandAutocomplete = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+
+	Func string
+
+	flagAggregateName string
+	flagResourceType  string
+	flagResourceId    string
+	flagOpType        string
+	flagStartTime     string
+	flagEndTime       string
+	flagOutput        string
+}
+
+func (c *Command) Synopsis() string {
+	switch c.Func {
+	case "":
+		return "Read the operation log of Boundary scopes"
+	case "list":
+		return "List the oplog entries of a scope"
+	case "export":
+		return "Export the oplog entries of a scope as JSON"
+	default:
+		return common.SynopsisFunc(c.Func, "oplog entry")
+	}
+}
+
+var flagsMap = map[string][]string{
+	"list":   {"scope-id", "page-size", "all", "recursive"},
+	"export": {"scope-id", "page-size", "recursive"},
+}
+
+func (c *Command) Help() string {
+	switch c.Func {
+	case "":
+		return baseHelp()
+	case "list":
+		return listHelp() + c.Flags().Help()
+	case "export":
+		return exportHelp() + c.Flags().Help()
+	}
+	return c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "oplog entry", flagsMap[c.Func])
+
+	if c.Func == "" {
+		return set
+	}
+	f.StringVar(&base.StringVar{
+		Name:   "aggregate-name",
+		Target: &c.flagAggregateName,
+		Usage:  `If set, only entries with this aggregate name, such as "iam_user", are returned.`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "resource-type",
+		Target: &c.flagResourceType,
+		Usage:  `If set, only entries changing resources of this type, such as "user", are returned.`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "resource-id",
+		Target: &c.flagResourceId,
+		Usage:  "If set, only entries changing the resource with this ID are returned. Requires -resource-type.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "op-type",
+		Target: &c.flagOpType,
+		Usage:  `If set, only entries of this operation type ("create", "update" or "delete") are returned.`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "start-time",
+		Target: &c.flagStartTime,
+		Usage:  "If set, only entries written at or after this time, in RFC 3339 format, are returned.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "end-time",
+		Target: &c.flagEndTime,
+		Usage:  "If set, only entries written before this time, in RFC 3339 format, are returned.",
+	})
+	if c.Func == "export" {
+		f.StringVar(&base.StringVar{
+			Name:       "output",
+			Target:     &c.flagOutput,
+			Completion: complete.PredictFiles("*"),
+			Usage:      "Path of the file to write the entries to. If not set, they are written to standard output.",
+		})
+	}
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	opts := []oplog.Option{
+		oplog.WithRecursive(c.FlagRecursive),
+	}
+	if c.flagAggregateName != "" {
+		opts = append(opts, oplog.WithAggregateName(c.flagAggregateName))
+	}
+	if c.flagResourceType != "" {
+		opts = append(opts, oplog.WithResourceType(c.flagResourceType))
+	}
+	if c.flagResourceId != "" {
+		opts = append(opts, oplog.WithResourceId(c.flagResourceId))
+	}
+	if c.flagOpType != "" {
+		opts = append(opts, oplog.WithOpType(c.flagOpType))
+	}
+	if c.flagStartTime != "" {
+		t, err := time.Parse(time.RFC3339, c.flagStartTime)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing -start-time: %s", err.Error()))
+			return 1
+		}
+		opts = append(opts, oplog.WithStartTime(t))
+	}
+	if c.flagEndTime != "" {
+		t, err := time.Parse(time.RFC3339, c.flagEndTime)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing -end-time: %s", err.Error()))
+			return 1
+		}
+		opts = append(opts, oplog.WithEndTime(t))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, oplog.WithPageSize(uint32(c.FlagPageSize)))
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+	oplogClient := oplog.NewClient(client)
+
+	switch c.Func {
+	case "list":
+		if c.FlagAll {
+			opts = append(opts, oplog.WithAll(true))
+		}
+		result, err := oplogClient.Read(c.Context, c.FlagScopeId, opts...)
+		if err != nil {
+			return c.printError(err)
+		}
+		switch base.Format(c.UI) {
+		case "json":
+			if len(result.Items) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(result.Items)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+		case "table":
+			if len(result.Items) == 0 {
+				c.UI.Output("No oplog entries found")
+				return 0
+			}
+			c.UI.Output(generateEntryTableOutput(result.Items))
+		}
+
+	case "export":
+		var w io.Writer = os.Stdout
+		if c.flagOutput != "" {
+			file, err := os.OpenFile(c.flagOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+			if err != nil {
+				c.UI.Error(fmt.Sprintf("Error opening output file: %s", err.Error()))
+				return 1
+			}
+			defer file.Close()
+			w = file
+		}
+		enc := json.NewEncoder(w)
+		var exported int
+		for {
+			result, err := oplogClient.Read(c.Context, c.FlagScopeId, opts...)
+			if err != nil {
+				return c.printError(err)
+			}
+			for _, e := range result.Items {
+				if err := enc.Encode(e); err != nil {
+					c.UI.Error(fmt.Sprintf("Error writing oplog entry: %s", err.Error()))
+					return 1
+				}
+			}
+			exported += len(result.Items)
+			if result.NextPageToken == "" {
+				break
+			}
+			opts = append(opts, oplog.WithPageToken(result.NextPageToken))
+		}
+		if c.flagOutput != "" {
+			c.UI.Info(fmt.Sprintf("%d oplog entries exported to %s.", exported, c.flagOutput))
+		}
+	}
+
+	return 0
+}
+
+func (c *Command) printError(err error) int {
+	if apiErr := api.AsServerError(err); apiErr != nil {
+		c.UI.Error(fmt.Sprintf("Error from controller when performing %s on oplog entries: %s", c.Func, base.PrintApiError(apiErr)))
+		return 1
+	}
+	c.UI.Error(fmt.Sprintf("Error trying to %s oplog entries: %s", c.Func, err.Error()))
+	return 2
+}
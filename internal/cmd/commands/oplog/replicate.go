@@ -0,0 +1,304 @@
+package oplog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog/replication"
+	"github.com/jinzhu/gorm"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*ReplicateCommand)(nil)
+var _ cli.CommandAutocomplete = (*ReplicateCommand)(nil)
+
+type ReplicateCommand struct {
+	*base.Command
+
+	flagConfig      string
+	flagConfigKms   string
+	flagStandbyUrl  string
+	flagName        string
+	flagTableSuffix string
+	flagInterval    time.Duration
+	flagGapTimeout  time.Duration
+	flagOnce        bool
+}
+
+// ReplicationStatus is the output of a replication run with -once.
+type ReplicationStatus struct {
+	Name          string  `json:"name"`
+	ReplayedCount int     `json:"replayed_count"`
+	EntryId       uint32  `json:"entry_id"`
+	LagEntries    int64   `json:"lag_entries"`
+	LagSeconds    float64 `json:"lag_seconds"`
+}
+
+func (c *ReplicateCommand) Synopsis() string {
+	return "Replay the oplog into a standby database"
+}
+
+func (c *ReplicateCommand) Help() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary oplog replicate [options]",
+		"",
+		"  Tail the oplog of the database configured in the controller configuration file and replay its entries into a standby database, to keep a warm standby or a reporting copy of the resources written through the oplog:",
+		"",
+		`    $ boundary oplog replicate -config=/etc/boundary/controller.hcl -standby-url=env://BOUNDARY_STANDBY_URL`,
+		"",
+		"  The standby database must have the schema of the primary database, created with \"boundary database migrate\", and no other data. Entries are decrypted with the root KMS of the configuration file and replayed in the order of their IDs. The ID of the last entry replayed is recorded in the standby database in the same transaction as its changes, so the replication resumes where it stopped when the command is restarted. Several replications can write to the same standby database under different names.",
+		"",
+		"  The lag of the replication is reported in the oplog.replication.lag.entries and oplog.replication.lag.seconds gauges, to the sinks of the telemetry block of the configuration file. Only the resources written through the oplog are replicated: the keys of the scopes, sessions and auth tokens are not, and encrypted values are replicated as the ciphertext of the primary database.",
+		"",
+		"",
+	}) + c.Flags().Help()
+}
+
+func (c *ReplicateCommand) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+
+	f.StringVar(&base.StringVar{
+		Name:   "config",
+		Target: &c.flagConfig,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: "Path to the configuration file.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "config-kms",
+		Target: &c.flagConfigKms,
+		Completion: complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		Usage: `Path to a configuration file containing a "kms" block marked for "config" purpose, to perform decryption of the main configuration file. If not set, will look for such a block in the main configuration file, which has some drawbacks; see the help output for "boundary config encrypt -h" for details.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "standby-url",
+		Target: &c.flagStandbyUrl,
+		Usage:  `The URL used to connect to the standby database. This can refer to a file on disk (file://) from which a URL will be read; an env var (env://) from which the URL will be read; or a direct database URL.`,
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:    "name",
+		Target:  &c.flagName,
+		Default: "default",
+		Usage:   "The name of the replication, under which its checkpoint is recorded in the standby database.",
+	})
+
+	f.StringVar(&base.StringVar{
+		Name:   "table-suffix",
+		Target: &c.flagTableSuffix,
+		Usage:  "If set, entries are replayed into tables named after the tables of the primary database with this suffix appended, which are created if they don't exist. This allows replaying into a copy of the tables in the same database.",
+	})
+
+	f.DurationVar(&base.DurationVar{
+		Name:    "interval",
+		Target:  &c.flagInterval,
+		Default: 5 * time.Second,
+		Usage:   "The time to wait for new entries after the replication caught up.",
+	})
+
+	f.DurationVar(&base.DurationVar{
+		Name:    "gap-timeout",
+		Target:  &c.flagGapTimeout,
+		Default: time.Minute,
+		Usage:   "How long an entry missing from the primary database is waited for before it is skipped. IDs can be missing because the transaction of an entry is still running, because it rolled back, or because the entry was deleted by the oplog retention policy.",
+	})
+
+	f.BoolVar(&base.BoolVar{
+		Name:   "once",
+		Target: &c.flagOnce,
+		Usage:  "If set, the command replays the entries written so far, reports the lag of the replication and exits.",
+	})
+
+	return set
+}
+
+func (c *ReplicateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+func (c *ReplicateCommand) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *ReplicateCommand) Run(args []string) int {
+	f := c.Flags()
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	switch {
+	case c.flagStandbyUrl == "":
+		c.UI.Error("Must specify the standby database using -standby-url")
+		return 1
+	case c.flagName == "":
+		c.UI.Error("Must specify a replication name using -name")
+		return 1
+	case c.flagInterval <= 0:
+		c.UI.Error("-interval must be positive")
+		return 1
+	}
+	standbyUrl, err := config.ParseAddress(c.flagStandbyUrl)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing standby url: %w", err).Error())
+		return 1
+	}
+	standbyUrl = strings.TrimSpace(standbyUrl)
+
+	cfg, configWrapper, err := common.LoadConfig(c.Context, c.flagConfig, c.flagConfigKms)
+	if configWrapper != nil {
+		defer func() {
+			if err := configWrapper.Finalize(c.Context); err != nil {
+				c.UI.Warn(fmt.Errorf("Error finalizing config kms: %w", err).Error())
+			}
+		}()
+	}
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if cfg.Controller == nil || cfg.Controller.Database == nil || cfg.Controller.Database.Url == "" {
+		c.UI.Error(`"url" not specified in "controller.database" config block`)
+		return 1
+	}
+	primaryUrl, err := config.ParseAddress(cfg.Controller.Database.Url)
+	if err != nil && err != config.ErrNotAUrl {
+		c.UI.Error(fmt.Errorf("Error parsing database url: %w", err).Error())
+		return 1
+	}
+
+	srv := base.NewServer(&base.Command{UI: c.UI})
+	defer func() {
+		if err := srv.RunShutdownFuncs(); err != nil {
+			c.UI.Warn(fmt.Errorf("Error finalizing kms: %w", err).Error())
+		}
+	}()
+	if err := srv.SetupLogging("", "", cfg.LogLevel, cfg.LogFormat); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if err := srv.SetupKMSes(c.UI, cfg); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	if srv.RootKms == nil {
+		c.UI.Error("Root KMS not found after parsing KMS blocks")
+		return 1
+	}
+	if err := srv.SetupMetrics(c.UI, cfg.Telemetry); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	srv.DatabaseUrl = strings.TrimSpace(primaryUrl)
+	if err := srv.ConnectToDatabase("postgres"); err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to database: %w", err).Error())
+		return 1
+	}
+	defer srv.Database.Close()
+	standby, err := db.Open(db.Postgres, standbyUrl)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error connecting to standby database: %w", err).Error())
+		return 1
+	}
+	defer standby.Close()
+
+	// Replaying depends on the two databases having the latest schema
+	for _, d := range []struct {
+		name string
+		db   *gorm.DB
+	}{{"database", srv.Database}, {"standby database", standby}} {
+		state, err := db.GetSchemaState(c.Context, d.db.DB(), "postgres")
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error reading %s schema state: %w", d.name, err).Error())
+			return 1
+		}
+		if err := state.Check(); err != nil {
+			c.UI.Error(fmt.Errorf("The %s schema is not up to date, run \"boundary database migrate\" first: %w", d.name, err).Error())
+			return 1
+		}
+	}
+
+	rw := db.New(srv.Database)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms repository: %w", err).Error())
+		return 1
+	}
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithLogger(srv.Logger.Named("kms")))
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating kms cache: %w", err).Error())
+		return 1
+	}
+	if err := kmsCache.AddExternalWrappers(kms.WithRootWrapper(srv.RootKms)); err != nil {
+		c.UI.Error(fmt.Errorf("Error adding config keys to kms: %w", err).Error())
+		return 1
+	}
+
+	replicator, err := replication.NewReplicator(rw, kmsCache, standby, c.flagName,
+		replication.WithTableSuffix(c.flagTableSuffix),
+		replication.WithGapTimeout(c.flagGapTimeout),
+		replication.WithLogger(srv.Logger.Named("replication")),
+	)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error creating replicator: %w", err).Error())
+		return 1
+	}
+
+	if !c.flagOnce {
+		c.UI.Info(fmt.Sprintf("Replicating the oplog as %q, press Ctrl-C to stop.", c.flagName))
+		replicator.Run(c.Context, c.flagInterval)
+		return 0
+	}
+
+	replayed, err := replicator.CatchUp(c.Context)
+	if err != nil {
+		c.UI.Error(fmt.Errorf("Error replicating oplog: %w", err).Error())
+		return 1
+	}
+	cp, err := replicator.Checkpoint(c.Context)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	lag, err := replicator.Lag(c.Context)
+	if err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+	status := &ReplicationStatus{
+		Name:          c.flagName,
+		ReplayedCount: replayed,
+		EntryId:       cp.EntryId,
+		LagEntries:    lag.Entries,
+		LagSeconds:    lag.Duration.Seconds(),
+	}
+
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateReplicationStatusTableOutput(status))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(status)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+	return 0
+}
@@ -0,0 +1,116 @@
+package reports
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api/reports"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func baseHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary reports [sub command] [options] [args]",
+		"",
+		"  This command allows reporting on the usage of Boundary from the data warehouse tables of its database. Example:",
+		"",
+		"    Report the sessions of each user of all projects, per week:",
+		"",
+		`      $ boundary reports sessions -scope-id global -recursive -group-by user -period week`,
+		"",
+		"  Please see the reports subcommand help for detailed usage information.",
+	})
+}
+
+func sessionsHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary reports sessions [options] [args]",
+		"",
+		"  Report the number of sessions, connections, session durations and bytes transferred of the users, targets or hosts of a project, per day, week or month in which the sessions were started. With -recursive, the sessions of the projects below the scope which the caller can read are reported. The duration of a session which is not terminated yet is the time since it was started. Example:",
+		"",
+		`    $ boundary reports sessions -scope-id p_1234567890 -group-by host -period month -start-time 2020-01-01T00:00:00Z`,
+		"",
+		`  Besides "table" and "json", the report can be printed with "-format csv" as comma-separated values with a header row, for use in spreadsheets.`,
+		"",
+		"",
+	})
+}
+
+// sessionReportCsvHeader are the columns of the session reports printed as
+// CSV.
+var sessionReportCsvHeader = []string{
+	"period_start",
+	"type",
+	"id",
+	"name",
+	"scope_id",
+	"session_count",
+	"connection_count",
+	"total_duration_seconds",
+	"average_duration_seconds",
+	"bytes_up",
+	"bytes_down",
+}
+
+func writeSessionReportCsv(w io.Writer, in []*reports.SessionReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(sessionReportCsvHeader); err != nil {
+		return err
+	}
+	for _, r := range in {
+		if err := cw.Write([]string{
+			r.PeriodStart.UTC().Format("2006-01-02"),
+			r.Type,
+			r.Id,
+			r.Name,
+			r.ScopeId,
+			strconv.FormatUint(r.SessionCount, 10),
+			strconv.FormatUint(r.ConnectionCount, 10),
+			strconv.FormatFloat(r.TotalDurationSeconds, 'f', 3, 64),
+			strconv.FormatFloat(r.AverageDurationSeconds, 'f', 3, 64),
+			strconv.FormatUint(r.BytesUp, 10),
+			strconv.FormatUint(r.BytesDown, 10),
+		}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func generateSessionReportTableOutput(in []*reports.SessionReport) string {
+	output := []string{
+		"",
+		"Session reports:",
+	}
+	for i, r := range in {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  Period Start:       %s", r.PeriodStart.UTC().Format("2006-01-02")),
+			fmt.Sprintf("    %-18s%s", strings.Title(r.Type)+" ID:", r.Id),
+		)
+		if r.Name != "" {
+			output = append(output, fmt.Sprintf("    Name:             %s", r.Name))
+		}
+		output = append(output,
+			fmt.Sprintf("    Scope ID:         %s", r.ScopeId),
+			fmt.Sprintf("    Sessions:         %d", r.SessionCount),
+			fmt.Sprintf("    Connections:      %d", r.ConnectionCount),
+			fmt.Sprintf("    Total Duration:   %s", secondsDuration(r.TotalDurationSeconds)),
+			fmt.Sprintf("    Average Duration: %s", secondsDuration(r.AverageDurationSeconds)),
+			fmt.Sprintf("    Bytes Up:         %d", r.BytesUp),
+			fmt.Sprintf("    Bytes Down:       %d", r.BytesDown),
+		)
+	}
+	return base.WrapForHelpText(output)
+}
+
+func secondsDuration(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second)).Round(time.Second)
+}
@@ -0,0 +1,189 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/reports"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*Command)(nil)
+var _ cli.CommandAutocomplete = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+
+	Func string
+
+	flagGroupBy   string
+	flagPeriod    string
+	flagStartTime string
+	flagEndTime   string
+}
+
+func (c *Command) Synopsis() string {
+	switch c.Func {
+	case "":
+		return "Report on the usage of Boundary"
+	case "sessions":
+		return "Report on the sessions of a scope"
+	default:
+		return common.SynopsisFunc(c.Func, "report")
+	}
+}
+
+var flagsMap = map[string][]string{
+	"sessions": {"scope-id", "recursive"},
+}
+
+func (c *Command) Help() string {
+	switch c.Func {
+	case "":
+		return baseHelp()
+	case "sessions":
+		return sessionsHelp() + c.Flags().Help()
+	}
+	return c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, "report", flagsMap[c.Func])
+
+	if c.Func == "" {
+		return set
+	}
+	f.StringVar(&base.StringVar{
+		Name:       "group-by",
+		Target:     &c.flagGroupBy,
+		Default:    "target",
+		Completion: complete.PredictSet("user", "target", "host"),
+		Usage:      `The resources the sessions are reported by: "user", "target" or "host".`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:       "period",
+		Target:     &c.flagPeriod,
+		Default:    "day",
+		Completion: complete.PredictSet("day", "week", "month"),
+		Usage:      `The periods the sessions are reported by, according to the time they were started: "day", "week" or "month".`,
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "start-time",
+		Target: &c.flagStartTime,
+		Usage:  "If set, only sessions started at or after this time, in RFC 3339 format, are reported.",
+	})
+	f.StringVar(&base.StringVar{
+		Name:   "end-time",
+		Target: &c.flagEndTime,
+		Usage:  "If set, only sessions started before this time, in RFC 3339 format, are reported.",
+	})
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	opts := []reports.Option{
+		reports.WithRecursive(c.FlagRecursive),
+		reports.WithGroupBy(c.flagGroupBy),
+		reports.WithPeriod(c.flagPeriod),
+	}
+	if c.flagStartTime != "" {
+		t, err := time.Parse(time.RFC3339, c.flagStartTime)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing -start-time: %s", err.Error()))
+			return 1
+		}
+		opts = append(opts, reports.WithStartTime(t))
+	}
+	if c.flagEndTime != "" {
+		t, err := time.Parse(time.RFC3339, c.flagEndTime)
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error parsing -end-time: %s", err.Error()))
+			return 1
+		}
+		opts = append(opts, reports.WithEndTime(t))
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+	reportsClient := reports.NewClient(client)
+
+	switch c.Func {
+	case "sessions":
+		result, err := reportsClient.Sessions(c.Context, c.FlagScopeId, opts...)
+		if err != nil {
+			return c.printError(err)
+		}
+		switch base.Format(c.UI) {
+		case "json":
+			if len(result.Items) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(result.Items)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+		case "csv":
+			var sb strings.Builder
+			if err := writeSessionReportCsv(&sb, result.Items); err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as CSV: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(strings.TrimSuffix(sb.String(), "\n"))
+		case "table":
+			if len(result.Items) == 0 {
+				c.UI.Output("No sessions found")
+				return 0
+			}
+			c.UI.Output(generateSessionReportTableOutput(result.Items))
+		}
+	}
+
+	return 0
+}
+
+func (c *Command) printError(err error) int {
+	if apiErr := api.AsServerError(err); apiErr != nil {
+		c.UI.Error(fmt.Sprintf("Error from controller when performing %s report: %s", c.Func, base.PrintApiError(apiErr)))
+		return 1
+	}
+	c.UI.Error(fmt.Sprintf("Error trying to perform %s report: %s", c.Func, err.Error()))
+	return 2
+}
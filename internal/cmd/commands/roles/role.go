@@ -56,7 +56,7 @@ var flagsMap = map[string][]string{
 	"update":            {"id", "name", "description", "grantscopeid", "version"},
 	"read":              {"id"},
 	"delete":            {"id"},
-	"list":              {"scope-id"},
+	"list":              {"scope-id", "filter", "page-size", "all", "recursive"},
 	"add-principals":    {"id", "principal", "version"},
 	"set-principals":    {"id", "principal", "version"},
 	"remove-principals": {"id", "principal", "version"},
@@ -188,6 +188,19 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, roles.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, roles.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, roles.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, roles.WithRecursive(true))
+	}
+
 	roleClient := roles.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -301,6 +314,9 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("  ID:            %s", r.Id),
 						fmt.Sprintf("    Version:     %d", r.Version),
 					)
+					if c.FlagRecursive && r.Scope != nil {
+						output = append(output, fmt.Sprintf("    Scope ID:    %s", r.Scope.Id))
+					}
 				}
 				if r.Name != "" {
 					output = append(output,
@@ -1,12 +1,61 @@
 package scopes
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/hashicorp/boundary/api/scopes"
 	"github.com/hashicorp/boundary/internal/cmd/base"
 )
 
+func rotateKeysHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes rotate-keys [options] [args]",
+		"",
+		"  Rotate the keys of a scope. New versions of the keys encrypt everything written from then on, and the controllers re-encrypt the existing data of the scope with them in the background. Example:",
+		"",
+		`    $ boundary scopes rotate-keys -id o_1234567890`,
+		"",
+		"",
+	})
+}
+
+func rewrapStatusHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes rewrap-status [options] [args]",
+		"",
+		"  Read the status of the re-encryption of the data of a scope with the newest versions of its keys. Example:",
+		"",
+		`    $ boundary scopes rewrap-status -id o_1234567890`,
+		"",
+		"",
+	})
+}
+
+func listKeyVersionsHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes list-key-versions [options] [args]",
+		"",
+		"  List the versions of the keys of a scope, newest first, with the number of values stored by the controllers which are encrypted with each version and the number of values issued to clients with it which are still valid. Example:",
+		"",
+		`    $ boundary scopes list-key-versions -id o_1234567890 -purpose tokens`,
+		"",
+		"",
+	})
+}
+
+func destroyKeyVersionHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary scopes destroy-key-version [options] [args]",
+		"",
+		"  Destroy a version of a key of a scope. The newest version of a key can't be destroyed, nor a version which values still depend on; rotate the keys and wait for the rewrap job to complete and for the values issued with the version to expire first. Example:",
+		"",
+		`    $ boundary scopes destroy-key-version -id o_1234567890 -key-version-id ktv_1234567890`,
+		"",
+		"",
+	})
+}
+
 func generateScopeTableOutput(in *scopes.Scope) string {
 	nonAttributeMap := map[string]interface{}{
 		"ID":           in.Id,
@@ -35,3 +84,55 @@ func generateScopeTableOutput(in *scopes.Scope) string {
 
 	return base.WrapForHelpText(ret)
 }
+
+func generateRewrapJobTableOutput(in *scopes.RewrapJob) string {
+	nonAttributeMap := map[string]interface{}{
+		"Scope ID":        in.ScopeId,
+		"State":           in.State,
+		"Rewrapped Count": in.RewrappedCount,
+		"Remaining Count": in.RemainingCount,
+		"Rotated Time":    in.RotatedTime.Local().Format(time.RFC1123),
+		"Updated Time":    in.UpdatedTime.Local().Format(time.RFC1123),
+	}
+
+	if !in.EndedTime.IsZero() {
+		nonAttributeMap["Ended Time"] = in.EndedTime.Local().Format(time.RFC1123)
+	}
+	if in.Error != "" {
+		nonAttributeMap["Error"] = in.Error
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Rewrap job information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+func generateKeyVersionsTableOutput(in []*scopes.KeyVersion) string {
+	if len(in) == 0 {
+		return "No key versions found"
+	}
+	output := []string{
+		"",
+		"Key version information:",
+	}
+	for i, kv := range in {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  ID:                %s", kv.Id),
+			fmt.Sprintf("    Purpose:         %s", kv.Purpose),
+			fmt.Sprintf("    Version:         %d", kv.Version),
+			fmt.Sprintf("    Created Time:    %s", kv.CreatedTime.Local().Format(time.RFC1123)),
+			fmt.Sprintf("    Encrypted Count: %d", kv.EncryptedCount),
+			fmt.Sprintf("    Issued Count:    %d", kv.IssuedCount),
+		)
+	}
+	return base.WrapForHelpText(output)
+}
@@ -24,26 +24,52 @@ type Command struct {
 
 	flagSkipAdminRoleCreation   bool
 	flagSkipDefaultRoleCreation bool
+	flagPurpose                 string
+	flagKeyVersionId            string
 }
 
 func (c *Command) Synopsis() string {
-	return common.SynopsisFunc(c.Func, "scope")
+	switch c.Func {
+	case "rotate-keys":
+		return "Rotate the keys of a scope"
+	case "rewrap-status":
+		return "Read the status of the re-encryption of a scope's data"
+	case "list-key-versions":
+		return "List the versions of a scope's keys"
+	case "destroy-key-version":
+		return "Destroy a version of a scope's key"
+	default:
+		return common.SynopsisFunc(c.Func, "scope")
+	}
+}
+
+var helpMap = func() map[string]func() string {
+	ret := common.HelpMap("scope")
+	ret["rotate-keys"] = rotateKeysHelp
+	ret["rewrap-status"] = rewrapStatusHelp
+	ret["list-key-versions"] = listKeyVersionsHelp
+	ret["destroy-key-version"] = destroyKeyVersionHelp
+	return ret
 }
 
 var flagsMap = map[string][]string{
-	"create": {"scope-id", "name", "description", "skip-admin-role-creation", "skip-default-role-creation"},
-	"update": {"id", "name", "description", "version"},
-	"read":   {"id"},
-	"delete": {"id"},
-	"list":   {"scope-id"},
+	"create":              {"scope-id", "name", "description", "skip-admin-role-creation", "skip-default-role-creation"},
+	"update":              {"id", "name", "description", "version"},
+	"read":                {"id"},
+	"delete":              {"id"},
+	"list":                {"scope-id", "filter", "page-size", "all", "recursive"},
+	"rotate-keys":         {"id"},
+	"rewrap-status":       {"id"},
+	"list-key-versions":   {"id"},
+	"destroy-key-version": {"id"},
 }
 
 func (c *Command) Help() string {
-	helpMap := common.HelpMap("scope")
+	hm := helpMap()
 	if c.Func == "" {
-		return helpMap["base"]()
+		return hm["base"]()
 	}
-	return helpMap[c.Func]() + c.Flags().Help()
+	return hm[c.Func]() + c.Flags().Help()
 }
 
 func (c *Command) Flags() *base.FlagSets {
@@ -64,6 +90,21 @@ func (c *Command) Flags() *base.FlagSets {
 		})
 	}
 
+	switch c.Func {
+	case "list-key-versions":
+		f.StringVar(&base.StringVar{
+			Name:   "purpose",
+			Target: &c.flagPurpose,
+			Usage:  `If set, only the versions of the key for this purpose ("database", "oplog", "tokens" or "sessions") are listed.`,
+		})
+	case "destroy-key-version":
+		f.StringVar(&base.StringVar{
+			Name:   "key-version-id",
+			Target: &c.flagKeyVersionId,
+			Usage:  "The ID of the key version to destroy.",
+		})
+	}
+
 	return set
 }
 
@@ -95,6 +136,10 @@ func (c *Command) Run(args []string) int {
 		c.UI.Error("Scope ID must be passed in via -scope-id")
 		return 1
 	}
+	if c.Func == "destroy-key-version" && c.flagKeyVersionId == "" {
+		c.UI.Error("Key version ID must be passed in via -key-version-id")
+		return 1
+	}
 
 	client, err := c.Client()
 	if err != nil {
@@ -127,12 +172,25 @@ func (c *Command) Run(args []string) int {
 		opts = append(opts, scopes.WithSkipDefaultRoleCreation(c.flagSkipDefaultRoleCreation))
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, scopes.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, scopes.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, scopes.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, scopes.WithRecursive(true))
+	}
+
 	scopeClient := scopes.NewClient(client)
 
 	// Perform check-and-set when needed
 	var version uint32
 	switch c.Func {
-	case "create", "read", "delete", "list":
+	case "create", "read", "delete", "list", "rotate-keys", "rewrap-status", "list-key-versions", "destroy-key-version":
 		// These don't udpate so don't need the existing version
 	default:
 		switch c.FlagVersion {
@@ -146,6 +204,8 @@ func (c *Command) Run(args []string) int {
 	existed := true
 	var result api.GenericResult
 	var listResult api.GenericListResult
+	var rewrapResult *scopes.RewrapJobReadResult
+	var keyVersionsResult *scopes.KeyVersionListResult
 
 	switch c.Func {
 	case "create":
@@ -162,6 +222,14 @@ func (c *Command) Run(args []string) int {
 		}
 	case "list":
 		listResult, err = scopeClient.List(c.Context, c.FlagScopeId, opts...)
+	case "rotate-keys":
+		rewrapResult, err = scopeClient.RotateKeys(c.Context, c.FlagId, opts...)
+	case "rewrap-status":
+		rewrapResult, err = scopeClient.ReadRewrapJob(c.Context, c.FlagId, opts...)
+	case "list-key-versions":
+		keyVersionsResult, err = scopeClient.ListKeyVersions(c.Context, c.FlagId, c.flagPurpose, opts...)
+	case "destroy-key-version":
+		_, err = scopeClient.DestroyKeyVersion(c.Context, c.FlagId, c.flagKeyVersionId, opts...)
 	}
 
 	plural := "scope"
@@ -228,6 +296,9 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("  ID:             %s", s.Id),
 						fmt.Sprintf("    Version:      %d", s.Version),
 					)
+					if c.FlagRecursive && s.Scope != nil {
+						output = append(output, fmt.Sprintf("    Scope ID:     %s", s.Scope.Id))
+					}
 				}
 				if s.Name != "" {
 					output = append(output,
@@ -243,6 +314,47 @@ func (c *Command) Run(args []string) int {
 			c.UI.Output(base.WrapForHelpText(output))
 		}
 		return 0
+
+	case "destroy-key-version":
+		switch base.Format(c.UI) {
+		case "json":
+			c.UI.Output("null")
+		case "table":
+			c.UI.Output("The destroy operation completed successfully.")
+		}
+		return 0
+
+	case "list-key-versions":
+		switch base.Format(c.UI) {
+		case "json":
+			if len(keyVersionsResult.Items) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(keyVersionsResult.Items)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+		case "table":
+			c.UI.Output(generateKeyVersionsTableOutput(keyVersionsResult.Items))
+		}
+		return 0
+
+	case "rotate-keys", "rewrap-status":
+		switch base.Format(c.UI) {
+		case "table":
+			c.UI.Output(generateRewrapJobTableOutput(rewrapResult.Item))
+		case "json":
+			b, err := base.JsonFormatter{}.Format(rewrapResult.Item)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+		}
+		return 0
 	}
 
 	scope := result.GetItem().(*scopes.Scope)
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/hashicorp/shared-secure-libs/configutil"
+)
+
+// reloadConfig applies the settings of newConf which can be changed while the
+// server runs and logs the settings which changed, separating those which
+// only take effect after a restart.
+func (c *Command) reloadConfig(newConf *config.Config) {
+	if c.Config.Controller != nil && newConf.Controller != nil {
+		if err := c.SetupControllerPublicClusterAddress(newConf, ""); err != nil {
+			c.Logger.Error("could not reload config", "error", err)
+			return
+		}
+	}
+	if c.Config.Worker != nil && newConf.Worker != nil {
+		if c.Config.Controller != nil {
+			// A combined controller and worker always uses its own cluster
+			// address
+			newConf.Worker.Controllers = c.Config.Worker.Controllers
+		}
+		if err := c.SetupWorkerPublicAddress(newConf, ""); err != nil {
+			c.Logger.Error("could not reload config", "error", err)
+			return
+		}
+	}
+
+	changed, restart := configChanges(c.Config, newConf)
+	if len(changed) == 0 && len(restart) == 0 {
+		c.Logger.Info("no configuration changes found on reload")
+		return
+	}
+	if len(changed) > 0 {
+		// The worker validates its settings, so it goes first to leave
+		// everything as it was if they are invalid
+		if c.Config.Worker != nil && newConf.Worker != nil {
+			if err := c.worker.Reload(newConf); err != nil {
+				c.Logger.Error("could not reload config", "error", err)
+				return
+			}
+		}
+		applyConfigChanges(c.Config, newConf)
+		if c.Config.Controller != nil {
+			c.controller.Reload(c.Config)
+		}
+		c.Logger.Info("configuration reloaded", "changed", changed)
+	}
+	if len(restart) > 0 {
+		c.Logger.Warn("configuration changes require a restart to take effect", "settings", restart)
+	}
+}
+
+// configChanges compares the running configuration with newConf and returns
+// the names of the changed settings which are applied by a reload and of
+// those which require a restart.
+func configChanges(running, newConf *config.Config) (changed, restart []string) {
+	add := func(reloadable bool, name string, differ bool) {
+		switch {
+		case !differ:
+		case reloadable:
+			changed = append(changed, name)
+		default:
+			restart = append(restart, name)
+		}
+	}
+
+	add(true, "log_level", newConf.LogLevel != "" && newConf.LogLevel != running.LogLevel)
+	add(false, "log_format", newConf.LogFormat != running.LogFormat)
+	add(false, "disable_mlock", newConf.DisableMlock != running.DisableMlock)
+	add(false, "kms", !reflect.DeepEqual(newConf.Seals, running.Seals))
+	add(false, "events", !reflect.DeepEqual(newConf.Events, running.Events))
+
+	oldListeners := listenersByKey(running.Listeners)
+	newListeners := listenersByKey(newConf.Listeners)
+	keys := make([]string, 0, len(newListeners))
+	for k := range newListeners {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		n := newListeners[k]
+		o, ok := oldListeners[k]
+		if !ok {
+			add(false, fmt.Sprintf("listener %s", k), true)
+			continue
+		}
+		add(false, fmt.Sprintf("listener %s", k), !strutil.EquivalentSlices(o.Purpose, n.Purpose) ||
+			o.TLSDisable != n.TLSDisable ||
+			o.TLSCertFile != n.TLSCertFile ||
+			o.TLSKeyFile != n.TLSKeyFile ||
+			o.TLSMinVersion != n.TLSMinVersion ||
+			o.TLSClientCAFile != n.TLSClientCAFile ||
+			o.TLSRequireAndVerifyClientCert != n.TLSRequireAndVerifyClientCert)
+		add(true, fmt.Sprintf("listener %s cors", k), o.CorsEnabled != n.CorsEnabled ||
+			!reflect.DeepEqual(o.CorsAllowedOrigins, n.CorsAllowedOrigins) ||
+			!reflect.DeepEqual(o.CorsAllowedHeaders, n.CorsAllowedHeaders))
+	}
+	for k := range oldListeners {
+		if _, ok := newListeners[k]; !ok {
+			add(false, fmt.Sprintf("listener %s", k), true)
+		}
+	}
+
+	switch {
+	case (running.Controller == nil) != (newConf.Controller == nil):
+		add(false, "controller", true)
+	case running.Controller != nil:
+		o, n := running.Controller, newConf.Controller
+		add(true, "controller.description", o.Description != n.Description)
+		add(true, "controller.public_cluster_addr", o.PublicClusterAddr != n.PublicClusterAddr)
+		add(true, "controller.auth_token_time_to_live", o.AuthTokenTimeToLiveDuration != n.AuthTokenTimeToLiveDuration)
+		add(true, "controller.auth_token_time_to_stale", o.AuthTokenTimeToStaleDuration != n.AuthTokenTimeToStaleDuration)
+		add(false, "controller.name", n.Name != "" && o.Name != n.Name)
+		add(false, "controller.database", !reflect.DeepEqual(o.Database, n.Database))
+	}
+
+	switch {
+	case (running.Worker == nil) != (newConf.Worker == nil):
+		add(false, "worker", true)
+	case running.Worker != nil:
+		o, n := running.Worker, newConf.Worker
+		add(true, "worker.description", o.Description != n.Description)
+		add(true, "worker.public_addr", o.PublicAddr != n.PublicAddr)
+		add(true, "worker.controllers", !strutil.EquivalentSlices(o.Controllers, n.Controllers))
+		add(true, "worker.tags", !reflect.DeepEqual(o.Tags, n.Tags))
+		add(false, "worker.name", n.Name != "" && o.Name != n.Name)
+		add(false, "worker.drain_timeout", o.DrainTimeoutDuration != n.DrainTimeoutDuration)
+		add(false, "worker.controller_outage_grace_period", o.ControllerOutageGracePeriodDuration != n.ControllerOutageGracePeriodDuration)
+	}
+
+	return changed, restart
+}
+
+// applyConfigChanges copies the settings of newConf which can be changed by
+// a reload into the running configuration.
+func applyConfigChanges(running, newConf *config.Config) {
+	if newConf.LogLevel != "" {
+		running.LogLevel = newConf.LogLevel
+	}
+	newListeners := listenersByKey(newConf.Listeners)
+	for k, o := range listenersByKey(running.Listeners) {
+		if n, ok := newListeners[k]; ok {
+			o.CorsEnabled = n.CorsEnabled
+			o.CorsAllowedOrigins = n.CorsAllowedOrigins
+			o.CorsAllowedHeaders = n.CorsAllowedHeaders
+		}
+	}
+	if running.Controller != nil && newConf.Controller != nil {
+		running.Controller.Description = newConf.Controller.Description
+		running.Controller.PublicClusterAddr = newConf.Controller.PublicClusterAddr
+		running.Controller.AuthTokenTimeToLive = newConf.Controller.AuthTokenTimeToLive
+		running.Controller.AuthTokenTimeToLiveDuration = newConf.Controller.AuthTokenTimeToLiveDuration
+		running.Controller.AuthTokenTimeToStale = newConf.Controller.AuthTokenTimeToStale
+		running.Controller.AuthTokenTimeToStaleDuration = newConf.Controller.AuthTokenTimeToStaleDuration
+	}
+	if running.Worker != nil && newConf.Worker != nil {
+		running.Worker.Description = newConf.Worker.Description
+		running.Worker.PublicAddr = newConf.Worker.PublicAddr
+		running.Worker.Controllers = newConf.Worker.Controllers
+		running.Worker.Tags = newConf.Worker.Tags
+	}
+}
+
+// listenersByKey returns the listeners keyed by their type and address,
+// which identify a listener across reloads.
+func listenersByKey(listeners []*configutil.Listener) map[string]*configutil.Listener {
+	ret := make(map[string]*configutil.Listener, len(listeners))
+	for _, l := range listeners {
+		ret[fmt.Sprintf("%q %s", l.Type, l.Address)] = l
+	}
+	return ret
+}
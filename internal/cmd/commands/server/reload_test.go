@@ -0,0 +1,161 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const reloadBaseConfig = `
+log_level = "info"
+
+controller {
+	name = "c1"
+	description = "first"
+	auth_token_time_to_live = "24h"
+	database {
+		url = "postgres://localhost/boundary"
+	}
+}
+
+worker {
+	name = "w1"
+	description = "first"
+	controllers = ["10.0.0.1"]
+	tags {
+		type = ["prod"]
+	}
+}
+
+listener "tcp" {
+	address = "127.0.0.1:9200"
+	purpose = "api"
+	tls_disable = true
+}
+
+listener "tcp" {
+	address = "127.0.0.1:9201"
+	purpose = "cluster"
+}
+`
+
+func TestConfigChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		newConf     string
+		wantChanged []string
+		wantRestart []string
+	}{
+		{
+			name:    "unchanged",
+			newConf: reloadBaseConfig,
+		},
+		{
+			name: "reloadable",
+			newConf: `
+log_level = "debug"
+
+controller {
+	name = "c1"
+	description = "second"
+	auth_token_time_to_live = "1h"
+	auth_token_time_to_stale = "10m"
+	database {
+		url = "postgres://localhost/boundary"
+	}
+}
+
+worker {
+	name = "w1"
+	description = "second"
+	controllers = ["10.0.0.2"]
+	public_addr = "example.com"
+	tags {
+		type = ["dev"]
+	}
+}
+
+listener "tcp" {
+	address = "127.0.0.1:9200"
+	purpose = "api"
+	tls_disable = true
+	cors_enabled = true
+	cors_allowed_origins = ["https://example.com"]
+}
+
+listener "tcp" {
+	address = "127.0.0.1:9201"
+	purpose = "cluster"
+}
+`,
+			wantChanged: []string{
+				"log_level",
+				`listener "tcp" 127.0.0.1:9200 cors`,
+				"controller.description",
+				"controller.auth_token_time_to_live",
+				"controller.auth_token_time_to_stale",
+				"worker.description",
+				"worker.public_addr",
+				"worker.controllers",
+				"worker.tags",
+			},
+		},
+		{
+			name: "restart",
+			newConf: `
+log_level = "info"
+log_format = "json"
+
+controller {
+	name = "c2"
+	description = "first"
+	auth_token_time_to_live = "24h"
+	database {
+		url = "postgres://otherhost/boundary"
+	}
+}
+
+listener "tcp" {
+	address = "127.0.0.1:9300"
+	purpose = "api"
+	tls_disable = true
+}
+
+listener "tcp" {
+	address = "127.0.0.1:9201"
+	purpose = "cluster"
+}
+`,
+			wantRestart: []string{
+				"log_format",
+				`listener "tcp" 127.0.0.1:9300`,
+				`listener "tcp" 127.0.0.1:9200`,
+				"controller.name",
+				"controller.database",
+				"worker",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			running, err := config.Parse(reloadBaseConfig)
+			require.NoError(err)
+			newConf, err := config.Parse(tt.newConf)
+			require.NoError(err)
+
+			changed, restart := configChanges(running, newConf)
+			assert.Equal(tt.wantChanged, changed)
+			assert.Equal(tt.wantRestart, restart)
+
+			// Once applied, only the settings requiring a restart differ
+			applyConfigChanges(running, newConf)
+			changed, restart = configChanges(running, newConf)
+			assert.Empty(changed)
+			assert.Equal(tt.wantRestart, restart)
+		})
+	}
+}
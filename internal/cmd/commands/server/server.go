@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/cmd/base"
@@ -29,6 +30,7 @@ type Command struct {
 	SighupCh      chan struct{}
 	ReloadedCh    chan struct{}
 	SigUSR2Ch     chan struct{}
+	SigUSR1Ch     chan struct{}
 
 	Config     *config.Config
 	controller *controller.Controller
@@ -140,6 +142,11 @@ func (c *Command) Run(args []string) int {
 		return 1
 	}
 
+	if err := c.SetupEvents(c.Config); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
 	if err := c.SetupKMSes(c.UI, c.Config); err != nil {
 		c.UI.Error(err.Error())
 		return 1
@@ -193,6 +200,7 @@ func (c *Command) Run(args []string) int {
 				foundApi = true
 			case "proxy":
 				foundProxy = true
+			case "ops":
 			default:
 				c.UI.Error(fmt.Sprintf("Unknown listener purpose %q", lnConfig.Purpose[0]))
 				return 1
@@ -233,7 +241,7 @@ func (c *Command) Run(args []string) int {
 			c.Config.Worker.Controllers = []string{clusterAddr}
 		}
 	}
-	if err := c.SetupListeners(c.UI, c.Config.SharedConfig, []string{"api", "cluster", "proxy"}); err != nil {
+	if err := c.SetupListeners(c.UI, c.Config.SharedConfig, []string{"api", "cluster", "proxy", "ops"}); err != nil {
 		c.UI.Error(err.Error())
 		return 1
 	}
@@ -287,6 +295,11 @@ func (c *Command) Run(args []string) int {
 	c.PrintInfo(c.UI)
 	c.ReleaseLogGate()
 
+	if err := c.StartOpsListeners(); err != nil {
+		c.UI.Error(fmt.Errorf("Error starting ops listeners: %w", err).Error())
+		return 1
+	}
+
 	if c.Config.Controller != nil {
 		if err := c.StartController(); err != nil {
 			c.UI.Error(err.Error())
@@ -418,24 +431,40 @@ func (c *Command) WaitForInterrupt() int {
 		shutdownCh = c.ExtShutdownCh
 	}
 
+	// workerDrained is closed once the worker has drained, whether the drain
+	// was triggered here or requested by a controller, and stays nil if no
+	// worker is running
+	var workerDrained <-chan struct{}
+	if c.Config.Worker != nil && c.worker != nil {
+		workerDrained = c.worker.Drained()
+	}
+	drainWorker := func(timeout time.Duration) {
+		if c.Config.Worker == nil || c.worker == nil {
+			c.Logger.Warn("drain requested but no worker is running")
+			return
+		}
+		c.UI.Output("==> Boundary worker drain triggered")
+		c.worker.Drain(timeout)
+	}
+
 	for !shutdownTriggered {
 		select {
 		case <-shutdownCh:
 			c.UI.Output("==> Boundary server shutdown triggered")
+			c.shutdown()
+			shutdownTriggered = true
 
-			if c.Config.Worker != nil {
-				if err := c.worker.Shutdown(false); err != nil {
-					c.UI.Error(fmt.Errorf("Error shutting down worker: %w", err).Error())
-				}
-			}
+		case <-workerDrained:
+			c.UI.Output("==> Boundary worker drained, shutting down")
+			c.shutdown()
+			shutdownTriggered = true
 
-			if c.Config.Controller != nil {
-				if err := c.controller.Shutdown(c.Config.Worker != nil); err != nil {
-					c.UI.Error(fmt.Errorf("Error shutting down controller: %w", err).Error())
-				}
+		case <-c.SigUSR1Ch:
+			var timeout time.Duration
+			if c.Config.Worker != nil {
+				timeout = c.Config.Worker.DrainTimeoutDuration
 			}
-
-			shutdownTriggered = true
+			drainWorker(timeout)
 
 		case <-c.SighupCh:
 			c.UI.Output("==> Boundary controller reload triggered")
@@ -481,6 +510,12 @@ func (c *Command) WaitForInterrupt() int {
 				c.Logger.SetLevel(level)
 			}
 
+			c.reloadConfig(newConf)
+
+			if newConf.Worker != nil && newConf.Worker.Drain {
+				drainWorker(newConf.Worker.DrainTimeoutDuration)
+			}
+
 		RUNRELOADFUNCS:
 			if err := c.Reload(); err != nil {
 				c.UI.Error(fmt.Errorf("Error(s) were encountered during controller reload: %w", err).Error())
@@ -496,6 +531,21 @@ func (c *Command) WaitForInterrupt() int {
 	return 0
 }
 
+// shutdown stops the worker and controller, whichever are running.
+func (c *Command) shutdown() {
+	if c.Config.Worker != nil {
+		if err := c.worker.Shutdown(false); err != nil {
+			c.UI.Error(fmt.Errorf("Error shutting down worker: %w", err).Error())
+		}
+	}
+
+	if c.Config.Controller != nil {
+		if err := c.controller.Shutdown(c.Config.Worker != nil); err != nil {
+			c.UI.Error(fmt.Errorf("Error shutting down controller: %w", err).Error())
+		}
+	}
+}
+
 func (c *Command) Reload() error {
 	c.ReloadFuncsLock.RLock()
 	defer c.ReloadFuncsLock.RUnlock()
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/servers/controller"
+	"github.com/hashicorp/boundary/internal/servers/worker"
+	"github.com/hashicorp/go-hclog"
+	"github.com/mitchellh/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForInterrupt_ControllerRequestedDrain(t *testing.T) {
+	require := require.New(t)
+	logger := hclog.New(&hclog.LoggerOptions{
+		Level: hclog.Trace,
+	})
+
+	tc := controller.NewTestController(t, &controller.TestControllerOpts{
+		Logger: logger.Named("c1"),
+	})
+	defer tc.Shutdown()
+
+	tw := worker.NewTestWorker(t, &worker.TestWorkerOpts{
+		WorkerAuthKms:      tc.Config().WorkerAuthKms,
+		InitialControllers: tc.ClusterAddrs(),
+		Logger:             logger.Named("w1"),
+	})
+	defer tw.Shutdown()
+
+	srv := base.NewServer(&base.Command{
+		UI:         cli.NewMockUi(),
+		ShutdownCh: make(chan struct{}),
+	})
+	srv.Logger = logger.Named("server")
+	c := &Command{
+		Server:    srv,
+		SighupCh:  make(chan struct{}),
+		SigUSR1Ch: make(chan struct{}),
+		SigUSR2Ch: make(chan struct{}),
+		Config:    tw.Config().RawConfig,
+		worker:    tw.Worker(),
+	}
+	done := make(chan int)
+	go func() {
+		done <- c.WaitForInterrupt()
+	}()
+
+	// Wait for the worker to report its status before requesting the drain
+	repo := tc.ServersRepo()
+	deadline := time.Now().Add(30 * time.Second)
+	for {
+		w, err := repo.RequestWorkerDrain(tc.Context(), tw.Name())
+		require.NoError(err)
+		if w != nil {
+			break
+		}
+		require.True(time.Now().Before(deadline), "worker never reported its status")
+		time.Sleep(time.Second)
+	}
+
+	// The drain is passed to the worker with its next status, and as it has
+	// no open connections it drains and the server shuts down
+	select {
+	case code := <-done:
+		require.Zero(code)
+	case <-time.After(30 * time.Second):
+		t.Fatal("server not shut down after a controller requested drain")
+	}
+	require.True(tw.Worker().Draining())
+}
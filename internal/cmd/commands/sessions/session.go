@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/boundary/internal/cmd/base"
 	"github.com/hashicorp/boundary/internal/cmd/common"
 	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/strutil"
 	"github.com/mitchellh/cli"
 	"github.com/posener/complete"
@@ -24,13 +25,17 @@ type Command struct {
 }
 
 func (c *Command) Synopsis() string {
+	if c.Func == "watch" {
+		return "Watch the state transitions of sessions and connections"
+	}
 	return common.SynopsisFunc(c.Func, "session")
 }
 
 var flagsMap = map[string][]string{
 	"read":   {"id"},
 	"cancel": {"id"},
-	"list":   {"scope-id"},
+	"list":   {"scope-id", "filter", "page-size", "all", "recursive"},
+	"watch":  {"scope-id", "recursive"},
 }
 
 func (c *Command) Help() string {
@@ -47,6 +52,10 @@ func (c *Command) Help() string {
 			"",
 			`      $ boundary sessions read -id s_1234567890`,
 			"",
+			"    Watch sessions and connections change state:",
+			"",
+			`      $ boundary sessions watch -scope-id o_1234567890 -recursive`,
+			"",
 			"  Please see the sessions subcommand help for detailed usage information.",
 		})
 	case "cancel":
@@ -59,6 +68,16 @@ func (c *Command) Help() string {
 			"",
 			"",
 		})
+	case "watch":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary sessions watch [options] [args]",
+			"",
+			"  Print the state transitions of the sessions and session connections in a scope as they happen, until interrupted. Only sessions which can be read are included. If -scope-id is not set, the global scope is watched. Example:",
+			"",
+			`    $ boundary sessions watch -scope-id o_1234567890 -recursive`,
+			"",
+			"",
+		})
 	default:
 		helpStr = helpMap[c.Func]()
 	}
@@ -97,6 +116,9 @@ func (c *Command) Run(args []string) int {
 		c.UI.Error("ID is required but not passed in via -id")
 		return 1
 	}
+	if c.Func == "watch" && c.FlagScopeId == "" {
+		c.FlagScopeId = scope.Global.String()
+	}
 	if strutil.StrListContains(flagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
 		c.UI.Error("Scope ID must be passed in via -scope-id")
 		return 1
@@ -108,6 +130,25 @@ func (c *Command) Run(args []string) int {
 		return 2
 	}
 
+	var opts []sessions.Option
+
+	if c.FlagFilter != "" {
+		opts = append(opts, sessions.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, sessions.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, sessions.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, sessions.WithRecursive(true))
+	}
+
+	if c.Func == "watch" {
+		return c.watch(client)
+	}
+
 	sessionClient := sessions.NewClient(client)
 
 	var result api.GenericResult
@@ -119,7 +160,7 @@ func (c *Command) Run(args []string) int {
 	case "cancel":
 		result, err = sessionClient.Cancel(c.Context, c.FlagId, 0, sessions.WithAutomaticVersioning(true))
 	case "list":
-		listResult, err = sessionClient.List(c.Context, c.FlagScopeId)
+		listResult, err = sessionClient.List(c.Context, c.FlagScopeId, opts...)
 	}
 
 	plural := "session"
@@ -174,6 +215,9 @@ func (c *Command) Run(args []string) int {
 					fmt.Sprintf("    User ID:          %s", t.UserId),
 					fmt.Sprintf("    Target ID:        %s", t.TargetId),
 				)
+				if c.FlagRecursive && t.Scope != nil {
+					output = append(output, fmt.Sprintf("    Scope ID:         %s", t.Scope.Id))
+				}
 			}
 			c.UI.Output(base.WrapForHelpText(output))
 		}
@@ -0,0 +1,58 @@
+package sessions
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+// watch prints session and connection events until the command is
+// interrupted.
+func (c *Command) watch(client *api.Client) int {
+	opts := []api.WatchOption{
+		api.WithWatchScopeId(c.FlagScopeId),
+		api.WithWatchTypes("session", "connection"),
+	}
+	if c.FlagRecursive {
+		opts = append(opts, api.WithWatchRecursive(true))
+	}
+
+	err := client.Watch(c.Context, func(e *api.WatchEvent) error {
+		switch base.Format(c.UI) {
+		case "json":
+			b, err := base.JsonFormatter{}.Format(e)
+			if err != nil {
+				return fmt.Errorf("error formatting as JSON: %w", err)
+			}
+			c.UI.Output(string(b))
+		case "table":
+			c.UI.Output(generateWatchEventOutput(e))
+		}
+		return nil
+	}, opts...)
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing watch on sessions: %s", base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to watch sessions: %s", err.Error()))
+		return 2
+	}
+	return 0
+}
+
+func generateWatchEventOutput(e *api.WatchEvent) string {
+	line := fmt.Sprintf("%s  %-10s  %s  %s", e.Time.Local().Format(time.RFC3339), e.Type, e.Id, e.State)
+	if e.SessionId != "" {
+		line += fmt.Sprintf("  session=%s", e.SessionId)
+	}
+	if e.UserId != "" {
+		line += fmt.Sprintf("  user=%s", e.UserId)
+	}
+	if e.TargetId != "" {
+		line += fmt.Sprintf("  target=%s", e.TargetId)
+	}
+	return line
+}
@@ -42,7 +42,7 @@ var flagsMap = map[string][]string{
 	"authorize-session": {"id", "host-id"},
 	"read":              {"id"},
 	"delete":            {"id"},
-	"list":              {"scope-id"},
+	"list":              {"scope-id", "filter", "page-size", "all", "recursive"},
 	"add-host-sets":     {"id", "host-set", "version"},
 	"remove-host-sets":  {"id", "host-set", "version"},
 	"set-host-sets":     {"id", "host-set", "version"},
@@ -333,6 +333,19 @@ func (c *Command) Run(args []string) int {
 		// file
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, targets.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, targets.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, targets.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, targets.WithRecursive(true))
+	}
+
 	targetClient := targets.NewClient(client)
 
 	existed := true
@@ -429,6 +442,9 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("    Version:      %d", m.Version),
 						fmt.Sprintf("    Type:         %s", m.Type),
 					)
+					if c.FlagRecursive && m.Scope != nil {
+						output = append(output, fmt.Sprintf("    Scope ID:     %s", m.Scope.Id))
+					}
 				}
 				if m.Name != "" {
 					output = append(output,
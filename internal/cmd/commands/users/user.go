@@ -46,7 +46,7 @@ var flagsMap = map[string][]string{
 	"update":          {"id", "name", "description", "version"},
 	"read":            {"id"},
 	"delete":          {"id"},
-	"list":            {"scope-id"},
+	"list":            {"scope-id", "filter", "page-size", "all", "recursive"},
 	"add-accounts":    {"id", "account", "version"},
 	"set-accounts":    {"id", "account", "version"},
 	"remove-accounts": {"id", "account", "version"},
@@ -141,6 +141,19 @@ func (c *Command) Run(args []string) int {
 		}
 	}
 
+	if c.FlagFilter != "" {
+		opts = append(opts, users.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, users.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, users.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, users.WithRecursive(true))
+	}
+
 	userClient := users.NewClient(client)
 
 	// Perform check-and-set when needed
@@ -248,6 +261,9 @@ func (c *Command) Run(args []string) int {
 						fmt.Sprintf("  ID:             %s", u.Id),
 						fmt.Sprintf("    Version:      %d", u.Version),
 					)
+					if c.FlagRecursive && u.Scope != nil {
+						output = append(output, fmt.Sprintf("    Scope ID:     %s", u.Scope.Id))
+					}
 				}
 				if u.Name != "" {
 					output = append(output,
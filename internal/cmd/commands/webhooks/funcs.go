@@ -0,0 +1,148 @@
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/api/webhooks"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/types/resource"
+)
+
+func baseHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary webhooks [sub command] [options] [args]",
+		"",
+		"  This command allows operations on Boundary webhook resources. Example:",
+		"",
+		"    Create a webhook:",
+		"",
+		`      $ boundary webhooks create -scope-id global -url https://example.com/hook -secret s3cr3t -event-type session.authorized`,
+		"",
+		"  Please see the webhooks subcommand help for detailed usage information.",
+	})
+}
+
+func createHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary webhooks create [options] [args]",
+		"",
+		`  Create a webhook. Events of the given types in the scope, and in the scopes beneath it, are posted to the URL as JSON and signed with the secret. The "event-type" flag can be specified multiple times. Example:`,
+		"",
+		`    $ boundary webhooks create -scope-id o_1234567890 -url https://example.com/hook -secret s3cr3t -event-type session.authorized -event-type session.terminated`,
+		"",
+		"",
+	})
+}
+
+func listDeliveriesHelp() string {
+	return base.WrapForHelpText([]string{
+		"Usage: boundary webhooks list-deliveries [options] [args]",
+		"",
+		"  List the most recent deliveries of a webhook, newest first. Example:",
+		"",
+		`    $ boundary webhooks list-deliveries -id wh_1234567890 -state failed`,
+		"",
+		"",
+	})
+}
+
+func populateFlags(c *Command, f *base.FlagSet, flagNames []string) {
+	common.PopulateCommonFlags(c.Command, f, resource.Webhook.String(), flagNames)
+
+	for _, name := range flagNames {
+		switch name {
+		case "url":
+			f.StringVar(&base.StringVar{
+				Name:   "url",
+				Target: &c.flagUrl,
+				Usage:  "The http or https URL the events are posted to.",
+			})
+		case "secret":
+			f.StringVar(&base.StringVar{
+				Name:   "secret",
+				Target: &c.flagSecret,
+				Usage:  "The secret used to sign the event payloads. It is never returned by the controller.",
+			})
+		case "event-type":
+			f.StringSliceVar(&base.StringSliceVar{
+				Name:   "event-type",
+				Target: &c.flagEventTypes,
+				Usage:  "An event type to deliver. May be specified multiple times.",
+			})
+		case "state":
+			f.StringVar(&base.StringVar{
+				Name:   "state",
+				Target: &c.flagState,
+				Usage:  `If set, only deliveries in this state ("pending", "succeeded" or "failed") are listed.`,
+			})
+		}
+	}
+}
+
+func generateWebhookTableOutput(in *webhooks.Webhook) string {
+	nonAttributeMap := map[string]interface{}{
+		"ID":           in.Id,
+		"Version":      in.Version,
+		"URL":          in.Url,
+		"Event Types":  strings.Join(in.EventTypes, ", "),
+		"Created Time": in.CreatedTime.Local().Format(time.RFC1123),
+		"Updated Time": in.UpdatedTime.Local().Format(time.RFC1123),
+	}
+
+	if in.Name != "" {
+		nonAttributeMap["Name"] = in.Name
+	}
+	if in.Description != "" {
+		nonAttributeMap["Description"] = in.Description
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Webhook information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+		"  Scope:",
+		base.ScopeInfoForOutput(in.Scope, maxLength),
+	}
+
+	return base.WrapForHelpText(ret)
+}
+
+func generateDeliveriesTableOutput(in []*webhooks.Delivery) string {
+	output := []string{
+		"",
+		"Delivery information:",
+	}
+	for i, d := range in {
+		if i > 0 {
+			output = append(output, "")
+		}
+		output = append(output,
+			fmt.Sprintf("  ID:                  %s", d.Id),
+			fmt.Sprintf("    Event Type:        %s", d.EventType),
+			fmt.Sprintf("    State:             %s", d.State),
+			fmt.Sprintf("    Attempts:          %d", d.Attempts),
+			fmt.Sprintf("    Created Time:      %s", d.CreatedTime.Local().Format(time.RFC1123)),
+		)
+		if !d.LastAttemptTime.IsZero() {
+			output = append(output,
+				fmt.Sprintf("    Last Attempt Time: %s", d.LastAttemptTime.Local().Format(time.RFC1123)),
+			)
+		}
+		if d.LastStatusCode != 0 {
+			output = append(output,
+				fmt.Sprintf("    Last Status Code:  %d", d.LastStatusCode),
+			)
+		}
+		if d.LastError != "" {
+			output = append(output,
+				fmt.Sprintf("    Last Error:        %s", d.LastError),
+			)
+		}
+	}
+	return base.WrapForHelpText(output)
+}
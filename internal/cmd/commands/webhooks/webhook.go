@@ -0,0 +1,323 @@
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/webhooks"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*Command)(nil)
+var _ cli.CommandAutocomplete = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+
+	Func string
+
+	flagUrl        string
+	flagSecret     string
+	flagEventTypes []string
+	flagState      string
+}
+
+func (c *Command) Synopsis() string {
+	switch c.Func {
+	case "list-deliveries":
+		return "List the deliveries of a webhook"
+	default:
+		return common.SynopsisFunc(c.Func, "webhook")
+	}
+}
+
+var helpMap = func() map[string]func() string {
+	ret := common.HelpMap("webhook")
+	ret["base"] = baseHelp
+	ret["create"] = createHelp
+	ret["list-deliveries"] = listDeliveriesHelp
+	return ret
+}
+
+var flagsMap = map[string][]string{
+	"create":          {"scope-id", "name", "description", "url", "secret", "event-type"},
+	"update":          {"id", "name", "description", "url", "secret", "event-type", "version"},
+	"read":            {"id"},
+	"delete":          {"id"},
+	"list":            {"scope-id", "filter", "page-size", "all", "recursive"},
+	"list-deliveries": {"id", "state"},
+}
+
+func (c *Command) Help() string {
+	hm := helpMap()
+	if c.Func == "" {
+		return hm["base"]()
+	}
+	return hm[c.Func]() + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	populateFlags(c, f, flagsMap[c.Func])
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(flagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(flagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+	if c.Func == "create" {
+		switch {
+		case c.flagUrl == "":
+			c.UI.Error("URL must be passed in via -url")
+			return 1
+		case c.flagSecret == "":
+			c.UI.Error("Secret must be passed in via -secret")
+			return 1
+		case len(c.flagEventTypes) == 0:
+			c.UI.Error("At least one event type must be passed in via -event-type")
+			return 1
+		}
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []webhooks.Option
+
+	switch c.FlagName {
+	case "":
+	case "null":
+		opts = append(opts, webhooks.DefaultName())
+	default:
+		opts = append(opts, webhooks.WithName(c.FlagName))
+	}
+
+	switch c.FlagDescription {
+	case "":
+	case "null":
+		opts = append(opts, webhooks.DefaultDescription())
+	default:
+		opts = append(opts, webhooks.WithDescription(c.FlagDescription))
+	}
+
+	if c.flagUrl != "" {
+		opts = append(opts, webhooks.WithUrl(c.flagUrl))
+	}
+	if c.flagSecret != "" {
+		opts = append(opts, webhooks.WithSecret(c.flagSecret))
+	}
+	if len(c.flagEventTypes) > 0 {
+		opts = append(opts, webhooks.WithEventTypes(c.flagEventTypes))
+	}
+
+	if c.FlagFilter != "" {
+		opts = append(opts, webhooks.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, webhooks.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, webhooks.WithAll(true))
+	}
+	if c.FlagRecursive {
+		opts = append(opts, webhooks.WithRecursive(true))
+	}
+
+	webhookClient := webhooks.NewClient(client)
+
+	// Perform check-and-set when needed
+	var version uint32
+	switch c.Func {
+	case "update":
+		switch c.FlagVersion {
+		case 0:
+			opts = append(opts, webhooks.WithAutomaticVersioning(true))
+		default:
+			version = uint32(c.FlagVersion)
+		}
+	}
+
+	existed := true
+	var result api.GenericResult
+	var listResult api.GenericListResult
+	var deliveriesResult *webhooks.DeliveryListResult
+
+	switch c.Func {
+	case "create":
+		result, err = webhookClient.Create(c.Context, c.FlagScopeId, opts...)
+	case "update":
+		result, err = webhookClient.Update(c.Context, c.FlagId, version, opts...)
+	case "read":
+		result, err = webhookClient.Read(c.Context, c.FlagId, opts...)
+	case "delete":
+		_, err = webhookClient.Delete(c.Context, c.FlagId, opts...)
+		if apiErr := api.AsServerError(err); apiErr != nil && apiErr.Status == int32(http.StatusNotFound) {
+			existed = false
+			err = nil
+		}
+	case "list":
+		listResult, err = webhookClient.List(c.Context, c.FlagScopeId, opts...)
+	case "list-deliveries":
+		deliveriesResult, err = webhookClient.ListDeliveries(c.Context, c.FlagId, c.flagState, opts...)
+	}
+
+	plural := "webhook"
+	switch c.Func {
+	case "list":
+		plural = "webhooks"
+	case "list-deliveries":
+		plural = "webhook deliveries"
+	}
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	switch c.Func {
+	case "delete":
+		switch base.Format(c.UI) {
+		case "json":
+			c.UI.Output("null")
+		case "table":
+			output := "The delete operation completed successfully"
+			switch existed {
+			case true:
+				output += "."
+			default:
+				output += ", however the resource did not exist at the time."
+			}
+			c.UI.Output(output)
+		}
+		return 0
+
+	case "list-deliveries":
+		deliveries := deliveriesResult.Items
+		switch base.Format(c.UI) {
+		case "json":
+			if len(deliveries) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(deliveries)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+
+		case "table":
+			if len(deliveries) == 0 {
+				c.UI.Output("No deliveries found")
+				return 0
+			}
+			c.UI.Output(generateDeliveriesTableOutput(deliveries))
+		}
+		return 0
+
+	case "list":
+		listedWebhooks := listResult.GetItems().([]*webhooks.Webhook)
+		switch base.Format(c.UI) {
+		case "json":
+			if len(listedWebhooks) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(listedWebhooks)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+
+		case "table":
+			if len(listedWebhooks) == 0 {
+				c.UI.Output("No webhooks found")
+				return 0
+			}
+			var output []string
+			output = []string{
+				"",
+				"Webhook information:",
+			}
+			for i, w := range listedWebhooks {
+				if i > 0 {
+					output = append(output, "")
+				}
+				output = append(output,
+					fmt.Sprintf("  ID:            %s", w.Id),
+					fmt.Sprintf("    Version:     %d", w.Version),
+					fmt.Sprintf("    URL:         %s", w.Url),
+				)
+				if c.FlagRecursive && w.Scope != nil {
+					output = append(output, fmt.Sprintf("    Scope ID:    %s", w.Scope.Id))
+				}
+				if w.Name != "" {
+					output = append(output,
+						fmt.Sprintf("    Name:        %s", w.Name),
+					)
+				}
+				if w.Description != "" {
+					output = append(output,
+						fmt.Sprintf("    Description: %s", w.Description),
+					)
+				}
+			}
+			c.UI.Output(base.WrapForHelpText(output))
+		}
+		return 0
+	}
+
+	webhook := result.GetItem().(*webhooks.Webhook)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateWebhookTableOutput(webhook))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(webhook)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
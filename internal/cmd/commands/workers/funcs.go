@@ -0,0 +1,38 @@
+package workers
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/api/workers"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+)
+
+func generateWorkerTableOutput(in *workers.Worker) string {
+	nonAttributeMap := map[string]interface{}{
+		"ID":              in.Id,
+		"Address":         in.Address,
+		"Created Time":    in.CreatedTime.Local().Format(time.RFC1123),
+		"Updated Time":    in.UpdatedTime.Local().Format(time.RFC1123),
+		"Draining":        in.Draining,
+		"Drain Requested": in.DrainRequested,
+	}
+	if in.Name != "" {
+		nonAttributeMap["Name"] = in.Name
+	}
+	if in.Description != "" {
+		nonAttributeMap["Description"] = in.Description
+	}
+
+	maxLength := base.MaxAttributesLength(nonAttributeMap, nil, nil)
+
+	ret := []string{
+		"",
+		"Worker information:",
+		base.WrapMap(2, maxLength+2, nonAttributeMap),
+		"",
+		"  Scope:",
+		base.ScopeInfoForOutput(in.Scope, maxLength),
+	}
+
+	return base.WrapForHelpText(ret)
+}
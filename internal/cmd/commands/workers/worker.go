@@ -0,0 +1,211 @@
+package workers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/api"
+	"github.com/hashicorp/boundary/api/workers"
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/common"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+)
+
+var _ cli.Command = (*Command)(nil)
+var _ cli.CommandAutocomplete = (*Command)(nil)
+
+type Command struct {
+	*base.Command
+
+	Func string
+}
+
+func (c *Command) Synopsis() string {
+	return common.SynopsisFunc(c.Func, "worker")
+}
+
+var flagsMap = map[string][]string{
+	"read":  {"id"},
+	"drain": {"id"},
+	"list":  {"scope-id", "filter", "page-size", "all"},
+}
+
+func (c *Command) Help() string {
+	helpMap := common.HelpMap("worker")
+	var helpStr string
+	switch c.Func {
+	case "":
+		return base.WrapForHelpText([]string{
+			"Usage: boundary workers [sub command] [options] [args]",
+			"",
+			"  This command allows operations on Boundary workers.",
+			"",
+			"    Read a worker:",
+			"",
+			`      $ boundary workers read -id worker1`,
+			"",
+			"  Please see the workers subcommand help for detailed usage information.",
+		})
+	case "drain":
+		helpStr = base.WrapForHelpText([]string{
+			"Usage: boundary workers drain [options] [args]",
+			"",
+			"  Drain the worker specified by ID. The worker stops accepting connections for new sessions and shuts down once its existing connections have closed or its drain timeout has passed. Example:",
+			"",
+			`    $ boundary workers drain -id worker1`,
+			"",
+			"",
+		})
+	default:
+		helpStr = helpMap[c.Func]()
+	}
+	return helpStr + c.Flags().Help()
+}
+
+func (c *Command) Flags() *base.FlagSets {
+	set := c.FlagSet(base.FlagSetHTTP | base.FlagSetClient | base.FlagSetOutputFormat)
+	f := set.NewFlagSet("Command Options")
+	common.PopulateCommonFlags(c.Command, f, resource.Worker.String(), flagsMap[c.Func])
+
+	return set
+}
+
+func (c *Command) AutocompleteArgs() complete.Predictor {
+	return complete.PredictAnything
+}
+
+func (c *Command) AutocompleteFlags() complete.Flags {
+	return c.Flags().Completions()
+}
+
+func (c *Command) Run(args []string) int {
+	if c.Func == "" {
+		return cli.RunResultHelp
+	}
+
+	f := c.Flags()
+
+	if err := f.Parse(args); err != nil {
+		c.UI.Error(err.Error())
+		return 1
+	}
+
+	if strutil.StrListContains(flagsMap[c.Func], "id") && c.FlagId == "" {
+		c.UI.Error("ID is required but not passed in via -id")
+		return 1
+	}
+	if strutil.StrListContains(flagsMap[c.Func], "scope-id") && c.FlagScopeId == "" {
+		c.UI.Error("Scope ID must be passed in via -scope-id")
+		return 1
+	}
+
+	client, err := c.Client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error creating API client: %s", err.Error()))
+		return 2
+	}
+
+	var opts []workers.Option
+
+	if c.FlagFilter != "" {
+		opts = append(opts, workers.WithFilter(c.FlagFilter))
+	}
+	if c.FlagPageSize != 0 {
+		opts = append(opts, workers.WithPageSize(uint32(c.FlagPageSize)))
+	}
+	if c.FlagAll {
+		opts = append(opts, workers.WithAll(true))
+	}
+
+	workerClient := workers.NewClient(client)
+
+	var result api.GenericResult
+	var listResult api.GenericListResult
+
+	switch c.Func {
+	case "read":
+		result, err = workerClient.Read(c.Context, c.FlagId)
+	case "drain":
+		result, err = workerClient.Drain(c.Context, c.FlagId)
+	case "list":
+		listResult, err = workerClient.List(c.Context, c.FlagScopeId, opts...)
+	}
+
+	plural := "worker"
+	if c.Func == "list" {
+		plural = "workers"
+	}
+	if err != nil {
+		if apiErr := api.AsServerError(err); apiErr != nil {
+			c.UI.Error(fmt.Sprintf("Error from controller when performing %s on %s: %s", c.Func, plural, base.PrintApiError(apiErr)))
+			return 1
+		}
+		c.UI.Error(fmt.Sprintf("Error trying to %s %s: %s", c.Func, plural, err.Error()))
+		return 2
+	}
+
+	switch c.Func {
+	case "list":
+		listedWorkers := listResult.GetItems().([]*workers.Worker)
+		switch base.Format(c.UI) {
+		case "json":
+			if len(listedWorkers) == 0 {
+				c.UI.Output("null")
+				return 0
+			}
+			b, err := base.JsonFormatter{}.Format(listedWorkers)
+			if err != nil {
+				c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+				return 1
+			}
+			c.UI.Output(string(b))
+
+		case "table":
+			if len(listedWorkers) == 0 {
+				c.UI.Output("No workers found")
+				return 0
+			}
+			var output []string
+			output = []string{
+				"",
+				"Worker information:",
+			}
+			for i, w := range listedWorkers {
+				if i > 0 {
+					output = append(output, "")
+				}
+				output = append(output,
+					fmt.Sprintf("  ID:                 %s", w.Id),
+					fmt.Sprintf("    Address:          %s", w.Address),
+					fmt.Sprintf("    Draining:         %t", w.Draining),
+					fmt.Sprintf("    Updated Time:     %s", w.UpdatedTime.Local().Format(time.RFC1123)),
+				)
+				if w.Description != "" {
+					output = append(output,
+						fmt.Sprintf("    Description:      %s", w.Description),
+					)
+				}
+			}
+			c.UI.Output(base.WrapForHelpText(output))
+		}
+		return 0
+	}
+
+	worker := result.GetItem().(*workers.Worker)
+	switch base.Format(c.UI) {
+	case "table":
+		c.UI.Output(generateWorkerTableOutput(worker))
+	case "json":
+		b, err := base.JsonFormatter{}.Format(worker)
+		if err != nil {
+			c.UI.Error(fmt.Errorf("Error formatting as JSON: %w", err).Error())
+			return 1
+		}
+		c.UI.Output(string(b))
+	}
+
+	return 0
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/sdk/wrapper"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// LoadConfig loads the config file at configPath, decrypting it with the
+// "config" purpose KMS found in configKmsPath, or in the config file itself if
+// configKmsPath is empty. The returned wrapper, if not nil, must be finalized
+// by the caller.
+func LoadConfig(ctx context.Context, configPath, configKmsPath string) (*config.Config, wrapping.Wrapper, error) {
+	if configPath == "" {
+		return nil, nil, fmt.Errorf("Must specify a config file using -config")
+	}
+	wrapperPath := configPath
+	if configKmsPath != "" {
+		wrapperPath = configKmsPath
+	}
+	wrapper, err := wrapper.GetWrapperFromPath(wrapperPath, "config")
+	if err != nil {
+		return nil, nil, err
+	}
+	if wrapper != nil {
+		if err := wrapper.Init(ctx); err != nil {
+			return nil, nil, fmt.Errorf("Could not initialize kms: %w", err)
+		}
+	}
+	cfg, err := config.LoadFile(configPath, wrapper)
+	if err != nil {
+		return nil, wrapper, fmt.Errorf("Error parsing config: %w", err)
+	}
+	return cfg, wrapper, nil
+}
+
+// MigrationUrl returns the URL used to connect to the database to manage
+// its schema: flagUrl if set, otherwise the migration URL in the config,
+// otherwise the database URL.
+func MigrationUrl(cfg *config.Config, flagUrl string) (string, error) {
+	if cfg.Controller == nil || cfg.Controller.Database == nil {
+		return "", fmt.Errorf(`"controller.database" config block not found`)
+	}
+	urlToParse := cfg.Controller.Database.Url
+	if cfg.Controller.Database.MigrationUrl != "" {
+		urlToParse = cfg.Controller.Database.MigrationUrl
+	}
+	if flagUrl != "" {
+		urlToParse = flagUrl
+	}
+	if urlToParse == "" {
+		return "", fmt.Errorf(`"url" not specified in "database" config block`)
+	}
+	u, err := config.ParseAddress(urlToParse)
+	if err != nil && err != config.ErrNotAUrl {
+		return "", fmt.Errorf("Error parsing migration url: %w", err)
+	}
+	return strings.TrimSpace(u), nil
+}
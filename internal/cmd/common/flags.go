@@ -65,6 +65,30 @@ func PopulateCommonFlags(c *base.Command, f *base.FlagSet, resourceType string,
 				Target: &c.FlagHostCatalogId,
 				Usage:  "The host-catalog resource to use for the operation.",
 			})
+		case "filter":
+			f.StringVar(&base.StringVar{
+				Name:   "filter",
+				Target: &c.FlagFilter,
+				Usage:  fmt.Sprintf(`Only list the %ss matching the given filter expression, e.g. 'name contains "prod"'.`, resourceType),
+			})
+		case "page-size":
+			f.UintVar(&base.UintVar{
+				Name:   "page-size",
+				Target: &c.FlagPageSize,
+				Usage:  fmt.Sprintf("The maximum number of %ss to request at a time. Without -all, only the first page is listed.", resourceType),
+			})
+		case "all":
+			f.BoolVar(&base.BoolVar{
+				Name:   "all",
+				Target: &c.FlagAll,
+				Usage:  fmt.Sprintf("List all matching %ss, requesting every page in turn.", resourceType),
+			})
+		case "recursive":
+			f.BoolVar(&base.BoolVar{
+				Name:   "recursive",
+				Target: &c.FlagRecursive,
+				Usage:  fmt.Sprintf("Also list the %ss in all scopes below the given scope.", resourceType),
+			})
 		}
 	}
 }
@@ -87,6 +87,7 @@ type Config struct {
 
 	Worker     *Worker     `hcl:"worker"`
 	Controller *Controller `hcl:"controller"`
+	Events     *Events     `hcl:"events"`
 
 	// Dev-related options
 	DevController        bool   `hcl:"-"`
@@ -110,6 +111,27 @@ type Controller struct {
 	// denoted by time.Duration
 	AuthTokenTimeToStale         interface{} `hcl:"auth_token_time_to_stale"`
 	AuthTokenTimeToStaleDuration time.Duration
+
+	// OplogRetention enables archiving and deleting old oplog entries
+	OplogRetention *OplogRetention `hcl:"oplog_retention"`
+}
+
+// OplogRetention is the retention policy of the oplog. Entries older than
+// MaxAge, and entries of an aggregate beyond its newest
+// MaxEntriesPerAggregate entries, are archived to ArchiveDir and deleted
+// every Interval.
+type OplogRetention struct {
+	// MaxAge is the age after which entries expire, denoted by time.Duration
+	MaxAge         interface{} `hcl:"max_age"`
+	MaxAgeDuration time.Duration
+
+	MaxEntriesPerAggregate int    `hcl:"max_entries_per_aggregate"`
+	ArchiveDir             string `hcl:"archive_dir"`
+
+	// Interval is the time between runs, denoted by time.Duration. It
+	// defaults to one hour.
+	Interval         interface{} `hcl:"interval"`
+	IntervalDuration time.Duration
 }
 
 type Worker struct {
@@ -117,6 +139,50 @@ type Worker struct {
 	Description string   `hcl:"description"`
 	Controllers []string `hcl:"controllers"`
 	PublicAddr  string   `hcl:"public_addr"`
+
+	// Tags are reported to the controller. Values of the "network" key are
+	// the networks, in CIDR notation, the worker can reach directly; other
+	// workers forward connections to endpoints in those networks through
+	// this worker.
+	Tags map[string][]string `hcl:"tags"`
+
+	// Drain puts the worker into drain mode when the configuration is
+	// reloaded
+	Drain bool `hcl:"drain"`
+
+	// DrainTimeout is how long a draining worker waits for existing
+	// connections to finish before shutting down, denoted by time.Duration
+	DrainTimeout         interface{} `hcl:"drain_timeout"`
+	DrainTimeoutDuration time.Duration
+
+	// ControllerOutageGracePeriod is how long after its last successful
+	// status report the worker keeps authorizing new connections for already
+	// active sessions on its own while no controller is reachable, denoted by
+	// time.Duration. Zero, the default, disables this.
+	ControllerOutageGracePeriod         interface{} `hcl:"controller_outage_grace_period"`
+	ControllerOutageGracePeriodDuration time.Duration
+}
+
+// Events configures where audit events are written
+type Events struct {
+	Sinks []*EventSink `hcl:"sink"`
+}
+
+// EventSink is a destination of audit events. Type is one of "file",
+// "stderr", or "webhook".
+type EventSink struct {
+	Type string `hcl:",key"`
+
+	// Path, RotateBytes and RotateMaxFiles apply to file sinks. When
+	// RotateBytes is set the file is rotated once it reaches that size,
+	// keeping RotateMaxFiles rotated files.
+	Path           string `hcl:"path"`
+	RotateBytes    int64  `hcl:"rotate_bytes"`
+	RotateMaxFiles int    `hcl:"rotate_max_files"`
+
+	// Url and Headers apply to webhook sinks
+	Url     string            `hcl:"url"`
+	Headers map[string]string `hcl:"headers"`
 }
 
 type Database struct {
@@ -239,6 +305,52 @@ func Parse(d string) (*Config, error) {
 			}
 			result.Controller.AuthTokenTimeToStaleDuration = t
 		}
+
+		if r := result.Controller.OplogRetention; r != nil {
+			if r.MaxAge != nil && r.MaxAge != "" {
+				t, err := parseutil.ParseDurationSecond(r.MaxAge)
+				if err != nil {
+					return result, err
+				}
+				r.MaxAgeDuration = t
+			}
+			if r.Interval != nil && r.Interval != "" {
+				t, err := parseutil.ParseDurationSecond(r.Interval)
+				if err != nil {
+					return result, err
+				}
+				r.IntervalDuration = t
+			}
+			switch {
+			case r.MaxAgeDuration < 0 || r.MaxEntriesPerAggregate < 0 || r.IntervalDuration < 0:
+				return result, errors.New("oplog_retention durations and counts must not be negative")
+			case r.ArchiveDir == "":
+				return result, errors.New("oplog_retention requires archive_dir")
+			case r.MaxAgeDuration == 0 && r.MaxEntriesPerAggregate == 0:
+				return result, errors.New("oplog_retention requires max_age or max_entries_per_aggregate")
+			}
+			if r.IntervalDuration == 0 {
+				r.IntervalDuration = time.Hour
+			}
+		}
+	}
+
+	if result.Worker != nil {
+		if result.Worker.DrainTimeout != nil && result.Worker.DrainTimeout != "" {
+			t, err := parseutil.ParseDurationSecond(result.Worker.DrainTimeout)
+			if err != nil {
+				return result, err
+			}
+			result.Worker.DrainTimeoutDuration = t
+		}
+
+		if result.Worker.ControllerOutageGracePeriod != nil && result.Worker.ControllerOutageGracePeriod != "" {
+			t, err := parseutil.ParseDurationSecond(result.Worker.ControllerOutageGracePeriod)
+			if err != nil {
+				return result, err
+			}
+			result.Worker.ControllerOutageGracePeriodDuration = t
+		}
 	}
 
 	sharedConfig, err := configutil.ParseConfig(d)
@@ -115,3 +115,117 @@ func TestDevWorker(t *testing.T) {
 
 	assert.Equal(t, exp, actual)
 }
+
+func TestWorkerTags(t *testing.T) {
+	actual, err := Parse(`
+worker {
+	name = "jump"
+	tags {
+		network = ["10.10.0.0/16", "10.20.0.0/16"]
+		region  = ["us-east-1"]
+	}
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string][]string{
+		"network": {"10.10.0.0/16", "10.20.0.0/16"},
+		"region":  {"us-east-1"},
+	}, actual.Worker.Tags)
+}
+
+func TestWorkerDurations(t *testing.T) {
+	actual, err := Parse(`
+worker {
+	name = "edge"
+	drain_timeout = "90s"
+	controller_outage_grace_period = 300
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 90*time.Second, actual.Worker.DrainTimeoutDuration)
+	assert.Equal(t, 5*time.Minute, actual.Worker.ControllerOutageGracePeriodDuration)
+
+	_, err = Parse(`
+worker {
+	controller_outage_grace_period = "soon"
+}`)
+	assert.Error(t, err)
+}
+
+func TestOplogRetention(t *testing.T) {
+	actual, err := Parse(`
+controller {
+	name = "control"
+	oplog_retention {
+		max_age = "720h"
+		max_entries_per_aggregate = 100000
+		archive_dir = "/var/lib/boundary/oplog"
+	}
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := actual.Controller.OplogRetention
+	assert.Equal(t, 720*time.Hour, r.MaxAgeDuration)
+	assert.Equal(t, 100000, r.MaxEntriesPerAggregate)
+	assert.Equal(t, "/var/lib/boundary/oplog", r.ArchiveDir)
+	assert.Equal(t, time.Hour, r.IntervalDuration)
+
+	_, err = Parse(`
+controller {
+	oplog_retention {
+		max_age = "720h"
+	}
+}`)
+	assert.Error(t, err)
+
+	_, err = Parse(`
+controller {
+	oplog_retention {
+		archive_dir = "/var/lib/boundary/oplog"
+		interval = "10m"
+	}
+}`)
+	assert.Error(t, err)
+}
+
+func TestEvents(t *testing.T) {
+	actual, err := Parse(`
+events {
+	sink "file" {
+		path = "/var/log/boundary/events.log"
+		rotate_bytes = 1048576
+		rotate_max_files = 3
+	}
+	sink "stderr" {}
+	sink "webhook" {
+		url = "https://example.com/events"
+		headers {
+			Authorization = "Bearer secret"
+		}
+	}
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &Events{
+		Sinks: []*EventSink{
+			{
+				Type:           "file",
+				Path:           "/var/log/boundary/events.log",
+				RotateBytes:    1048576,
+				RotateMaxFiles: 3,
+			},
+			{
+				Type: "stderr",
+			},
+			{
+				Type:    "webhook",
+				Url:     "https://example.com/events",
+				Headers: map[string]string{"Authorization": "Bearer secret"},
+			},
+		},
+	}, actual.Events)
+}
@@ -5355,6 +5355,1876 @@ begin;
 
 commit;
 
+`),
+	},
+	"migrations/70_static_host_label.down.sql": {
+		name: "70_static_host_label.down.sql",
+		bytes: []byte(`
+begin;
+
+  alter table static_host_set
+    drop column selector;
+
+  drop table static_host_label cascade;
+  drop function insert_static_host_label;
+
+  delete
+    from oplog_ticket
+   where name = 'static_host_label';
+
+commit;
+
+`),
+	},
+	"migrations/70_static_host_label.up.sql": {
+		name: "70_static_host_label.up.sql",
+		bytes: []byte(`
+begin;
+
+/*
+
+  ┌─────────────────────┐          ┌─────────────────────┐
+  │     static_host     │          │  static_host_label  │
+  ├─────────────────────┤          ├─────────────────────┤
+  │ public_id  (pk)     │┼┼──────○<│ host_id    (pk,fk)  │
+  │ catalog_id          │          │ key        (pk)     │
+  │                     │          │ value               │
+  │                     │          │ catalog_id (fk)     │
+  └─────────────────────┘          └─────────────────────┘
+
+  Labels are free-form key/value pairs attached to a static host. A static
+  host set with a selector contains every host in its catalog with labels
+  matching the selector, in addition to the hosts in static_host_set_member.
+
+*/
+
+  create table static_host_label (
+    host_id wt_public_id not null,
+    catalog_id wt_public_id not null,
+    key text not null
+      constraint key_must_be_valid
+      check(key ~ '^[A-Za-z0-9_./-]{1,63}$'),
+    value text not null
+      constraint value_must_be_valid
+      check(value ~ '^[A-Za-z0-9_./-]{0,255}$'),
+    primary key(host_id, key),
+    foreign key (catalog_id, host_id)
+      references static_host (catalog_id, public_id)
+      on delete cascade
+      on update cascade
+  );
+
+  create index static_host_label_catalog_id_key_value_idx
+    on static_host_label (catalog_id, key, value);
+
+  create trigger immutable_columns before update on static_host_label
+    for each row execute procedure immutable_columns('host_id', 'catalog_id', 'key');
+
+  create or replace function insert_static_host_label()
+    returns trigger
+  as $$
+  begin
+    select static_host.catalog_id
+      into new.catalog_id
+    from static_host
+    where static_host.public_id = new.host_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_static_host_label before insert on static_host_label
+    for each row execute procedure insert_static_host_label();
+
+  alter table static_host_set
+    add column selector text
+      constraint selector_must_not_be_empty
+      check(length(trim(selector)) > 0);
+
+  insert into oplog_ticket (name, version)
+  values
+    ('static_host_label', 1);
+
+commit;
+
+`),
+	},
+	"migrations/71_server_tag.down.sql": {
+		name: "71_server_tag.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table server_tag;
+
+commit;
+
+`),
+	},
+	"migrations/71_server_tag.up.sql": {
+		name: "71_server_tag.up.sql",
+		bytes: []byte(`
+begin;
+
+-- server_tag contains the tags reported by a server. Workers report the
+-- networks they can reach directly with the 'network' key, which the
+-- controller uses to find a path of workers to a session's endpoint.
+create table server_tag (
+    server_id text,
+    server_type text,
+    key text not null
+      constraint server_tag_key_must_not_be_empty
+      check(length(trim(key)) > 0),
+    value text not null
+      constraint server_tag_value_must_not_be_empty
+      check(length(trim(value)) > 0),
+    primary key (server_id, server_type, key, value),
+    foreign key (server_id, server_type)
+      references server (private_id, type)
+      on delete cascade
+      on update cascade
+  );
+
+commit;
+
+`),
+	},
+	"migrations/72_server_drain.down.sql": {
+		name: "72_server_drain.down.sql",
+		bytes: []byte(`
+begin;
+
+  alter table server
+    drop column draining,
+    drop column drain_requested;
+
+commit;
+
+`),
+	},
+	"migrations/72_server_drain.up.sql": {
+		name: "72_server_drain.up.sql",
+		bytes: []byte(`
+begin;
+
+-- draining is reported by a worker in its status once it has begun to drain.
+-- Draining workers are not handed out for new sessions. drain_requested is set
+-- through the API and cleared once the worker reports that it is draining.
+alter table server
+  add column draining boolean not null default false,
+  add column drain_requested boolean not null default false;
+
+commit;
+
+`),
+	},
+	"migrations/73_watch.down.sql": {
+		name: "73_watch.down.sql",
+		bytes: []byte(`
+begin;
+
+drop trigger watch_notify_session_connection_state on session_connection_state;
+drop function watch_notify_session_connection_state;
+drop trigger watch_notify_session_state on session_state;
+drop function watch_notify_session_state;
+
+commit;
+
+`),
+	},
+	"migrations/73_watch.up.sql": {
+		name: "73_watch.up.sql",
+		bytes: []byte(`
+begin;
+
+-- Session and connection state transitions are published on the
+-- boundary_watch channel for the watch API, along with the resource changes
+-- published by the oplog write path. Notifications are delivered when the
+-- inserting transaction commits.
+create function
+  watch_notify_session_state()
+  returns trigger
+as $$
+begin
+  perform pg_notify('boundary_watch', json_build_object(
+    'type', 'session',
+    'op', case when new.previous_end_time is null then 'create' else 'update' end,
+    'id', new.session_id,
+    'scope_id', s.scope_id,
+    'user_id', s.user_id,
+    'target_id', s.target_id,
+    'state', new.state,
+    'time', new.start_time
+  )::text)
+  from session s
+  where s.public_id = new.session_id;
+  return null;
+end;
+$$ language plpgsql;
+
+create trigger
+  watch_notify_session_state
+after insert on session_state
+  for each row execute procedure watch_notify_session_state();
+
+create function
+  watch_notify_session_connection_state()
+  returns trigger
+as $$
+begin
+  perform pg_notify('boundary_watch', json_build_object(
+    'type', 'connection',
+    'op', case when new.previous_end_time is null then 'create' else 'update' end,
+    'id', new.connection_id,
+    'session_id', c.session_id,
+    'scope_id', s.scope_id,
+    'user_id', s.user_id,
+    'target_id', s.target_id,
+    'state', new.state,
+    'time', new.start_time
+  )::text)
+  from session_connection c
+    join session s on s.public_id = c.session_id
+  where c.public_id = new.connection_id;
+  return null;
+end;
+$$ language plpgsql;
+
+create trigger
+  watch_notify_session_connection_state
+after insert on session_connection_state
+  for each row execute procedure watch_notify_session_connection_state();
+
+commit;
+
+`),
+	},
+	"migrations/74_webhook.down.sql": {
+		name: "74_webhook.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop trigger webhook_session_connection_state on session_connection_state;
+  drop trigger webhook_session_state on session_state;
+  drop function webhook_session_connection_state;
+  drop function webhook_session_state;
+  drop function insert_webhook_deliveries;
+  drop table webhook_delivery;
+  drop table webhook_delivery_state_enm;
+  drop table webhook_event_type;
+  drop table webhook;
+  drop table webhook_event_type_enm;
+
+  delete from oplog_ticket where name in ('webhook', 'webhook_event_type');
+
+commit;
+
+`),
+	},
+	"migrations/74_webhook.up.sql": {
+		name: "74_webhook.up.sql",
+		bytes: []byte(`
+begin;
+
+  create table webhook_event_type_enm (
+    name text primary key
+      constraint only_predefined_webhook_event_types_allowed
+      check (
+        name in (
+          'session.authorized',
+          'session.activated',
+          'session.canceled',
+          'session.terminated',
+          'connection.opened',
+          'connection.closed'
+        )
+      )
+  );
+
+  insert into webhook_event_type_enm (name)
+  values
+    ('session.authorized'),
+    ('session.activated'),
+    ('session.canceled'),
+    ('session.terminated'),
+    ('connection.opened'),
+    ('connection.closed');
+
+  -- webhook is a subscription to the session lifecycle events of a scope.
+  -- Events for sessions in a project are delivered to the webhooks of the
+  -- project, its org and the global scope.
+  create table webhook (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    url text not null
+      constraint url_must_be_http_or_https
+      check(url ~ '^https?://'),
+    -- the secret used to sign deliveries, encrypted with the database key
+    -- of the scope
+    secret bytea not null,
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(name, scope_id)
+  );
+
+  create trigger
+    update_version_column
+  after update on webhook
+    for each row execute procedure update_version_column();
+
+  create trigger
+    update_time_column
+  before update on webhook
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on webhook
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on webhook
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create table webhook_event_type (
+    webhook_id wt_public_id
+      references webhook (public_id)
+      on delete cascade
+      on update cascade,
+    event_type text
+      references webhook_event_type_enm (name)
+      on delete restrict
+      on update cascade,
+    create_time wt_timestamp,
+    primary key (webhook_id, event_type)
+  );
+
+  create trigger
+    default_create_time_column
+  before
+  insert on webhook_event_type
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on webhook_event_type
+    for each row execute procedure immutable_columns('webhook_id', 'event_type', 'create_time');
+
+  create table webhook_delivery_state_enm (
+    name text primary key
+      constraint only_predefined_webhook_delivery_states_allowed
+      check (
+        name in ('pending', 'succeeded', 'failed')
+      )
+  );
+
+  insert into webhook_delivery_state_enm (name)
+  values
+    ('pending'),
+    ('succeeded'),
+    ('failed');
+
+  -- webhook_delivery is the outbox of events to deliver to webhooks. Rows are
+  -- inserted in the transaction which changes the state of a session or
+  -- connection and are updated by the controllers as they are delivered.
+  create table webhook_delivery (
+    public_id wt_public_id
+      primary key
+      default 'whd_' || encode(gen_random_bytes(5), 'hex'),
+    webhook_id wt_public_id not null
+      references webhook (public_id)
+      on delete cascade
+      on update cascade,
+    event_type text not null
+      references webhook_event_type_enm (name)
+      on delete restrict
+      on update cascade,
+    -- the json body sent to the webhook
+    payload text not null,
+    state text not null
+      default 'pending'
+      references webhook_delivery_state_enm (name)
+      on delete restrict
+      on update cascade,
+    attempts integer not null default 0
+      constraint attempts_must_not_be_negative
+      check(attempts >= 0),
+    -- the earliest time of the next attempt; pending deliveries being
+    -- attempted by a controller are leased by moving it into the future
+    next_attempt_time wt_timestamp,
+    last_attempt_time timestamp with time zone,
+    last_status_code integer,
+    last_error text,
+    create_time wt_timestamp,
+    update_time wt_timestamp
+  );
+
+  create trigger
+    update_time_column
+  before update on webhook_delivery
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on webhook_delivery
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on webhook_delivery
+    for each row execute procedure immutable_columns('public_id', 'webhook_id', 'event_type', 'payload', 'create_time');
+
+  create index webhook_delivery_pending_ix
+    on webhook_delivery (next_attempt_time)
+    where state = 'pending';
+
+  create index webhook_delivery_webhook_id_ix
+    on webhook_delivery (webhook_id, create_time);
+
+  -- insert_webhook_deliveries queues an event for each webhook subscribed to
+  -- it in the project of the session, its org or the global scope.
+  create function
+    insert_webhook_deliveries(_event_type text, _scope_id text, _payload text)
+    returns void
+  as $$
+  begin
+    insert into webhook_delivery
+      (webhook_id, event_type, payload)
+    select w.public_id, _event_type, _payload
+      from webhook w
+      join webhook_event_type e on e.webhook_id = w.public_id
+     where e.event_type = _event_type
+       and w.scope_id in (
+         select s.public_id
+           from iam_scope s
+          where s.public_id = _scope_id
+          union
+         select s.parent_id
+           from iam_scope s
+          where s.public_id = _scope_id
+            and s.parent_id is not null
+          union
+         select 'global'
+       );
+  end;
+  $$ language plpgsql;
+
+  create function
+    webhook_session_state()
+    returns trigger
+  as $$
+  declare
+    _event_type text;
+  begin
+    _event_type = case new.state
+      when 'pending' then 'session.authorized'
+      when 'active' then 'session.activated'
+      when 'canceling' then 'session.canceled'
+      when 'terminated' then 'session.terminated'
+    end;
+    if _event_type is null then
+      return null;
+    end if;
+    perform insert_webhook_deliveries(_event_type, s.scope_id, json_build_object(
+        'event_type', _event_type,
+        'time', new.start_time,
+        'session', json_build_object(
+          'id', s.public_id,
+          'scope_id', s.scope_id,
+          'user_id', s.user_id,
+          'target_id', s.target_id,
+          'host_set_id', s.host_set_id,
+          'host_id', s.host_id,
+          'auth_token_id', s.auth_token_id,
+          'endpoint', s.endpoint,
+          'expiration_time', s.expiration_time,
+          'termination_reason', s.termination_reason,
+          'state', new.state
+        )
+      )::text)
+      from session s
+     where s.public_id = new.session_id;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    webhook_session_state
+  after insert on session_state
+    for each row execute procedure webhook_session_state();
+
+  create function
+    webhook_session_connection_state()
+    returns trigger
+  as $$
+  declare
+    _event_type text;
+  begin
+    _event_type = case new.state
+      when 'connected' then 'connection.opened'
+      when 'closed' then 'connection.closed'
+    end;
+    if _event_type is null then
+      return null;
+    end if;
+    perform insert_webhook_deliveries(_event_type, s.scope_id, json_build_object(
+        'event_type', _event_type,
+        'time', new.start_time,
+        'session', json_build_object(
+          'id', s.public_id,
+          'scope_id', s.scope_id,
+          'user_id', s.user_id,
+          'target_id', s.target_id,
+          'host_set_id', s.host_set_id,
+          'host_id', s.host_id,
+          'auth_token_id', s.auth_token_id,
+          'endpoint', s.endpoint,
+          'expiration_time', s.expiration_time
+        ),
+        'connection', json_build_object(
+          'id', c.public_id,
+          'client_tcp_address', host(c.client_tcp_address),
+          'client_tcp_port', c.client_tcp_port,
+          'endpoint_tcp_address', host(c.endpoint_tcp_address),
+          'endpoint_tcp_port', c.endpoint_tcp_port,
+          'bytes_up', c.bytes_up,
+          'bytes_down', c.bytes_down,
+          'closed_reason', c.closed_reason,
+          'state', new.state
+        )
+      )::text)
+      from session_connection c
+      join session s on s.public_id = c.session_id
+     where c.public_id = new.connection_id;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    webhook_session_connection_state
+  after insert on session_connection_state
+    for each row execute procedure webhook_session_connection_state();
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('webhook', 1),
+    ('webhook_event_type', 1);
+
+commit;
+
+`),
+	},
+	"migrations/75_kms_rewrap.down.sql": {
+		name: "75_kms_rewrap.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table kms_rewrap_job;
+  drop table kms_rewrap_job_state_enm;
+
+  drop trigger immutable_unless_rewrapped on auth_token;
+
+  create or replace function
+    immutable_auth_token_columns()
+    returns trigger
+  as $$
+  begin
+    if new.auth_account_id is distinct from old.auth_account_id then
+      raise exception 'auth_account_id is read-only';
+    end if;
+    if new.token is distinct from old.token then
+      raise exception 'token is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  drop trigger immutable_unless_rewrapped on oplog_entry;
+  drop trigger immutable_columns on oplog_entry;
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'data');
+
+  drop index oplog_entry_key_id_ix;
+  alter table oplog_entry
+    drop column key_id;
+
+  drop function immutable_unless_rewrapped;
+
+commit;
+
+`),
+	},
+	"migrations/75_kms_rewrap.up.sql": {
+		name: "75_kms_rewrap.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- immutable_unless_rewrapped() makes the ciphertext column passed as a
+  -- parameter when the trigger is created immutable, except when the key_id
+  -- column of the row changes along with it: ciphertext can only be replaced
+  -- by the same value encrypted with another version of the scope's key.
+  create or replace function
+    immutable_unless_rewrapped()
+    returns trigger
+  as $$
+  declare
+    col_name text;
+    new_value text;
+    old_value text;
+  begin
+    if new.key_id is not distinct from old.key_id then
+      foreach col_name in array tg_argv loop
+        execute format('SELECT $1.%I', col_name) into new_value using new;
+        execute format('SELECT $1.%I', col_name) into old_value using old;
+        if new_value is distinct from old_value then
+          raise exception 'immutable column: %.%', tg_table_name, col_name using
+            errcode = '23601',
+            schema = tg_table_schema,
+            table = tg_table_name,
+            column = col_name;
+        end if;
+      end loop;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  comment on function
+    immutable_unless_rewrapped()
+  is
+    'function used in before update triggers to make ciphertext columns immutable unless the key_id column changes';
+
+  -- key_id is the key version which encrypted the data of the entry. It is
+  -- null for entries written before it was recorded, until the rewrap job
+  -- reads it from their data.
+  alter table oplog_entry
+    add column key_id text;
+
+  create index oplog_entry_key_id_ix
+    on oplog_entry (key_id);
+
+  drop trigger immutable_columns on oplog_entry;
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name');
+
+  create trigger
+    immutable_unless_rewrapped
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_unless_rewrapped('data');
+
+  create or replace function
+    immutable_auth_token_columns()
+    returns trigger
+  as $$
+  begin
+    if new.auth_account_id is distinct from old.auth_account_id then
+      raise exception 'auth_account_id is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    immutable_unless_rewrapped
+  before
+  update on auth_token
+    for each row execute procedure immutable_unless_rewrapped('token');
+
+  create table kms_rewrap_job_state_enm (
+    name text primary key
+      constraint only_predefined_rewrap_job_states_allowed
+      check (
+        name in ('pending', 'running', 'completed', 'failed')
+      )
+  );
+
+  insert into kms_rewrap_job_state_enm (name)
+  values
+    ('pending'),
+    ('running'),
+    ('completed'),
+    ('failed');
+
+  -- kms_rewrap_job is the job re-encrypting the data of a scope with the
+  -- newest versions of its keys, created when the keys of the scope are
+  -- rotated. Only the latest job of a scope is kept.
+  create table kms_rewrap_job (
+    scope_id wt_scope_id
+      primary key
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    state text not null
+      references kms_rewrap_job_state_enm (name)
+      on delete restrict
+      on update cascade,
+    -- the number of rows re-encrypted so far
+    rewrapped_count bigint not null default 0
+      constraint rewrapped_count_must_not_be_negative
+      check(rewrapped_count >= 0),
+    -- the reason the job failed
+    error text,
+    -- the time the keys were last rotated, which started the job
+    rotate_time wt_timestamp,
+    update_time wt_timestamp,
+    end_time timestamp with time zone
+  );
+
+  create trigger
+    update_time_column
+  before update on kms_rewrap_job
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_rewrap_job
+    for each row execute procedure immutable_columns('scope_id');
+
+commit;
+
+`),
+	},
+	"migrations/76_kms_key_version_destruction.down.sql": {
+		name: "76_kms_key_version_destruction.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table kms_destroyed_key_version;
+
+commit;
+
+`),
+	},
+	"migrations/76_kms_key_version_destruction.up.sql": {
+		name: "76_kms_key_version_destruction.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- kms_destroyed_key_version records the versions of the DEKs of scopes
+  -- which were destroyed. Controllers read it to drop the destroyed versions
+  -- from the wrappers they have cached.
+  create table kms_destroyed_key_version (
+    private_id wt_private_id
+      primary key,
+    scope_id wt_scope_id not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    purpose text not null
+      constraint only_predefined_key_purposes_allowed
+      check (
+        purpose in ('database', 'oplog', 'tokens', 'sessions')
+      ),
+    version wt_version,
+    destroy_time wt_timestamp
+  );
+
+  create index kms_destroyed_key_version_destroy_time_ix
+    on kms_destroyed_key_version (destroy_time);
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_destroyed_key_version
+    for each row execute procedure immutable_columns('private_id', 'scope_id', 'purpose', 'version', 'destroy_time');
+
+commit;
+
+`),
+	},
+	"migrations/77_kms_root_key_version_rewrap.down.sql": {
+		name: "77_kms_root_key_version_rewrap.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop trigger immutable_unless_rewrapped on kms_root_key_version;
+  drop trigger immutable_columns on kms_root_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_root_key_version
+    for each row execute procedure immutable_columns('private_id', 'root_key_id', 'version', 'key', 'create_time');
+
+  alter table kms_root_key_version
+    drop column key_id;
+
+commit;
+
+`),
+	},
+	"migrations/77_kms_root_key_version_rewrap.up.sql": {
+		name: "77_kms_root_key_version_rewrap.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- key_id is the id of the key of the root KMS which encrypted the key of the
+  -- version. It is null for versions created before it was recorded.
+  alter table kms_root_key_version
+    add column key_id text;
+
+  drop trigger immutable_columns on kms_root_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_root_key_version
+    for each row execute procedure immutable_columns('private_id', 'root_key_id', 'version', 'create_time');
+
+  -- the key can only be replaced when it is re-encrypted with another root
+  -- KMS, which changes key_id
+  create trigger
+    immutable_unless_rewrapped
+  before
+  update on kms_root_key_version
+    for each row execute procedure immutable_unless_rewrapped('key');
+
+commit;
+
+`),
+	},
+	"migrations/78_oplog_search.down.sql": {
+		name: "78_oplog_search.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop index oplog_entry_create_time_ix;
+  drop index oplog_metadata_key_value_ix;
+  drop index oplog_metadata_entry_id_ix;
+
+commit;
+
+`),
+	},
+	"migrations/78_oplog_search.up.sql": {
+		name: "78_oplog_search.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- indexes used to search oplog entries by their metadata and time
+  create index oplog_metadata_entry_id_ix
+    on oplog_metadata (entry_id);
+
+  create index oplog_metadata_key_value_ix
+    on oplog_metadata (key, value);
+
+  create index oplog_entry_create_time_ix
+    on oplog_entry (create_time);
+
+commit;
+
+`),
+	},
+	"migrations/79_oplog_hash_chain.down.sql": {
+		name: "79_oplog_hash_chain.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop trigger immutable_columns on oplog_entry;
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name');
+
+  drop index oplog_entry_chain_ix;
+  drop index oplog_entry_prev_hash_uq;
+
+  alter table oplog_entry
+    drop constraint prev_hash_requires_hash,
+    drop column prev_hash,
+    drop column hash;
+
+commit;
+
+`),
+	},
+	"migrations/79_oplog_hash_chain.up.sql": {
+		name: "79_oplog_hash_chain.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- hash chains each entry to the previous entry of its aggregate, so that
+  -- entries edited, deleted or inserted directly in the database can be
+  -- detected. Entries written before are not chained.
+  alter table oplog_entry
+    add column hash bytea
+      constraint hash_must_be_sha256
+      check(hash is null or length(hash) = 32),
+    add column prev_hash bytea
+      constraint prev_hash_must_be_sha256
+      check(prev_hash is null or length(prev_hash) = 32),
+    add constraint prev_hash_requires_hash
+      check(prev_hash is null or hash is not null);
+
+  -- an entry can only be followed by one entry of its aggregate
+  create unique index oplog_entry_prev_hash_uq
+    on oplog_entry (aggregate_name, prev_hash);
+
+  -- used to find the last chained entry of an aggregate
+  create index oplog_entry_chain_ix
+    on oplog_entry (aggregate_name, id)
+    where hash is not null;
+
+  drop trigger immutable_columns on oplog_entry;
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'hash', 'prev_hash');
+
+commit;
+
+`),
+	},
+	"migrations/80_oplog_retention.down.sql": {
+		name: "80_oplog_retention.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table oplog_chain_anchor;
+
+commit;
+
+`),
+	},
+	"migrations/80_oplog_retention.up.sql": {
+		name: "80_oplog_retention.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- oplog_chain_anchor records, for each aggregate whose oldest entries were
+  -- archived and deleted by the oplog retention policy, the last hash chained
+  -- entry deleted. The first remaining entry of the aggregate is chained to
+  -- it.
+  create table oplog_chain_anchor (
+    aggregate_name text primary key,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    entry_id bigint not null,
+    hash bytea not null
+      constraint hash_must_be_sha256
+      check(length(hash) = 32)
+  );
+
+  create trigger
+    update_time_column
+  before
+  update on oplog_chain_anchor
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on oplog_chain_anchor
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_chain_anchor
+    for each row execute procedure immutable_columns('aggregate_name', 'create_time');
+
+commit;
+
+`),
+	},
+	"migrations/81_oplog_replication.down.sql": {
+		name: "81_oplog_replication.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table oplog_replication_checkpoint;
+
+commit;
+
+`),
+	},
+	"migrations/81_oplog_replication.up.sql": {
+		name: "81_oplog_replication.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- oplog_replication_checkpoint records, in a standby database, the last
+  -- oplog entry of the primary database replayed into it by each named
+  -- replication. It is updated in the same transaction as the replayed
+  -- changes.
+  create table oplog_replication_checkpoint (
+    name text primary key
+      constraint name_must_not_be_empty
+      check(length(trim(name)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    entry_id bigint not null default 0,
+    entry_create_time timestamp with time zone,
+    replayed_count bigint not null default 0
+  );
+
+  create trigger
+    update_time_column
+  before
+  update on oplog_replication_checkpoint
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on oplog_replication_checkpoint
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_replication_checkpoint
+    for each row execute procedure immutable_columns('name', 'create_time');
+
+commit;
+
+`),
+	},
+	"migrations/82_wh_target_scope_dimensions.down.sql": {
+		name: "82_wh_target_scope_dimensions.down.sql",
+		bytes: []byte(`
+begin;
+
+  -- Restore the session triggers of 69_wh_session_facts.
+  create or replace function wh_insert_session_connection()
+    returns trigger
+  as $$
+  declare
+    new_row wh_session_connection_accumulating_fact%rowtype;
+  begin
+    with
+    authorized_timestamp (date_dim_id, time_dim_id, ts) as (
+      select wh_date_id(start_time), wh_time_id(start_time), start_time
+        from session_connection_state
+       where connection_id = new.public_id
+         and state = 'authorized'
+    ),
+    session_dimension (host_dim_id, user_dim_id) as (
+      select host_id, user_id
+        from wh_session_accumulating_fact
+       where session_id = new.session_id
+    )
+    insert into wh_session_connection_accumulating_fact (
+           connection_id,
+           session_id,
+           host_id,
+           user_id,
+           connection_authorized_date_id,
+           connection_authorized_time_id,
+           connection_authorized_time,
+           client_tcp_address,
+           client_tcp_port_number,
+           endpoint_tcp_address,
+           endpoint_tcp_port_number,
+           bytes_up,
+           bytes_down
+    )
+    select new.public_id,
+           new.session_id,
+           session_dimension.host_dim_id,
+           session_dimension.user_dim_id,
+           authorized_timestamp.date_dim_id,
+           authorized_timestamp.time_dim_id,
+           authorized_timestamp.ts,
+           new.client_tcp_address,
+           new.client_tcp_port,
+           new.endpoint_tcp_address,
+           new.endpoint_tcp_port,
+           new.bytes_up,
+           new.bytes_down
+      from authorized_timestamp,
+           session_dimension
+      returning * into strict new_row;
+    perform wh_rollup_connections(new.session_id);
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create or replace function wh_insert_session()
+    returns trigger
+  as $$
+  declare
+    new_row wh_session_accumulating_fact%rowtype;
+  begin
+    with
+    pending_timestamp (date_dim_id, time_dim_id, ts) as (
+      select wh_date_id(start_time), wh_time_id(start_time), start_time
+        from session_state
+       where session_id = new.public_id
+         and state = 'pending'
+    )
+    insert into wh_session_accumulating_fact (
+           session_id,
+           auth_token_id,
+           host_id,
+           user_id,
+           session_pending_date_id,
+           session_pending_time_id,
+           session_pending_time
+    )
+    select new.public_id,
+           new.auth_token_id,
+           wh_upsert_host(new.host_id, new.host_set_id, new.target_id),
+           wh_upsert_user(new.user_id, new.auth_token_id),
+           pending_timestamp.date_dim_id,
+           pending_timestamp.time_dim_id,
+           pending_timestamp.ts
+      from pending_timestamp
+      returning * into strict new_row;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  alter table wh_session_connection_accumulating_fact
+    drop column project_id,
+    drop column target_id;
+
+  alter table wh_session_accumulating_fact
+    drop column project_id,
+    drop column target_id;
+
+  drop function wh_upsert_scope;
+  drop function wh_upsert_target;
+
+  drop view whx_scope_dimension_target;
+  drop view whx_scope_dimension_source;
+  drop table wh_scope_dimension;
+
+  drop view whx_target_dimension_target;
+  drop view whx_target_dimension_source;
+  drop table wh_target_dimension;
+
+commit;
+
+`),
+	},
+	"migrations/82_wh_target_scope_dimensions.up.sql": {
+		name: "82_wh_target_scope_dimensions.up.sql",
+		bytes: []byte(`
+begin;
+
+  create table wh_target_dimension (
+    -- random id generated using encode(digest(gen_random_bytes(16), 'sha256'), 'base64')
+    -- this is done to prevent conflicts with rows in other clusters
+    -- which enables warehouse data from multiple clusters to be loaded into a
+    -- single database instance
+    id                              wh_dim_id     primary key default wh_dim_id(),
+
+    target_id                       wh_public_id  not null,
+    target_type                     wh_dim_text,
+    target_name                     wh_dim_text,
+    target_description              wh_dim_text,
+    target_default_port_number      integer       not null,
+    target_session_max_seconds      integer       not null,
+    target_session_connection_limit integer       not null,
+
+    project_id                      wt_scope_id   not null,
+
+    current_row_indicator           wh_dim_text,
+    row_effective_time              wh_timestamp,
+    row_expiration_time             wh_timestamp
+  );
+
+  create unique index wh_target_dim_current_constraint
+    on wh_target_dimension (target_id)
+    where current_row_indicator = 'Current';
+
+  -- The whx_target_dimension_source and whx_target_dimension_target views are
+  -- used by wh_upsert_target to determine if the current row for the dimension
+  -- has changed and new one needs to be inserted. The first column in the
+  -- target view must be the current warehouse id and all remaining columns must
+  -- match the columns in the source view.
+
+  -- The whx_target_dimension_source view shows the current values in the
+  -- operational tables of the target dimension.
+  create view whx_target_dimension_source as
+  select -- id is the first column in the target view
+         t.public_id                     as target_id,
+         'tcp target'                    as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         t.scope_id                      as project_id
+    from target_tcp as t
+  ;
+
+  -- The whx_target_dimension_target view shows the rows in the
+  -- wh_target_dimension table marked as 'Current'.
+  create view whx_target_dimension_target as
+  select id,
+         target_id,
+         target_type,
+         target_name,
+         target_description,
+         target_default_port_number,
+         target_session_max_seconds,
+         target_session_connection_limit,
+         project_id
+    from wh_target_dimension
+   where current_row_indicator = 'Current'
+  ;
+
+  -- The wh_scope_dimension table holds the scope hierarchy: each row is a
+  -- version of a scope together with the version of its parent scope at the
+  -- time, so the names of the project and organization of a session are kept
+  -- as they were when the session was created.
+  create table wh_scope_dimension (
+    -- random id generated using encode(digest(gen_random_bytes(16), 'sha256'), 'base64')
+    -- this is done to prevent conflicts with rows in other clusters
+    -- which enables warehouse data from multiple clusters to be loaded into a
+    -- single database instance
+    id                       wh_dim_id    primary key default wh_dim_id(),
+
+    scope_id                 wt_scope_id  not null,
+    scope_type               wh_dim_text,
+    scope_name               wh_dim_text,
+    scope_description        wh_dim_text,
+
+    -- 'None' for the global scope
+    parent_scope_id          wh_dim_text,
+    parent_scope_type        wh_dim_text,
+    parent_scope_name        wh_dim_text,
+    parent_scope_description wh_dim_text,
+
+    current_row_indicator    wh_dim_text,
+    row_effective_time       wh_timestamp,
+    row_expiration_time      wh_timestamp
+  );
+
+  create unique index wh_scope_dim_current_constraint
+    on wh_scope_dimension (scope_id)
+    where current_row_indicator = 'Current';
+
+  -- The whx_scope_dimension_source view shows the current values in the
+  -- operational tables of the scope dimension.
+  create view whx_scope_dimension_source as
+       select -- id is the first column in the target view
+              s.public_id                     as scope_id,
+              s.type                          as scope_type,
+              coalesce(s.name, 'None')        as scope_name,
+              coalesce(s.description, 'None') as scope_description,
+              coalesce(p.public_id, 'None')   as parent_scope_id,
+              coalesce(p.type, 'None')        as parent_scope_type,
+              coalesce(p.name, 'None')        as parent_scope_name,
+              coalesce(p.description, 'None') as parent_scope_description
+         from iam_scope as s
+    left join iam_scope as p on s.parent_id = p.public_id
+  ;
+
+  -- The whx_scope_dimension_target view shows the rows in the
+  -- wh_scope_dimension table marked as 'Current'.
+  create view whx_scope_dimension_target as
+  select id,
+         scope_id,
+         scope_type,
+         scope_name,
+         scope_description,
+         parent_scope_id,
+         parent_scope_type,
+         parent_scope_name,
+         parent_scope_description
+    from wh_scope_dimension
+   where current_row_indicator = 'Current'
+  ;
+
+  -- wh_upsert_target returns the wh_target_dimension id for p_target_id.
+  -- wh_upsert_target compares the current values in the wh_target_dimension
+  -- with the current values in the operational tables for the provided
+  -- parameter. If the values between the operational tables and the
+  -- wh_target_dimension differ, a new row is inserted in the
+  -- wh_target_dimension to match the current values in the operational tables
+  -- and the new id is returned. If the values do not differ, the current id is
+  -- returned.
+  create or replace function wh_upsert_target(p_target_id wt_public_id)
+    returns wh_dim_id
+  as $$
+  declare
+    src     whx_target_dimension_target%rowtype;
+    target  whx_target_dimension_target%rowtype;
+    new_row wh_target_dimension%rowtype;
+  begin
+    select * into target
+      from whx_target_dimension_target as t
+     where t.target_id = p_target_id;
+
+    select target.id, t.* into src
+      from whx_target_dimension_source as t
+     where t.target_id = p_target_id;
+
+    if src is distinct from target then
+
+      -- expire the current row
+      update wh_target_dimension
+         set current_row_indicator = 'Expired',
+             row_expiration_time   = current_timestamp
+       where target_id             = p_target_id
+         and current_row_indicator = 'Current';
+
+      -- insert a new row
+      insert into wh_target_dimension (
+             target_id,                  target_type,                target_name,                     target_description,
+             target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+             project_id,
+             current_row_indicator,      row_effective_time,         row_expiration_time
+      )
+      select target_id,                  target_type,                target_name,                     target_description,
+             target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+             project_id,
+             'Current',                  current_timestamp,          'infinity'::timestamptz
+        from whx_target_dimension_source
+       where target_id = p_target_id
+      returning * into new_row;
+
+      return new_row.id;
+    end if;
+    return target.id;
+
+  end;
+  $$ language plpgsql;
+
+  -- wh_upsert_scope returns the wh_scope_dimension id for p_scope_id.
+  -- wh_upsert_scope compares the current values in the wh_scope_dimension with
+  -- the current values in the operational tables for the provided parameter.
+  -- If the values between the operational tables and the wh_scope_dimension
+  -- differ, a new row is inserted in the wh_scope_dimension to match the
+  -- current values in the operational tables and the new id is returned. If
+  -- the values do not differ, the current id is returned.
+  create or replace function wh_upsert_scope(p_scope_id wt_scope_id)
+    returns wh_dim_id
+  as $$
+  declare
+    src     whx_scope_dimension_target%rowtype;
+    target  whx_scope_dimension_target%rowtype;
+    new_row wh_scope_dimension%rowtype;
+  begin
+    select * into target
+      from whx_scope_dimension_target as t
+     where t.scope_id = p_scope_id;
+
+    select target.id, t.* into src
+      from whx_scope_dimension_source as t
+     where t.scope_id = p_scope_id;
+
+    if src is distinct from target then
+
+      -- expire the current row
+      update wh_scope_dimension
+         set current_row_indicator = 'Expired',
+             row_expiration_time   = current_timestamp
+       where scope_id              = p_scope_id
+         and current_row_indicator = 'Current';
+
+      -- insert a new row
+      insert into wh_scope_dimension (
+             scope_id,              scope_type,         scope_name,        scope_description,
+             parent_scope_id,       parent_scope_type,  parent_scope_name, parent_scope_description,
+             current_row_indicator, row_effective_time, row_expiration_time
+      )
+      select scope_id,              scope_type,         scope_name,        scope_description,
+             parent_scope_id,       parent_scope_type,  parent_scope_name, parent_scope_description,
+             'Current',             current_timestamp,  'infinity'::timestamptz
+        from whx_scope_dimension_source
+       where scope_id = p_scope_id
+      returning * into new_row;
+
+      return new_row.id;
+    end if;
+    return target.id;
+
+  end;
+  $$ language plpgsql;
+
+  --
+  -- Backfill
+  --
+
+  -- The target and project of the existing sessions are only known from the
+  -- wh_host_dimension, since their targets and scopes may have been deleted
+  -- or changed since. Each distinct version of a target or project in the
+  -- wh_host_dimension becomes a row of the new dimension, effective from the
+  -- first time it was seen until the next version of the same target or
+  -- project was seen. The last version is marked 'Current' so that
+  -- wh_upsert_target and wh_upsert_scope compare the operational tables
+  -- with it.
+  with
+  target_version as (
+    select target_id,                  target_type,                target_name,                     target_description,
+           target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+           project_id,
+           min(row_effective_time) as row_effective_time
+      from wh_host_dimension
+     group by target_id,                  target_type,                target_name,                     target_description,
+              target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+              project_id
+  ),
+  target_history as (
+    select *,
+           lead(row_effective_time) over (partition by target_id order by row_effective_time) as next_effective_time
+      from target_version
+  )
+  insert into wh_target_dimension (
+         target_id,                  target_type,                target_name,                     target_description,
+         target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+         project_id,
+         current_row_indicator,      row_effective_time,         row_expiration_time
+  )
+  select target_id,                  target_type,                target_name,                     target_description,
+         target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+         project_id,
+         case when next_effective_time is null then 'Current' else 'Expired' end,
+         row_effective_time,
+         coalesce(next_effective_time, 'infinity'::timestamptz)
+    from target_history;
+
+  with
+  project_version as (
+    select project_id,           project_name,           project_description,
+           host_organization_id, host_organization_name, host_organization_description,
+           min(row_effective_time) as row_effective_time
+      from wh_host_dimension
+     group by project_id,           project_name,           project_description,
+              host_organization_id, host_organization_name, host_organization_description
+  ),
+  project_history as (
+    select *,
+           lead(row_effective_time) over (partition by project_id order by row_effective_time) as next_effective_time
+      from project_version
+  )
+  insert into wh_scope_dimension (
+         scope_id,              scope_type,         scope_name,        scope_description,
+         parent_scope_id,       parent_scope_type,  parent_scope_name, parent_scope_description,
+         current_row_indicator, row_effective_time, row_expiration_time
+  )
+  select project_id,           'project',              project_name,           project_description,
+         host_organization_id, 'org',                  host_organization_name, host_organization_description,
+         case when next_effective_time is null then 'Current' else 'Expired' end,
+         row_effective_time,
+         coalesce(next_effective_time, 'infinity'::timestamptz)
+    from project_history;
+
+  -- target_id and project_id are foreign keys to the new dimension tables.
+  alter table wh_session_accumulating_fact
+    add column target_id wh_dim_id
+      references wh_target_dimension (id)
+      on delete restrict
+      on update cascade,
+    add column project_id wh_dim_id
+      references wh_scope_dimension (id)
+      on delete restrict
+      on update cascade;
+
+  alter table wh_session_connection_accumulating_fact
+    add column target_id wh_dim_id
+      references wh_target_dimension (id)
+      on delete restrict
+      on update cascade,
+    add column project_id wh_dim_id
+      references wh_scope_dimension (id)
+      on delete restrict
+      on update cascade;
+
+  update wh_session_accumulating_fact as f
+     set target_id  = t.id,
+         project_id = s.id
+    from wh_host_dimension as h,
+         wh_target_dimension as t,
+         wh_scope_dimension as s
+   where h.id = f.host_id
+     and (t.target_id,                  t.target_type,                t.target_name,                     t.target_description,
+          t.target_default_port_number, t.target_session_max_seconds, t.target_session_connection_limit,
+          t.project_id)
+       = (h.target_id,                  h.target_type,                h.target_name,                     h.target_description,
+          h.target_default_port_number, h.target_session_max_seconds, h.target_session_connection_limit,
+          h.project_id)
+     and (s.scope_id,        s.scope_name,             s.scope_description,
+          s.parent_scope_id, s.parent_scope_name,      s.parent_scope_description)
+       = (h.project_id,      h.project_name,           h.project_description,
+          h.host_organization_id, h.host_organization_name, h.host_organization_description);
+
+  update wh_session_connection_accumulating_fact as c
+     set target_id  = f.target_id,
+         project_id = f.project_id
+    from wh_session_accumulating_fact as f
+   where f.session_id = c.session_id;
+
+  alter table wh_session_accumulating_fact
+    alter column target_id set not null,
+    alter column project_id set not null;
+
+  alter table wh_session_connection_accumulating_fact
+    alter column target_id set not null,
+    alter column project_id set not null;
+
+  --
+  -- Session triggers
+  --
+
+  -- wh_insert_session is replaced to also set the target and project of the
+  -- new session, which can result in new rows in wh_target_dimension and
+  -- wh_scope_dimension respectively.
+  create or replace function wh_insert_session()
+    returns trigger
+  as $$
+  declare
+    new_row wh_session_accumulating_fact%rowtype;
+  begin
+    with
+    pending_timestamp (date_dim_id, time_dim_id, ts) as (
+      select wh_date_id(start_time), wh_time_id(start_time), start_time
+        from session_state
+       where session_id = new.public_id
+         and state = 'pending'
+    )
+    insert into wh_session_accumulating_fact (
+           session_id,
+           auth_token_id,
+           host_id,
+           user_id,
+           target_id,
+           project_id,
+           session_pending_date_id,
+           session_pending_time_id,
+           session_pending_time
+    )
+    select new.public_id,
+           new.auth_token_id,
+           wh_upsert_host(new.host_id, new.host_set_id, new.target_id),
+           wh_upsert_user(new.user_id, new.auth_token_id),
+           wh_upsert_target(new.target_id),
+           wh_upsert_scope(new.scope_id),
+           pending_timestamp.date_dim_id,
+           pending_timestamp.time_dim_id,
+           pending_timestamp.ts
+      from pending_timestamp
+      returning * into strict new_row;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  -- wh_insert_session_connection is replaced to also copy the target and
+  -- project of the session to the new session connection.
+  create or replace function wh_insert_session_connection()
+    returns trigger
+  as $$
+  declare
+    new_row wh_session_connection_accumulating_fact%rowtype;
+  begin
+    with
+    authorized_timestamp (date_dim_id, time_dim_id, ts) as (
+      select wh_date_id(start_time), wh_time_id(start_time), start_time
+        from session_connection_state
+       where connection_id = new.public_id
+         and state = 'authorized'
+    ),
+    session_dimension (host_dim_id, user_dim_id, target_dim_id, project_dim_id) as (
+      select host_id, user_id, target_id, project_id
+        from wh_session_accumulating_fact
+       where session_id = new.session_id
+    )
+    insert into wh_session_connection_accumulating_fact (
+           connection_id,
+           session_id,
+           host_id,
+           user_id,
+           target_id,
+           project_id,
+           connection_authorized_date_id,
+           connection_authorized_time_id,
+           connection_authorized_time,
+           client_tcp_address,
+           client_tcp_port_number,
+           endpoint_tcp_address,
+           endpoint_tcp_port_number,
+           bytes_up,
+           bytes_down
+    )
+    select new.public_id,
+           new.session_id,
+           session_dimension.host_dim_id,
+           session_dimension.user_dim_id,
+           session_dimension.target_dim_id,
+           session_dimension.project_dim_id,
+           authorized_timestamp.date_dim_id,
+           authorized_timestamp.time_dim_id,
+           authorized_timestamp.ts,
+           new.client_tcp_address,
+           new.client_tcp_port,
+           new.endpoint_tcp_address,
+           new.endpoint_tcp_port,
+           new.bytes_up,
+           new.bytes_down
+      from authorized_timestamp,
+           session_dimension
+      returning * into strict new_row;
+    perform wh_rollup_connections(new.session_id);
+    return null;
+  end;
+  $$ language plpgsql;
+
+commit;
+
+`),
+	},
+	"migrations/83_wh_export.down.sql": {
+		name: "83_wh_export.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop trigger wh_row_transaction_id on wh_session_connection_accumulating_fact;
+  alter table wh_session_connection_accumulating_fact
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_session_accumulating_fact;
+  alter table wh_session_accumulating_fact
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_scope_dimension;
+  alter table wh_scope_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_target_dimension;
+  alter table wh_target_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_user_dimension;
+  alter table wh_user_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_host_dimension;
+  alter table wh_host_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_time_of_day_dimension;
+  alter table wh_time_of_day_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_date_dimension;
+  alter table wh_date_dimension
+    drop column row_transaction_id;
+
+  drop function wh_row_transaction_id;
+
+commit;
+
+`),
+	},
+	"migrations/83_wh_export.up.sql": {
+		name: "83_wh_export.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- wh_row_transaction_id returns a before insert or update trigger which
+  -- sets the row_transaction_id column of the row to the ID of the current
+  -- transaction. Rows with a row_transaction_id below the xmin of a snapshot
+  -- were written by transactions which are finished, so the xmin can be used as
+  -- a high-water mark to incrementally export the rows which were inserted or
+  -- updated since a previous export.
+  create function wh_row_transaction_id()
+    returns trigger
+  as $$
+  begin
+    new.row_transaction_id = txid_current();
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- The existing rows are exported by the first export.
+
+  alter table wh_date_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_date_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_date_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_time_of_day_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_time_of_day_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_time_of_day_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_host_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_host_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_host_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_user_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_user_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_user_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_target_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_target_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_target_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_scope_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_scope_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_scope_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_session_accumulating_fact
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_session_accumulating_fact (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_session_accumulating_fact
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_session_connection_accumulating_fact
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_session_connection_accumulating_fact (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_session_connection_accumulating_fact
+    for each row
+    execute function wh_row_transaction_id();
+
+commit;
+
+`),
+	},
+	"migrations/84_watch_listener.down.sql": {
+		name: "84_watch_listener.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table watch_listener;
+
+commit;
+
+`),
+	},
+	"migrations/84_watch_listener.up.sql": {
+		name: "84_watch_listener.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- watch_listener records the controllers serving watch streams. Resource
+  -- changes are only published on the boundary_watch channel by the oplog
+  -- write path while there is a listener which hasn't expired. Controllers
+  -- refresh the expiration of their listener while they have streams and
+  -- delete it when they have none.
+  create table watch_listener (
+    server_id text primary key
+      constraint server_id_must_not_be_empty
+      check(length(trim(server_id)) > 0),
+    create_time wt_timestamp,
+    expiration_time timestamp with time zone not null
+  );
+
+commit;
+
+`),
+	},
+	"migrations/85_oplog_archive.down.sql": {
+		name: "85_oplog_archive.down.sql",
+		bytes: []byte(`
+begin;
+
+  drop table oplog_archive_key_version;
+  drop table oplog_archive;
+
+commit;
+
+`),
+	},
+	"migrations/85_oplog_archive.up.sql": {
+		name: "85_oplog_archive.up.sql",
+		bytes: []byte(`
+begin;
+
+  -- oplog_archive records the archives written by the oplog retention policy,
+  -- and the controller whose archive_dir each of them was written to. An
+  -- archive is deleted from the table once its files are no longer kept.
+  create table oplog_archive (
+    name text primary key
+      constraint name_must_not_be_empty
+      check(length(trim(name)) > 0),
+    create_time wt_timestamp,
+    controller_name text not null
+      constraint controller_name_must_not_be_empty
+      check(length(trim(controller_name)) > 0)
+  );
+
+  create trigger
+    default_create_time_column
+  before
+  insert on oplog_archive
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_archive
+    for each row execute procedure immutable_columns('name', 'create_time', 'controller_name');
+
+  -- oplog_archive_key_version records the number of entries of each archive
+  -- encrypted with each oplog key version. Key versions are not destroyed
+  -- while archived entries depend on them. key_id is not a foreign key since
+  -- the key versions of deleted scopes are deleted with them.
+  create table oplog_archive_key_version (
+    archive_name text not null
+      references oplog_archive (name)
+      on delete cascade
+      on update cascade,
+    key_id text not null,
+    entry_count bigint not null default 0,
+    primary key (archive_name, key_id)
+  );
+
+  create index oplog_archive_key_version_key_id_ix
+    on oplog_archive_key_version (key_id);
+
+commit;
+
 `),
 	},
 }
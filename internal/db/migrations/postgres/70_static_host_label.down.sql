@@ -0,0 +1,13 @@
+begin;
+
+  alter table static_host_set
+    drop column selector;
+
+  drop table static_host_label cascade;
+  drop function insert_static_host_label;
+
+  delete
+    from oplog_ticket
+   where name = 'static_host_label';
+
+commit;
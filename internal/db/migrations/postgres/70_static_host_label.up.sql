@@ -0,0 +1,66 @@
+begin;
+
+/*
+
+  ┌─────────────────────┐          ┌─────────────────────┐
+  │     static_host     │          │  static_host_label  │
+  ├─────────────────────┤          ├─────────────────────┤
+  │ public_id  (pk)     │┼┼──────○<│ host_id    (pk,fk)  │
+  │ catalog_id          │          │ key        (pk)     │
+  │                     │          │ value               │
+  │                     │          │ catalog_id (fk)     │
+  └─────────────────────┘          └─────────────────────┘
+
+  Labels are free-form key/value pairs attached to a static host. A static
+  host set with a selector contains every host in its catalog with labels
+  matching the selector, in addition to the hosts in static_host_set_member.
+
+*/
+
+  create table static_host_label (
+    host_id wt_public_id not null,
+    catalog_id wt_public_id not null,
+    key text not null
+      constraint key_must_be_valid
+      check(key ~ '^[A-Za-z0-9_./-]{1,63}$'),
+    value text not null
+      constraint value_must_be_valid
+      check(value ~ '^[A-Za-z0-9_./-]{0,255}$'),
+    primary key(host_id, key),
+    foreign key (catalog_id, host_id)
+      references static_host (catalog_id, public_id)
+      on delete cascade
+      on update cascade
+  );
+
+  create index static_host_label_catalog_id_key_value_idx
+    on static_host_label (catalog_id, key, value);
+
+  create trigger immutable_columns before update on static_host_label
+    for each row execute procedure immutable_columns('host_id', 'catalog_id', 'key');
+
+  create or replace function insert_static_host_label()
+    returns trigger
+  as $$
+  begin
+    select static_host.catalog_id
+      into new.catalog_id
+    from static_host
+    where static_host.public_id = new.host_id;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger insert_static_host_label before insert on static_host_label
+    for each row execute procedure insert_static_host_label();
+
+  alter table static_host_set
+    add column selector text
+      constraint selector_must_not_be_empty
+      check(length(trim(selector)) > 0);
+
+  insert into oplog_ticket (name, version)
+  values
+    ('static_host_label', 1);
+
+commit;
@@ -0,0 +1,22 @@
+begin;
+
+-- server_tag contains the tags reported by a server. Workers report the
+-- networks they can reach directly with the 'network' key, which the
+-- controller uses to find a path of workers to a session's endpoint.
+create table server_tag (
+    server_id text,
+    server_type text,
+    key text not null
+      constraint server_tag_key_must_not_be_empty
+      check(length(trim(key)) > 0),
+    value text not null
+      constraint server_tag_value_must_not_be_empty
+      check(length(trim(value)) > 0),
+    primary key (server_id, server_type, key, value),
+    foreign key (server_id, server_type)
+      references server (private_id, type)
+      on delete cascade
+      on update cascade
+  );
+
+commit;
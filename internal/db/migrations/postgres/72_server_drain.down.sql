@@ -0,0 +1,7 @@
+begin;
+
+  alter table server
+    drop column draining,
+    drop column drain_requested;
+
+commit;
@@ -0,0 +1,10 @@
+begin;
+
+-- draining is reported by a worker in its status once it has begun to drain.
+-- Draining workers are not handed out for new sessions. drain_requested is set
+-- through the API and cleared once the worker reports that it is draining.
+alter table server
+  add column draining boolean not null default false,
+  add column drain_requested boolean not null default false;
+
+commit;
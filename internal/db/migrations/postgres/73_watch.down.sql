@@ -0,0 +1,8 @@
+begin;
+
+drop trigger watch_notify_session_connection_state on session_connection_state;
+drop function watch_notify_session_connection_state;
+drop trigger watch_notify_session_state on session_state;
+drop function watch_notify_session_state;
+
+commit;
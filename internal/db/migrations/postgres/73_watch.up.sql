@@ -0,0 +1,61 @@
+begin;
+
+-- Session and connection state transitions are published on the
+-- boundary_watch channel for the watch API, along with the resource changes
+-- published by the oplog write path. Notifications are delivered when the
+-- inserting transaction commits.
+create function
+  watch_notify_session_state()
+  returns trigger
+as $$
+begin
+  perform pg_notify('boundary_watch', json_build_object(
+    'type', 'session',
+    'op', case when new.previous_end_time is null then 'create' else 'update' end,
+    'id', new.session_id,
+    'scope_id', s.scope_id,
+    'user_id', s.user_id,
+    'target_id', s.target_id,
+    'state', new.state,
+    'time', new.start_time
+  )::text)
+  from session s
+  where s.public_id = new.session_id;
+  return null;
+end;
+$$ language plpgsql;
+
+create trigger
+  watch_notify_session_state
+after insert on session_state
+  for each row execute procedure watch_notify_session_state();
+
+create function
+  watch_notify_session_connection_state()
+  returns trigger
+as $$
+begin
+  perform pg_notify('boundary_watch', json_build_object(
+    'type', 'connection',
+    'op', case when new.previous_end_time is null then 'create' else 'update' end,
+    'id', new.connection_id,
+    'session_id', c.session_id,
+    'scope_id', s.scope_id,
+    'user_id', s.user_id,
+    'target_id', s.target_id,
+    'state', new.state,
+    'time', new.start_time
+  )::text)
+  from session_connection c
+    join session s on s.public_id = c.session_id
+  where c.public_id = new.connection_id;
+  return null;
+end;
+$$ language plpgsql;
+
+create trigger
+  watch_notify_session_connection_state
+after insert on session_connection_state
+  for each row execute procedure watch_notify_session_connection_state();
+
+commit;
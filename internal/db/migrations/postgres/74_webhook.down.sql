@@ -0,0 +1,16 @@
+begin;
+
+  drop trigger webhook_session_connection_state on session_connection_state;
+  drop trigger webhook_session_state on session_state;
+  drop function webhook_session_connection_state;
+  drop function webhook_session_state;
+  drop function insert_webhook_deliveries;
+  drop table webhook_delivery;
+  drop table webhook_delivery_state_enm;
+  drop table webhook_event_type;
+  drop table webhook;
+  drop table webhook_event_type_enm;
+
+  delete from oplog_ticket where name in ('webhook', 'webhook_event_type');
+
+commit;
@@ -0,0 +1,306 @@
+begin;
+
+  create table webhook_event_type_enm (
+    name text primary key
+      constraint only_predefined_webhook_event_types_allowed
+      check (
+        name in (
+          'session.authorized',
+          'session.activated',
+          'session.canceled',
+          'session.terminated',
+          'connection.opened',
+          'connection.closed'
+        )
+      )
+  );
+
+  insert into webhook_event_type_enm (name)
+  values
+    ('session.authorized'),
+    ('session.activated'),
+    ('session.canceled'),
+    ('session.terminated'),
+    ('connection.opened'),
+    ('connection.closed');
+
+  -- webhook is a subscription to the session lifecycle events of a scope.
+  -- Events for sessions in a project are delivered to the webhooks of the
+  -- project, its org and the global scope.
+  create table webhook (
+    public_id wt_public_id
+      primary key,
+    scope_id wt_scope_id
+      not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    name text,
+    description text,
+    url text not null
+      constraint url_must_be_http_or_https
+      check(url ~ '^https?://'),
+    -- the secret used to sign deliveries, encrypted with the database key
+    -- of the scope
+    secret bytea not null,
+    -- TODO: Make key_id a foreign key once we have DEKs
+    key_id text not null
+      constraint key_id_must_not_be_empty
+      check(length(trim(key_id)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    version wt_version,
+    unique(name, scope_id)
+  );
+
+  create trigger
+    update_version_column
+  after update on webhook
+    for each row execute procedure update_version_column();
+
+  create trigger
+    update_time_column
+  before update on webhook
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on webhook
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on webhook
+    for each row execute procedure immutable_columns('public_id', 'scope_id', 'create_time');
+
+  create table webhook_event_type (
+    webhook_id wt_public_id
+      references webhook (public_id)
+      on delete cascade
+      on update cascade,
+    event_type text
+      references webhook_event_type_enm (name)
+      on delete restrict
+      on update cascade,
+    create_time wt_timestamp,
+    primary key (webhook_id, event_type)
+  );
+
+  create trigger
+    default_create_time_column
+  before
+  insert on webhook_event_type
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on webhook_event_type
+    for each row execute procedure immutable_columns('webhook_id', 'event_type', 'create_time');
+
+  create table webhook_delivery_state_enm (
+    name text primary key
+      constraint only_predefined_webhook_delivery_states_allowed
+      check (
+        name in ('pending', 'succeeded', 'failed')
+      )
+  );
+
+  insert into webhook_delivery_state_enm (name)
+  values
+    ('pending'),
+    ('succeeded'),
+    ('failed');
+
+  -- webhook_delivery is the outbox of events to deliver to webhooks. Rows are
+  -- inserted in the transaction which changes the state of a session or
+  -- connection and are updated by the controllers as they are delivered.
+  create table webhook_delivery (
+    public_id wt_public_id
+      primary key
+      default 'whd_' || encode(gen_random_bytes(5), 'hex'),
+    webhook_id wt_public_id not null
+      references webhook (public_id)
+      on delete cascade
+      on update cascade,
+    event_type text not null
+      references webhook_event_type_enm (name)
+      on delete restrict
+      on update cascade,
+    -- the json body sent to the webhook
+    payload text not null,
+    state text not null
+      default 'pending'
+      references webhook_delivery_state_enm (name)
+      on delete restrict
+      on update cascade,
+    attempts integer not null default 0
+      constraint attempts_must_not_be_negative
+      check(attempts >= 0),
+    -- the earliest time of the next attempt; pending deliveries being
+    -- attempted by a controller are leased by moving it into the future
+    next_attempt_time wt_timestamp,
+    last_attempt_time timestamp with time zone,
+    last_status_code integer,
+    last_error text,
+    create_time wt_timestamp,
+    update_time wt_timestamp
+  );
+
+  create trigger
+    update_time_column
+  before update on webhook_delivery
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on webhook_delivery
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on webhook_delivery
+    for each row execute procedure immutable_columns('public_id', 'webhook_id', 'event_type', 'payload', 'create_time');
+
+  create index webhook_delivery_pending_ix
+    on webhook_delivery (next_attempt_time)
+    where state = 'pending';
+
+  create index webhook_delivery_webhook_id_ix
+    on webhook_delivery (webhook_id, create_time);
+
+  -- insert_webhook_deliveries queues an event for each webhook subscribed to
+  -- it in the project of the session, its org or the global scope.
+  create function
+    insert_webhook_deliveries(_event_type text, _scope_id text, _payload text)
+    returns void
+  as $$
+  begin
+    insert into webhook_delivery
+      (webhook_id, event_type, payload)
+    select w.public_id, _event_type, _payload
+      from webhook w
+      join webhook_event_type e on e.webhook_id = w.public_id
+     where e.event_type = _event_type
+       and w.scope_id in (
+         select s.public_id
+           from iam_scope s
+          where s.public_id = _scope_id
+          union
+         select s.parent_id
+           from iam_scope s
+          where s.public_id = _scope_id
+            and s.parent_id is not null
+          union
+         select 'global'
+       );
+  end;
+  $$ language plpgsql;
+
+  create function
+    webhook_session_state()
+    returns trigger
+  as $$
+  declare
+    _event_type text;
+  begin
+    _event_type = case new.state
+      when 'pending' then 'session.authorized'
+      when 'active' then 'session.activated'
+      when 'canceling' then 'session.canceled'
+      when 'terminated' then 'session.terminated'
+    end;
+    if _event_type is null then
+      return null;
+    end if;
+    perform insert_webhook_deliveries(_event_type, s.scope_id, json_build_object(
+        'event_type', _event_type,
+        'time', new.start_time,
+        'session', json_build_object(
+          'id', s.public_id,
+          'scope_id', s.scope_id,
+          'user_id', s.user_id,
+          'target_id', s.target_id,
+          'host_set_id', s.host_set_id,
+          'host_id', s.host_id,
+          'auth_token_id', s.auth_token_id,
+          'endpoint', s.endpoint,
+          'expiration_time', s.expiration_time,
+          'termination_reason', s.termination_reason,
+          'state', new.state
+        )
+      )::text)
+      from session s
+     where s.public_id = new.session_id;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    webhook_session_state
+  after insert on session_state
+    for each row execute procedure webhook_session_state();
+
+  create function
+    webhook_session_connection_state()
+    returns trigger
+  as $$
+  declare
+    _event_type text;
+  begin
+    _event_type = case new.state
+      when 'connected' then 'connection.opened'
+      when 'closed' then 'connection.closed'
+    end;
+    if _event_type is null then
+      return null;
+    end if;
+    perform insert_webhook_deliveries(_event_type, s.scope_id, json_build_object(
+        'event_type', _event_type,
+        'time', new.start_time,
+        'session', json_build_object(
+          'id', s.public_id,
+          'scope_id', s.scope_id,
+          'user_id', s.user_id,
+          'target_id', s.target_id,
+          'host_set_id', s.host_set_id,
+          'host_id', s.host_id,
+          'auth_token_id', s.auth_token_id,
+          'endpoint', s.endpoint,
+          'expiration_time', s.expiration_time
+        ),
+        'connection', json_build_object(
+          'id', c.public_id,
+          'client_tcp_address', host(c.client_tcp_address),
+          'client_tcp_port', c.client_tcp_port,
+          'endpoint_tcp_address', host(c.endpoint_tcp_address),
+          'endpoint_tcp_port', c.endpoint_tcp_port,
+          'bytes_up', c.bytes_up,
+          'bytes_down', c.bytes_down,
+          'closed_reason', c.closed_reason,
+          'state', new.state
+        )
+      )::text)
+      from session_connection c
+      join session s on s.public_id = c.session_id
+     where c.public_id = new.connection_id;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    webhook_session_connection_state
+  after insert on session_connection_state
+    for each row execute procedure webhook_session_connection_state();
+
+  insert into oplog_ticket
+    (name, version)
+  values
+    ('webhook', 1),
+    ('webhook_event_type', 1);
+
+commit;
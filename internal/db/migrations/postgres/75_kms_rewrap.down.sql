@@ -0,0 +1,38 @@
+begin;
+
+  drop table kms_rewrap_job;
+  drop table kms_rewrap_job_state_enm;
+
+  drop trigger immutable_unless_rewrapped on auth_token;
+
+  create or replace function
+    immutable_auth_token_columns()
+    returns trigger
+  as $$
+  begin
+    if new.auth_account_id is distinct from old.auth_account_id then
+      raise exception 'auth_account_id is read-only';
+    end if;
+    if new.token is distinct from old.token then
+      raise exception 'token is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  drop trigger immutable_unless_rewrapped on oplog_entry;
+  drop trigger immutable_columns on oplog_entry;
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'data');
+
+  drop index oplog_entry_key_id_ix;
+  alter table oplog_entry
+    drop column key_id;
+
+  drop function immutable_unless_rewrapped;
+
+commit;
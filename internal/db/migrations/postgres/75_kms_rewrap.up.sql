@@ -0,0 +1,130 @@
+begin;
+
+  -- immutable_unless_rewrapped() makes the ciphertext column passed as a
+  -- parameter when the trigger is created immutable, except when the key_id
+  -- column of the row changes along with it: ciphertext can only be replaced
+  -- by the same value encrypted with another version of the scope's key.
+  create or replace function
+    immutable_unless_rewrapped()
+    returns trigger
+  as $$
+  declare
+    col_name text;
+    new_value text;
+    old_value text;
+  begin
+    if new.key_id is not distinct from old.key_id then
+      foreach col_name in array tg_argv loop
+        execute format('SELECT $1.%I', col_name) into new_value using new;
+        execute format('SELECT $1.%I', col_name) into old_value using old;
+        if new_value is distinct from old_value then
+          raise exception 'immutable column: %.%', tg_table_name, col_name using
+            errcode = '23601',
+            schema = tg_table_schema,
+            table = tg_table_name,
+            column = col_name;
+        end if;
+      end loop;
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  comment on function
+    immutable_unless_rewrapped()
+  is
+    'function used in before update triggers to make ciphertext columns immutable unless the key_id column changes';
+
+  -- key_id is the key version which encrypted the data of the entry. It is
+  -- null for entries written before it was recorded, until the rewrap job
+  -- reads it from their data.
+  alter table oplog_entry
+    add column key_id text;
+
+  create index oplog_entry_key_id_ix
+    on oplog_entry (key_id);
+
+  drop trigger immutable_columns on oplog_entry;
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name');
+
+  create trigger
+    immutable_unless_rewrapped
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_unless_rewrapped('data');
+
+  create or replace function
+    immutable_auth_token_columns()
+    returns trigger
+  as $$
+  begin
+    if new.auth_account_id is distinct from old.auth_account_id then
+      raise exception 'auth_account_id is read-only';
+    end if;
+    return new;
+  end;
+  $$ language plpgsql;
+
+  create trigger
+    immutable_unless_rewrapped
+  before
+  update on auth_token
+    for each row execute procedure immutable_unless_rewrapped('token');
+
+  create table kms_rewrap_job_state_enm (
+    name text primary key
+      constraint only_predefined_rewrap_job_states_allowed
+      check (
+        name in ('pending', 'running', 'completed', 'failed')
+      )
+  );
+
+  insert into kms_rewrap_job_state_enm (name)
+  values
+    ('pending'),
+    ('running'),
+    ('completed'),
+    ('failed');
+
+  -- kms_rewrap_job is the job re-encrypting the data of a scope with the
+  -- newest versions of its keys, created when the keys of the scope are
+  -- rotated. Only the latest job of a scope is kept.
+  create table kms_rewrap_job (
+    scope_id wt_scope_id
+      primary key
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    state text not null
+      references kms_rewrap_job_state_enm (name)
+      on delete restrict
+      on update cascade,
+    -- the number of rows re-encrypted so far
+    rewrapped_count bigint not null default 0
+      constraint rewrapped_count_must_not_be_negative
+      check(rewrapped_count >= 0),
+    -- the reason the job failed
+    error text,
+    -- the time the keys were last rotated, which started the job
+    rotate_time wt_timestamp,
+    update_time wt_timestamp,
+    end_time timestamp with time zone
+  );
+
+  create trigger
+    update_time_column
+  before update on kms_rewrap_job
+    for each row execute procedure update_time_column();
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_rewrap_job
+    for each row execute procedure immutable_columns('scope_id');
+
+commit;
@@ -0,0 +1,5 @@
+begin;
+
+  drop table kms_destroyed_key_version;
+
+commit;
@@ -0,0 +1,31 @@
+begin;
+
+  -- kms_destroyed_key_version records the versions of the DEKs of scopes
+  -- which were destroyed. Controllers read it to drop the destroyed versions
+  -- from the wrappers they have cached.
+  create table kms_destroyed_key_version (
+    private_id wt_private_id
+      primary key,
+    scope_id wt_scope_id not null
+      references iam_scope (public_id)
+      on delete cascade
+      on update cascade,
+    purpose text not null
+      constraint only_predefined_key_purposes_allowed
+      check (
+        purpose in ('database', 'oplog', 'tokens', 'sessions')
+      ),
+    version wt_version,
+    destroy_time wt_timestamp
+  );
+
+  create index kms_destroyed_key_version_destroy_time_ix
+    on kms_destroyed_key_version (destroy_time);
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_destroyed_key_version
+    for each row execute procedure immutable_columns('private_id', 'scope_id', 'purpose', 'version', 'destroy_time');
+
+commit;
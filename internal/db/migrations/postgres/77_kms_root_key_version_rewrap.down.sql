@@ -0,0 +1,15 @@
+begin;
+
+  drop trigger immutable_unless_rewrapped on kms_root_key_version;
+  drop trigger immutable_columns on kms_root_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_root_key_version
+    for each row execute procedure immutable_columns('private_id', 'root_key_id', 'version', 'key', 'create_time');
+
+  alter table kms_root_key_version
+    drop column key_id;
+
+commit;
@@ -0,0 +1,24 @@
+begin;
+
+  -- key_id is the id of the key of the root KMS which encrypted the key of the
+  -- version. It is null for versions created before it was recorded.
+  alter table kms_root_key_version
+    add column key_id text;
+
+  drop trigger immutable_columns on kms_root_key_version;
+
+  create trigger
+    immutable_columns
+  before
+  update on kms_root_key_version
+    for each row execute procedure immutable_columns('private_id', 'root_key_id', 'version', 'create_time');
+
+  -- the key can only be replaced when it is re-encrypted with another root
+  -- KMS, which changes key_id
+  create trigger
+    immutable_unless_rewrapped
+  before
+  update on kms_root_key_version
+    for each row execute procedure immutable_unless_rewrapped('key');
+
+commit;
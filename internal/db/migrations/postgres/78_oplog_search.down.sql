@@ -0,0 +1,7 @@
+begin;
+
+  drop index oplog_entry_create_time_ix;
+  drop index oplog_metadata_key_value_ix;
+  drop index oplog_metadata_entry_id_ix;
+
+commit;
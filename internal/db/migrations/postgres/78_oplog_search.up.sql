@@ -0,0 +1,13 @@
+begin;
+
+  -- indexes used to search oplog entries by their metadata and time
+  create index oplog_metadata_entry_id_ix
+    on oplog_metadata (entry_id);
+
+  create index oplog_metadata_key_value_ix
+    on oplog_metadata (key, value);
+
+  create index oplog_entry_create_time_ix
+    on oplog_entry (create_time);
+
+commit;
@@ -0,0 +1,19 @@
+begin;
+
+  drop trigger immutable_columns on oplog_entry;
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name');
+
+  drop index oplog_entry_chain_ix;
+  drop index oplog_entry_prev_hash_uq;
+
+  alter table oplog_entry
+    drop constraint prev_hash_requires_hash,
+    drop column prev_hash,
+    drop column hash;
+
+commit;
@@ -0,0 +1,33 @@
+begin;
+
+  -- hash chains each entry to the previous entry of its aggregate, so that
+  -- entries edited, deleted or inserted directly in the database can be
+  -- detected. Entries written before are not chained.
+  alter table oplog_entry
+    add column hash bytea
+      constraint hash_must_be_sha256
+      check(hash is null or length(hash) = 32),
+    add column prev_hash bytea
+      constraint prev_hash_must_be_sha256
+      check(prev_hash is null or length(prev_hash) = 32),
+    add constraint prev_hash_requires_hash
+      check(prev_hash is null or hash is not null);
+
+  -- an entry can only be followed by one entry of its aggregate
+  create unique index oplog_entry_prev_hash_uq
+    on oplog_entry (aggregate_name, prev_hash);
+
+  -- used to find the last chained entry of an aggregate
+  create index oplog_entry_chain_ix
+    on oplog_entry (aggregate_name, id)
+    where hash is not null;
+
+  drop trigger immutable_columns on oplog_entry;
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_entry
+    for each row execute procedure immutable_columns('id', 'update_time', 'create_time', 'version', 'aggregate_name', 'hash', 'prev_hash');
+
+commit;
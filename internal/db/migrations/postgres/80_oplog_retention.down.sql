@@ -0,0 +1,5 @@
+begin;
+
+  drop table oplog_chain_anchor;
+
+commit;
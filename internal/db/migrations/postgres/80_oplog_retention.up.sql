@@ -0,0 +1,35 @@
+begin;
+
+  -- oplog_chain_anchor records, for each aggregate whose oldest entries were
+  -- archived and deleted by the oplog retention policy, the last hash chained
+  -- entry deleted. The first remaining entry of the aggregate is chained to
+  -- it.
+  create table oplog_chain_anchor (
+    aggregate_name text primary key,
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    entry_id bigint not null,
+    hash bytea not null
+      constraint hash_must_be_sha256
+      check(length(hash) = 32)
+  );
+
+  create trigger
+    update_time_column
+  before
+  update on oplog_chain_anchor
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on oplog_chain_anchor
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_chain_anchor
+    for each row execute procedure immutable_columns('aggregate_name', 'create_time');
+
+commit;
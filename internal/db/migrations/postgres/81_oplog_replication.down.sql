@@ -0,0 +1,5 @@
+begin;
+
+  drop table oplog_replication_checkpoint;
+
+commit;
@@ -0,0 +1,36 @@
+begin;
+
+  -- oplog_replication_checkpoint records, in a standby database, the last
+  -- oplog entry of the primary database replayed into it by each named
+  -- replication. It is updated in the same transaction as the replayed
+  -- changes.
+  create table oplog_replication_checkpoint (
+    name text primary key
+      constraint name_must_not_be_empty
+      check(length(trim(name)) > 0),
+    create_time wt_timestamp,
+    update_time wt_timestamp,
+    entry_id bigint not null default 0,
+    entry_create_time timestamp with time zone,
+    replayed_count bigint not null default 0
+  );
+
+  create trigger
+    update_time_column
+  before
+  update on oplog_replication_checkpoint
+    for each row execute procedure update_time_column();
+
+  create trigger
+    default_create_time_column
+  before
+  insert on oplog_replication_checkpoint
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_replication_checkpoint
+    for each row execute procedure immutable_columns('name', 'create_time');
+
+commit;
@@ -0,0 +1,112 @@
+begin;
+
+  -- Restore the session triggers of 69_wh_session_facts.
+  create or replace function wh_insert_session_connection()
+    returns trigger
+  as $$
+  declare
+    new_row wh_session_connection_accumulating_fact%rowtype;
+  begin
+    with
+    authorized_timestamp (date_dim_id, time_dim_id, ts) as (
+      select wh_date_id(start_time), wh_time_id(start_time), start_time
+        from session_connection_state
+       where connection_id = new.public_id
+         and state = 'authorized'
+    ),
+    session_dimension (host_dim_id, user_dim_id) as (
+      select host_id, user_id
+        from wh_session_accumulating_fact
+       where session_id = new.session_id
+    )
+    insert into wh_session_connection_accumulating_fact (
+           connection_id,
+           session_id,
+           host_id,
+           user_id,
+           connection_authorized_date_id,
+           connection_authorized_time_id,
+           connection_authorized_time,
+           client_tcp_address,
+           client_tcp_port_number,
+           endpoint_tcp_address,
+           endpoint_tcp_port_number,
+           bytes_up,
+           bytes_down
+    )
+    select new.public_id,
+           new.session_id,
+           session_dimension.host_dim_id,
+           session_dimension.user_dim_id,
+           authorized_timestamp.date_dim_id,
+           authorized_timestamp.time_dim_id,
+           authorized_timestamp.ts,
+           new.client_tcp_address,
+           new.client_tcp_port,
+           new.endpoint_tcp_address,
+           new.endpoint_tcp_port,
+           new.bytes_up,
+           new.bytes_down
+      from authorized_timestamp,
+           session_dimension
+      returning * into strict new_row;
+    perform wh_rollup_connections(new.session_id);
+    return null;
+  end;
+  $$ language plpgsql;
+
+  create or replace function wh_insert_session()
+    returns trigger
+  as $$
+  declare
+    new_row wh_session_accumulating_fact%rowtype;
+  begin
+    with
+    pending_timestamp (date_dim_id, time_dim_id, ts) as (
+      select wh_date_id(start_time), wh_time_id(start_time), start_time
+        from session_state
+       where session_id = new.public_id
+         and state = 'pending'
+    )
+    insert into wh_session_accumulating_fact (
+           session_id,
+           auth_token_id,
+           host_id,
+           user_id,
+           session_pending_date_id,
+           session_pending_time_id,
+           session_pending_time
+    )
+    select new.public_id,
+           new.auth_token_id,
+           wh_upsert_host(new.host_id, new.host_set_id, new.target_id),
+           wh_upsert_user(new.user_id, new.auth_token_id),
+           pending_timestamp.date_dim_id,
+           pending_timestamp.time_dim_id,
+           pending_timestamp.ts
+      from pending_timestamp
+      returning * into strict new_row;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  alter table wh_session_connection_accumulating_fact
+    drop column project_id,
+    drop column target_id;
+
+  alter table wh_session_accumulating_fact
+    drop column project_id,
+    drop column target_id;
+
+  drop function wh_upsert_scope;
+  drop function wh_upsert_target;
+
+  drop view whx_scope_dimension_target;
+  drop view whx_scope_dimension_source;
+  drop table wh_scope_dimension;
+
+  drop view whx_target_dimension_target;
+  drop view whx_target_dimension_source;
+  drop table wh_target_dimension;
+
+commit;
@@ -0,0 +1,463 @@
+begin;
+
+  create table wh_target_dimension (
+    -- random id generated using encode(digest(gen_random_bytes(16), 'sha256'), 'base64')
+    -- this is done to prevent conflicts with rows in other clusters
+    -- which enables warehouse data from multiple clusters to be loaded into a
+    -- single database instance
+    id                              wh_dim_id     primary key default wh_dim_id(),
+
+    target_id                       wh_public_id  not null,
+    target_type                     wh_dim_text,
+    target_name                     wh_dim_text,
+    target_description              wh_dim_text,
+    target_default_port_number      integer       not null,
+    target_session_max_seconds      integer       not null,
+    target_session_connection_limit integer       not null,
+
+    project_id                      wt_scope_id   not null,
+
+    current_row_indicator           wh_dim_text,
+    row_effective_time              wh_timestamp,
+    row_expiration_time             wh_timestamp
+  );
+
+  create unique index wh_target_dim_current_constraint
+    on wh_target_dimension (target_id)
+    where current_row_indicator = 'Current';
+
+  -- The whx_target_dimension_source and whx_target_dimension_target views are
+  -- used by wh_upsert_target to determine if the current row for the dimension
+  -- has changed and new one needs to be inserted. The first column in the
+  -- target view must be the current warehouse id and all remaining columns must
+  -- match the columns in the source view.
+
+  -- The whx_target_dimension_source view shows the current values in the
+  -- operational tables of the target dimension.
+  create view whx_target_dimension_source as
+  select -- id is the first column in the target view
+         t.public_id                     as target_id,
+         'tcp target'                    as target_type,
+         coalesce(t.name, 'None')        as target_name,
+         coalesce(t.description, 'None') as target_description,
+         coalesce(t.default_port, 0)     as target_default_port_number,
+         t.session_max_seconds           as target_session_max_seconds,
+         t.session_connection_limit      as target_session_connection_limit,
+         t.scope_id                      as project_id
+    from target_tcp as t
+  ;
+
+  -- The whx_target_dimension_target view shows the rows in the
+  -- wh_target_dimension table marked as 'Current'.
+  create view whx_target_dimension_target as
+  select id,
+         target_id,
+         target_type,
+         target_name,
+         target_description,
+         target_default_port_number,
+         target_session_max_seconds,
+         target_session_connection_limit,
+         project_id
+    from wh_target_dimension
+   where current_row_indicator = 'Current'
+  ;
+
+  -- The wh_scope_dimension table holds the scope hierarchy: each row is a
+  -- version of a scope together with the version of its parent scope at the
+  -- time, so the names of the project and organization of a session are kept
+  -- as they were when the session was created.
+  create table wh_scope_dimension (
+    -- random id generated using encode(digest(gen_random_bytes(16), 'sha256'), 'base64')
+    -- this is done to prevent conflicts with rows in other clusters
+    -- which enables warehouse data from multiple clusters to be loaded into a
+    -- single database instance
+    id                       wh_dim_id    primary key default wh_dim_id(),
+
+    scope_id                 wt_scope_id  not null,
+    scope_type               wh_dim_text,
+    scope_name               wh_dim_text,
+    scope_description        wh_dim_text,
+
+    -- 'None' for the global scope
+    parent_scope_id          wh_dim_text,
+    parent_scope_type        wh_dim_text,
+    parent_scope_name        wh_dim_text,
+    parent_scope_description wh_dim_text,
+
+    current_row_indicator    wh_dim_text,
+    row_effective_time       wh_timestamp,
+    row_expiration_time      wh_timestamp
+  );
+
+  create unique index wh_scope_dim_current_constraint
+    on wh_scope_dimension (scope_id)
+    where current_row_indicator = 'Current';
+
+  -- The whx_scope_dimension_source view shows the current values in the
+  -- operational tables of the scope dimension.
+  create view whx_scope_dimension_source as
+       select -- id is the first column in the target view
+              s.public_id                     as scope_id,
+              s.type                          as scope_type,
+              coalesce(s.name, 'None')        as scope_name,
+              coalesce(s.description, 'None') as scope_description,
+              coalesce(p.public_id, 'None')   as parent_scope_id,
+              coalesce(p.type, 'None')        as parent_scope_type,
+              coalesce(p.name, 'None')        as parent_scope_name,
+              coalesce(p.description, 'None') as parent_scope_description
+         from iam_scope as s
+    left join iam_scope as p on s.parent_id = p.public_id
+  ;
+
+  -- The whx_scope_dimension_target view shows the rows in the
+  -- wh_scope_dimension table marked as 'Current'.
+  create view whx_scope_dimension_target as
+  select id,
+         scope_id,
+         scope_type,
+         scope_name,
+         scope_description,
+         parent_scope_id,
+         parent_scope_type,
+         parent_scope_name,
+         parent_scope_description
+    from wh_scope_dimension
+   where current_row_indicator = 'Current'
+  ;
+
+  -- wh_upsert_target returns the wh_target_dimension id for p_target_id.
+  -- wh_upsert_target compares the current values in the wh_target_dimension
+  -- with the current values in the operational tables for the provided
+  -- parameter. If the values between the operational tables and the
+  -- wh_target_dimension differ, a new row is inserted in the
+  -- wh_target_dimension to match the current values in the operational tables
+  -- and the new id is returned. If the values do not differ, the current id is
+  -- returned.
+  create or replace function wh_upsert_target(p_target_id wt_public_id)
+    returns wh_dim_id
+  as $$
+  declare
+    src     whx_target_dimension_target%rowtype;
+    target  whx_target_dimension_target%rowtype;
+    new_row wh_target_dimension%rowtype;
+  begin
+    select * into target
+      from whx_target_dimension_target as t
+     where t.target_id = p_target_id;
+
+    select target.id, t.* into src
+      from whx_target_dimension_source as t
+     where t.target_id = p_target_id;
+
+    if src is distinct from target then
+
+      -- expire the current row
+      update wh_target_dimension
+         set current_row_indicator = 'Expired',
+             row_expiration_time   = current_timestamp
+       where target_id             = p_target_id
+         and current_row_indicator = 'Current';
+
+      -- insert a new row
+      insert into wh_target_dimension (
+             target_id,                  target_type,                target_name,                     target_description,
+             target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+             project_id,
+             current_row_indicator,      row_effective_time,         row_expiration_time
+      )
+      select target_id,                  target_type,                target_name,                     target_description,
+             target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+             project_id,
+             'Current',                  current_timestamp,          'infinity'::timestamptz
+        from whx_target_dimension_source
+       where target_id = p_target_id
+      returning * into new_row;
+
+      return new_row.id;
+    end if;
+    return target.id;
+
+  end;
+  $$ language plpgsql;
+
+  -- wh_upsert_scope returns the wh_scope_dimension id for p_scope_id.
+  -- wh_upsert_scope compares the current values in the wh_scope_dimension with
+  -- the current values in the operational tables for the provided parameter.
+  -- If the values between the operational tables and the wh_scope_dimension
+  -- differ, a new row is inserted in the wh_scope_dimension to match the
+  -- current values in the operational tables and the new id is returned. If
+  -- the values do not differ, the current id is returned.
+  create or replace function wh_upsert_scope(p_scope_id wt_scope_id)
+    returns wh_dim_id
+  as $$
+  declare
+    src     whx_scope_dimension_target%rowtype;
+    target  whx_scope_dimension_target%rowtype;
+    new_row wh_scope_dimension%rowtype;
+  begin
+    select * into target
+      from whx_scope_dimension_target as t
+     where t.scope_id = p_scope_id;
+
+    select target.id, t.* into src
+      from whx_scope_dimension_source as t
+     where t.scope_id = p_scope_id;
+
+    if src is distinct from target then
+
+      -- expire the current row
+      update wh_scope_dimension
+         set current_row_indicator = 'Expired',
+             row_expiration_time   = current_timestamp
+       where scope_id              = p_scope_id
+         and current_row_indicator = 'Current';
+
+      -- insert a new row
+      insert into wh_scope_dimension (
+             scope_id,              scope_type,         scope_name,        scope_description,
+             parent_scope_id,       parent_scope_type,  parent_scope_name, parent_scope_description,
+             current_row_indicator, row_effective_time, row_expiration_time
+      )
+      select scope_id,              scope_type,         scope_name,        scope_description,
+             parent_scope_id,       parent_scope_type,  parent_scope_name, parent_scope_description,
+             'Current',             current_timestamp,  'infinity'::timestamptz
+        from whx_scope_dimension_source
+       where scope_id = p_scope_id
+      returning * into new_row;
+
+      return new_row.id;
+    end if;
+    return target.id;
+
+  end;
+  $$ language plpgsql;
+
+  --
+  -- Backfill
+  --
+
+  -- The target and project of the existing sessions are only known from the
+  -- wh_host_dimension, since their targets and scopes may have been deleted
+  -- or changed since. Each distinct version of a target or project in the
+  -- wh_host_dimension becomes a row of the new dimension, effective from the
+  -- first time it was seen until the next version of the same target or
+  -- project was seen. The last version is marked 'Current' so that
+  -- wh_upsert_target and wh_upsert_scope compare the operational tables
+  -- with it.
+  with
+  target_version as (
+    select target_id,                  target_type,                target_name,                     target_description,
+           target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+           project_id,
+           min(row_effective_time) as row_effective_time
+      from wh_host_dimension
+     group by target_id,                  target_type,                target_name,                     target_description,
+              target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+              project_id
+  ),
+  target_history as (
+    select *,
+           lead(row_effective_time) over (partition by target_id order by row_effective_time) as next_effective_time
+      from target_version
+  )
+  insert into wh_target_dimension (
+         target_id,                  target_type,                target_name,                     target_description,
+         target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+         project_id,
+         current_row_indicator,      row_effective_time,         row_expiration_time
+  )
+  select target_id,                  target_type,                target_name,                     target_description,
+         target_default_port_number, target_session_max_seconds, target_session_connection_limit,
+         project_id,
+         case when next_effective_time is null then 'Current' else 'Expired' end,
+         row_effective_time,
+         coalesce(next_effective_time, 'infinity'::timestamptz)
+    from target_history;
+
+  with
+  project_version as (
+    select project_id,           project_name,           project_description,
+           host_organization_id, host_organization_name, host_organization_description,
+           min(row_effective_time) as row_effective_time
+      from wh_host_dimension
+     group by project_id,           project_name,           project_description,
+              host_organization_id, host_organization_name, host_organization_description
+  ),
+  project_history as (
+    select *,
+           lead(row_effective_time) over (partition by project_id order by row_effective_time) as next_effective_time
+      from project_version
+  )
+  insert into wh_scope_dimension (
+         scope_id,              scope_type,         scope_name,        scope_description,
+         parent_scope_id,       parent_scope_type,  parent_scope_name, parent_scope_description,
+         current_row_indicator, row_effective_time, row_expiration_time
+  )
+  select project_id,           'project',              project_name,           project_description,
+         host_organization_id, 'org',                  host_organization_name, host_organization_description,
+         case when next_effective_time is null then 'Current' else 'Expired' end,
+         row_effective_time,
+         coalesce(next_effective_time, 'infinity'::timestamptz)
+    from project_history;
+
+  -- target_id and project_id are foreign keys to the new dimension tables.
+  alter table wh_session_accumulating_fact
+    add column target_id wh_dim_id
+      references wh_target_dimension (id)
+      on delete restrict
+      on update cascade,
+    add column project_id wh_dim_id
+      references wh_scope_dimension (id)
+      on delete restrict
+      on update cascade;
+
+  alter table wh_session_connection_accumulating_fact
+    add column target_id wh_dim_id
+      references wh_target_dimension (id)
+      on delete restrict
+      on update cascade,
+    add column project_id wh_dim_id
+      references wh_scope_dimension (id)
+      on delete restrict
+      on update cascade;
+
+  update wh_session_accumulating_fact as f
+     set target_id  = t.id,
+         project_id = s.id
+    from wh_host_dimension as h,
+         wh_target_dimension as t,
+         wh_scope_dimension as s
+   where h.id = f.host_id
+     and (t.target_id,                  t.target_type,                t.target_name,                     t.target_description,
+          t.target_default_port_number, t.target_session_max_seconds, t.target_session_connection_limit,
+          t.project_id)
+       = (h.target_id,                  h.target_type,                h.target_name,                     h.target_description,
+          h.target_default_port_number, h.target_session_max_seconds, h.target_session_connection_limit,
+          h.project_id)
+     and (s.scope_id,        s.scope_name,             s.scope_description,
+          s.parent_scope_id, s.parent_scope_name,      s.parent_scope_description)
+       = (h.project_id,      h.project_name,           h.project_description,
+          h.host_organization_id, h.host_organization_name, h.host_organization_description);
+
+  update wh_session_connection_accumulating_fact as c
+     set target_id  = f.target_id,
+         project_id = f.project_id
+    from wh_session_accumulating_fact as f
+   where f.session_id = c.session_id;
+
+  alter table wh_session_accumulating_fact
+    alter column target_id set not null,
+    alter column project_id set not null;
+
+  alter table wh_session_connection_accumulating_fact
+    alter column target_id set not null,
+    alter column project_id set not null;
+
+  --
+  -- Session triggers
+  --
+
+  -- wh_insert_session is replaced to also set the target and project of the
+  -- new session, which can result in new rows in wh_target_dimension and
+  -- wh_scope_dimension respectively.
+  create or replace function wh_insert_session()
+    returns trigger
+  as $$
+  declare
+    new_row wh_session_accumulating_fact%rowtype;
+  begin
+    with
+    pending_timestamp (date_dim_id, time_dim_id, ts) as (
+      select wh_date_id(start_time), wh_time_id(start_time), start_time
+        from session_state
+       where session_id = new.public_id
+         and state = 'pending'
+    )
+    insert into wh_session_accumulating_fact (
+           session_id,
+           auth_token_id,
+           host_id,
+           user_id,
+           target_id,
+           project_id,
+           session_pending_date_id,
+           session_pending_time_id,
+           session_pending_time
+    )
+    select new.public_id,
+           new.auth_token_id,
+           wh_upsert_host(new.host_id, new.host_set_id, new.target_id),
+           wh_upsert_user(new.user_id, new.auth_token_id),
+           wh_upsert_target(new.target_id),
+           wh_upsert_scope(new.scope_id),
+           pending_timestamp.date_dim_id,
+           pending_timestamp.time_dim_id,
+           pending_timestamp.ts
+      from pending_timestamp
+      returning * into strict new_row;
+    return null;
+  end;
+  $$ language plpgsql;
+
+  -- wh_insert_session_connection is replaced to also copy the target and
+  -- project of the session to the new session connection.
+  create or replace function wh_insert_session_connection()
+    returns trigger
+  as $$
+  declare
+    new_row wh_session_connection_accumulating_fact%rowtype;
+  begin
+    with
+    authorized_timestamp (date_dim_id, time_dim_id, ts) as (
+      select wh_date_id(start_time), wh_time_id(start_time), start_time
+        from session_connection_state
+       where connection_id = new.public_id
+         and state = 'authorized'
+    ),
+    session_dimension (host_dim_id, user_dim_id, target_dim_id, project_dim_id) as (
+      select host_id, user_id, target_id, project_id
+        from wh_session_accumulating_fact
+       where session_id = new.session_id
+    )
+    insert into wh_session_connection_accumulating_fact (
+           connection_id,
+           session_id,
+           host_id,
+           user_id,
+           target_id,
+           project_id,
+           connection_authorized_date_id,
+           connection_authorized_time_id,
+           connection_authorized_time,
+           client_tcp_address,
+           client_tcp_port_number,
+           endpoint_tcp_address,
+           endpoint_tcp_port_number,
+           bytes_up,
+           bytes_down
+    )
+    select new.public_id,
+           new.session_id,
+           session_dimension.host_dim_id,
+           session_dimension.user_dim_id,
+           session_dimension.target_dim_id,
+           session_dimension.project_dim_id,
+           authorized_timestamp.date_dim_id,
+           authorized_timestamp.time_dim_id,
+           authorized_timestamp.ts,
+           new.client_tcp_address,
+           new.client_tcp_port,
+           new.endpoint_tcp_address,
+           new.endpoint_tcp_port,
+           new.bytes_up,
+           new.bytes_down
+      from authorized_timestamp,
+           session_dimension
+      returning * into strict new_row;
+    perform wh_rollup_connections(new.session_id);
+    return null;
+  end;
+  $$ language plpgsql;
+
+commit;
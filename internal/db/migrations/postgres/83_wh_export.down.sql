@@ -0,0 +1,37 @@
+begin;
+
+  drop trigger wh_row_transaction_id on wh_session_connection_accumulating_fact;
+  alter table wh_session_connection_accumulating_fact
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_session_accumulating_fact;
+  alter table wh_session_accumulating_fact
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_scope_dimension;
+  alter table wh_scope_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_target_dimension;
+  alter table wh_target_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_user_dimension;
+  alter table wh_user_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_host_dimension;
+  alter table wh_host_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_time_of_day_dimension;
+  alter table wh_time_of_day_dimension
+    drop column row_transaction_id;
+
+  drop trigger wh_row_transaction_id on wh_date_dimension;
+  alter table wh_date_dimension
+    drop column row_transaction_id;
+
+  drop function wh_row_transaction_id;
+
+commit;
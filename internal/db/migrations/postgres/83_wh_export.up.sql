@@ -0,0 +1,84 @@
+begin;
+
+  -- wh_row_transaction_id returns a before insert or update trigger which
+  -- sets the row_transaction_id column of the row to the ID of the current
+  -- transaction. Rows with a row_transaction_id below the xmin of a snapshot
+  -- were written by transactions which are finished, so the xmin can be used as
+  -- a high-water mark to incrementally export the rows which were inserted or
+  -- updated since a previous export.
+  create function wh_row_transaction_id()
+    returns trigger
+  as $$
+  begin
+    new.row_transaction_id = txid_current();
+    return new;
+  end;
+  $$ language plpgsql;
+
+  -- The existing rows are exported by the first export.
+
+  alter table wh_date_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_date_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_date_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_time_of_day_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_time_of_day_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_time_of_day_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_host_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_host_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_host_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_user_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_user_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_user_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_target_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_target_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_target_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_scope_dimension
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_scope_dimension (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_scope_dimension
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_session_accumulating_fact
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_session_accumulating_fact (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_session_accumulating_fact
+    for each row
+    execute function wh_row_transaction_id();
+
+  alter table wh_session_connection_accumulating_fact
+    add column row_transaction_id bigint default 0 not null;
+  create index on wh_session_connection_accumulating_fact (row_transaction_id);
+  create trigger wh_row_transaction_id
+    before insert or update on wh_session_connection_accumulating_fact
+    for each row
+    execute function wh_row_transaction_id();
+
+commit;
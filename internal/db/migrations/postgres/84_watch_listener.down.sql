@@ -0,0 +1,5 @@
+begin;
+
+  drop table watch_listener;
+
+commit;
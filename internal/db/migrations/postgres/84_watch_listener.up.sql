@@ -0,0 +1,16 @@
+begin;
+
+  -- watch_listener records the controllers serving watch streams. Resource
+  -- changes are only published on the boundary_watch channel by the oplog
+  -- write path while there is a listener which hasn't expired. Controllers
+  -- refresh the expiration of their listener while they have streams and
+  -- delete it when they have none.
+  create table watch_listener (
+    server_id text primary key
+      constraint server_id_must_not_be_empty
+      check(length(trim(server_id)) > 0),
+    create_time wt_timestamp,
+    expiration_time timestamp with time zone not null
+  );
+
+commit;
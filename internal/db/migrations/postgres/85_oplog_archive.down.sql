@@ -0,0 +1,6 @@
+begin;
+
+  drop table oplog_archive_key_version;
+  drop table oplog_archive;
+
+commit;
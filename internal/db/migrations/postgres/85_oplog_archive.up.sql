@@ -0,0 +1,45 @@
+begin;
+
+  -- oplog_archive records the archives written by the oplog retention policy,
+  -- and the controller whose archive_dir each of them was written to. An
+  -- archive is deleted from the table once its files are no longer kept.
+  create table oplog_archive (
+    name text primary key
+      constraint name_must_not_be_empty
+      check(length(trim(name)) > 0),
+    create_time wt_timestamp,
+    controller_name text not null
+      constraint controller_name_must_not_be_empty
+      check(length(trim(controller_name)) > 0)
+  );
+
+  create trigger
+    default_create_time_column
+  before
+  insert on oplog_archive
+    for each row execute procedure default_create_time();
+
+  create trigger
+    immutable_columns
+  before
+  update on oplog_archive
+    for each row execute procedure immutable_columns('name', 'create_time', 'controller_name');
+
+  -- oplog_archive_key_version records the number of entries of each archive
+  -- encrypted with each oplog key version. Key versions are not destroyed
+  -- while archived entries depend on them. key_id is not a foreign key since
+  -- the key versions of deleted scopes are deleted with them.
+  create table oplog_archive_key_version (
+    archive_name text not null
+      references oplog_archive (name)
+      on delete cascade
+      on update cascade,
+    key_id text not null,
+    entry_count bigint not null default 0,
+    primary key (archive_name, key_id)
+  );
+
+  create index oplog_archive_key_version_key_id_ix
+    on oplog_archive_key_version (key_id);
+
+commit;
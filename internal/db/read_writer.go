@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/armon/go-metrics"
 	"github.com/hashicorp/boundary/internal/db/common"
 	"github.com/hashicorp/boundary/internal/errors"
 	"github.com/hashicorp/boundary/internal/oplog"
@@ -167,6 +168,10 @@ type VetForWriter interface {
 // Db uses a gorm DB connection for read/write
 type Db struct {
 	underlying *gorm.DB
+
+	// watch caches whether there are watch listeners for the oplog writes
+	// of the Db and of its transactions
+	watch *watchListenerCache
 }
 
 // ensure that Db implements the interfaces of: Reader and Writer
@@ -174,7 +179,7 @@ var _ Reader = (*Db)(nil)
 var _ Writer = (*Db)(nil)
 
 func New(underlying *gorm.DB) *Db {
-	return &Db{underlying: underlying}
+	return &Db{underlying: underlying, watch: new(watchListenerCache)}
 }
 
 // Exec will execute the sql with the values as parameters. The int returned
@@ -757,6 +762,9 @@ func (rw *Db) addOplogForItems(ctx context.Context, opType OpType, opts Options,
 	); err != nil {
 		return fmt.Errorf("oplog for items: unable to write oplog entry %w", err)
 	}
+	if err := rw.notifyWatchers(ctx, opType, oplogArgs.metadata, oplogArgs.wrapper.KeyID(), items...); err != nil {
+		return fmt.Errorf("oplog for items: %w", err)
+	}
 	return nil
 }
 
@@ -795,6 +803,9 @@ func (rw *Db) addOplog(ctx context.Context, opType OpType, opts Options, ticket
 	if err != nil {
 		return fmt.Errorf("add oplog: unable to write oplog entry: %w", err)
 	}
+	if err := rw.notifyWatchers(ctx, opType, oplogArgs.metadata, oplogArgs.wrapper.KeyID(), i); err != nil {
+		return fmt.Errorf("add oplog: %w", err)
+	}
 	return nil
 }
 
@@ -843,6 +854,9 @@ func (rw *Db) WriteOplogEntryWith(ctx context.Context, wrapper wrapping.Wrapper,
 	if err != nil {
 		return fmt.Errorf("write oplog: unable to write oplog entry: %w", err)
 	}
+	if err := rw.notifyWatchers(ctx, UpdateOp, metadata, wrapper.KeyID(), nil); err != nil {
+		return fmt.Errorf("write oplog: %w", err)
+	}
 	return nil
 }
 
@@ -871,6 +885,9 @@ func (rw *Db) newOplogMessage(ctx context.Context, opType OpType, i interface{},
 	return &msg, nil
 }
 
+// txRetriesKey counts the attempts DoTx retries
+var txRetriesKey = []string{"db", "tx", "retries"}
+
 // DoTx will wrap the Handler func passed within a transaction with retries
 // you should ensure that any objects written to the db in your TxHandler are retryable, which
 // means that the object may be sent to the db several times (retried), so things like the primary key must
@@ -888,7 +905,7 @@ func (w *Db) DoTx(ctx context.Context, retries uint, backOff Backoff, Handler Tx
 		// step one of this, start a transaction...
 		newTx := w.underlying.BeginTx(ctx, nil)
 
-		rw := &Db{newTx}
+		rw := &Db{underlying: newTx, watch: w.watch}
 		if err := Handler(rw, rw); err != nil {
 			if err := newTx.Rollback().Error; err != nil {
 				return info, err
@@ -897,6 +914,7 @@ func (w *Db) DoTx(ctx context.Context, retries uint, backOff Backoff, Handler Tx
 				d := backOff.Duration(attempts)
 				info.Retries++
 				info.Backoff = info.Backoff + d
+				metrics.IncrCounter(txRetriesKey, 1)
 				time.Sleep(d)
 				continue
 			}
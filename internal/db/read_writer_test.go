@@ -1108,7 +1108,7 @@ func TestDb_DoTx(t *testing.T) {
 	})
 	t.Run("nil-tx", func(t *testing.T) {
 		assert, require := assert.New(t), require.New(t)
-		w := &Db{nil}
+		w := &Db{underlying: nil}
 		attempts := 0
 		got, err := w.DoTx(context.Background(), 1, ExpBackoff{}, func(Reader, Writer) error { attempts += 1; return nil })
 		require.Error(err)
@@ -0,0 +1,148 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/hashicorp/boundary/internal/db/migrations"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-multierror"
+)
+
+var (
+	// ErrSchemaNotInitialized is returned when no migrations have been
+	// applied to the database.
+	ErrSchemaNotInitialized = stderrors.New("database schema is not initialized")
+	// ErrSchemaDirty is returned when a migration failed part way through.
+	ErrSchemaDirty = stderrors.New("database schema is dirty")
+	// ErrSchemaBehind is returned when the database is missing migrations
+	// known to this binary.
+	ErrSchemaBehind = stderrors.New("database schema is behind this binary")
+	// ErrSchemaAhead is returned when the database has migrations which are
+	// unknown to this binary.
+	ErrSchemaAhead = stderrors.New("database schema is ahead of this binary")
+)
+
+// SchemaState is the version of a database's schema compared to the
+// migrations built into this binary.
+type SchemaState struct {
+	Initialized    bool `json:"initialized"`
+	CurrentVersion uint `json:"current_version"`
+	LatestVersion  uint `json:"latest_version"`
+	Dirty          bool `json:"dirty"`
+}
+
+// Check returns an error describing why a controller cannot run against the
+// schema, or nil if the schema is current.
+func (s *SchemaState) Check() error {
+	switch {
+	case !s.Initialized:
+		return fmt.Errorf(`%w; run "boundary database init" to initialize it`, ErrSchemaNotInitialized)
+	case s.Dirty:
+		return fmt.Errorf("%w: migration %d did not complete and must be fixed by hand", ErrSchemaDirty, s.CurrentVersion)
+	case s.CurrentVersion < s.LatestVersion:
+		return fmt.Errorf(`%w: the database is at version %d and this binary requires version %d; run "boundary database migrate" to upgrade it`, ErrSchemaBehind, s.CurrentVersion, s.LatestVersion)
+	case s.CurrentVersion > s.LatestVersion:
+		return fmt.Errorf("%w: the database is at version %d and this binary only knows of version %d; upgrade Boundary", ErrSchemaAhead, s.CurrentVersion, s.LatestVersion)
+	}
+	return nil
+}
+
+// LatestSchemaVersion returns the version of the last migration built into
+// this binary for the dialect.
+func LatestSchemaVersion(dialect string) (uint, error) {
+	source, err := migrations.NewMigrationSource(dialect)
+	if err != nil {
+		return 0, fmt.Errorf("error creating migration driver: %w", err)
+	}
+	defer source.Close()
+	v, err := source.First()
+	if err != nil {
+		return 0, fmt.Errorf("error reading first migration: %w", err)
+	}
+	for {
+		next, err := source.Next(v)
+		switch {
+		case stderrors.Is(err, os.ErrNotExist):
+			return v, nil
+		case err != nil:
+			return 0, fmt.Errorf("error reading migration after %d: %w", v, err)
+		}
+		v = next
+	}
+}
+
+// GetSchemaState returns the state of the schema of the database d.
+func GetSchemaState(ctx context.Context, d *sql.DB, dialect string) (*SchemaState, error) {
+	latest, err := LatestSchemaVersion(dialect)
+	if err != nil {
+		return nil, err
+	}
+	state := &SchemaState{LatestVersion: latest}
+	var version int64
+	err = d.QueryRowContext(ctx, "select version, dirty from schema_migrations limit 1").Scan(&version, &state.Dirty)
+	switch {
+	case err == sql.ErrNoRows:
+		return state, nil
+	case errors.IsMissingTableError(err):
+		return state, nil
+	case err != nil:
+		return nil, fmt.Errorf("error querying schema version: %w", err)
+	}
+	state.Initialized = true
+	state.CurrentVersion = uint(version)
+	return state, nil
+}
+
+// MigrateStore applies the migrations missing from an initialized database.
+// Migrations are run while holding a database lock, so concurrent calls are
+// safe; all but the first find nothing to do. It returns the state of the
+// schema before and after.
+func MigrateStore(ctx context.Context, dialect string, url string) (*SchemaState, *SchemaState, error) {
+	d, err := sql.Open(dialect, url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error opening database: %w", err)
+	}
+	defer d.Close()
+
+	before, err := GetSchemaState(ctx, d, dialect)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch err := before.Check(); {
+	case err == nil:
+		return before, before, nil
+	case !stderrors.Is(err, ErrSchemaBehind):
+		return nil, nil, err
+	}
+
+	source, err := migrations.NewMigrationSource(dialect)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating migration driver: %w", err)
+	}
+	m, err := migrate.NewWithSourceInstance("httpfs", source, url)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating migrations: %w", err)
+	}
+	upErr := m.Up()
+	var mErr *multierror.Error
+	if upErr != nil && upErr != migrate.ErrNoChange {
+		mErr = multierror.Append(mErr, fmt.Errorf("error running migrations: %w", upErr))
+	}
+	if srcErr, dbErr := m.Close(); srcErr != nil || dbErr != nil {
+		mErr = multierror.Append(mErr, fmt.Errorf("error closing migrations: source: %v, database: %v", srcErr, dbErr))
+	}
+	if err := mErr.ErrorOrNil(); err != nil {
+		return nil, nil, err
+	}
+
+	after, err := GetSchemaState(ctx, d, dialect)
+	if err != nil {
+		return nil, nil, err
+	}
+	return before, after, nil
+}
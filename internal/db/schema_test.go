@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/hashicorp/boundary/internal/db/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatestSchemaVersion(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	files, err := ioutil.ReadDir("migrations/postgres")
+	require.NoError(err)
+	var want uint
+	for _, f := range files {
+		v, err := strconv.ParseUint(strings.SplitN(f.Name(), "_", 2)[0], 10, 64)
+		require.NoError(err)
+		if uint(v) > want {
+			want = uint(v)
+		}
+	}
+
+	got, err := LatestSchemaVersion("postgres")
+	require.NoError(err)
+	assert.Equal(want, got)
+
+	_, err = LatestSchemaVersion("mysql")
+	assert.Error(err)
+}
+
+func TestSchemaState_Check(t *testing.T) {
+	tests := []struct {
+		name  string
+		state SchemaState
+		want  error
+	}{
+		{
+			name:  "current",
+			state: SchemaState{Initialized: true, CurrentVersion: 10, LatestVersion: 10},
+		},
+		{
+			name:  "not-initialized",
+			state: SchemaState{LatestVersion: 10},
+			want:  ErrSchemaNotInitialized,
+		},
+		{
+			name:  "dirty",
+			state: SchemaState{Initialized: true, CurrentVersion: 10, LatestVersion: 10, Dirty: true},
+			want:  ErrSchemaDirty,
+		},
+		{
+			name:  "behind",
+			state: SchemaState{Initialized: true, CurrentVersion: 9, LatestVersion: 10},
+			want:  ErrSchemaBehind,
+		},
+		{
+			name:  "ahead",
+			state: SchemaState{Initialized: true, CurrentVersion: 11, LatestVersion: 10},
+			want:  ErrSchemaAhead,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.state.Check()
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.want), "got %v, want %v", err, tt.want)
+		})
+	}
+}
+
+func TestMigrateStore(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	cleanup, url, _, err := StartDbInDocker("postgres")
+	require.NoError(err)
+	defer func() {
+		assert.NoError(cleanup())
+	}()
+	d, err := sql.Open("postgres", url)
+	require.NoError(err)
+	defer d.Close()
+
+	state, err := GetSchemaState(ctx, d, "postgres")
+	require.NoError(err)
+	assert.False(state.Initialized)
+	_, _, err = MigrateStore(ctx, "postgres", url)
+	assert.True(errors.Is(err, ErrSchemaNotInitialized))
+
+	_, err = InitStore("postgres", nil, url)
+	require.NoError(err)
+	state, err = GetSchemaState(ctx, d, "postgres")
+	require.NoError(err)
+	assert.NoError(state.Check())
+	latest := state.LatestVersion
+
+	// Roll back the last migration so the database is behind.
+	source, err := migrations.NewMigrationSource("postgres")
+	require.NoError(err)
+	m, err := migrate.NewWithSourceInstance("httpfs", source, url)
+	require.NoError(err)
+	require.NoError(m.Steps(-1))
+	m.Close()
+
+	state, err = GetSchemaState(ctx, d, "postgres")
+	require.NoError(err)
+	assert.True(errors.Is(state.Check(), ErrSchemaBehind))
+
+	before, after, err := MigrateStore(ctx, "postgres", url)
+	require.NoError(err)
+	assert.Less(before.CurrentVersion, latest)
+	assert.Equal(latest, after.CurrentVersion)
+	assert.NoError(after.Check())
+
+	// A current schema is left alone.
+	before, after, err = MigrateStore(ctx, "postgres", url)
+	require.NoError(err)
+	assert.Equal(before, after)
+
+	// Pretend the database was migrated by a newer binary.
+	_, err = d.ExecContext(ctx, "update schema_migrations set version = $1", latest+1)
+	require.NoError(err)
+	_, _, err = MigrateStore(ctx, "postgres", url)
+	assert.True(errors.Is(err, ErrSchemaAhead))
+}
@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// WatchChannel is the Postgres notification channel on which resource changes
+// are published when their oplog entries are written, and session and
+// connection state transitions are published by the watch triggers. Payloads
+// are JSON objects and are only delivered once the writing transaction
+// commits.
+const WatchChannel = "boundary_watch"
+
+const (
+	// WatchListenerTTL is how long a watch listener registered with
+	// RegisterWatchListener lasts unless it is registered again.
+	WatchListenerTTL = time.Minute
+
+	// WatchListenerCheckInterval is how long the oplog write path relies on
+	// its last check for watch listeners. Changes are only guaranteed to be
+	// published once a listener has been registered for this long.
+	WatchListenerCheckInterval = 5 * time.Second
+)
+
+const registerWatchListener = `
+insert into watch_listener
+  (server_id, expiration_time)
+values
+  ($1, now() + $2 * interval '1 second')
+on conflict (server_id) do update
+  set expiration_time = excluded.expiration_time`
+
+const unregisterWatchListener = `
+delete from watch_listener where server_id = $1`
+
+const activeWatchListeners = `
+select exists (
+  select 1
+    from watch_listener
+   where expiration_time > now()
+)`
+
+// notifyWatch publishes a change to every resource in items on WatchChannel.
+// The scope of the change is the scope of the oplog key the entry is
+// encrypted with.
+const notifyWatch = `
+select pg_notify($1, json_build_object(
+  'op', $2::text,
+  'id', $3::text,
+  'scope_id', (
+    select r.scope_id
+      from kms_oplog_key_version v
+      join kms_oplog_key k on v.oplog_key_id = k.private_id
+      join kms_root_key r on k.root_key_id = r.private_id
+     where v.private_id = $4
+  ),
+  'time', now()
+)::text)`
+
+// watchListenerCache caches whether there are watch listeners, so that oplog
+// writes don't query for them every time.
+type watchListenerCache struct {
+	sync.Mutex
+	active    bool
+	checkTime time.Time
+}
+
+// RegisterWatchListener records that the server serves watch streams, so
+// that resource changes are published on WatchChannel. The registration
+// expires after WatchListenerTTL.
+func (rw *Db) RegisterWatchListener(ctx context.Context, serverId string) error {
+	if serverId == "" {
+		return fmt.Errorf("register watch listener: missing server id: %w", errors.ErrInvalidParameter)
+	}
+	if _, err := rw.Exec(ctx, registerWatchListener, []interface{}{serverId, WatchListenerTTL.Seconds()}); err != nil {
+		return fmt.Errorf("register watch listener: %w", err)
+	}
+	// Changes written with this Db are published right away
+	if c := rw.watch; c != nil {
+		c.Lock()
+		c.active = true
+		c.checkTime = time.Now()
+		c.Unlock()
+	}
+	return nil
+}
+
+// UnregisterWatchListener removes the registration of the server's watch
+// listener.
+func (rw *Db) UnregisterWatchListener(ctx context.Context, serverId string) error {
+	if serverId == "" {
+		return fmt.Errorf("unregister watch listener: missing server id: %w", errors.ErrInvalidParameter)
+	}
+	if _, err := rw.Exec(ctx, unregisterWatchListener, []interface{}{serverId}); err != nil {
+		return fmt.Errorf("unregister watch listener: %w", err)
+	}
+	return nil
+}
+
+// watchListenersActive reports whether any server has a registered watch
+// listener, checking at most once every WatchListenerCheckInterval. If the
+// check fails, changes are published anyway: a notification nobody listens
+// to is harmless, while failing the write or missing a change is not.
+func (rw *Db) watchListenersActive(ctx context.Context) bool {
+	c := rw.watch
+	if c != nil {
+		c.Lock()
+		defer c.Unlock()
+		if time.Since(c.checkTime) < WatchListenerCheckInterval {
+			return c.active
+		}
+	}
+	active, err := rw.queryWatchListeners(ctx)
+	if err != nil {
+		return true
+	}
+	if c != nil {
+		c.active = active
+		c.checkTime = time.Now()
+	}
+	return active
+}
+
+func (rw *Db) queryWatchListeners(ctx context.Context) (bool, error) {
+	rows, err := rw.Query(ctx, activeWatchListeners, nil)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+	var active bool
+	for rows.Next() {
+		if err := rows.Scan(&active); err != nil {
+			return false, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	return active, nil
+}
+
+type publicIder interface {
+	GetPublicId() string
+}
+
+// notifyWatchers publishes the changes recorded by an oplog entry. Items with
+// a public ID are reported with the entry's operation; others, such as role
+// grants or set members, are reported as an update of the resource in the
+// entry's resource-public-id metadata. Nothing is published while no server
+// has a registered watch listener.
+func (rw *Db) notifyWatchers(ctx context.Context, opType OpType, metadata oplog.Metadata, keyId string, items ...interface{}) error {
+	if !rw.watchListenersActive(ctx) {
+		return nil
+	}
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		op, id := watchChange(opType, metadata, item)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, err := rw.Exec(ctx, notifyWatch, []interface{}{WatchChannel, op, id, keyId}); err != nil {
+			return fmt.Errorf("notify watchers: %w", err)
+		}
+	}
+	return nil
+}
+
+func watchChange(opType OpType, metadata oplog.Metadata, item interface{}) (string, string) {
+	if i, ok := item.(publicIder); ok && i.GetPublicId() != "" {
+		switch opType {
+		case CreateOp:
+			return "create", i.GetPublicId()
+		case DeleteOp:
+			return "delete", i.GetPublicId()
+		default:
+			return "update", i.GetPublicId()
+		}
+	}
+	ids := metadata["resource-public-id"]
+	if len(ids) == 0 {
+		return "", ""
+	}
+	return "update", ids[0]
+}
@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDb_WatchListeners(t *testing.T) {
+	conn, _ := TestSetup(t, "postgres")
+	rw := New(conn)
+	ctx := context.Background()
+
+	// Forget the result of any earlier check
+	resetCheck := func() {
+		rw.watch.Lock()
+		rw.watch.checkTime = time.Time{}
+		rw.watch.Unlock()
+	}
+
+	err := rw.RegisterWatchListener(ctx, "")
+	assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+	err = rw.UnregisterWatchListener(ctx, "")
+	assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+
+	resetCheck()
+	assert.False(t, rw.watchListenersActive(ctx))
+
+	require.NoError(t, rw.RegisterWatchListener(ctx, "controller-1"))
+	assert.True(t, rw.watchListenersActive(ctx))
+
+	// Each Db keeps its own check, so another Db only sees the registration
+	// once its last check is stale
+	other := New(conn)
+	other.watch.checkTime = time.Now()
+	assert.False(t, other.watchListenersActive(ctx))
+	other.watch.checkTime = time.Time{}
+	assert.True(t, other.watchListenersActive(ctx))
+
+	// Registering again refreshes the registration
+	require.NoError(t, rw.RegisterWatchListener(ctx, "controller-1"))
+	resetCheck()
+	assert.True(t, rw.watchListenersActive(ctx))
+
+	// The last check is relied on until it is stale
+	require.NoError(t, rw.UnregisterWatchListener(ctx, "controller-1"))
+	assert.True(t, rw.watchListenersActive(ctx))
+	resetCheck()
+	assert.False(t, rw.watchListenersActive(ctx))
+
+	// Expired registrations are ignored
+	_, err = rw.Exec(ctx, "insert into watch_listener (server_id, expiration_time) values ($1, now() - interval '1 minute')", []interface{}{"controller-2"})
+	require.NoError(t, err)
+	resetCheck()
+	assert.False(t, rw.watchListenersActive(ctx))
+
+	// Changes are published when the check fails
+	tx := conn.BeginTx(ctx, nil)
+	require.NoError(t, tx.Rollback().Error)
+	failing := &Db{underlying: tx, watch: new(watchListenerCache)}
+	assert.True(t, failing.watchListenersActive(ctx))
+}
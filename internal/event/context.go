@@ -0,0 +1,82 @@
+package event
+
+import (
+	"context"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIdMetadataKey is the gRPC metadata key carrying the request ID of
+// calls from workers to controllers.
+const RequestIdMetadataKey = "x-boundary-request-id"
+
+type ctxKey int
+
+const (
+	eventerKey ctxKey = iota
+	requestIdKey
+)
+
+// NewRequestId returns a new request ID.
+func NewRequestId() (string, error) {
+	return db.NewPublicId(RequestPrefix)
+}
+
+// NewContext returns a copy of ctx carrying the eventer and the ID of the
+// request being handled. Events emitted with the returned context are written
+// by eventer and carry the request ID.
+func NewContext(ctx context.Context, eventer *Eventer, requestId string) context.Context {
+	ctx = context.WithValue(ctx, eventerKey, eventer)
+	return context.WithValue(ctx, requestIdKey, requestId)
+}
+
+// RequestId returns the request ID carried by ctx, if any.
+func RequestId(ctx context.Context) string {
+	id, _ := ctx.Value(requestIdKey).(string)
+	return id
+}
+
+// Emit writes an event with the eventer carried by ctx. Without one the event
+// is discarded.
+func Emit(ctx context.Context, typ Type, data map[string]interface{}) {
+	eventer, _ := ctx.Value(eventerKey).(*Eventer)
+	eventer.Emit(ctx, typ, data)
+}
+
+// UnaryClientInterceptor returns an interceptor adding the request ID carried
+// by the context of calls to their outgoing metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id := RequestId(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIdMetadataKey, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor returns an interceptor giving handlers a context
+// carrying eventer and the request ID from the incoming metadata, or a new
+// one if the caller sent none. If eventer is not enabled handlers receive the
+// context unchanged.
+func UnaryServerInterceptor(eventer *Eventer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !eventer.Enabled() {
+			return handler(ctx, req)
+		}
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if ids := md.Get(RequestIdMetadataKey); len(ids) > 0 {
+				id = ids[0]
+			}
+		}
+		if id == "" {
+			var err error
+			if id, err = NewRequestId(); err != nil {
+				return nil, err
+			}
+		}
+		return handler(NewContext(ctx, eventer, id), req)
+	}
+}
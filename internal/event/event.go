@@ -0,0 +1,132 @@
+// Package event implements the structured audit events emitted by controllers
+// and workers. Events are written as JSON to the sinks configured in the
+// events block of the server configuration: files with rotation, stderr, and
+// webhooks.
+//
+// Events emitted while handling the same request carry the same request ID,
+// which is propagated from workers to controllers in the metadata of their
+// gRPC calls.
+package event
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-multierror"
+)
+
+// Type is the type of an event.
+type Type string
+
+const (
+	// RequestType is the type of events recording API requests.
+	RequestType Type = "request"
+	// ResponseType is the type of events recording API responses.
+	ResponseType Type = "response"
+	// AuthenticationType is the type of events recording authentication
+	// attempts.
+	AuthenticationType Type = "authentication"
+	// SessionAuthorizationType is the type of events recording authorized
+	// sessions.
+	SessionAuthorizationType Type = "session_authorization"
+	// ConnectionOpenType is the type of events recording established
+	// connections of sessions.
+	ConnectionOpenType Type = "connection_open"
+	// ConnectionCloseType is the type of events recording closed connections
+	// of sessions.
+	ConnectionCloseType Type = "connection_close"
+)
+
+const (
+	// EventPrefix is the prefix of event IDs.
+	EventPrefix = "evt"
+	// RequestPrefix is the prefix of request IDs.
+	RequestPrefix = "req"
+)
+
+// Event is a single audit event.
+type Event struct {
+	Id        string                 `json:"id"`
+	Type      Type                   `json:"type"`
+	Time      time.Time              `json:"time"`
+	RequestId string                 `json:"request_id,omitempty"`
+	Server    string                 `json:"server,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+// Sink is a destination of events.
+type Sink interface {
+	// Write records the event. It must be safe for concurrent use.
+	Write(e *Event) error
+	// Close releases the resources of the sink.
+	Close() error
+}
+
+// Eventer sends events to sinks. A nil Eventer discards all events.
+type Eventer struct {
+	logger hclog.Logger
+	server string
+	sinks  []Sink
+}
+
+// NewEventer returns an Eventer writing events to sinks. server identifies
+// the controller or worker in the events.
+func NewEventer(logger hclog.Logger, server string, sinks ...Sink) *Eventer {
+	return &Eventer{
+		logger: logger,
+		server: server,
+		sinks:  sinks,
+	}
+}
+
+// Enabled returns whether events are written anywhere.
+func (e *Eventer) Enabled() bool {
+	return e != nil && len(e.sinks) > 0
+}
+
+// Emit writes an event of the given type and data to all sinks. The request
+// ID is taken from ctx. Failures to write are logged but not returned, so
+// that recording events never fails the operation they describe.
+func (e *Eventer) Emit(ctx context.Context, typ Type, data map[string]interface{}) {
+	if !e.Enabled() {
+		return
+	}
+	id, err := db.NewPublicId(EventPrefix)
+	if err != nil {
+		e.logger.Error("error generating event id", "error", err)
+		return
+	}
+	ev := &Event{
+		Id:        id,
+		Type:      typ,
+		Time:      time.Now().UTC(),
+		RequestId: RequestId(ctx),
+		Server:    e.server,
+		Data:      data,
+	}
+	for _, s := range e.sinks {
+		if err := s.Write(ev); err != nil {
+			e.logger.Error("error writing event", "event_id", ev.Id, "type", typ, "error", err)
+		}
+	}
+}
+
+// Close closes all sinks.
+func (e *Eventer) Close() error {
+	if e == nil {
+		return nil
+	}
+	var result *multierror.Error
+	for _, s := range e.sinks {
+		if err := s.Close(); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	if err := result.ErrorOrNil(); err != nil {
+		return fmt.Errorf("error closing event sinks: %w", err)
+	}
+	return nil
+}
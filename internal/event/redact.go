@@ -0,0 +1,133 @@
+package event
+
+import (
+	"sync"
+
+	"github.com/hashicorp/boundary/internal/gen/controller/protooptions"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Redacted replaces the values of sensitive fields in events.
+const Redacted = "[REDACTED]"
+
+// isSensitive reports whether the field is marked with the sensitive option.
+func isSensitive(fd protoreflect.FieldDescriptor) bool {
+	opts, ok := fd.Options().(*descriptorpb.FieldOptions)
+	if !ok || opts == nil {
+		return false
+	}
+	return proto.GetExtension(opts, protooptions.E_Sensitive).(bool)
+}
+
+var (
+	sensitiveNamesOnce sync.Once
+	sensitiveNames     map[string]bool
+)
+
+// sensitiveFieldNames returns the proto and JSON names of all registered
+// fields marked with the sensitive option.
+func sensitiveFieldNames() map[string]bool {
+	sensitiveNamesOnce.Do(func() {
+		sensitiveNames = make(map[string]bool)
+		var walk func(protoreflect.MessageDescriptors)
+		walk = func(mds protoreflect.MessageDescriptors) {
+			for i := 0; i < mds.Len(); i++ {
+				md := mds.Get(i)
+				fields := md.Fields()
+				for j := 0; j < fields.Len(); j++ {
+					if fd := fields.Get(j); isSensitive(fd) {
+						sensitiveNames[string(fd.Name())] = true
+						sensitiveNames[fd.JSONName()] = true
+					}
+				}
+				walk(md.Messages())
+			}
+		}
+		protoregistry.GlobalFiles.RangeFiles(func(fd protoreflect.FileDescriptor) bool {
+			walk(fd.Messages())
+			return true
+		})
+	})
+	return sensitiveNames
+}
+
+// Redact returns a copy of m with the values of all fields marked with the
+// sensitive option replaced. Sensitive string fields, and wrappers of
+// strings, are set to Redacted; other sensitive fields are cleared.
+func Redact(m proto.Message) proto.Message {
+	if m == nil {
+		return nil
+	}
+	c := proto.Clone(m)
+	redactMessage(c.ProtoReflect())
+	return c
+}
+
+func redactMessage(m protoreflect.Message) {
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		if isSensitive(fd) {
+			redactField(m, fd)
+			return true
+		}
+		switch {
+		case fd.IsList() && fd.Message() != nil:
+			l := v.List()
+			for i := 0; i < l.Len(); i++ {
+				redactMessage(l.Get(i).Message())
+			}
+		case fd.IsMap() && fd.MapValue().Message() != nil:
+			v.Map().Range(func(_ protoreflect.MapKey, mv protoreflect.Value) bool {
+				redactMessage(mv.Message())
+				return true
+			})
+		case fd.Message() != nil && !fd.IsList() && !fd.IsMap():
+			redactMessage(v.Message())
+		}
+		return true
+	})
+}
+
+func redactField(m protoreflect.Message, fd protoreflect.FieldDescriptor) {
+	switch {
+	case fd.IsList() || fd.IsMap():
+		m.Clear(fd)
+	case fd.Kind() == protoreflect.StringKind:
+		m.Set(fd, protoreflect.ValueOfString(Redacted))
+	case fd.Kind() == protoreflect.BytesKind:
+		m.Set(fd, protoreflect.ValueOfBytes([]byte(Redacted)))
+	case fd.Message() != nil && fd.Message().FullName() == "google.protobuf.StringValue":
+		w := m.Mutable(fd).Message()
+		w.Set(w.Descriptor().Fields().ByName("value"), protoreflect.ValueOfString(Redacted))
+	default:
+		m.Clear(fd)
+	}
+}
+
+// RedactJSON redacts in place the values of keys of v, and of the objects
+// and arrays nested in it, named after fields marked with the sensitive
+// option. v is expected to be the result of decoding JSON into an
+// interface{}, e.g. a request body.
+func RedactJSON(v interface{}) {
+	names := sensitiveFieldNames()
+	var walk func(interface{})
+	walk = func(v interface{}) {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			for k, e := range t {
+				if names[k] {
+					t[k] = Redacted
+					continue
+				}
+				walk(e)
+			}
+		case []interface{}:
+			for _, e := range t {
+				walk(e)
+			}
+		}
+	}
+	walk(v)
+}
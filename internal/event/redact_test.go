@@ -0,0 +1,74 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	pba "github.com/hashicorp/boundary/internal/gen/controller/api/resources/accounts"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestRedact(t *testing.T) {
+	t.Run("string field", func(t *testing.T) {
+		in := &pbs.SetPasswordRequest{Id: "apw_1234567890", Version: 2, Password: "secret"}
+		got := Redact(in).(*pbs.SetPasswordRequest)
+		assert.Equal(t, Redacted, got.GetPassword())
+		assert.Equal(t, "apw_1234567890", got.GetId())
+		assert.Equal(t, uint32(2), got.GetVersion())
+		// The original is left untouched
+		assert.Equal(t, "secret", in.GetPassword())
+	})
+	t.Run("nested message", func(t *testing.T) {
+		in := &pbs.AuthorizeSessionResponse{Item: &targets.SessionAuthorization{
+			SessionId:          "s_1234567890",
+			Scope:              &scopes.ScopeInfo{Id: "p_1234567890"},
+			AuthorizationToken: "token",
+		}}
+		got := Redact(in).(*pbs.AuthorizeSessionResponse)
+		assert.Empty(t, cmp.Diff(&pbs.AuthorizeSessionResponse{Item: &targets.SessionAuthorization{
+			SessionId:          "s_1234567890",
+			Scope:              &scopes.ScopeInfo{Id: "p_1234567890"},
+			AuthorizationToken: Redacted,
+		}}, got, protocmp.Transform()))
+	})
+	t.Run("wrapper", func(t *testing.T) {
+		in := &pba.PasswordAccountAttributes{LoginName: "jim", Password: wrapperspb.String("secret")}
+		got := Redact(in).(*pba.PasswordAccountAttributes)
+		assert.Equal(t, "jim", got.GetLoginName())
+		assert.Equal(t, Redacted, got.GetPassword().GetValue())
+	})
+	t.Run("unset fields stay unset", func(t *testing.T) {
+		in := &pba.PasswordAccountAttributes{LoginName: "jim"}
+		got := Redact(in).(*pba.PasswordAccountAttributes)
+		assert.Nil(t, got.GetPassword())
+	})
+}
+
+func TestRedactJSON(t *testing.T) {
+	in := map[string]interface{}{
+		"auth_method_id": "ampw_1234567890",
+		"credentials": map[string]interface{}{
+			"login_name": "jim",
+			"password":   "secret",
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": "at_1234567890", "token": "at_1234567890_secret"},
+		},
+	}
+	RedactJSON(in)
+	assert.Equal(t, map[string]interface{}{
+		"auth_method_id": "ampw_1234567890",
+		"credentials": map[string]interface{}{
+			"login_name": "jim",
+			"password":   Redacted,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": "at_1234567890", "token": Redacted},
+		},
+	}, in)
+}
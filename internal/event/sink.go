@@ -0,0 +1,252 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cleanhttp"
+	"github.com/hashicorp/go-hclog"
+)
+
+// WriterSink writes events to an io.Writer as lines of JSON.
+type WriterSink struct {
+	l sync.Mutex
+	w io.Writer
+}
+
+var _ Sink = (*WriterSink)(nil)
+
+// NewWriterSink returns a sink writing to w, e.g. os.Stderr.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Write implements Sink.
+func (s *WriterSink) Write(e *Event) error {
+	b, err := marshal(e)
+	if err != nil {
+		return err
+	}
+	s.l.Lock()
+	defer s.l.Unlock()
+	_, err = s.w.Write(b)
+	return err
+}
+
+// Close implements Sink. The writer is not closed.
+func (s *WriterSink) Close() error {
+	return nil
+}
+
+// FileSink writes events to a file as lines of JSON. When rotation is enabled
+// and writing an event would grow the file beyond the rotation size, the file
+// is renamed with a numeric suffix and a new one is started. The newest
+// rotated file has suffix .1.
+type FileSink struct {
+	l              sync.Mutex
+	path           string
+	rotateBytes    int64
+	rotateMaxFiles int
+	f              *os.File
+	size           int64
+}
+
+var _ Sink = (*FileSink)(nil)
+
+// NewFileSink returns a sink appending to the file at path. If rotateBytes is
+// greater than zero the file is rotated once it reaches that size, keeping
+// rotateMaxFiles rotated files.
+func NewFileSink(path string, rotateBytes int64, rotateMaxFiles int) (*FileSink, error) {
+	if path == "" {
+		return nil, errors.New("new file sink: missing path")
+	}
+	s := &FileSink{
+		path:           path,
+		rotateBytes:    rotateBytes,
+		rotateMaxFiles: rotateMaxFiles,
+	}
+	if err := s.open(); err != nil {
+		return nil, fmt.Errorf("new file sink: %w", err)
+	}
+	return s, nil
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	s.f = f
+	s.size = info.Size()
+	return nil
+}
+
+func (s *FileSink) rotate() error {
+	if err := s.f.Close(); err != nil {
+		return err
+	}
+	if s.rotateMaxFiles <= 0 {
+		if err := os.Remove(s.path); err != nil {
+			return err
+		}
+		return s.open()
+	}
+	rotated := func(i int) string { return fmt.Sprintf("%s.%d", s.path, i) }
+	if err := os.Remove(rotated(s.rotateMaxFiles)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := s.rotateMaxFiles - 1; i > 0; i-- {
+		if err := os.Rename(rotated(i), rotated(i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(s.path, rotated(1)); err != nil {
+		return err
+	}
+	return s.open()
+}
+
+// Write implements Sink.
+func (s *FileSink) Write(e *Event) error {
+	b, err := marshal(e)
+	if err != nil {
+		return err
+	}
+	s.l.Lock()
+	defer s.l.Unlock()
+	if s.f == nil {
+		return errors.New("file sink is closed")
+	}
+	if s.rotateBytes > 0 && s.size > 0 && s.size+int64(len(b)) > s.rotateBytes {
+		if err := s.rotate(); err != nil {
+			return fmt.Errorf("error rotating event file: %w", err)
+		}
+	}
+	n, err := s.f.Write(b)
+	s.size += int64(n)
+	return err
+}
+
+// Close implements Sink.
+func (s *FileSink) Close() error {
+	s.l.Lock()
+	defer s.l.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
+
+const (
+	webhookQueueSize = 1024
+	webhookTimeout   = 10 * time.Second
+)
+
+// WebhookSink posts each event as JSON to a URL. Events are sent in the
+// background, in order; when the queue of unsent events is full new events
+// are dropped.
+type WebhookSink struct {
+	logger  hclog.Logger
+	url     string
+	headers map[string]string
+	client  *http.Client
+	queue   chan []byte
+	done    chan struct{}
+	once    sync.Once
+}
+
+var _ Sink = (*WebhookSink)(nil)
+
+// NewWebhookSink returns a sink posting events to url with the given
+// additional headers. Failed deliveries are logged to logger.
+func NewWebhookSink(logger hclog.Logger, url string, headers map[string]string) (*WebhookSink, error) {
+	if url == "" {
+		return nil, errors.New("new webhook sink: missing url")
+	}
+	client := cleanhttp.DefaultPooledClient()
+	client.Timeout = webhookTimeout
+	s := &WebhookSink{
+		logger:  logger,
+		url:     url,
+		headers: headers,
+		client:  client,
+		queue:   make(chan []byte, webhookQueueSize),
+		done:    make(chan struct{}),
+	}
+	go s.run()
+	return s, nil
+}
+
+func (s *WebhookSink) run() {
+	defer close(s.done)
+	for b := range s.queue {
+		if err := s.post(b); err != nil {
+			s.logger.Error("error sending event to webhook", "url", s.url, "error", err)
+		}
+	}
+}
+
+func (s *WebhookSink) post(b []byte) error {
+	req, err := http.NewRequest(http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// Write implements Sink.
+func (s *WebhookSink) Write(e *Event) error {
+	b, err := marshal(e)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.queue <- b:
+		return nil
+	default:
+		return errors.New("webhook queue is full, dropping event")
+	}
+}
+
+// Close implements Sink. It waits for queued events to be sent.
+func (s *WebhookSink) Close() error {
+	s.once.Do(func() { close(s.queue) })
+	<-s.done
+	return nil
+}
+
+func marshal(e *Event) ([]byte, error) {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling event: %w", err)
+	}
+	return append(b, '\n'), nil
+}
@@ -0,0 +1,130 @@
+package event
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readEvents(t *testing.T, path string) []*Event {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var ret []*Event
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		var e Event
+		require.NoError(t, json.Unmarshal(s.Bytes(), &e))
+		ret = append(ret, &e)
+	}
+	require.NoError(t, s.Err())
+	return ret
+}
+
+func TestEventer(t *testing.T) {
+	var buf bytes.Buffer
+	e := NewEventer(hclog.NewNullLogger(), "controller-1", NewWriterSink(&buf))
+	assert.True(t, e.Enabled())
+
+	ctx := NewContext(context.Background(), e, "req_1234567890")
+	Emit(ctx, AuthenticationType, map[string]interface{}{"success": true})
+	require.NoError(t, e.Close())
+
+	var got Event
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, AuthenticationType, got.Type)
+	assert.Equal(t, "req_1234567890", got.RequestId)
+	assert.Equal(t, "controller-1", got.Server)
+	assert.Equal(t, map[string]interface{}{"success": true}, got.Data)
+	assert.NotEmpty(t, got.Id)
+	assert.False(t, got.Time.IsZero())
+
+	// Neither a nil eventer nor a context without one write anything
+	var nilEventer *Eventer
+	assert.False(t, nilEventer.Enabled())
+	nilEventer.Emit(ctx, AuthenticationType, nil)
+	Emit(context.Background(), AuthenticationType, nil)
+	assert.NoError(t, nilEventer.Close())
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "events")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.log")
+
+	ev := &Event{Id: "evt_1234567890", Type: RequestType}
+	b, err := marshal(ev)
+	require.NoError(t, err)
+
+	// Room for two events per file, keeping two rotated files
+	s, err := NewFileSink(path, int64(2*len(b)), 2)
+	require.NoError(t, err)
+	for i := 0; i < 7; i++ {
+		require.NoError(t, s.Write(ev))
+	}
+	require.NoError(t, s.Close())
+	assert.Error(t, s.Write(ev))
+
+	assert.Len(t, readEvents(t, path), 1)
+	assert.Len(t, readEvents(t, path+".1"), 2)
+	assert.Len(t, readEvents(t, path+".2"), 2)
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+
+	// Reopening appends to the existing file
+	s, err = NewFileSink(path, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, s.Write(ev))
+	require.NoError(t, s.Close())
+	assert.Len(t, readEvents(t, path), 2)
+
+	_, err = NewFileSink("", 0, 0)
+	assert.Error(t, err)
+}
+
+func TestWebhookSink(t *testing.T) {
+	var l sync.Mutex
+	var got []*Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		var e Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&e))
+		l.Lock()
+		got = append(got, &e)
+		l.Unlock()
+	}))
+	defer srv.Close()
+
+	s, err := NewWebhookSink(hclog.NewNullLogger(), srv.URL, map[string]string{"Authorization": "Bearer secret"})
+	require.NoError(t, err)
+	for _, id := range []string{"evt_1", "evt_2", "evt_3"} {
+		require.NoError(t, s.Write(&Event{Id: id, Type: ResponseType}))
+	}
+	// Close waits for queued events to be sent
+	require.NoError(t, s.Close())
+
+	l.Lock()
+	defer l.Unlock()
+	require.Len(t, got, 3)
+	for i, id := range []string{"evt_1", "evt_2", "evt_3"} {
+		assert.Equal(t, id, got[i].Id)
+	}
+
+	_, err = NewWebhookSink(hclog.NewNullLogger(), "", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,185 @@
+// Package filter implements the boolean expressions used to select the items
+// returned by List requests. An expression is evaluated against the JSON
+// representation of an item, as returned by the API.
+//
+// Fields are selected by their JSON names, with dots separating the names of
+// nested fields, e.g. scope.id. Supported expressions are:
+//
+//	name == "web"             field equals a string, number or boolean
+//	name != "web"             field does not equal a value
+//	name contains "prod"      string field contains a substring, or list field
+//	                          contains an element
+//	"read" in authorized_actions
+//	                          list field contains an element, or string field
+//	                          contains a substring
+//	name matches "^web-[0-9]+$"
+//	                          string field matches a regular expression
+//	description is empty      field is missing, empty or null
+//	description is not empty  field is set
+//
+// Expressions can be combined with and, or and not, and grouped with
+// parentheses. and binds more tightly than or.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Filter is a parsed filter expression.
+type Filter struct {
+	expr string
+	root node
+}
+
+// New parses expr into a Filter.
+func New(expr string) (*Filter, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("invalid filter: unexpected %s at position %d", t, t.pos)
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// String returns the expression the filter was parsed from.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// Match returns whether item, a value decoded from JSON, matches the filter.
+func (f *Filter) Match(item interface{}) bool {
+	return f.root.eval(item)
+}
+
+type node interface {
+	eval(item interface{}) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n *andNode) eval(item interface{}) bool { return n.left.eval(item) && n.right.eval(item) }
+
+type orNode struct{ left, right node }
+
+func (n *orNode) eval(item interface{}) bool { return n.left.eval(item) || n.right.eval(item) }
+
+type notNode struct{ inner node }
+
+func (n *notNode) eval(item interface{}) bool { return !n.inner.eval(item) }
+
+type operator int
+
+const (
+	opEqual operator = iota
+	opNotEqual
+	opContains
+	opMatches
+	opEmpty
+	opNotEmpty
+)
+
+type matchNode struct {
+	selector []string
+	op       operator
+	value    literal
+	re       *regexp.Regexp
+}
+
+// literal is a value in an expression. text is the string value, or the
+// literal text of numbers and booleans.
+type literal struct {
+	text   string
+	number *float64
+}
+
+func (n *matchNode) eval(item interface{}) bool {
+	v, ok := lookup(item, n.selector)
+	switch n.op {
+	case opEmpty:
+		return !ok || isEmpty(v)
+	case opNotEmpty:
+		return ok && !isEmpty(v)
+	case opNotEqual:
+		return !ok || !n.value.equals(v)
+	}
+	if !ok {
+		return false
+	}
+	switch n.op {
+	case opEqual:
+		return n.value.equals(v)
+	case opContains:
+		switch tv := v.(type) {
+		case []interface{}:
+			for _, e := range tv {
+				if n.value.equals(e) {
+					return true
+				}
+			}
+			return false
+		case string:
+			return strings.Contains(tv, n.value.text)
+		}
+		return false
+	case opMatches:
+		s, ok := v.(string)
+		return ok && n.re.MatchString(s)
+	}
+	return false
+}
+
+// lookup returns the value at selector in item.
+func lookup(item interface{}, selector []string) (interface{}, bool) {
+	v := item
+	for _, key := range selector {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func isEmpty(v interface{}) bool {
+	switch tv := v.(type) {
+	case nil:
+		return true
+	case string:
+		return tv == ""
+	case []interface{}:
+		return len(tv) == 0
+	case map[string]interface{}:
+		return len(tv) == 0
+	}
+	return false
+}
+
+// equals compares l to v. Numbers are compared numerically; everything else,
+// including numbers encoded as strings in JSON, is compared by its text.
+func (l literal) equals(v interface{}) bool {
+	switch tv := v.(type) {
+	case float64:
+		if l.number != nil {
+			return *l.number == tv
+		}
+		return strconv.FormatFloat(tv, 'f', -1, 64) == l.text
+	case string:
+		return tv == l.text
+	case bool:
+		return strconv.FormatBool(tv) == l.text
+	}
+	return false
+}
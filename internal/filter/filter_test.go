@@ -0,0 +1,82 @@
+package filter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter_Match(t *testing.T) {
+	var item interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "ttcp_1234567890",
+		"name": "web-01",
+		"description": "",
+		"version": 3,
+		"session_max_seconds": "28800",
+		"disabled": false,
+		"scope": {"id": "p_1234567890", "type": "project"},
+		"authorized_actions": ["read", "update"]
+	}`), &item))
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{expr: `name == "web-01"`, want: true},
+		{expr: `name != "web-01"`, want: false},
+		{expr: `scope.id == "p_1234567890"`, want: true},
+		{expr: `scope.type == "org"`, want: false},
+		{expr: `version == 3`, want: true},
+		{expr: `version == "3"`, want: true},
+		{expr: `session_max_seconds == 28800`, want: true},
+		{expr: `disabled == false`, want: true},
+		{expr: `name contains "web"`, want: true},
+		{expr: `authorized_actions contains "delete"`, want: false},
+		{expr: `"read" in authorized_actions`, want: true},
+		{expr: `name matches "^web-[0-9]+$"`, want: true},
+		{expr: `description is empty`, want: true},
+		{expr: `missing is empty`, want: true},
+		{expr: `name is not empty`, want: true},
+		{expr: `missing == "x"`, want: false},
+		{expr: `missing != "x"`, want: true},
+		{expr: `name == "other" or version == 3 and disabled == false`, want: true},
+		{expr: `(name == "other" or version == 3) and disabled == true`, want: false},
+		{expr: `not name == "other"`, want: true},
+		{expr: `not (name == "web-01" and "read" in authorized_actions)`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			f, err := New(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, f.Match(item))
+		})
+	}
+}
+
+func TestNew_Errors(t *testing.T) {
+	tests := []string{
+		``,
+		`name`,
+		`name = "web"`,
+		`name == `,
+		`name == web`,
+		`"web" == name`,
+		`(name == "web"`,
+		`name == "web")`,
+		`name == "web" and`,
+		`name matches "("`,
+		`name is "web"`,
+		`and == "web"`,
+		`name == "unterminated`,
+		`scope..id == "p_1234567890"`,
+	}
+	for _, expr := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := New(expr)
+			assert.Error(t, err)
+		})
+	}
+}
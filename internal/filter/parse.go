@@ -0,0 +1,277 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenNumber
+	tokenEqual
+	tokenNotEqual
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of filter"
+	case tokenString:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// isKeyword returns whether t is the given keyword.
+func (t token) isKeyword(keyword string) bool {
+	return t.kind == tokenWord && t.text == keyword
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == '-'
+}
+
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", pos: i})
+			i++
+		case r == '=' || r == '!':
+			if i+1 >= len(runes) || runes[i+1] != '=' {
+				return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+			}
+			kind := tokenEqual
+			if r == '!' {
+				kind = tokenNotEqual
+			}
+			tokens = append(tokens, token{kind: kind, text: string(runes[i : i+2]), pos: i})
+			i += 2
+		case r == '"':
+			start := i
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			s, err := strconv.Unquote(string(runes[start:i]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid string at position %d: %w", start, err)
+			}
+			tokens = append(tokens, token{kind: tokenString, text: s, pos: start})
+		case isWordRune(r):
+			start := i
+			for i < len(runes) && isWordRune(runes[i]) {
+				i++
+			}
+			text := string(runes[start:i])
+			kind := tokenWord
+			if _, err := strconv.ParseFloat(text, 64); err == nil {
+				kind = tokenNumber
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: start})
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("or") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().isKeyword("and") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (node, error) {
+	if p.peek().isKeyword("not") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &notNode{inner: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenLeftParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, fmt.Errorf("expected \")\" at position %d, got %s", closing.pos, closing)
+		}
+		return inner, nil
+
+	case tokenString, tokenNumber:
+		// value in selector
+		if in := p.next(); !in.isKeyword("in") {
+			return nil, fmt.Errorf("expected \"in\" at position %d, got %s", in.pos, in)
+		}
+		selector, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		return &matchNode{selector: selector, op: opContains, value: newLiteral(t)}, nil
+
+	case tokenWord:
+		p.pos--
+		selector, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		op := p.next()
+		n := &matchNode{selector: selector}
+		switch {
+		case op.kind == tokenEqual:
+			n.op = opEqual
+		case op.kind == tokenNotEqual:
+			n.op = opNotEqual
+		case op.isKeyword("contains"):
+			n.op = opContains
+		case op.isKeyword("matches"):
+			n.op = opMatches
+		case op.isKeyword("is"):
+			n.op = opEmpty
+			if p.peek().isKeyword("not") {
+				p.next()
+				n.op = opNotEmpty
+			}
+			if empty := p.next(); !empty.isKeyword("empty") {
+				return nil, fmt.Errorf("expected \"empty\" at position %d, got %s", empty.pos, empty)
+			}
+			return n, nil
+		default:
+			return nil, fmt.Errorf("expected an operator at position %d, got %s", op.pos, op)
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		n.value = value
+		if n.op == opMatches {
+			if n.re, err = regexp.Compile(value.text); err != nil {
+				return nil, fmt.Errorf("invalid regular expression %q: %w", value.text, err)
+			}
+		}
+		return n, nil
+	}
+	return nil, fmt.Errorf("unexpected %s at position %d", t, t.pos)
+}
+
+func (p *parser) parseSelector() ([]string, error) {
+	t := p.next()
+	if t.kind != tokenWord || isReserved(t.text) {
+		return nil, fmt.Errorf("expected a field at position %d, got %s", t.pos, t)
+	}
+	selector := strings.Split(t.text, ".")
+	for _, part := range selector {
+		if part == "" {
+			return nil, fmt.Errorf("invalid field %q at position %d", t.text, t.pos)
+		}
+	}
+	return selector, nil
+}
+
+func (p *parser) parseValue() (literal, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenString, t.kind == tokenNumber,
+		t.isKeyword("true"), t.isKeyword("false"):
+		return newLiteral(t), nil
+	}
+	return literal{}, fmt.Errorf("expected a value at position %d, got %s", t.pos, t)
+}
+
+func newLiteral(t token) literal {
+	l := literal{text: t.text}
+	if t.kind == tokenNumber {
+		f, _ := strconv.ParseFloat(t.text, 64)
+		l.number = &f
+	}
+	return l
+}
+
+func isReserved(word string) bool {
+	switch word {
+	case "and", "or", "not", "in", "contains", "matches", "is", "empty", "true", "false":
+		return true
+	}
+	return false
+}
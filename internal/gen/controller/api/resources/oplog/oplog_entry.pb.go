@@ -0,0 +1,364 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        v3.12.4
+// source: controller/api/resources/oplog/v1/oplog_entry.proto
+
+package oplog
+
+import (
+	proto "github.com/golang/protobuf/proto"
+	_struct "github.com/golang/protobuf/ptypes/struct"
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+// OplogEntry is an entry of the operation log, recording the changes made to
+// resources in a single transaction.
+type OplogEntry struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Output only. The ID of the entry. IDs increase in the order entries are written.
+	Id uint64 `protobuf:"varint,10,opt,name=id,proto3" json:"id,omitempty"`
+	// Output only. The ID of the Scope of the changed resources.
+	ScopeId string `protobuf:"bytes,20,opt,name=scope_id,proto3" json:"scope_id,omitempty"`
+	// Output only. The name of the aggregate the changes were made to, usually the table of the resource.
+	AggregateName string `protobuf:"bytes,30,opt,name=aggregate_name,proto3" json:"aggregate_name,omitempty"`
+	// Output only. The version of the format of the entry.
+	Version string `protobuf:"bytes,40,opt,name=version,proto3" json:"version,omitempty"`
+	// Output only. The time the entry was written.
+	CreatedTime *timestamp.Timestamp `protobuf:"bytes,50,opt,name=created_time,proto3" json:"created_time,omitempty"`
+	// Output only. The metadata of the entry, such as the ID and type of the changed resource. Each key maps to a list of values.
+	Metadata *_struct.Struct `protobuf:"bytes,60,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Output only. The changes recorded by the entry, in the order they were made.
+	Messages []*OplogMessage `protobuf:"bytes,70,rep,name=messages,proto3" json:"messages,omitempty"`
+	// Output only. The reason the changes of the entry could not be decrypted or decoded, if they could not.
+	DataError string `protobuf:"bytes,80,opt,name=data_error,proto3" json:"data_error,omitempty"`
+}
+
+func (x *OplogEntry) Reset() {
+	*x = OplogEntry{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_api_resources_oplog_v1_oplog_entry_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OplogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OplogEntry) ProtoMessage() {}
+
+func (x *OplogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_api_resources_oplog_v1_oplog_entry_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OplogEntry.ProtoReflect.Descriptor instead.
+func (*OplogEntry) Descriptor() ([]byte, []int) {
+	return file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *OplogEntry) GetId() uint64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OplogEntry) GetScopeId() string {
+	if x != nil {
+		return x.ScopeId
+	}
+	return ""
+}
+
+func (x *OplogEntry) GetAggregateName() string {
+	if x != nil {
+		return x.AggregateName
+	}
+	return ""
+}
+
+func (x *OplogEntry) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *OplogEntry) GetCreatedTime() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedTime
+	}
+	return nil
+}
+
+func (x *OplogEntry) GetMetadata() *_struct.Struct {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *OplogEntry) GetMessages() []*OplogMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+func (x *OplogEntry) GetDataError() string {
+	if x != nil {
+		return x.DataError
+	}
+	return ""
+}
+
+// OplogMessage is a change recorded by an oplog entry.
+type OplogMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Output only. The type of the changed value, usually its table.
+	TypeName string `protobuf:"bytes,10,opt,name=type_name,proto3" json:"type_name,omitempty"`
+	// Output only. The operation: create, update or delete.
+	OpType string `protobuf:"bytes,20,opt,name=op_type,proto3" json:"op_type,omitempty"`
+	// Output only. The fields set by an update.
+	FieldMaskPaths []string `protobuf:"bytes,30,rep,name=field_mask_paths,proto3" json:"field_mask_paths,omitempty"`
+	// Output only. The fields set to null by an update.
+	SetToNullPaths []string `protobuf:"bytes,40,rep,name=set_to_null_paths,proto3" json:"set_to_null_paths,omitempty"`
+	// Output only. The value written. Fields holding secrets are omitted.
+	Value *_struct.Struct `protobuf:"bytes,50,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *OplogMessage) Reset() {
+	*x = OplogMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_controller_api_resources_oplog_v1_oplog_entry_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OplogMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OplogMessage) ProtoMessage() {}
+
+func (x *OplogMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_controller_api_resources_oplog_v1_oplog_entry_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OplogMessage.ProtoReflect.Descriptor instead.
+func (*OplogMessage) Descriptor() ([]byte, []int) {
+	return file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OplogMessage) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *OplogMessage) GetOpType() string {
+	if x != nil {
+		return x.OpType
+	}
+	return ""
+}
+
+func (x *OplogMessage) GetFieldMaskPaths() []string {
+	if x != nil {
+		return x.FieldMaskPaths
+	}
+	return nil
+}
+
+func (x *OplogMessage) GetSetToNullPaths() []string {
+	if x != nil {
+		return x.SetToNullPaths
+	}
+	return nil
+}
+
+func (x *OplogMessage) GetValue() *_struct.Struct {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+var File_controller_api_resources_oplog_v1_oplog_entry_proto protoreflect.FileDescriptor
+
+var file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDesc = []byte{
+	0x0a, 0x33, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f, 0x6f, 0x70, 0x6c, 0x6f, 0x67,
+	0x2f, 0x76, 0x31, 0x2f, 0x6f, 0x70, 0x6c, 0x6f, 0x67, 0x5f, 0x65, 0x6e, 0x74, 0x72, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x21, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e,
+	0x6f, 0x70, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdc, 0x02, 0x0a, 0x0a, 0x4f, 0x70, 0x6c, 0x6f,
+	0x67, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x63, 0x6f, 0x70, 0x65, 0x5f,
+	0x69, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x28, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72,
+	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x3e, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x32, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x12, 0x33, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61,
+	0x18, 0x3c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52,
+	0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x4b, 0x0a, 0x08, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x73, 0x18, 0x46, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65, 0x72, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x72, 0x65, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2e, 0x6f, 0x70, 0x6c, 0x6f, 0x67, 0x2e, 0x76, 0x31, 0x2e,
+	0x4f, 0x70, 0x6c, 0x6f, 0x67, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x08, 0x6d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x50, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x64, 0x61, 0x74, 0x61,
+	0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xcf, 0x01, 0x0a, 0x0c, 0x4f, 0x70, 0x6c, 0x6f, 0x67,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x79, 0x70, 0x65,
+	0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6f, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x70, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x2a, 0x0a, 0x10, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x5f, 0x70, 0x61,
+	0x74, 0x68, 0x73, 0x18, 0x1e, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x66, 0x69, 0x65, 0x6c, 0x64,
+	0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x73,
+	0x65, 0x74, 0x5f, 0x74, 0x6f, 0x5f, 0x6e, 0x75, 0x6c, 0x6c, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73,
+	0x18, 0x28, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x73, 0x65, 0x74, 0x5f, 0x74, 0x6f, 0x5f, 0x6e,
+	0x75, 0x6c, 0x6c, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x32, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63,
+	0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x51, 0x5a, 0x4f, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x68, 0x61, 0x73, 0x68, 0x69, 0x63, 0x6f, 0x72, 0x70,
+	0x2f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x6c, 0x65,
+	0x72, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x2f,
+	0x6f, 0x70, 0x6c, 0x6f, 0x67, 0x3b, 0x6f, 0x70, 0x6c, 0x6f, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDescOnce sync.Once
+	file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDescData = file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDesc
+)
+
+func file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDescGZIP() []byte {
+	file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDescOnce.Do(func() {
+		file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDescData = protoimpl.X.CompressGZIP(file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDescData)
+	})
+	return file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDescData
+}
+
+var file_controller_api_resources_oplog_v1_oplog_entry_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_controller_api_resources_oplog_v1_oplog_entry_proto_goTypes = []interface{}{
+	(*OplogEntry)(nil),          // 0: controller.api.resources.oplog.v1.OplogEntry
+	(*OplogMessage)(nil),        // 1: controller.api.resources.oplog.v1.OplogMessage
+	(*timestamp.Timestamp)(nil), // 2: google.protobuf.Timestamp
+	(*_struct.Struct)(nil),      // 3: google.protobuf.Struct
+}
+var file_controller_api_resources_oplog_v1_oplog_entry_proto_depIdxs = []int32{
+	2, // 0: controller.api.resources.oplog.v1.OplogEntry.created_time:type_name -> google.protobuf.Timestamp
+	3, // 1: controller.api.resources.oplog.v1.OplogEntry.metadata:type_name -> google.protobuf.Struct
+	1, // 2: controller.api.resources.oplog.v1.OplogEntry.messages:type_name -> controller.api.resources.oplog.v1.OplogMessage
+	3, // 3: controller.api.resources.oplog.v1.OplogMessage.value:type_name -> google.protobuf.Struct
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_controller_api_resources_oplog_v1_oplog_entry_proto_init() }
+func file_controller_api_resources_oplog_v1_oplog_entry_proto_init() {
+	if File_controller_api_resources_oplog_v1_oplog_entry_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_controller_api_resources_oplog_v1_oplog_entry_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OplogEntry); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_controller_api_resources_oplog_v1_oplog_entry_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OplogMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_controller_api_resources_oplog_v1_oplog_entry_proto_goTypes,
+		DependencyIndexes: file_controller_api_resources_oplog_v1_oplog_entry_proto_depIdxs,
+		MessageInfos:      file_controller_api_resources_oplog_v1_oplog_entry_proto_msgTypes,
+	}.Build()
+	File_controller_api_resources_oplog_v1_oplog_entry_proto = out.File
+	file_controller_api_resources_oplog_v1_oplog_entry_proto_rawDesc = nil
+	file_controller_api_resources_oplog_v1_oplog_entry_proto_goTypes = nil
+	file_controller_api_resources_oplog_v1_oplog_entry_proto_depIdxs = nil
+}
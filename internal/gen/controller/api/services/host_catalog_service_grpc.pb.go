@@ -43,6 +43,20 @@ type HostCatalogServiceClient interface {
 	// sets from Boundary. If the provided Host Catalog IDs is malformed or not
 	// provided DeleteHostCatalog returns an error.
 	DeleteHostCatalog(ctx context.Context, in *DeleteHostCatalogRequest, opts ...grpc.CallOption) (*DeleteHostCatalogResponse, error)
+	// ImportHosts creates or updates Hosts in a static Host Catalog and adds
+	// them to Host Sets in the same Host Catalog, all in a single transaction.
+	// Hosts are matched with existing Hosts in the Host Catalog by name, so
+	// each provided Host must have a name which is unique within the request.
+	// If dry_run is set, the changes are calculated and returned but not
+	// applied. An error is returned if the Host Catalog ID is missing,
+	// malformed or references a non-existing resource, or if any of the
+	// provided Hosts are invalid.
+	ImportHosts(ctx context.Context, in *ImportHostsRequest, opts ...grpc.CallOption) (*ImportHostsResponse, error)
+	// ExportHosts returns every Host in a static Host Catalog along with the
+	// IDs of the Host Sets each Host is a member of, in a form which can be
+	// passed to ImportHosts. An error is returned if the Host Catalog ID is
+	// missing, malformed or references a non-existing resource.
+	ExportHosts(ctx context.Context, in *ExportHostsRequest, opts ...grpc.CallOption) (*ExportHostsResponse, error)
 }
 
 type hostCatalogServiceClient struct {
@@ -98,6 +112,24 @@ func (c *hostCatalogServiceClient) DeleteHostCatalog(ctx context.Context, in *De
 	return out, nil
 }
 
+func (c *hostCatalogServiceClient) ImportHosts(ctx context.Context, in *ImportHostsRequest, opts ...grpc.CallOption) (*ImportHostsResponse, error) {
+	out := new(ImportHostsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostCatalogService/ImportHosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hostCatalogServiceClient) ExportHosts(ctx context.Context, in *ExportHostsRequest, opts ...grpc.CallOption) (*ExportHostsResponse, error) {
+	out := new(ExportHostsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.HostCatalogService/ExportHosts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HostCatalogServiceServer is the server API for HostCatalogService service.
 // All implementations must embed UnimplementedHostCatalogServiceServer
 // for forward compatibility
@@ -128,6 +160,20 @@ type HostCatalogServiceServer interface {
 	// sets from Boundary. If the provided Host Catalog IDs is malformed or not
 	// provided DeleteHostCatalog returns an error.
 	DeleteHostCatalog(context.Context, *DeleteHostCatalogRequest) (*DeleteHostCatalogResponse, error)
+	// ImportHosts creates or updates Hosts in a static Host Catalog and adds
+	// them to Host Sets in the same Host Catalog, all in a single transaction.
+	// Hosts are matched with existing Hosts in the Host Catalog by name, so
+	// each provided Host must have a name which is unique within the request.
+	// If dry_run is set, the changes are calculated and returned but not
+	// applied. An error is returned if the Host Catalog ID is missing,
+	// malformed or references a non-existing resource, or if any of the
+	// provided Hosts are invalid.
+	ImportHosts(context.Context, *ImportHostsRequest) (*ImportHostsResponse, error)
+	// ExportHosts returns every Host in a static Host Catalog along with the
+	// IDs of the Host Sets each Host is a member of, in a form which can be
+	// passed to ImportHosts. An error is returned if the Host Catalog ID is
+	// missing, malformed or references a non-existing resource.
+	ExportHosts(context.Context, *ExportHostsRequest) (*ExportHostsResponse, error)
 	mustEmbedUnimplementedHostCatalogServiceServer()
 }
 
@@ -150,6 +196,12 @@ func (UnimplementedHostCatalogServiceServer) UpdateHostCatalog(context.Context,
 func (UnimplementedHostCatalogServiceServer) DeleteHostCatalog(context.Context, *DeleteHostCatalogRequest) (*DeleteHostCatalogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteHostCatalog not implemented")
 }
+func (UnimplementedHostCatalogServiceServer) ImportHosts(context.Context, *ImportHostsRequest) (*ImportHostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportHosts not implemented")
+}
+func (UnimplementedHostCatalogServiceServer) ExportHosts(context.Context, *ExportHostsRequest) (*ExportHostsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportHosts not implemented")
+}
 func (UnimplementedHostCatalogServiceServer) mustEmbedUnimplementedHostCatalogServiceServer() {}
 
 // UnsafeHostCatalogServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -253,6 +305,42 @@ func _HostCatalogService_DeleteHostCatalog_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HostCatalogService_ImportHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostCatalogServiceServer).ImportHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostCatalogService/ImportHosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostCatalogServiceServer).ImportHosts(ctx, req.(*ImportHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HostCatalogService_ExportHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HostCatalogServiceServer).ExportHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.HostCatalogService/ExportHosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HostCatalogServiceServer).ExportHosts(ctx, req.(*ExportHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _HostCatalogService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.HostCatalogService",
 	HandlerType: (*HostCatalogServiceServer)(nil),
@@ -277,6 +365,14 @@ var _HostCatalogService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteHostCatalog",
 			Handler:    _HostCatalogService_DeleteHostCatalog_Handler,
 		},
+		{
+			MethodName: "ImportHosts",
+			Handler:    _HostCatalogService_ImportHosts_Handler,
+		},
+		{
+			MethodName: "ExportHosts",
+			Handler:    _HostCatalogService_ExportHosts_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/host_catalog_service.proto",
@@ -43,6 +43,42 @@ type ScopeServiceClient interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(ctx context.Context, in *DeleteScopeRequest, opts ...grpc.CallOption) (*DeleteScopeResponse, error)
+	// RotateScopeKeys creates new versions of the root key and the data
+	// encryption keys of a Scope, which are used to encrypt from then on, and
+	// queues a job to re-encrypt the existing data of the Scope with them. The
+	// queued job is returned. If the provided Scope ID is missing, malformed
+	// or references a non existing resource an error is returned.
+	RotateScopeKeys(ctx context.Context, in *RotateScopeKeysRequest, opts ...grpc.CallOption) (*RotateScopeKeysResponse, error)
+	// GetScopeRewrapJob returns the progress of the job re-encrypting the data
+	// of a Scope after its keys were last rotated. An error is returned if the
+	// keys of the Scope were never rotated, or if the provided Scope ID is
+	// missing, malformed or references a non existing resource.
+	GetScopeRewrapJob(ctx context.Context, in *GetScopeRewrapJobRequest, opts ...grpc.CallOption) (*GetScopeRewrapJobResponse, error)
+	// ListScopeKeyVersions returns the versions of the keys of a Scope, newest
+	// first, with the number of values which still depend on each of them. If
+	// a purpose is provided only the versions of the key for that purpose are
+	// returned. An error is returned if the provided Scope ID is missing,
+	// malformed or references a non existing resource.
+	ListScopeKeyVersions(ctx context.Context, in *ListScopeKeyVersionsRequest, opts ...grpc.CallOption) (*ListScopeKeyVersionsResponse, error)
+	// DestroyScopeKeyVersion destroys a version of one of the keys of a Scope.
+	// The newest version of a key can't be destroyed, nor a version which
+	// values still depend on. If the provided Scope ID or key version ID is
+	// missing, malformed or references a non existing resource an error is
+	// returned.
+	DestroyScopeKeyVersion(ctx context.Context, in *DestroyScopeKeyVersionRequest, opts ...grpc.CallOption) (*DestroyScopeKeyVersionResponse, error)
+	// ReadScopeOplog returns the decrypted entries of the operation log of a
+	// Scope, oldest first, optionally only those matching the provided
+	// aggregate name, resource type, resource ID, operation type and time
+	// range. An error is returned if the provided Scope ID is missing,
+	// malformed or references a non existing resource.
+	ReadScopeOplog(ctx context.Context, in *ReadScopeOplogRequest, opts ...grpc.CallOption) (*ReadScopeOplogResponse, error)
+	// ReportScopeSessions returns the number of sessions, connections, session
+	// durations and bytes transferred of the users, targets or hosts of a
+	// Scope, per day, week or month in which the sessions were started. Only
+	// the sessions of targets of the Scope, and of its descendants with
+	// recursive, are reported. An error is returned if the provided Scope ID is
+	// missing, malformed or references a non existing resource.
+	ReportScopeSessions(ctx context.Context, in *ReportScopeSessionsRequest, opts ...grpc.CallOption) (*ReportScopeSessionsResponse, error)
 }
 
 type scopeServiceClient struct {
@@ -98,6 +134,60 @@ func (c *scopeServiceClient) DeleteScope(ctx context.Context, in *DeleteScopeReq
 	return out, nil
 }
 
+func (c *scopeServiceClient) RotateScopeKeys(ctx context.Context, in *RotateScopeKeysRequest, opts ...grpc.CallOption) (*RotateScopeKeysResponse, error) {
+	out := new(RotateScopeKeysResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/RotateScopeKeys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) GetScopeRewrapJob(ctx context.Context, in *GetScopeRewrapJobRequest, opts ...grpc.CallOption) (*GetScopeRewrapJobResponse, error) {
+	out := new(GetScopeRewrapJobResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/GetScopeRewrapJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) ListScopeKeyVersions(ctx context.Context, in *ListScopeKeyVersionsRequest, opts ...grpc.CallOption) (*ListScopeKeyVersionsResponse, error) {
+	out := new(ListScopeKeyVersionsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ListScopeKeyVersions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) DestroyScopeKeyVersion(ctx context.Context, in *DestroyScopeKeyVersionRequest, opts ...grpc.CallOption) (*DestroyScopeKeyVersionResponse, error) {
+	out := new(DestroyScopeKeyVersionResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/DestroyScopeKeyVersion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) ReadScopeOplog(ctx context.Context, in *ReadScopeOplogRequest, opts ...grpc.CallOption) (*ReadScopeOplogResponse, error) {
+	out := new(ReadScopeOplogResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ReadScopeOplog", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *scopeServiceClient) ReportScopeSessions(ctx context.Context, in *ReportScopeSessionsRequest, opts ...grpc.CallOption) (*ReportScopeSessionsResponse, error) {
+	out := new(ReportScopeSessionsResponse)
+	err := c.cc.Invoke(ctx, "/controller.api.services.v1.ScopeService/ReportScopeSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ScopeServiceServer is the server API for ScopeService service.
 // All implementations must embed UnimplementedScopeServiceServer
 // for forward compatibility
@@ -128,6 +218,42 @@ type ScopeServiceServer interface {
 	// DeleteScope remotes a Scope and all child resources from Boundary. If the
 	// provided Scope IDs are malformed or not provided an error is returned.
 	DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error)
+	// RotateScopeKeys creates new versions of the root key and the data
+	// encryption keys of a Scope, which are used to encrypt from then on, and
+	// queues a job to re-encrypt the existing data of the Scope with them. The
+	// queued job is returned. If the provided Scope ID is missing, malformed
+	// or references a non existing resource an error is returned.
+	RotateScopeKeys(context.Context, *RotateScopeKeysRequest) (*RotateScopeKeysResponse, error)
+	// GetScopeRewrapJob returns the progress of the job re-encrypting the data
+	// of a Scope after its keys were last rotated. An error is returned if the
+	// keys of the Scope were never rotated, or if the provided Scope ID is
+	// missing, malformed or references a non existing resource.
+	GetScopeRewrapJob(context.Context, *GetScopeRewrapJobRequest) (*GetScopeRewrapJobResponse, error)
+	// ListScopeKeyVersions returns the versions of the keys of a Scope, newest
+	// first, with the number of values which still depend on each of them. If
+	// a purpose is provided only the versions of the key for that purpose are
+	// returned. An error is returned if the provided Scope ID is missing,
+	// malformed or references a non existing resource.
+	ListScopeKeyVersions(context.Context, *ListScopeKeyVersionsRequest) (*ListScopeKeyVersionsResponse, error)
+	// DestroyScopeKeyVersion destroys a version of one of the keys of a Scope.
+	// The newest version of a key can't be destroyed, nor a version which
+	// values still depend on. If the provided Scope ID or key version ID is
+	// missing, malformed or references a non existing resource an error is
+	// returned.
+	DestroyScopeKeyVersion(context.Context, *DestroyScopeKeyVersionRequest) (*DestroyScopeKeyVersionResponse, error)
+	// ReadScopeOplog returns the decrypted entries of the operation log of a
+	// Scope, oldest first, optionally only those matching the provided
+	// aggregate name, resource type, resource ID, operation type and time
+	// range. An error is returned if the provided Scope ID is missing,
+	// malformed or references a non existing resource.
+	ReadScopeOplog(context.Context, *ReadScopeOplogRequest) (*ReadScopeOplogResponse, error)
+	// ReportScopeSessions returns the number of sessions, connections, session
+	// durations and bytes transferred of the users, targets or hosts of a
+	// Scope, per day, week or month in which the sessions were started. Only
+	// the sessions of targets of the Scope, and of its descendants with
+	// recursive, are reported. An error is returned if the provided Scope ID is
+	// missing, malformed or references a non existing resource.
+	ReportScopeSessions(context.Context, *ReportScopeSessionsRequest) (*ReportScopeSessionsResponse, error)
 	mustEmbedUnimplementedScopeServiceServer()
 }
 
@@ -150,6 +276,24 @@ func (UnimplementedScopeServiceServer) UpdateScope(context.Context, *UpdateScope
 func (UnimplementedScopeServiceServer) DeleteScope(context.Context, *DeleteScopeRequest) (*DeleteScopeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteScope not implemented")
 }
+func (UnimplementedScopeServiceServer) RotateScopeKeys(context.Context, *RotateScopeKeysRequest) (*RotateScopeKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RotateScopeKeys not implemented")
+}
+func (UnimplementedScopeServiceServer) GetScopeRewrapJob(context.Context, *GetScopeRewrapJobRequest) (*GetScopeRewrapJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScopeRewrapJob not implemented")
+}
+func (UnimplementedScopeServiceServer) ListScopeKeyVersions(context.Context, *ListScopeKeyVersionsRequest) (*ListScopeKeyVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScopeKeyVersions not implemented")
+}
+func (UnimplementedScopeServiceServer) DestroyScopeKeyVersion(context.Context, *DestroyScopeKeyVersionRequest) (*DestroyScopeKeyVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DestroyScopeKeyVersion not implemented")
+}
+func (UnimplementedScopeServiceServer) ReadScopeOplog(context.Context, *ReadScopeOplogRequest) (*ReadScopeOplogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadScopeOplog not implemented")
+}
+func (UnimplementedScopeServiceServer) ReportScopeSessions(context.Context, *ReportScopeSessionsRequest) (*ReportScopeSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportScopeSessions not implemented")
+}
 func (UnimplementedScopeServiceServer) mustEmbedUnimplementedScopeServiceServer() {}
 
 // UnsafeScopeServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -253,6 +397,114 @@ func _ScopeService_DeleteScope_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ScopeService_RotateScopeKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RotateScopeKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).RotateScopeKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/RotateScopeKeys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).RotateScopeKeys(ctx, req.(*RotateScopeKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_GetScopeRewrapJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScopeRewrapJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).GetScopeRewrapJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/GetScopeRewrapJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).GetScopeRewrapJob(ctx, req.(*GetScopeRewrapJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_ListScopeKeyVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScopeKeyVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ListScopeKeyVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ListScopeKeyVersions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ListScopeKeyVersions(ctx, req.(*ListScopeKeyVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_DestroyScopeKeyVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DestroyScopeKeyVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).DestroyScopeKeyVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/DestroyScopeKeyVersion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).DestroyScopeKeyVersion(ctx, req.(*DestroyScopeKeyVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_ReadScopeOplog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadScopeOplogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ReadScopeOplog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ReadScopeOplog",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ReadScopeOplog(ctx, req.(*ReadScopeOplogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ScopeService_ReportScopeSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportScopeSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScopeServiceServer).ReportScopeSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/controller.api.services.v1.ScopeService/ReportScopeSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScopeServiceServer).ReportScopeSessions(ctx, req.(*ReportScopeSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ScopeService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "controller.api.services.v1.ScopeService",
 	HandlerType: (*ScopeServiceServer)(nil),
@@ -277,6 +529,30 @@ var _ScopeService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteScope",
 			Handler:    _ScopeService_DeleteScope_Handler,
 		},
+		{
+			MethodName: "RotateScopeKeys",
+			Handler:    _ScopeService_RotateScopeKeys_Handler,
+		},
+		{
+			MethodName: "GetScopeRewrapJob",
+			Handler:    _ScopeService_GetScopeRewrapJob_Handler,
+		},
+		{
+			MethodName: "ListScopeKeyVersions",
+			Handler:    _ScopeService_ListScopeKeyVersions_Handler,
+		},
+		{
+			MethodName: "DestroyScopeKeyVersion",
+			Handler:    _ScopeService_DestroyScopeKeyVersion_Handler,
+		},
+		{
+			MethodName: "ReadScopeOplog",
+			Handler:    _ScopeService_ReadScopeOplog_Handler,
+		},
+		{
+			MethodName: "ReportScopeSessions",
+			Handler:    _ScopeService_ReportScopeSessions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controller/api/services/v1/scope_service.proto",
@@ -6,4 +6,12 @@ var (
 	// ErrInvalidAddress results from attempting to perform an operation
 	// that sets an address on a host to an invalid value.
 	ErrInvalidAddress = errors.New("invalid address")
+
+	// ErrInvalidLabel results from attempting to perform an operation
+	// that sets a label on a host to an invalid key or value.
+	ErrInvalidLabel = errors.New("invalid label")
+
+	// ErrInvalidSelector results from attempting to perform an operation
+	// that sets the selector of a host set to an invalid value.
+	ErrInvalidSelector = errors.New("invalid selector")
 )
@@ -17,12 +17,17 @@ const (
 // A Host contains a static address.
 type Host struct {
 	*store.Host
+
+	// Labels are stored in the static_host_label table and are loaded
+	// separately from the host.
+	Labels map[string]string `gorm:"-"`
+
 	tableName string `gorm:"-"`
 }
 
 // NewHost creates a new in memory Host for address assigned to catalogId.
-// Name and description are the only valid options. All other options are
-// ignored.
+// Name, description, address and labels are the only valid options. All
+// other options are ignored.
 func NewHost(catalogId string, opt ...Option) (*Host, error) {
 	if catalogId == "" {
 		return nil, fmt.Errorf("new: static host: no catalog id: %w", errors.ErrInvalidParameter)
@@ -36,6 +41,7 @@ func NewHost(catalogId string, opt ...Option) (*Host, error) {
 			Name:        opts.withName,
 			Description: opts.withDescription,
 		},
+		Labels: opts.withLabels,
 	}
 	return host, nil
 }
@@ -62,8 +68,16 @@ func allocHost() *Host {
 
 func (h *Host) clone() *Host {
 	cp := proto.Clone(h.Host)
+	var labels map[string]string
+	if h.Labels != nil {
+		labels = make(map[string]string, len(h.Labels))
+		for k, v := range h.Labels {
+			labels[k] = v
+		}
+	}
 	return &Host{
-		Host: cp.(*store.Host),
+		Host:   cp.(*store.Host),
+		Labels: labels,
 	}
 }
 
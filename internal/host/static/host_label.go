@@ -0,0 +1,79 @@
+package static
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/host/static/store"
+)
+
+var (
+	labelKeyRegexp   = regexp.MustCompile(`^[A-Za-z0-9_./-]{1,63}$`)
+	labelValueRegexp = regexp.MustCompile(`^[A-Za-z0-9_./-]{0,255}$`)
+)
+
+// ValidLabelKey reports whether k can be used as the key of a host label.
+// A key must be between 1 and 63 characters long and may only contain
+// letters, digits, '-', '_', '.' and '/'.
+func ValidLabelKey(k string) bool {
+	return labelKeyRegexp.MatchString(k)
+}
+
+// ValidLabelValue reports whether v can be used as the value of a host
+// label. A value may be empty, must be no more than 255 characters long
+// and may only contain letters, digits, '-', '_', '.' and '/'.
+func ValidLabelValue(v string) bool {
+	return labelValueRegexp.MatchString(v)
+}
+
+func validateLabels(labels map[string]string) error {
+	for k, v := range labels {
+		if !ValidLabelKey(k) {
+			return fmt.Errorf("key %q: %w", k, ErrInvalidLabel)
+		}
+		if !ValidLabelValue(v) {
+			return fmt.Errorf("value %q for key %q: %w", v, k, ErrInvalidLabel)
+		}
+	}
+	return nil
+}
+
+// A HostLabel is a key/value pair attached to a host. Labels are used to
+// select the members of host sets with a selector.
+type HostLabel struct {
+	*store.HostLabel
+	tableName string `gorm:"-"`
+}
+
+// NewHostLabel creates a new in memory HostLabel for hostId.
+func NewHostLabel(hostId, key, value string, opt ...Option) (*HostLabel, error) {
+	if hostId == "" {
+		return nil, fmt.Errorf("new: static host label: no host id: %w", errors.ErrInvalidParameter)
+	}
+	if key == "" {
+		return nil, fmt.Errorf("new: static host label: no key: %w", errors.ErrInvalidParameter)
+	}
+	label := &HostLabel{
+		HostLabel: &store.HostLabel{
+			HostId: hostId,
+			Key:    key,
+			Value:  value,
+		},
+	}
+	return label, nil
+}
+
+// TableName returns the table name for the host label.
+func (l *HostLabel) TableName() string {
+	if l.tableName != "" {
+		return l.tableName
+	}
+	return "static_host_label"
+}
+
+// SetTableName sets the table name. If the caller attempts to
+// set the name to "" the name will be reset to the default name.
+func (l *HostLabel) SetTableName(n string) {
+	l.tableName = n
+}
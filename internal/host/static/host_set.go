@@ -16,8 +16,8 @@ type HostSet struct {
 }
 
 // NewHostSet creates a new in memory HostSet assigned to catalogId.
-// Name and description are the only valid options. All other options are
-// ignored.
+// Name, description and selector are the only valid options. All other
+// options are ignored.
 func NewHostSet(catalogId string, opt ...Option) (*HostSet, error) {
 	if catalogId == "" {
 		return nil, fmt.Errorf("new: static host set: no catalog id: %w", errors.ErrInvalidParameter)
@@ -29,6 +29,7 @@ func NewHostSet(catalogId string, opt ...Option) (*HostSet, error) {
 			CatalogId:   catalogId,
 			Name:        opts.withName,
 			Description: opts.withDescription,
+			Selector:    opts.withSelector,
 		},
 	}
 	return set, nil
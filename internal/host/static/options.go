@@ -19,6 +19,10 @@ type options struct {
 	withLimit       int
 	withAddress     string
 	withPublicId    string
+	withDryRun      bool
+	withLabels      map[string]string
+	withSelector    string
+	withAfterId     string
 }
 
 func getDefaultOptions() options {
@@ -65,3 +69,34 @@ func WithPublicId(id string) Option {
 		o.withPublicId = id
 	}
 }
+
+// WithDryRun provides an option to calculate the changes an operation
+// would make without writing them to the repository.
+func WithDryRun(enable bool) Option {
+	return func(o *options) {
+		o.withDryRun = enable
+	}
+}
+
+// WithLabels provides optional labels.
+func WithLabels(labels map[string]string) Option {
+	return func(o *options) {
+		o.withLabels = labels
+	}
+}
+
+// WithSelector provides an optional label selector.
+func WithSelector(selector string) Option {
+	return func(o *options) {
+		o.withSelector = selector
+	}
+}
+
+// WithAfterId provides an option to list only the items whose id sorts after
+// id. Lists are ordered by id so it can be used to list items a page at a
+// time.
+func WithAfterId(id string) Option {
+	return func(o *options) {
+		o.withAfterId = id
+	}
+}
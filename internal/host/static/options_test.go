@@ -38,4 +38,28 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withPublicId = "test"
 		assert.Equal(t, opts, testOpts)
 	})
+	t.Run("WithDryRun", func(t *testing.T) {
+		opts := getOpts(WithDryRun(true))
+		testOpts := getDefaultOptions()
+		testOpts.withDryRun = true
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithLabels", func(t *testing.T) {
+		opts := getOpts(WithLabels(map[string]string{"env": "prod"}))
+		testOpts := getDefaultOptions()
+		testOpts.withLabels = map[string]string{"env": "prod"}
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithSelector", func(t *testing.T) {
+		opts := getOpts(WithSelector("env=prod"))
+		testOpts := getDefaultOptions()
+		testOpts.withSelector = "env=prod"
+		assert.Equal(t, opts, testOpts)
+	})
+	t.Run("WithAfterId", func(t *testing.T) {
+		opts := getOpts(WithAfterId("hcst_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withAfterId = "hcst_1234567890"
+		assert.Equal(t, opts, testOpts)
+	})
 }
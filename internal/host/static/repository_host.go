@@ -20,7 +20,8 @@ import (
 // h must contain a valid Address.
 //
 // Both h.Name and h.Description are optional. If h.Name is set, it must be
-// unique within h.CatalogId.
+// unique within h.CatalogId. h.Labels are optional. If set, each key and
+// value must be valid.
 func (r *Repository) CreateHost(ctx context.Context, scopeId string, h *Host, opt ...Option) (*Host, error) {
 	if h == nil {
 		return nil, fmt.Errorf("create: static host: %w", errors.ErrInvalidParameter)
@@ -41,6 +42,9 @@ func (r *Repository) CreateHost(ctx context.Context, scopeId string, h *Host, op
 	if len(h.Address) < MinHostAddressLength || len(h.Address) > MaxHostAddressLength {
 		return nil, fmt.Errorf("create: static host: bad address: %w", ErrInvalidAddress)
 	}
+	if err := validateLabels(h.Labels); err != nil {
+		return nil, fmt.Errorf("create: static host: bad labels: %w", err)
+	}
 	h = h.clone()
 
 	opts := getOpts(opt...)
@@ -67,7 +71,17 @@ func (r *Repository) CreateHost(ctx context.Context, scopeId string, h *Host, op
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
 		func(_ db.Reader, w db.Writer) error {
 			newHost = h.clone()
-			return w.Create(ctx, newHost, db.WithOplog(oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_CREATE)))
+			if err := w.Create(ctx, newHost, db.WithOplog(oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+				return err
+			}
+			if len(h.Labels) == 0 {
+				return nil
+			}
+			labels, err := newLabels(newHost.PublicId, h.Labels)
+			if err != nil {
+				return err
+			}
+			return w.CreateItems(ctx, labels, db.WithOplog(oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_CREATE)))
 		},
 	)
 
@@ -90,10 +104,11 @@ func (r *Repository) CreateHost(ctx context.Context, scopeId string, h *Host, op
 // containing the updated values and a count of the number of records
 // updated. h is not changed.
 //
-// h must contain a valid PublicId. Only h.Name, h.Description, and
-// h.Address can be updated. If h.Name is set to a non-empty string, it
-// must be unique within h.CatalogId. If h.Address is set, it must contain
-// a valid address.
+// h must contain a valid PublicId. Only h.Name, h.Description,
+// h.Address and h.Labels can be updated. If h.Name is set to a non-empty
+// string, it must be unique within h.CatalogId. If h.Address is set, it
+// must contain a valid address. If Labels is included in fieldMaskPaths,
+// the labels of the host are replaced with h.Labels.
 //
 // An attribute of h will be set to NULL in the database if the attribute
 // in h is the zero value and it is included in fieldMaskPaths.
@@ -114,6 +129,7 @@ func (r *Repository) UpdateHost(ctx context.Context, scopeId string, h *Host, ve
 		return nil, db.NoRowsAffected, fmt.Errorf("update: static host: no scopeId: %w", errors.ErrInvalidParameter)
 	}
 
+	var updateLabels bool
 	for _, f := range fieldMaskPaths {
 		switch {
 		case strings.EqualFold("Name", f):
@@ -123,6 +139,11 @@ func (r *Repository) UpdateHost(ctx context.Context, scopeId string, h *Host, ve
 			if len(h.Address) < MinHostAddressLength || len(h.Address) > MaxHostAddressLength {
 				return nil, db.NoRowsAffected, fmt.Errorf("update: static host: bad address: %w", ErrInvalidAddress)
 			}
+		case strings.EqualFold("Labels", f):
+			if err := validateLabels(h.Labels); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update: static host: bad labels: %w", err)
+			}
+			updateLabels = true
 		default:
 			return nil, db.NoRowsAffected, fmt.Errorf("update: static host: field: %s: %w", f, errors.ErrInvalidFieldMask)
 		}
@@ -137,7 +158,7 @@ func (r *Repository) UpdateHost(ctx context.Context, scopeId string, h *Host, ve
 		fieldMaskPaths,
 		nil,
 	)
-	if len(dbMask) == 0 && len(nullFields) == 0 {
+	if len(dbMask) == 0 && len(nullFields) == 0 && !updateLabels {
 		return nil, db.NoRowsAffected, fmt.Errorf("update: static host: %w", errors.ErrEmptyFieldMask)
 	}
 
@@ -149,16 +170,31 @@ func (r *Repository) UpdateHost(ctx context.Context, scopeId string, h *Host, ve
 	var rowsUpdated int
 	var returnedHost *Host
 	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{},
-		func(_ db.Reader, w db.Writer) error {
+		func(reader db.Reader, w db.Writer) error {
 			returnedHost = h.clone()
+			updateMask := dbMask
+			if len(dbMask) == 0 && len(nullFields) == 0 {
+				// Only the labels are being updated, so just increment
+				// the version of the host.
+				returnedHost.Version = version + 1
+				updateMask = []string{"Version"}
+			}
 			var err error
-			rowsUpdated, err = w.Update(ctx, returnedHost, dbMask, nullFields,
+			rowsUpdated, err = w.Update(ctx, returnedHost, updateMask, nullFields,
 				db.WithOplog(oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_UPDATE)),
 				db.WithVersion(&version))
 			if err == nil && rowsUpdated > 1 {
 				return errors.ErrMultipleRecords
 			}
-			return err
+			if err != nil || rowsUpdated == 0 {
+				return err
+			}
+			if updateLabels {
+				if err := replaceLabels(ctx, reader, w, oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_UPDATE), h.PublicId, h.Labels); err != nil {
+					return err
+				}
+			}
+			return loadLabels(ctx, reader, returnedHost)
 		},
 	)
 
@@ -176,8 +212,9 @@ func (r *Repository) UpdateHost(ctx context.Context, scopeId string, h *Host, ve
 	return returnedHost, rowsUpdated, nil
 }
 
-// LookupHost will look up a host in the repository. If the host is not
-// found, it will return nil, nil. All options are ignored.
+// LookupHost will look up a host in the repository, along with its
+// labels. If the host is not found, it will return nil, nil. All options
+// are ignored.
 func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Option) (*Host, error) {
 	if publicId == "" {
 		return nil, fmt.Errorf("lookup: static host: missing public id %w", errors.ErrInvalidParameter)
@@ -190,11 +227,14 @@ func (r *Repository) LookupHost(ctx context.Context, publicId string, opt ...Opt
 		}
 		return nil, fmt.Errorf("lookup: static host: failed %w for %s", err, publicId)
 	}
+	if err := loadLabels(ctx, r.reader, h); err != nil {
+		return nil, fmt.Errorf("lookup: static host: failed %w for %s", err, publicId)
+	}
 	return h, nil
 }
 
-// ListHosts returns a slice of Hosts for the catalogId.
-// WithLimit is the only option supported.
+// ListHosts returns a slice of Hosts, along with their labels, for the
+// catalogId. WithLimit is the only option supported.
 func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Option) ([]*Host, error) {
 	if catalogId == "" {
 		return nil, fmt.Errorf("list: static host: missing catalog id: %w", errors.ErrInvalidParameter)
@@ -206,10 +246,17 @@ func (r *Repository) ListHosts(ctx context.Context, catalogId string, opt ...Opt
 		limit = opts.withLimit
 	}
 	var hosts []*Host
-	err := r.reader.SearchWhere(ctx, &hosts, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(limit))
+	where, args := "catalog_id = ?", []interface{}{catalogId}
+	if opts.withAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withAfterId)
+	}
+	err := r.reader.SearchWhere(ctx, &hosts, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
 	if err != nil {
 		return nil, fmt.Errorf("list: static host: %w", err)
 	}
+	if err := loadLabels(ctx, r.reader, hosts...); err != nil {
+		return nil, fmt.Errorf("list: static host: %w", err)
+	}
 	return hosts, nil
 }
 
@@ -0,0 +1,351 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/sdk/strutil"
+)
+
+// A BulkHost is the representation of a static host, together with its
+// host set memberships, used by ImportHosts and ExportHosts.
+type BulkHost struct {
+	// PublicId is set by ExportHosts and ignored by ImportHosts.
+	PublicId    string
+	Name        string
+	Description string
+	Address     string
+	HostSetIds  []string
+}
+
+func (h *BulkHost) clone() *BulkHost {
+	cp := *h
+	cp.HostSetIds = append([]string(nil), h.HostSetIds...)
+	return &cp
+}
+
+// ImportAction describes the change ImportHosts made, or would make in a
+// dry run, to a single host.
+type ImportAction string
+
+const (
+	ImportActionCreate    ImportAction = "create"
+	ImportActionUpdate    ImportAction = "update"
+	ImportActionUnchanged ImportAction = "unchanged"
+)
+
+// An ImportResult is the outcome of importing a single BulkHost.
+type ImportResult struct {
+	Action ImportAction
+	// Host is the host after the import. In a dry run, hosts which would
+	// be created do not have a PublicId.
+	Host *Host
+	// ChangedFields lists the host fields updated by the import.
+	ChangedFields []string
+	// AddedSetIds lists the host sets the host was added to by the import.
+	AddedSetIds []string
+}
+
+// ImportHosts creates or updates hosts in catalogId from hosts and adds
+// them to the listed host sets. All changes are applied in a single
+// transaction. It returns one ImportResult per element of hosts, in the
+// same order.
+//
+// Hosts are matched to existing hosts in the catalog by Name, so every
+// element of hosts must have a Name, and names must be unique within
+// hosts. Existing hosts which are not in hosts are left unchanged, as are
+// existing host set memberships. Every host set id must belong to
+// catalogId. The elements of hosts are not modified.
+//
+// WithDryRun is the only option supported. When it is set to true, the
+// changes are calculated and returned but nothing is written to the
+// repository.
+func (r *Repository) ImportHosts(ctx context.Context, scopeId string, catalogId string, hosts []*BulkHost, opt ...Option) ([]*ImportResult, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("import: static hosts: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	if catalogId == "" {
+		return nil, fmt.Errorf("import: static hosts: missing catalog id: %w", errors.ErrInvalidParameter)
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("import: static hosts: empty hosts: %w", errors.ErrInvalidParameter)
+	}
+	names := make(map[string]bool, len(hosts))
+	in := make([]*BulkHost, 0, len(hosts))
+	for i, bh := range hosts {
+		if bh == nil {
+			return nil, fmt.Errorf("import: static hosts: host %d: %w", i, errors.ErrInvalidParameter)
+		}
+		h := bh.clone()
+		in = append(in, h)
+		h.Name = strings.TrimSpace(h.Name)
+		if h.Name == "" {
+			return nil, fmt.Errorf("import: static hosts: host %d: missing name: %w", i, errors.ErrInvalidParameter)
+		}
+		if names[h.Name] {
+			return nil, fmt.Errorf("import: static hosts: host %d: name %s is not unique: %w", i, h.Name, errors.ErrNotUnique)
+		}
+		names[h.Name] = true
+		h.Address = strings.TrimSpace(h.Address)
+		if len(h.Address) < MinHostAddressLength || len(h.Address) > MaxHostAddressLength {
+			return nil, fmt.Errorf("import: static hosts: host %s: bad address: %w", h.Name, ErrInvalidAddress)
+		}
+	}
+	hosts = in
+
+	opts := getOpts(opt...)
+	if opts.withDryRun {
+		plan, err := r.planImport(ctx, r.reader, catalogId, hosts)
+		if err != nil {
+			return nil, fmt.Errorf("import: static hosts: %w", err)
+		}
+		return plan.results, nil
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("import: static hosts: unable to get oplog wrapper: %w", err)
+	}
+
+	var results []*ImportResult
+	_, err = r.writer.DoTx(ctx, db.StdRetryCnt, db.ExpBackoff{}, func(reader db.Reader, w db.Writer) error {
+		plan, err := r.planImport(ctx, reader, catalogId, hosts)
+		if err != nil {
+			return err
+		}
+		results = plan.results
+
+		for _, res := range results {
+			switch res.Action {
+			case ImportActionCreate:
+				id, err := newHostId()
+				if err != nil {
+					return err
+				}
+				h := res.Host.clone()
+				h.PublicId = id
+				if err := w.Create(ctx, h, db.WithOplog(oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_CREATE))); err != nil {
+					return fmt.Errorf("unable to create host %s: %w", h.Name, err)
+				}
+				res.Host = h
+			case ImportActionUpdate:
+				if len(res.ChangedFields) == 0 {
+					continue
+				}
+				h := res.Host.clone()
+				version := h.Version
+				dbMask, nullFields := dbcommon.BuildUpdatePaths(
+					map[string]interface{}{
+						"Description": h.Description,
+						"Address":     h.Address,
+					},
+					res.ChangedFields,
+					nil,
+				)
+				rowsUpdated, err := w.Update(ctx, h, dbMask, nullFields,
+					db.WithOplog(oplogWrapper, h.oplog(oplog.OpType_OP_TYPE_UPDATE)),
+					db.WithVersion(&version))
+				switch {
+				case err != nil:
+					return fmt.Errorf("unable to update host %s: %w", h.Name, err)
+				case rowsUpdated != 1:
+					return fmt.Errorf("unable to update host %s: %w", h.Name, errors.ErrMultipleRecords)
+				}
+				res.Host = h
+			}
+		}
+
+		// Add the new memberships one host set at a time so each host set
+		// version is only incremented once.
+		for _, set := range plan.sets {
+			var members []interface{}
+			for _, res := range results {
+				for _, setId := range res.AddedSetIds {
+					if setId != set.PublicId {
+						continue
+					}
+					m, err := NewHostSetMember(setId, res.Host.PublicId)
+					if err != nil {
+						return err
+					}
+					members = append(members, m)
+				}
+			}
+			if len(members) == 0 {
+				continue
+			}
+			msgs, err := createMembers(ctx, w, members)
+			if err != nil {
+				return err
+			}
+			s := newHostSetForMembers(set.PublicId, set.Version)
+			if err := updateVersion(ctx, w, oplogWrapper, s.oplog(oplog.OpType_OP_TYPE_CREATE), msgs, s, set.Version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, fmt.Errorf("import: static hosts: in catalog: %s: %w", catalogId, errors.ErrNotUnique)
+		}
+		if errors.IsCheckConstraintError(err) || errors.IsNotNullError(err) {
+			return nil, fmt.Errorf("import: static hosts: in catalog: %s: %w", catalogId, ErrInvalidAddress)
+		}
+		return nil, fmt.Errorf("import: static hosts: in catalog: %s: %w", catalogId, err)
+	}
+	return results, nil
+}
+
+type importPlan struct {
+	results []*ImportResult
+	// sets holds the host sets which are referenced by the import, sorted
+	// by public id.
+	sets []*HostSet
+}
+
+// planImport compares hosts with the current contents of catalogId and
+// returns the changes needed to apply hosts.
+func (r *Repository) planImport(ctx context.Context, reader db.Reader, catalogId string, hosts []*BulkHost) (*importPlan, error) {
+	var existingHosts []*Host
+	if err := reader.SearchWhere(ctx, &existingHosts, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(unlimited)); err != nil {
+		return nil, fmt.Errorf("unable to list hosts: %w", err)
+	}
+	hostsByName := make(map[string]*Host, len(existingHosts))
+	for _, h := range existingHosts {
+		if h.Name != "" {
+			hostsByName[h.Name] = h
+		}
+	}
+
+	var existingSets []*HostSet
+	if err := reader.SearchWhere(ctx, &existingSets, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(unlimited)); err != nil {
+		return nil, fmt.Errorf("unable to list host sets: %w", err)
+	}
+	setsById := make(map[string]*HostSet, len(existingSets))
+	for _, s := range existingSets {
+		setsById[s.PublicId] = s
+	}
+
+	members, err := catalogMembers(ctx, reader, catalogId)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &importPlan{}
+	referenced := make(map[string]bool)
+	for _, in := range hosts {
+		res := &ImportResult{}
+		existing, ok := hostsByName[in.Name]
+		switch {
+		case !ok:
+			h, err := NewHost(catalogId, WithName(in.Name), WithDescription(in.Description), WithAddress(in.Address))
+			if err != nil {
+				return nil, err
+			}
+			res.Action = ImportActionCreate
+			res.Host = h
+		default:
+			h := existing.clone()
+			if h.Address != in.Address {
+				h.Address = in.Address
+				res.ChangedFields = append(res.ChangedFields, "Address")
+			}
+			if h.Description != in.Description {
+				h.Description = in.Description
+				res.ChangedFields = append(res.ChangedFields, "Description")
+			}
+			res.Action = ImportActionUnchanged
+			if len(res.ChangedFields) > 0 {
+				res.Action = ImportActionUpdate
+			}
+			res.Host = h
+		}
+
+		for _, setId := range in.HostSetIds {
+			setId = strings.TrimSpace(setId)
+			if _, ok := setsById[setId]; !ok {
+				return nil, fmt.Errorf("host %s: host set %s not in catalog %s: %w", in.Name, setId, catalogId, errors.ErrInvalidParameter)
+			}
+			if res.Host.PublicId != "" && members[res.Host.PublicId][setId] {
+				continue
+			}
+			if strutil.StrListContains(res.AddedSetIds, setId) {
+				continue
+			}
+			res.AddedSetIds = append(res.AddedSetIds, setId)
+			referenced[setId] = true
+		}
+		if res.Action == ImportActionUnchanged && len(res.AddedSetIds) > 0 {
+			res.Action = ImportActionUpdate
+		}
+		plan.results = append(plan.results, res)
+	}
+
+	for id := range referenced {
+		plan.sets = append(plan.sets, setsById[id])
+	}
+	sort.Slice(plan.sets, func(i, j int) bool { return plan.sets[i].PublicId < plan.sets[j].PublicId })
+	return plan, nil
+}
+
+// ExportHosts returns every named host in catalogId along with the ids of
+// the host sets each host is a member of. The result can be passed to
+// ImportHosts. Hosts without a name cannot be matched by ImportHosts so
+// they are not included in the result. All options are ignored.
+func (r *Repository) ExportHosts(ctx context.Context, catalogId string, opt ...Option) ([]*BulkHost, error) {
+	if catalogId == "" {
+		return nil, fmt.Errorf("export: static hosts: missing catalog id: %w", errors.ErrInvalidParameter)
+	}
+	var hosts []*Host
+	if err := r.reader.SearchWhere(ctx, &hosts, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(unlimited), db.WithOrder("public_id")); err != nil {
+		return nil, fmt.Errorf("export: static hosts: %w", err)
+	}
+	members, err := catalogMembers(ctx, r.reader, catalogId)
+	if err != nil {
+		return nil, fmt.Errorf("export: static hosts: %w", err)
+	}
+
+	var out []*BulkHost
+	for _, h := range hosts {
+		if h.Name == "" {
+			continue
+		}
+		bh := &BulkHost{
+			PublicId:    h.PublicId,
+			Name:        h.Name,
+			Description: h.Description,
+			Address:     h.Address,
+		}
+		for setId := range members[h.PublicId] {
+			bh.HostSetIds = append(bh.HostSetIds, setId)
+		}
+		sort.Strings(bh.HostSetIds)
+		out = append(out, bh)
+	}
+	return out, nil
+}
+
+// catalogMembers returns the host set memberships in catalogId as a map of
+// host id to a set of host set ids.
+func catalogMembers(ctx context.Context, reader db.Reader, catalogId string) (map[string]map[string]bool, error) {
+	var members []*HostSetMember
+	if err := reader.SearchWhere(ctx, &members, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(unlimited)); err != nil {
+		return nil, fmt.Errorf("unable to list host set members: %w", err)
+	}
+	out := make(map[string]map[string]bool)
+	for _, m := range members {
+		if out[m.HostId] == nil {
+			out[m.HostId] = make(map[string]bool)
+		}
+		out[m.HostId][m.SetId] = true
+	}
+	return out, nil
+}
@@ -0,0 +1,178 @@
+package static
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ImportHosts_Parameters(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	otherCatalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	otherSet := TestSets(t, conn, otherCatalog.PublicId, 1)[0]
+
+	tests := []struct {
+		name      string
+		scopeId   string
+		catalogId string
+		hosts     []*BulkHost
+		wantIsErr error
+	}{
+		{
+			name:      "empty-scope-id",
+			catalogId: catalog.PublicId,
+			hosts:     []*BulkHost{{Name: "web1", Address: "10.0.0.1"}},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "empty-catalog-id",
+			scopeId:   prj.PublicId,
+			hosts:     []*BulkHost{{Name: "web1", Address: "10.0.0.1"}},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "no-hosts",
+			scopeId:   prj.PublicId,
+			catalogId: catalog.PublicId,
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "missing-name",
+			scopeId:   prj.PublicId,
+			catalogId: catalog.PublicId,
+			hosts:     []*BulkHost{{Address: "10.0.0.1"}},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+		{
+			name:      "duplicate-name",
+			scopeId:   prj.PublicId,
+			catalogId: catalog.PublicId,
+			hosts: []*BulkHost{
+				{Name: "web1", Address: "10.0.0.1"},
+				{Name: "web1", Address: "10.0.0.2"},
+			},
+			wantIsErr: errors.ErrNotUnique,
+		},
+		{
+			name:      "bad-address",
+			scopeId:   prj.PublicId,
+			catalogId: catalog.PublicId,
+			hosts:     []*BulkHost{{Name: "web1", Address: "1"}},
+			wantIsErr: ErrInvalidAddress,
+		},
+		{
+			name:      "set-in-other-catalog",
+			scopeId:   prj.PublicId,
+			catalogId: catalog.PublicId,
+			hosts:     []*BulkHost{{Name: "web1", Address: "10.0.0.1", HostSetIds: []string{otherSet.PublicId}}},
+			wantIsErr: errors.ErrInvalidParameter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			repo, err := NewRepository(rw, rw, kms)
+			require.NoError(err)
+			require.NotNil(repo)
+			got, err := repo.ImportHosts(context.Background(), tt.scopeId, tt.catalogId, tt.hosts)
+			assert.Truef(errors.Is(err, tt.wantIsErr), "want err: %q got: %q", tt.wantIsErr, err)
+			assert.Nil(got)
+		})
+	}
+}
+
+func TestRepository_ImportHosts(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	sets := TestSets(t, conn, catalog.PublicId, 2)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	ctx := context.Background()
+
+	existing, err := NewHost(catalog.PublicId, WithName("db1"), WithAddress("10.0.1.1"))
+	require.NoError(err)
+	existing, err = repo.CreateHost(ctx, prj.PublicId, existing)
+	require.NoError(err)
+
+	in := []*BulkHost{
+		{Name: " web1 ", Address: "10.0.0.1", HostSetIds: []string{sets[0].PublicId}},
+		{Name: "web2", Address: "10.0.0.2", Description: "second", HostSetIds: []string{sets[0].PublicId, sets[1].PublicId}},
+		{Name: "db1", Address: "10.0.1.2", HostSetIds: []string{sets[1].PublicId}},
+	}
+
+	// A dry run reports the changes without making them.
+	got, err := repo.ImportHosts(ctx, prj.PublicId, catalog.PublicId, in, WithDryRun(true))
+	require.NoError(err)
+	require.Len(got, 3)
+	assert.Equal(ImportActionCreate, got[0].Action)
+	assert.Empty(got[0].Host.PublicId)
+	assert.Equal("web1", got[0].Host.Name)
+	assert.Equal(" web1 ", in[0].Name, "caller's hosts must not be modified")
+	assert.Equal(ImportActionCreate, got[1].Action)
+	assert.Equal(ImportActionUpdate, got[2].Action)
+	assert.Equal([]string{"Address"}, got[2].ChangedFields)
+	assert.Equal([]string{sets[1].PublicId}, got[2].AddedSetIds)
+
+	hosts, err := repo.ListHosts(ctx, catalog.PublicId)
+	require.NoError(err)
+	assert.Len(hosts, 1)
+
+	// Applying the import makes the changes.
+	got, err = repo.ImportHosts(ctx, prj.PublicId, catalog.PublicId, in)
+	require.NoError(err)
+	require.Len(got, 3)
+	for _, r := range got {
+		assert.NotEmpty(r.Host.PublicId)
+	}
+	assert.Equal(existing.PublicId, got[2].Host.PublicId)
+	assert.Equal("10.0.1.2", got[2].Host.Address)
+
+	_, members, err := repo.LookupSet(ctx, sets[1].PublicId)
+	require.NoError(err)
+	assert.Len(members, 2)
+
+	// Importing the same hosts again changes nothing.
+	got, err = repo.ImportHosts(ctx, prj.PublicId, catalog.PublicId, in)
+	require.NoError(err)
+	for _, r := range got {
+		assert.Equal(ImportActionUnchanged, r.Action)
+		assert.Empty(r.AddedSetIds)
+	}
+
+	// Hosts without a name are not exported since they cannot be
+	// imported.
+	unnamed, err := NewHost(catalog.PublicId, WithAddress("10.0.2.1"))
+	require.NoError(err)
+	_, err = repo.CreateHost(ctx, prj.PublicId, unnamed)
+	require.NoError(err)
+
+	exported, err := repo.ExportHosts(ctx, catalog.PublicId)
+	require.NoError(err)
+	require.Len(exported, 3)
+	byName := make(map[string]*BulkHost)
+	for _, h := range exported {
+		byName[h.Name] = h
+	}
+	assert.Equal("second", byName["web2"].Description)
+	assert.ElementsMatch([]string{sets[0].PublicId, sets[1].PublicId}, byName["web2"].HostSetIds)
+	assert.Equal([]string{sets[1].PublicId}, byName["db1"].HostSetIds)
+}
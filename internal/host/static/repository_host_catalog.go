@@ -200,7 +200,11 @@ func (r *Repository) ListCatalogs(ctx context.Context, scopeId string, opt ...Op
 		limit = opts.withLimit
 	}
 	var hostCatalogs []*HostCatalog
-	err := r.reader.SearchWhere(ctx, &hostCatalogs, "scope_id = ?", []interface{}{scopeId}, db.WithLimit(limit))
+	where, args := "scope_id = ?", []interface{}{scopeId}
+	if opts.withAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withAfterId)
+	}
+	err := r.reader.SearchWhere(ctx, &hostCatalogs, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
 	if err != nil {
 		return nil, fmt.Errorf("list: static host catalog: %w", err)
 	}
@@ -0,0 +1,83 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+func newLabels(hostId string, labels map[string]string) ([]interface{}, error) {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var items []interface{}
+	for _, k := range keys {
+		l, err := NewHostLabel(hostId, k, labels[k])
+		if err != nil {
+			return nil, fmt.Errorf("new labels: %w", err)
+		}
+		items = append(items, l)
+	}
+	return items, nil
+}
+
+// replaceLabels replaces the labels of hostId with labels.
+func replaceLabels(ctx context.Context, reader db.Reader, w db.Writer, wrapper wrapping.Wrapper, metadata oplog.Metadata, hostId string, labels map[string]string) error {
+	var current []*HostLabel
+	if err := reader.SearchWhere(ctx, &current, "host_id = ?", []interface{}{hostId}, db.WithLimit(unlimited)); err != nil {
+		return fmt.Errorf("unable to get host labels: %w", err)
+	}
+	if len(current) > 0 {
+		deleteItems := make([]interface{}, 0, len(current))
+		for _, l := range current {
+			deleteItems = append(deleteItems, l)
+		}
+		if _, err := w.DeleteItems(ctx, deleteItems, db.WithOplog(wrapper, metadata)); err != nil {
+			return fmt.Errorf("unable to delete host labels: %w", err)
+		}
+	}
+	createItems, err := newLabels(hostId, labels)
+	if err != nil {
+		return err
+	}
+	if len(createItems) > 0 {
+		if err := w.CreateItems(ctx, createItems, db.WithOplog(wrapper, metadata)); err != nil {
+			return fmt.Errorf("unable to create host labels: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadLabels sets the Labels of each of hosts from the repository.
+func loadLabels(ctx context.Context, reader db.Reader, hosts ...*Host) error {
+	if len(hosts) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		ids = append(ids, h.PublicId)
+	}
+	var labels []*HostLabel
+	if err := reader.SearchWhere(ctx, &labels, "host_id in (?)", []interface{}{ids}, db.WithLimit(unlimited)); err != nil {
+		return fmt.Errorf("load labels: %w", err)
+	}
+	byHost := make(map[string]map[string]string)
+	for _, l := range labels {
+		if byHost[l.HostId] == nil {
+			byHost[l.HostId] = make(map[string]string)
+		}
+		byHost[l.HostId][l.Key] = l.Value
+	}
+	for _, h := range hosts {
+		h.Labels = byHost[h.PublicId]
+	}
+	return nil
+}
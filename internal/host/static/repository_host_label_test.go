@@ -0,0 +1,184 @@
+package static
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_HostLabels(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	ctx := context.Background()
+
+	assert, require := assert.New(t), require.New(t)
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+
+	h, err := NewHost(catalog.PublicId, WithAddress("10.0.0.1"), WithLabels(map[string]string{"env": "prod", "role": "db"}))
+	require.NoError(err)
+	got, err := repo.CreateHost(ctx, prj.PublicId, h)
+	require.NoError(err)
+	assert.Equal(map[string]string{"env": "prod", "role": "db"}, got.Labels)
+
+	found, err := repo.LookupHost(ctx, got.PublicId)
+	require.NoError(err)
+	assert.Equal(map[string]string{"env": "prod", "role": "db"}, found.Labels)
+
+	bad, err := NewHost(catalog.PublicId, WithAddress("10.0.0.2"), WithLabels(map[string]string{"env": "prod,dev"}))
+	require.NoError(err)
+	_, err = repo.CreateHost(ctx, prj.PublicId, bad)
+	assert.Truef(errors.Is(err, ErrInvalidLabel), "want err: %q got: %q", ErrInvalidLabel, err)
+
+	upd := found.clone()
+	upd.Labels = map[string]string{"env": "dev"}
+	updated, rows, err := repo.UpdateHost(ctx, prj.PublicId, upd, found.Version, []string{"Labels"})
+	require.NoError(err)
+	assert.Equal(1, rows)
+	assert.Equal(map[string]string{"env": "dev"}, updated.Labels)
+
+	list, err := repo.ListHosts(ctx, catalog.PublicId)
+	require.NoError(err)
+	require.Len(list, 1)
+	assert.Equal(map[string]string{"env": "dev"}, list[0].Labels)
+	assert.Equal(found.Version+1, list[0].Version)
+
+	upd = list[0].clone()
+	upd.Labels = nil
+	updated, rows, err = repo.UpdateHost(ctx, prj.PublicId, upd, list[0].Version, []string{"Labels"})
+	require.NoError(err)
+	assert.Equal(1, rows)
+	assert.Empty(updated.Labels)
+}
+
+func TestRepository_LookupSet_Selector(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	otherCatalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(t, err)
+
+	newHost := func(catalogId, address string, labels map[string]string) *Host {
+		h, err := NewHost(catalogId, WithAddress(address), WithLabels(labels))
+		require.NoError(t, err)
+		h, err = repo.CreateHost(ctx, prj.PublicId, h)
+		require.NoError(t, err)
+		return h
+	}
+	prodDb := newHost(catalog.PublicId, "10.0.0.1", map[string]string{"env": "prod", "role": "db"})
+	prodWeb := newHost(catalog.PublicId, "10.0.0.2", map[string]string{"env": "prod", "role": "web"})
+	devDb := newHost(catalog.PublicId, "10.0.0.3", map[string]string{"env": "dev", "role": "db"})
+	unlabeled := newHost(catalog.PublicId, "10.0.0.4", nil)
+	newHost(otherCatalog.PublicId, "10.0.0.5", map[string]string{"env": "prod", "role": "db"})
+
+	tests := []struct {
+		name      string
+		selector  string
+		members   []*Host
+		wantHosts []*Host
+	}{
+		{
+			name:      "equal",
+			selector:  "env=prod,role=db",
+			wantHosts: []*Host{prodDb},
+		},
+		{
+			name:      "not-equal",
+			selector:  "role!=db",
+			wantHosts: []*Host{prodWeb, unlabeled},
+		},
+		{
+			name:      "has",
+			selector:  "role",
+			wantHosts: []*Host{prodDb, prodWeb, devDb},
+		},
+		{
+			name:      "with-members",
+			selector:  "env=dev",
+			members:   []*Host{prodWeb},
+			wantHosts: []*Host{prodWeb, devDb},
+		},
+		{
+			name:      "no-matches",
+			selector:  "env=stage",
+			wantHosts: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			s, err := NewHostSet(catalog.PublicId, WithName(tt.name), WithSelector(tt.selector))
+			require.NoError(err)
+			s, err = repo.CreateSet(ctx, prj.PublicId, s)
+			require.NoError(err)
+			if len(tt.members) > 0 {
+				TestSetMembers(t, conn, s.PublicId, tt.members)
+			}
+
+			got, gotHosts, err := repo.LookupSet(ctx, s.PublicId)
+			require.NoError(err)
+			assert.Equal(tt.selector, got.Selector)
+			assert.Equal(hostIds(tt.wantHosts), hostIds(gotHosts))
+		})
+	}
+
+	t.Run("invalid-selector", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := NewHostSet(catalog.PublicId, WithSelector("env=prod=db"))
+		require.NoError(err)
+		_, err = repo.CreateSet(ctx, prj.PublicId, s)
+		assert.Truef(errors.Is(err, ErrInvalidSelector), "want err: %q got: %q", ErrInvalidSelector, err)
+	})
+
+	t.Run("update-selector", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		s, err := NewHostSet(catalog.PublicId, WithSelector("env=prod"))
+		require.NoError(err)
+		s, err = repo.CreateSet(ctx, prj.PublicId, s)
+		require.NoError(err)
+
+		upd := s.clone()
+		upd.Selector = " env = dev "
+		got, gotHosts, rows, err := repo.UpdateSet(ctx, prj.PublicId, upd, s.Version, []string{"Selector"})
+		require.NoError(err)
+		assert.Equal(1, rows)
+		assert.Equal("env=dev", got.Selector)
+		assert.Equal(hostIds([]*Host{devDb}), hostIds(gotHosts))
+
+		upd = got.clone()
+		upd.Selector = ""
+		_, gotHosts, rows, err = repo.UpdateSet(ctx, prj.PublicId, upd, got.Version, []string{"Selector"})
+		require.NoError(err)
+		assert.Equal(1, rows)
+		assert.Empty(gotHosts)
+	})
+}
+
+func hostIds(hosts []*Host) []string {
+	var ids []string
+	for _, h := range hosts {
+		ids = append(ids, h.PublicId)
+	}
+	sort.Strings(ids)
+	return ids
+}
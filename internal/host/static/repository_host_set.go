@@ -18,7 +18,8 @@ import (
 // generated and assigned by this method. opt is ignored.
 //
 // Both s.Name and s.Description are optional. If s.Name is set, it must be
-// unique within s.CatalogId.
+// unique within s.CatalogId. s.Selector is optional. If set, it must be a
+// valid label selector.
 func (r *Repository) CreateSet(ctx context.Context, scopeId string, s *HostSet, opt ...Option) (*HostSet, error) {
 	if s == nil {
 		return nil, fmt.Errorf("create: static host set: %w", errors.ErrInvalidParameter)
@@ -36,6 +37,13 @@ func (r *Repository) CreateSet(ctx context.Context, scopeId string, s *HostSet,
 		return nil, fmt.Errorf("create: static host set: no scopeId: %w", errors.ErrInvalidParameter)
 	}
 	s = s.clone()
+	if s.Selector != "" {
+		selector, err := NormalizeSelector(s.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("create: static host set: bad selector: %w", err)
+		}
+		s.Selector = selector
+	}
 
 	opts := getOpts(opt...)
 
@@ -80,9 +88,10 @@ func (r *Repository) CreateSet(ctx context.Context, scopeId string, s *HostSet,
 // containing the updated values, the hosts assigned to the host set, and a
 // count of the number of records updated. s is not changed.
 //
-// s must contain a valid PublicId. Only s.Name and s.Description can be
-// updated. If s.Name is set to a non-empty string, it must be unique
-// within s.CatalogId.
+// s must contain a valid PublicId. Only s.Name, s.Description and
+// s.Selector can be updated. If s.Name is set to a non-empty string, it
+// must be unique within s.CatalogId. If s.Selector is set to a non-empty
+// string, it must be a valid label selector.
 //
 // An attribute of s will be set to NULL in the database if the attribute
 // in s is the zero value and it is included in fieldMaskPaths.
@@ -110,6 +119,16 @@ func (r *Repository) UpdateSet(ctx context.Context, scopeId string, s *HostSet,
 		switch {
 		case strings.EqualFold("Name", f):
 		case strings.EqualFold("Description", f):
+		case strings.EqualFold("Selector", f):
+			if s.Selector == "" {
+				continue
+			}
+			selector, err := NormalizeSelector(s.Selector)
+			if err != nil {
+				return nil, nil, db.NoRowsAffected, fmt.Errorf("update: static host set: bad selector: %w", err)
+			}
+			s = s.clone()
+			s.Selector = selector
 		default:
 			return nil, nil, db.NoRowsAffected, fmt.Errorf("update: static host set: field: %s: %w", f, errors.ErrInvalidFieldMask)
 		}
@@ -119,6 +138,7 @@ func (r *Repository) UpdateSet(ctx context.Context, scopeId string, s *HostSet,
 		map[string]interface{}{
 			"Name":        s.Name,
 			"Description": s.Description,
+			"Selector":    s.Selector,
 		},
 		fieldMaskPaths,
 		nil,
@@ -172,8 +192,10 @@ func (r *Repository) UpdateSet(ctx context.Context, scopeId string, s *HostSet,
 }
 
 // LookupSet will look up a host set in the repository and return the host
-// set and the hosts assigned to the host set. If the host set is not
-// found, it will return nil, nil, nil. The WithLimit option can be used to
+// set and the hosts in the host set. The hosts in a host set are the hosts
+// assigned to it and, if it has a selector, the hosts in its catalog with
+// labels matching the selector. If the host set is not found, it will
+// return nil, nil, nil. The WithLimit option can be used to
 // limit the number of hosts returned. All other options are ignored.
 func (r *Repository) LookupSet(ctx context.Context, publicId string, opt ...Option) (*HostSet, []*Host, error) {
 	if publicId == "" {
@@ -199,7 +221,7 @@ func (r *Repository) LookupSet(ctx context.Context, publicId string, opt ...Opti
 			return err
 		}
 		var err error
-		hosts, err = getHosts(ctx, reader, s.PublicId, limit)
+		hosts, err = resolveHosts(ctx, reader, s, limit)
 		return err
 	})
 
@@ -223,7 +245,11 @@ func (r *Repository) ListSets(ctx context.Context, catalogId string, opt ...Opti
 		limit = opts.withLimit
 	}
 	var sets []*HostSet
-	err := r.reader.SearchWhere(ctx, &sets, "catalog_id = ?", []interface{}{catalogId}, db.WithLimit(limit))
+	where, args := "catalog_id = ?", []interface{}{catalogId}
+	if opts.withAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withAfterId)
+	}
+	err := r.reader.SearchWhere(ctx, &sets, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
 	if err != nil {
 		return nil, fmt.Errorf("list: static host set: %w", err)
 	}
@@ -112,28 +112,37 @@ func updateVersion(ctx context.Context, w db.Writer, wrapper wrapping.Wrapper, m
 
 const unlimited = -1
 
+// getHosts returns the hosts in setId. See resolveHosts.
 func getHosts(ctx context.Context, reader db.Reader, setId string, limit int) ([]*Host, error) {
-	const whereNoLimit = `public_id in
-       ( select host_id
-           from static_host_set_member
-          where set_id = $1
-       )`
+	s := allocHostSet()
+	s.PublicId = setId
+	if err := reader.LookupByPublicId(ctx, s); err != nil {
+		return nil, fmt.Errorf("get hosts: %w", err)
+	}
+	return resolveHosts(ctx, reader, s, limit)
+}
 
-	const whereLimit = `public_id in
+// resolveHosts returns the hosts in s: the hosts assigned to s and, if s
+// has a selector, the hosts in the catalog of s with labels matching the
+// selector.
+func resolveHosts(ctx context.Context, reader db.Reader, s *HostSet, limit int) ([]*Host, error) {
+	const whereMembers = `public_id in
        ( select host_id
            from static_host_set_member
           where set_id = $1
-          limit $2
        )`
 
-	params := []interface{}{setId}
-	var where string
-	switch limit {
-	case unlimited:
-		where = whereNoLimit
-	default:
-		where = whereLimit
-		params = append(params, limit)
+	where := whereMembers
+	params := []interface{}{s.PublicId}
+	if s.Selector != "" {
+		terms, err := parseSelector(s.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("get hosts: %w", err)
+		}
+		cond, condParams := selectorWhere(terms, 3)
+		where = fmt.Sprintf("(%s\n    or (catalog_id = $2\n     and %s))", whereMembers, cond)
+		params = append(params, s.CatalogId)
+		params = append(params, condParams...)
 	}
 
 	var hosts []*Host
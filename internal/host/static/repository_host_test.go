@@ -2,6 +2,7 @@ package static
 
 import (
 	"context"
+	"sort"
 	"testing"
 	"time"
 
@@ -913,6 +914,43 @@ func TestRepository_ListHosts_Limits(t *testing.T) {
 	}
 }
 
+func TestRepository_ListHosts_AfterId(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+
+	_, prj := iam.TestScopes(t, iamRepo)
+	catalog := TestCatalogs(t, conn, prj.PublicId, 1)[0]
+	hosts := TestHosts(t, conn, catalog.PublicId, 5)
+	var ids []string
+	for _, h := range hosts {
+		ids = append(ids, h.PublicId)
+	}
+	sort.Strings(ids)
+
+	repo, err := NewRepository(rw, rw, kms)
+	require.NoError(err)
+	require.NotNil(repo)
+
+	var got []string
+	var afterId string
+	for {
+		page, err := repo.ListHosts(context.Background(), catalog.PublicId, WithAfterId(afterId), WithLimit(2))
+		require.NoError(err)
+		if len(page) == 0 {
+			break
+		}
+		for _, h := range page {
+			got = append(got, h.PublicId)
+		}
+		afterId = page[len(page)-1].PublicId
+	}
+	assert.Equal(ids, got)
+}
+
 func TestRepository_DeleteHost(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	rw := db.New(conn)
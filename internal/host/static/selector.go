@@ -0,0 +1,145 @@
+package static
+
+import (
+	"fmt"
+	"strings"
+)
+
+// selectorOp is the comparison a selector term makes against the labels
+// of a host.
+type selectorOp int
+
+const (
+	// selectorHas matches hosts with a label for the key, e.g. "role".
+	selectorHas selectorOp = iota
+	// selectorEqual matches hosts with the label, e.g. "env=prod".
+	selectorEqual
+	// selectorNotEqual matches hosts without the label, e.g. "env!=prod".
+	// Hosts with no label for the key match.
+	selectorNotEqual
+)
+
+type selectorTerm struct {
+	op    selectorOp
+	key   string
+	value string
+}
+
+func (t selectorTerm) String() string {
+	switch t.op {
+	case selectorEqual:
+		return t.key + "=" + t.value
+	case selectorNotEqual:
+		return t.key + "!=" + t.value
+	default:
+		return t.key
+	}
+}
+
+// parseSelector parses a label selector, a comma separated list of terms
+// which must all match a host for it to be selected.
+func parseSelector(s string) ([]selectorTerm, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, fmt.Errorf("empty selector: %w", ErrInvalidSelector)
+	}
+	var terms []selectorTerm
+	for _, raw := range strings.Split(s, ",") {
+		raw = strings.TrimSpace(raw)
+		var t selectorTerm
+		switch {
+		case strings.Contains(raw, "!="):
+			t.op = selectorNotEqual
+			kv := strings.SplitN(raw, "!=", 2)
+			t.key, t.value = strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		case strings.Contains(raw, "="):
+			t.op = selectorEqual
+			kv := strings.SplitN(raw, "=", 2)
+			t.key, t.value = strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+		default:
+			t.op = selectorHas
+			t.key = raw
+		}
+		if !ValidLabelKey(t.key) || !ValidLabelValue(t.value) {
+			return nil, fmt.Errorf("term %q: %w", raw, ErrInvalidSelector)
+		}
+		terms = append(terms, t)
+	}
+	return terms, nil
+}
+
+// ValidSelector reports whether s is a valid host set selector.
+func ValidSelector(s string) bool {
+	_, err := parseSelector(s)
+	return err == nil
+}
+
+// NormalizeSelector returns s with the whitespace around its terms
+// removed. It returns an error if s is not a valid selector.
+func NormalizeSelector(s string) (string, error) {
+	terms, err := parseSelector(s)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, 0, len(terms))
+	for _, t := range terms {
+		parts = append(parts, t.String())
+	}
+	return strings.Join(parts, ","), nil
+}
+
+// MatchSelector reports whether a host with labels is selected by the
+// selector s.
+func MatchSelector(s string, labels map[string]string) (bool, error) {
+	terms, err := parseSelector(s)
+	if err != nil {
+		return false, err
+	}
+	for _, t := range terms {
+		v, ok := labels[t.key]
+		switch t.op {
+		case selectorHas:
+			if !ok {
+				return false, nil
+			}
+		case selectorEqual:
+			if !ok || v != t.value {
+				return false, nil
+			}
+		case selectorNotEqual:
+			if ok && v == t.value {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// selectorWhere returns a condition on static_host matching the hosts
+// selected by terms. Parameters are numbered starting with $n.
+func selectorWhere(terms []selectorTerm, n int) (string, []interface{}) {
+	const labelQuery = `exists
+         ( select 1
+             from static_host_label
+            where static_host_label.host_id = static_host.public_id
+              and static_host_label.key = $%d%s
+         )`
+	var conds []string
+	var params []interface{}
+	for _, t := range terms {
+		switch t.op {
+		case selectorHas:
+			conds = append(conds, fmt.Sprintf(labelQuery, n, ""))
+			params = append(params, t.key)
+			n++
+		case selectorEqual, selectorNotEqual:
+			cond := fmt.Sprintf(labelQuery, n, fmt.Sprintf("\n              and static_host_label.value = $%d", n+1))
+			if t.op == selectorNotEqual {
+				cond = "not " + cond
+			}
+			conds = append(conds, cond)
+			params = append(params, t.key, t.value)
+			n += 2
+		}
+	}
+	return strings.Join(conds, "\n     and "), params
+}
@@ -0,0 +1,140 @@
+package static
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseSelector(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		selector string
+		want     []selectorTerm
+		wantNorm string
+		wantErr  bool
+	}{
+		{
+			name:     "equal",
+			selector: "env=prod",
+			want:     []selectorTerm{{op: selectorEqual, key: "env", value: "prod"}},
+			wantNorm: "env=prod",
+		},
+		{
+			name:     "multiple-terms",
+			selector: " env = prod , role!=db,  canary ",
+			want: []selectorTerm{
+				{op: selectorEqual, key: "env", value: "prod"},
+				{op: selectorNotEqual, key: "role", value: "db"},
+				{op: selectorHas, key: "canary"},
+			},
+			wantNorm: "env=prod,role!=db,canary",
+		},
+		{
+			name:     "empty-value",
+			selector: "env=",
+			want:     []selectorTerm{{op: selectorEqual, key: "env", value: ""}},
+			wantNorm: "env=",
+		},
+		{
+			name:     "empty",
+			selector: " ",
+			wantErr:  true,
+		},
+		{
+			name:     "empty-term",
+			selector: "env=prod,,role=db",
+			wantErr:  true,
+		},
+		{
+			name:     "empty-key",
+			selector: "=prod",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid-value",
+			selector: "env=prod=db",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid-key",
+			selector: "env prod",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := parseSelector(tt.selector)
+			if tt.wantErr {
+				assert.Error(err)
+				assert.Truef(errors.Is(err, ErrInvalidSelector), "want err: %q got: %q", ErrInvalidSelector, err)
+				assert.False(ValidSelector(tt.selector))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+			assert.True(ValidSelector(tt.selector))
+			norm, err := NormalizeSelector(tt.selector)
+			require.NoError(err)
+			assert.Equal(tt.wantNorm, norm)
+		})
+	}
+}
+
+func Test_selectorWhere(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	terms := []selectorTerm{
+		{op: selectorEqual, key: "env", value: "prod"},
+		{op: selectorHas, key: "canary"},
+		{op: selectorNotEqual, key: "role", value: "db"},
+	}
+	where, params := selectorWhere(terms, 3)
+	assert.Equal([]interface{}{"env", "prod", "canary", "role", "db"}, params)
+	assert.Contains(where, "static_host_label.key = $3\n              and static_host_label.value = $4")
+	assert.Contains(where, "static_host_label.key = $5\n         )")
+	assert.Contains(where, "not exists")
+	assert.Contains(where, "static_host_label.key = $6\n              and static_host_label.value = $7")
+}
+
+func TestMatchSelector(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	labels := map[string]string{"env": "prod", "canary": ""}
+	for selector, want := range map[string]bool{
+		"env=prod":           true,
+		"env=dev":            false,
+		"canary":             true,
+		"role":               false,
+		"role!=db":           true,
+		"env!=prod":          false,
+		"env=prod,canary":    true,
+		"env=prod,canary=on": false,
+	} {
+		got, err := MatchSelector(selector, labels)
+		require.NoError(err)
+		assert.Equalf(want, got, "selector %q", selector)
+	}
+	_, err := MatchSelector("env prod", labels)
+	assert.True(errors.Is(err, ErrInvalidSelector))
+}
+
+func TestValidLabel(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	assert.True(ValidLabelKey("kubernetes.io/role"))
+	assert.False(ValidLabelKey(""))
+	assert.False(ValidLabelKey("env,prod"))
+	assert.True(ValidLabelValue(""))
+	assert.True(ValidLabelValue("us-east-1"))
+	assert.False(ValidLabelValue("a=b"))
+	assert.NoError(validateLabels(map[string]string{"env": "prod", "canary": ""}))
+	assert.True(errors.Is(validateLabels(map[string]string{"env!": "prod"}), ErrInvalidLabel))
+	assert.True(errors.Is(validateLabels(map[string]string{"env": "prod db"}), ErrInvalidLabel))
+}
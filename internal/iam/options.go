@@ -29,6 +29,7 @@ type options struct {
 	withSkipDefaultRoleCreation bool
 	withUserId                  string
 	withRandomReader            io.Reader
+	withAfterId                 string
 }
 
 func getDefaultOptions() options {
@@ -142,3 +143,12 @@ func WithRandomReader(reader io.Reader) Option {
 		o.withRandomReader = reader
 	}
 }
+
+// WithAfterId provides an option to list only the items whose id sorts after
+// id. Lists are ordered by id so it can be used to list items a page at a
+// time.
+func WithAfterId(id string) Option {
+	return func(o *options) {
+		o.withAfterId = id
+	}
+}
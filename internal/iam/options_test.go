@@ -74,4 +74,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withDisassociate = true
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAfterId("u_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withAfterId = "u_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -63,6 +63,22 @@ func (r *Repository) list(ctx context.Context, resources interface{}, where stri
 	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit))
 }
 
+// listByPublicId is list for resources with a public id. The resources are
+// ordered by public id and only those after the id provided by WithAfterId
+// are returned.
+func (r *Repository) listByPublicId(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	if opts.withAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withAfterId)
+	}
+	return r.reader.SearchWhere(ctx, resources, where, args, db.WithLimit(limit), db.WithOrder("public_id"))
+}
+
 // create will create a new iam resource in the db repository with an oplog entry
 func (r *Repository) create(ctx context.Context, resource Resource, opt ...Option) (Resource, error) {
 	if resource == nil {
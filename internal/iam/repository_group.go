@@ -172,7 +172,7 @@ func (r *Repository) ListGroups(ctx context.Context, withScopeId string, opt ...
 		return nil, fmt.Errorf("list groups: missing scope id %w", errors.ErrInvalidParameter)
 	}
 	var grps []*Group
-	err := r.list(ctx, &grps, "scope_id = ?", []interface{}{withScopeId}, opt...)
+	err := r.listByPublicId(ctx, &grps, "scope_id = ?", []interface{}{withScopeId}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list groups: %w", err)
 	}
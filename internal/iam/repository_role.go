@@ -183,7 +183,7 @@ func (r *Repository) ListRoles(ctx context.Context, withScopeId string, opt ...O
 		return nil, fmt.Errorf("list roles: missing scope id %w", errors.ErrInvalidParameter)
 	}
 	var roles []*Role
-	err := r.list(ctx, &roles, "scope_id = ?", []interface{}{withScopeId}, opt...)
+	err := r.listByPublicId(ctx, &roles, "scope_id = ?", []interface{}{withScopeId}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list roles: %w", err)
 	}
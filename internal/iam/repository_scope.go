@@ -426,7 +426,7 @@ func (r *Repository) ListProjects(ctx context.Context, withOrgId string, opt ...
 		return nil, fmt.Errorf("list projects: missing org id %w", errors.ErrInvalidParameter)
 	}
 	var projects []*Scope
-	err := r.list(ctx, &projects, "parent_id = ? and type = ?", []interface{}{withOrgId, scope.Project.String()}, opt...)
+	err := r.listByPublicId(ctx, &projects, "parent_id = ? and type = ?", []interface{}{withOrgId, scope.Project.String()}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list projects: %w", err)
 	}
@@ -436,7 +436,7 @@ func (r *Repository) ListProjects(ctx context.Context, withOrgId string, opt ...
 // ListOrgs and supports the WithLimit option.
 func (r *Repository) ListOrgs(ctx context.Context, opt ...Option) ([]*Scope, error) {
 	var orgs []*Scope
-	err := r.list(ctx, &orgs, "parent_id = ? and type = ?", []interface{}{"global", scope.Org.String()}, opt...)
+	err := r.listByPublicId(ctx, &orgs, "parent_id = ? and type = ?", []interface{}{"global", scope.Org.String()}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list orgs: %w", err)
 	}
@@ -201,7 +201,7 @@ func (r *Repository) ListUsers(ctx context.Context, withOrgId string, opt ...Opt
 		return nil, fmt.Errorf("list users: missing org id %w", errors.ErrInvalidParameter)
 	}
 	var users []*User
-	err := r.list(ctx, &users, "scope_id = ?", []interface{}{withOrgId}, opt...)
+	err := r.listByPublicId(ctx, &users, "scope_id = ?", []interface{}{withOrgId}, opt...)
 	if err != nil {
 		return nil, fmt.Errorf("list users: %w", err)
 	}
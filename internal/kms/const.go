@@ -1,5 +1,7 @@
 package kms
 
+import "fmt"
+
 // KeyPurpose allows an application to specify the reason they need a key; this
 // is used to select which DEK to return
 type KeyPurpose uint
@@ -45,6 +47,16 @@ func (k KeyPurpose) String() string {
 	}
 }
 
+// ParseKeyPurpose returns the key purpose whose String is s.
+func ParseKeyPurpose(s string) (KeyPurpose, error) {
+	for _, p := range []KeyPurpose{KeyPurposeDatabase, KeyPurposeOplog, KeyPurposeRecovery, KeyPurposeTokens, KeyPurposeSessions} {
+		if p.String() == s {
+			return p, nil
+		}
+	}
+	return KeyPurposeUnknown, fmt.Errorf("unknown key purpose %q", s)
+}
+
 // KeyType allows the kms repo to return a map[KeyType]Key which can be easily
 // used without type casting.
 type KeyType uint
@@ -0,0 +1,326 @@
+package kms
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+)
+
+// defaultKeyVersionGracePeriod is the default of WithKeyVersionGracePeriod.
+const defaultKeyVersionGracePeriod = time.Minute
+
+// ErrKeyVersionInUse is returned when destroying a key version which values
+// still depend on.
+var ErrKeyVersionInUse = stderrors.New("key version is in use")
+
+// IssuedCountFn returns the number of values of the scope issued to clients
+// between issuedAfter and issuedBefore, which were encrypted with or derived
+// from the key of the scope for the purpose the function was registered with,
+// and which are still valid. Clients hold copies of these values which can't
+// be re-encrypted, so the key version current when they were issued must be
+// kept until they are no longer valid.
+type IssuedCountFn func(ctx context.Context, r db.Reader, scopeId string, issuedAfter, issuedBefore time.Time) (int64, error)
+
+type issuedCounter struct {
+	name    string
+	purpose KeyPurpose
+	fn      IssuedCountFn
+}
+
+var (
+	issuedCountersMutex sync.RWMutex
+	issuedCounters      []issuedCounter
+)
+
+// RegisterIssuedCountFn registers fn to count the values named name which are
+// issued to clients with the key for purpose. Packages issuing such values
+// call it from their init functions.
+func RegisterIssuedCountFn(name string, purpose KeyPurpose, fn IssuedCountFn) {
+	issuedCountersMutex.Lock()
+	defer issuedCountersMutex.Unlock()
+	for _, c := range issuedCounters {
+		if c.name == name {
+			panic(fmt.Sprintf("issued count function for %s registered twice", name))
+		}
+	}
+	issuedCounters = append(issuedCounters, issuedCounter{
+		name:    name,
+		purpose: purpose,
+		fn:      fn,
+	})
+}
+
+func registeredIssuedCounters() []issuedCounter {
+	issuedCountersMutex.RLock()
+	defer issuedCountersMutex.RUnlock()
+	ret := make([]issuedCounter, len(issuedCounters))
+	copy(ret, issuedCounters)
+	return ret
+}
+
+// KeyVersion is a version of a DEK of a scope.
+type KeyVersion struct {
+	Id         string
+	Purpose    KeyPurpose
+	Version    uint32
+	CreateTime time.Time
+	// EncryptedCount is the number of rows encrypted with the version,
+	// including the oplog entries of the recorded oplog archives.
+	EncryptedCount int64
+	// IssuedCount is the number of values issued to clients with the version
+	// which are still valid.
+	IssuedCount int64
+}
+
+// keyVersionPurpose returns the purpose of the DEK of a key version from the
+// prefix of its ID.
+func keyVersionPurpose(keyVersionId string) (KeyPurpose, error) {
+	prefix := keyVersionId
+	if i := strings.Index(keyVersionId, "_"); i > 0 {
+		prefix = keyVersionId[:i]
+	}
+	switch prefix {
+	case DatabaseKeyVersionPrefix:
+		return KeyPurposeDatabase, nil
+	case OplogKeyVersionPrefix:
+		return KeyPurposeOplog, nil
+	case TokenKeyVersionPrefix:
+		return KeyPurposeTokens, nil
+	case SessionKeyVersionPrefix:
+		return KeyPurposeSessions, nil
+	default:
+		return KeyPurposeUnknown, fmt.Errorf("%q is not the id of a key version: %w", keyVersionId, errors.ErrInvalidParameter)
+	}
+}
+
+// ListKeyVersions returns the versions of the DEK of the scope for purpose,
+// newest first, with the number of values which depend on each of them. If
+// purpose is KeyPurposeUnknown, the versions of all the DEKs of the scope are
+// returned.
+func (k *Kms) ListKeyVersions(ctx context.Context, scopeId string, purpose KeyPurpose) ([]*KeyVersion, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("list key versions: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	purposes := []KeyPurpose{purpose}
+	if purpose == KeyPurposeUnknown {
+		purposes = []KeyPurpose{KeyPurposeDatabase, KeyPurposeOplog, KeyPurposeTokens, KeyPurposeSessions}
+	}
+	var versions []*KeyVersion
+	for _, p := range purposes {
+		kvs, err := k.listKeyVersions(ctx, k.repo.reader, scopeId, p)
+		if err != nil {
+			return nil, fmt.Errorf("list key versions: %w", err)
+		}
+		versions = append(versions, kvs...)
+	}
+	return versions, nil
+}
+
+func (k *Kms) listKeyVersions(ctx context.Context, r db.Reader, scopeId string, purpose KeyPurpose) ([]*KeyVersion, error) {
+	name, err := dekName(purpose)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.Query(ctx, fmt.Sprintf(dekVersionsQuery, name), []interface{}{scopeId})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list %s key versions: %w", purpose, err)
+	}
+	defer rows.Close()
+	var versions []*KeyVersion
+	for rows.Next() {
+		kv := &KeyVersion{Purpose: purpose}
+		if err := rows.Scan(&kv.Id, &kv.Version, &kv.CreateTime); err != nil {
+			return nil, fmt.Errorf("unable to list %s key versions: %w", purpose, err)
+		}
+		versions = append(versions, kv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to list %s key versions: %w", purpose, err)
+	}
+	rows.Close()
+
+	for i, kv := range versions {
+		for _, t := range registeredRewrapTables() {
+			if t.purpose != purpose {
+				continue
+			}
+			count, err := k.countEncryptedRows(ctx, r, t.tableName, kv.Id)
+			if err != nil {
+				return nil, err
+			}
+			kv.EncryptedCount += count
+		}
+		if purpose == KeyPurposeOplog {
+			count, err := countRows(ctx, r, countArchivedOplogEntriesQuery, kv.Id)
+			if err != nil {
+				return nil, fmt.Errorf("unable to count archived oplog entries: %w", err)
+			}
+			kv.EncryptedCount += count
+		}
+		// Values issued while this version was the newest one, or soon after
+		// it was superseded by controllers which had not seen the rotation yet
+		issuedBefore := time.Now().Add(k.keyVersionGracePeriod)
+		if i > 0 {
+			issuedBefore = versions[i-1].CreateTime.Add(k.keyVersionGracePeriod)
+		}
+		for _, c := range registeredIssuedCounters() {
+			if c.purpose != purpose {
+				continue
+			}
+			count, err := c.fn(ctx, r, scopeId, kv.CreateTime, issuedBefore)
+			if err != nil {
+				return nil, fmt.Errorf("unable to count issued %s: %w", c.name, err)
+			}
+			kv.IssuedCount += count
+		}
+	}
+	return versions, nil
+}
+
+// DestroyKeyVersion deletes a version of a DEK of the scope and removes it
+// from the cached wrappers of the scope. It returns ErrKeyVersionInUse when
+// the version is the newest version of its DEK, was superseded too recently
+// for all the controllers to have stopped encrypting with it, or when any
+// values still depend on it, including the entries of recorded oplog
+// archives. The other controllers remove the version from
+// their cached wrappers the next time they call RunRewrapJobs.
+func (k *Kms) DestroyKeyVersion(ctx context.Context, scopeId, keyVersionId string) error {
+	if scopeId == "" {
+		return fmt.Errorf("destroy key version: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	if keyVersionId == "" {
+		return fmt.Errorf("destroy key version: missing key version id: %w", errors.ErrInvalidParameter)
+	}
+	purpose, err := keyVersionPurpose(keyVersionId)
+	if err != nil {
+		return fmt.Errorf("destroy key version: %w", err)
+	}
+	name, err := dekName(purpose)
+	if err != nil {
+		return fmt.Errorf("destroy key version: %w", err)
+	}
+
+	_, err = k.repo.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(r db.Reader, w db.Writer) error {
+			versions, err := k.listKeyVersions(ctx, r, scopeId, purpose)
+			if err != nil {
+				return err
+			}
+			idx := -1
+			for i, kv := range versions {
+				if kv.Id == keyVersionId {
+					idx = i
+					break
+				}
+			}
+			switch {
+			case idx == -1:
+				return fmt.Errorf("key version %s of scope %s: %w", keyVersionId, scopeId, errors.ErrRecordNotFound)
+			case idx == 0:
+				return fmt.Errorf("%s is the newest version of the %s key: %w", keyVersionId, purpose, ErrKeyVersionInUse)
+			case time.Since(versions[idx-1].CreateTime) < k.keyVersionGracePeriod:
+				return fmt.Errorf("%s was superseded less than %s ago: %w", keyVersionId, k.keyVersionGracePeriod, ErrKeyVersionInUse)
+			}
+			kv := versions[idx]
+			if kv.EncryptedCount > 0 || kv.IssuedCount > 0 {
+				return fmt.Errorf("%d stored and %d issued values depend on %s: %w", kv.EncryptedCount, kv.IssuedCount, keyVersionId, ErrKeyVersionInUse)
+			}
+			if purpose == KeyPurposeOplog {
+				// The key versions of oplog entries written before they were
+				// recorded are unknown until a rewrap job reads them
+				count, err := countRows(ctx, r, countUnattributedOplogEntriesQuery)
+				if err != nil {
+					return fmt.Errorf("unable to count oplog entries: %w", err)
+				}
+				if count > 0 {
+					return fmt.Errorf("the key versions of %d oplog entries are not known yet: %w", count, ErrKeyVersionInUse)
+				}
+				// Nor are those of entries archived before they were recorded
+				count, err = countRows(ctx, r, countArchivedOplogEntriesQuery, "")
+				if err != nil {
+					return fmt.Errorf("unable to count archived oplog entries: %w", err)
+				}
+				if count > 0 {
+					return fmt.Errorf("the key versions of %d archived oplog entries are not known: %w", count, ErrKeyVersionInUse)
+				}
+			}
+			rowsDeleted, err := w.Exec(ctx, fmt.Sprintf(deleteDekVersionQuery, name), []interface{}{keyVersionId})
+			if err != nil {
+				return fmt.Errorf("unable to delete %s: %w", keyVersionId, err)
+			}
+			if rowsDeleted != 1 {
+				return fmt.Errorf("unable to delete %s: %d rows deleted", keyVersionId, rowsDeleted)
+			}
+			if _, err := w.Exec(ctx, insertDestroyedKeyVersionQuery, []interface{}{keyVersionId, scopeId, purpose.String(), kv.Version}); err != nil {
+				return fmt.Errorf("unable to record destruction of %s: %w", keyVersionId, err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("destroy key version: %w", err)
+	}
+	k.removeCachedKeyVersion(scopeId, purpose, keyVersionId)
+	return nil
+}
+
+// removeCachedKeyVersion removes a key version from the cached wrapper of the
+// scope for purpose, if any.
+func (k *Kms) removeCachedKeyVersion(scopeId string, purpose KeyPurpose, keyVersionId string) {
+	val, ok := k.scopePurposeCache.Load(scopeId + purpose.String())
+	if !ok {
+		return
+	}
+	val.(*multiwrapper.MultiWrapper).RemoveWrapper(keyVersionId)
+}
+
+func (k *Kms) refreshDestroyedKeyVersions(ctx context.Context) error {
+	k.lastDestroyTimeMutex.Lock()
+	defer k.lastDestroyTimeMutex.Unlock()
+	rows, err := k.repo.reader.Query(ctx, destroyedKeyVersionsQuery, []interface{}{k.lastDestroyTime})
+	if err != nil {
+		return fmt.Errorf("unable to list destroyed key versions: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var keyVersionId, scopeId, purposeName string
+		var destroyTime time.Time
+		if err := rows.Scan(&keyVersionId, &scopeId, &purposeName, &destroyTime); err != nil {
+			return fmt.Errorf("unable to list destroyed key versions: %w", err)
+		}
+		purpose, err := ParseKeyPurpose(purposeName)
+		if err != nil {
+			return fmt.Errorf("unable to list destroyed key versions: %w", err)
+		}
+		k.removeCachedKeyVersion(scopeId, purpose, keyVersionId)
+		if destroyTime.After(k.lastDestroyTime) {
+			k.lastDestroyTime = destroyTime
+		}
+	}
+	return rows.Err()
+}
+
+func countRows(ctx context.Context, r db.Reader, query string, args ...interface{}) (int64, error) {
+	rows, err := r.Query(ctx, query, args)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, err
+		}
+	}
+	return count, rows.Err()
+}
@@ -0,0 +1,138 @@
+package kms_test
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/authtoken"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKms_DestroyKeyVersion(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	require.NoError(err)
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithKeyVersionGracePeriod(time.Nanosecond))
+	require.NoError(err)
+	require.NoError(kmsCache.AddExternalWrappers(kms.WithRootWrapper(wrapper)))
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	at := authtoken.TestAuthToken(t, conn, kmsCache, org.GetPublicId())
+
+	kvs, err := kmsCache.ListKeyVersions(ctx, org.GetPublicId(), kms.KeyPurposeUnknown)
+	require.NoError(err)
+	assert.Len(kvs, 4)
+	kvs, err = kmsCache.ListKeyVersions(ctx, org.GetPublicId(), kms.KeyPurposeTokens)
+	require.NoError(err)
+	require.Len(kvs, 1)
+	oldTokenVersion := kvs[0]
+	assert.Equal(kms.KeyPurposeTokens, oldTokenVersion.Purpose)
+	assert.Equal(uint32(1), oldTokenVersion.Version)
+	// The stored auth token is encrypted with the database key
+	assert.Zero(oldTokenVersion.EncryptedCount)
+	assert.Equal(int64(1), oldTokenVersion.IssuedCount)
+
+	err = kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldTokenVersion.Id)
+	assert.True(errors.Is(err, kms.ErrKeyVersionInUse), "newest version destroyed: %v", err)
+
+	_, err = kmsCache.RotateKeys(ctx, org.GetPublicId(), rand.Reader)
+	require.NoError(err)
+	_, err = kmsCache.RunRewrapJobs(ctx)
+	require.NoError(err)
+
+	kvs, err = kmsCache.ListKeyVersions(ctx, org.GetPublicId(), kms.KeyPurposeTokens)
+	require.NoError(err)
+	require.Len(kvs, 2)
+	assert.Equal(uint32(2), kvs[0].Version)
+	assert.Equal(oldTokenVersion.Id, kvs[1].Id)
+	assert.Zero(kvs[1].EncryptedCount)
+	assert.Equal(int64(1), kvs[1].IssuedCount)
+
+	// The token held by the client was issued with the old version
+	err = kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldTokenVersion.Id)
+	assert.True(errors.Is(err, kms.ErrKeyVersionInUse), "version of issued token destroyed: %v", err)
+
+	atRepo, err := authtoken.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	_, err = atRepo.DeleteAuthToken(ctx, at.GetPublicId())
+	require.NoError(err)
+	require.NoError(kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldTokenVersion.Id))
+
+	kvs, err = kmsCache.ListKeyVersions(ctx, org.GetPublicId(), kms.KeyPurposeTokens)
+	require.NoError(err)
+	require.Len(kvs, 1)
+	assert.NotEqual(oldTokenVersion.Id, kvs[0].Id)
+	w, err := kmsCache.GetWrapper(ctx, org.GetPublicId(), kms.KeyPurposeTokens)
+	require.NoError(err)
+	assert.Nil(w.(*multiwrapper.MultiWrapper).WrapperForKeyID(oldTokenVersion.Id))
+
+	err = kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldTokenVersion.Id)
+	assert.Error(err)
+	err = kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), "krkv_1234567890")
+	assert.Error(err)
+}
+
+func TestKms_DestroyArchivedKeyVersion(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	kmsRepo, err := kms.NewRepository(rw, rw)
+	require.NoError(err)
+	kmsCache, err := kms.NewKms(kmsRepo, kms.WithKeyVersionGracePeriod(time.Nanosecond))
+	require.NoError(err)
+	require.NoError(kmsCache.AddExternalWrappers(kms.WithRootWrapper(wrapper)))
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	kvs, err := kmsCache.ListKeyVersions(ctx, org.GetPublicId(), kms.KeyPurposeOplog)
+	require.NoError(err)
+	require.Len(kvs, 1)
+	oldOplogVersion := kvs[0]
+
+	_, err = kmsCache.RotateKeys(ctx, org.GetPublicId(), rand.Reader)
+	require.NoError(err)
+	_, err = kmsCache.RunRewrapJobs(ctx)
+	require.NoError(err)
+
+	// Entries encrypted with the old version were archived
+	_, err = rw.Exec(ctx, "insert into oplog_archive (name, controller_name) values ('oplog-archive', 'controller')", nil)
+	require.NoError(err)
+	_, err = rw.Exec(ctx, "insert into oplog_archive_key_version (archive_name, key_id, entry_count) values ('oplog-archive', $1, 3)", []interface{}{oldOplogVersion.Id})
+	require.NoError(err)
+
+	kvs, err = kmsCache.ListKeyVersions(ctx, org.GetPublicId(), kms.KeyPurposeOplog)
+	require.NoError(err)
+	require.Len(kvs, 2)
+	assert.Equal(oldOplogVersion.Id, kvs[1].Id)
+	assert.Equal(int64(3), kvs[1].EncryptedCount)
+
+	err = kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldOplogVersion.Id)
+	assert.True(errors.Is(err, kms.ErrKeyVersionInUse), "version of archived entries destroyed: %v", err)
+
+	// Neither can any version be destroyed while archived entries have
+	// unknown versions
+	_, err = rw.Exec(ctx, "update oplog_archive_key_version set key_id = '' where archive_name = 'oplog-archive'", nil)
+	require.NoError(err)
+	err = kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldOplogVersion.Id)
+	assert.True(errors.Is(err, kms.ErrKeyVersionInUse), "version of archived entries destroyed: %v", err)
+
+	// Once the archive is forgotten
+	_, err = rw.Exec(ctx, "delete from oplog_archive where name = 'oplog-archive'", nil)
+	require.NoError(err)
+	require.NoError(kmsCache.DestroyKeyVersion(ctx, org.GetPublicId(), oldOplogVersion.Id))
+}
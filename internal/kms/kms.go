@@ -5,6 +5,7 @@ import (
 	stderrors "errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/go-hclog"
@@ -56,21 +57,45 @@ type Kms struct {
 	externalScopeCache      map[string]*ExternalWrappers
 	externalScopeCacheMutex sync.RWMutex
 
+	// lastRotateTime is the latest time the keys of a scope were rotated, as
+	// seen by RunRewrapJobs, which drops the cached wrappers of the scopes
+	// rotated since
+	lastRotateTime      time.Time
+	lastRotateTimeMutex sync.Mutex
+
+	// lastDestroyTime is the latest time a key version was destroyed, as seen
+	// by RunRewrapJobs, which removes the versions destroyed since from the
+	// cached wrappers
+	lastDestroyTime      time.Time
+	lastDestroyTimeMutex sync.Mutex
+
+	// keyVersionGracePeriod is how long after a key version is superseded it
+	// can't be destroyed, see WithKeyVersionGracePeriod
+	keyVersionGracePeriod time.Duration
+
 	repo *Repository
 }
 
-// NewKms takes in a repo and returns a Kms. Supported options: WithLogger.
+// NewKms takes in a repo and returns a Kms. Supported options: WithLogger,
+// WithKeyVersionGracePeriod.
 func NewKms(repo *Repository, opt ...Option) (*Kms, error) {
 	if repo == nil {
 		return nil, stderrors.New("new kms created without an underlying repo")
 	}
 
 	opts := getOpts(opt...)
+	if opts.withLogger == nil {
+		opts.withLogger = hclog.NewNullLogger()
+	}
+	if opts.withKeyVersionGracePeriod == 0 {
+		opts.withKeyVersionGracePeriod = defaultKeyVersionGracePeriod
+	}
 
 	return &Kms{
-		logger:             opts.withLogger,
-		externalScopeCache: make(map[string]*ExternalWrappers),
-		repo:               repo,
+		logger:                opts.withLogger,
+		externalScopeCache:    make(map[string]*ExternalWrappers),
+		keyVersionGracePeriod: opts.withKeyVersionGracePeriod,
+		repo:                  repo,
 	}, nil
 }
 
@@ -2,6 +2,7 @@ package kms
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -27,4 +28,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withLimit = 1
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithKeyVersionGracePeriod", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithKeyVersionGracePeriod(time.Second))
+		testOpts := getDefaultOptions()
+		testOpts.withKeyVersionGracePeriod = time.Second
+		assert.Equal(opts, testOpts)
+	})
 }
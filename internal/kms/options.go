@@ -1,6 +1,8 @@
 package kms
 
 import (
+	"time"
+
 	"github.com/hashicorp/go-hclog"
 	wrapping "github.com/hashicorp/go-kms-wrapping"
 )
@@ -27,6 +29,8 @@ type options struct {
 	withRepository        *Repository
 	withOrder             string
 	withKeyId             string
+
+	withKeyVersionGracePeriod time.Duration
 }
 
 func getDefaultOptions() options {
@@ -93,3 +97,13 @@ func WithKeyId(keyId string) Option {
 		o.withKeyId = keyId
 	}
 }
+
+// WithKeyVersionGracePeriod sets how long after a key version is superseded by
+// a rotation controllers may still encrypt with it, from the wrappers they
+// cached before the rotation. It should be longer than the interval at which
+// the controllers call RunRewrapJobs.
+func WithKeyVersionGracePeriod(d time.Duration) Option {
+	return func(o *options) {
+		o.withKeyVersionGracePeriod = d
+	}
+}
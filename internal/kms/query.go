@@ -0,0 +1,174 @@
+package kms
+
+const (
+	// dekVersionIdsQuery returns the ids of the versions of a DEK of a scope,
+	// newest first. It is formatted with the name of the DEK, e.g. database,
+	// as in the names of its tables.
+	dekVersionIdsQuery = `
+select kv.private_id
+  from kms_%[1]s_key_version kv
+  join kms_%[1]s_key k
+    on k.private_id = kv.%[1]s_key_id
+  join kms_root_key rk
+    on rk.private_id = k.root_key_id
+ where rk.scope_id = $1
+ order by kv.version desc
+`
+
+	// dekVersionsQuery returns the versions of a DEK of a scope, newest
+	// first. It is formatted like dekVersionIdsQuery.
+	dekVersionsQuery = `
+select kv.private_id, kv.version, kv.create_time
+  from kms_%[1]s_key_version kv
+  join kms_%[1]s_key k
+    on k.private_id = kv.%[1]s_key_id
+  join kms_root_key rk
+    on rk.private_id = k.root_key_id
+ where rk.scope_id = $1
+ order by kv.version desc
+`
+
+	// countEncryptedRowsQuery is formatted with the name of a table whose
+	// values are encrypted, and returns the number of its rows encrypted with
+	// a key version.
+	countEncryptedRowsQuery = `
+select count(*)
+  from %s
+ where key_id = $1
+`
+
+	// countRewrappableRowsQuery is formatted with the name of a table whose
+	// values are encrypted and with the condition selecting the rows its
+	// rewrap function re-encrypts, and returns the number of these rows
+	// encrypted with a key version.
+	countRewrappableRowsQuery = `
+select count(*)
+  from %s
+ where key_id = $1
+   and (%s)
+`
+
+	upsertRewrapJobQuery = `
+insert into kms_rewrap_job
+  (scope_id, state)
+values
+  ($1, 'pending')
+on conflict (scope_id) do update
+   set state = 'pending',
+       rewrapped_count = 0,
+       error = null,
+       rotate_time = now(),
+       end_time = null
+`
+
+	// claimRewrapJobQuery sets the oldest pending job to running and returns
+	// it. Jobs left running without progress for longer than $1 seconds, by a
+	// controller which stopped, are claimed again.
+	claimRewrapJobQuery = `
+update kms_rewrap_job
+   set state = 'running'
+ where scope_id = (
+         select scope_id
+           from kms_rewrap_job
+          where state = 'pending'
+             or (state = 'running' and update_time < now() - $1 * interval '1 second')
+          order by rotate_time
+          limit 1
+            for update skip locked
+       )
+returning scope_id, rotate_time
+`
+
+	updateRewrapJobProgressQuery = `
+update kms_rewrap_job
+   set rewrapped_count = rewrapped_count + $3
+ where scope_id = $1
+   and rotate_time = $2
+   and state = 'running'
+`
+
+	endRewrapJobQuery = `
+update kms_rewrap_job
+   set state = $3,
+       error = nullif($4, ''),
+       end_time = now()
+ where scope_id = $1
+   and rotate_time = $2
+   and state = 'running'
+`
+
+	lookupRewrapJobQuery = `
+select scope_id, state, rewrapped_count, error, rotate_time, update_time, end_time
+  from kms_rewrap_job
+ where scope_id = $1
+`
+
+	rotatedScopesQuery = `
+select scope_id, rotate_time
+  from kms_rewrap_job
+ where rotate_time > $1
+`
+)
+
+const (
+	setOplogEntryKeyIdQuery = `
+update oplog_entry
+   set key_id = $1
+ where id = $2
+   and key_id is null
+`
+
+	rewrapOplogEntryQuery = `
+update oplog_entry
+   set data = $1,
+       key_id = $2
+ where id = $3
+   and key_id = $4
+   and hash is null
+`
+)
+
+const (
+	countUnattributedOplogEntriesQuery = `
+select count(*)
+  from oplog_entry
+ where key_id is null
+`
+
+	// countArchivedOplogEntriesQuery returns the number of entries of the
+	// recorded oplog archives encrypted with a key version. Entries archived
+	// before their key version was known are counted with an empty key id.
+	countArchivedOplogEntriesQuery = `
+select coalesce(sum(entry_count), 0)
+  from oplog_archive_key_version
+ where key_id = $1
+`
+
+	// deleteDekVersionQuery is formatted with the name of the DEK.
+	deleteDekVersionQuery = `
+delete from kms_%s_key_version
+ where private_id = $1
+`
+
+	insertDestroyedKeyVersionQuery = `
+insert into kms_destroyed_key_version
+  (private_id, scope_id, purpose, version)
+values
+  ($1, $2, $3, $4)
+`
+
+	destroyedKeyVersionsQuery = `
+select private_id, scope_id, purpose, destroy_time
+  from kms_destroyed_key_version
+ where destroy_time > $1
+`
+)
+
+const (
+	rewrapRootKeyVersionQuery = `
+update kms_root_key_version
+   set key = $1,
+       key_id = $2
+ where private_id = $3
+`
+)
@@ -163,6 +163,127 @@ func CreateKeysTx(ctx context.Context, dbReader db.Reader, dbWriter db.Writer, r
 	return keys, nil
 }
 
+// RotateKeys creates a new version of the root key of the scope, encrypted
+// with rootWrapper, and new versions of each of its DEKs, encrypted with the
+// new root key version, and returns a map of the new key versions. The new
+// versions are used to encrypt from then on, while the previous versions are
+// kept to decrypt existing values. The rotation also queues a rewrap job to
+// re-encrypt the existing values of the scope with the new versions.
+func (r *Repository) RotateKeys(ctx context.Context, rootWrapper wrapping.Wrapper, randomReader io.Reader, scopeId string) (Keys, error) {
+	if rootWrapper == nil {
+		return nil, fmt.Errorf("rotate keys: missing root wrapper: %w", errors.ErrInvalidParameter)
+	}
+	if randomReader == nil {
+		return nil, fmt.Errorf("rotate keys: missing random reader: %w", errors.ErrInvalidParameter)
+	}
+	if scopeId == "" {
+		return nil, fmt.Errorf("rotate keys: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	var keys Keys
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			keys, err = rotateKeysTx(ctx, reader, w, rootWrapper, randomReader, scopeId)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Exec(ctx, upsertRewrapJobQuery, []interface{}{scopeId}); err != nil {
+				return fmt.Errorf("unable to queue rewrap job: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("rotate keys: %w", err)
+	}
+	return keys, nil
+}
+
+func rotateKeysTx(ctx context.Context, r db.Reader, w db.Writer, rootWrapper wrapping.Wrapper, randomReader io.Reader, scopeId string) (Keys, error) {
+	rk := AllocRootKey()
+	if err := r.LookupWhere(ctx, &rk, "scope_id = ?", scopeId); err != nil {
+		return nil, fmt.Errorf("unable to lookup root key in scope %s: %w", scopeId, err)
+	}
+	k, err := generateKey(randomReader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating random bytes for root key in scope %s: %w", scopeId, err)
+	}
+	rootKeyVersion, err := createRootKeyVersionTx(ctx, w, rootWrapper, rk.PrivateId, k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create root key version in scope %s: %w", scopeId, err)
+	}
+
+	rkvWrapper := aead.NewWrapper(nil)
+	if _, err := rkvWrapper.SetConfig(map[string]string{
+		"key_id": rootKeyVersion.GetPrivateId(),
+	}); err != nil {
+		return nil, fmt.Errorf("error setting config on aead root wrapper in scope %s: %w", scopeId, err)
+	}
+	if err := rkvWrapper.SetAESGCMKeyBytes(rootKeyVersion.GetKey()); err != nil {
+		return nil, fmt.Errorf("error setting key bytes on aead root wrapper in scope %s: %w", scopeId, err)
+	}
+
+	dbKey := AllocDatabaseKey()
+	if err := r.LookupWhere(ctx, &dbKey, "root_key_id = ?", rk.PrivateId); err != nil {
+		return nil, fmt.Errorf("unable to lookup database key in scope %s: %w", scopeId, err)
+	}
+	if k, err = generateKey(randomReader); err != nil {
+		return nil, fmt.Errorf("error generating random bytes for database key in scope %s: %w", scopeId, err)
+	}
+	dbKeyVersion, err := createDatabaseKeyVersionTx(ctx, w, rkvWrapper, dbKey.PrivateId, k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create database key version in scope %s: %w", scopeId, err)
+	}
+
+	oplogKey := AllocOplogKey()
+	if err := r.LookupWhere(ctx, &oplogKey, "root_key_id = ?", rk.PrivateId); err != nil {
+		return nil, fmt.Errorf("unable to lookup oplog key in scope %s: %w", scopeId, err)
+	}
+	if k, err = generateKey(randomReader); err != nil {
+		return nil, fmt.Errorf("error generating random bytes for oplog key in scope %s: %w", scopeId, err)
+	}
+	oplogKeyVersion, err := createOplogKeyVersionTx(ctx, w, rkvWrapper, oplogKey.PrivateId, k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create oplog key version in scope %s: %w", scopeId, err)
+	}
+
+	sessionKey := AllocSessionKey()
+	if err := r.LookupWhere(ctx, &sessionKey, "root_key_id = ?", rk.PrivateId); err != nil {
+		return nil, fmt.Errorf("unable to lookup session key in scope %s: %w", scopeId, err)
+	}
+	if k, err = generateKey(randomReader); err != nil {
+		return nil, fmt.Errorf("error generating random bytes for session key in scope %s: %w", scopeId, err)
+	}
+	sessionKeyVersion, err := createSessionKeyVersionTx(ctx, w, rkvWrapper, sessionKey.PrivateId, k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create session key version in scope %s: %w", scopeId, err)
+	}
+
+	tokenKey := AllocTokenKey()
+	if err := r.LookupWhere(ctx, &tokenKey, "root_key_id = ?", rk.PrivateId); err != nil {
+		return nil, fmt.Errorf("unable to lookup token key in scope %s: %w", scopeId, err)
+	}
+	if k, err = generateKey(randomReader); err != nil {
+		return nil, fmt.Errorf("error generating random bytes for token key in scope %s: %w", scopeId, err)
+	}
+	tokenKeyVersion, err := createTokenKeyVersionTx(ctx, w, rkvWrapper, tokenKey.PrivateId, k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create token key version in scope %s: %w", scopeId, err)
+	}
+
+	keys := Keys{
+		KeyTypeRootKeyVersion:     rootKeyVersion,
+		KeyTypeDatabaseKeyVersion: dbKeyVersion,
+		KeyTypeOplogKeyVersion:    oplogKeyVersion,
+		KeyTypeSessionKeyVersion:  sessionKeyVersion,
+		KeyTypeTokenKeyVersion:    tokenKeyVersion,
+	}
+	return keys, nil
+}
+
 func generateKey(randomReader io.Reader) ([]byte, error) {
 	k, err := uuid.GenerateRandomBytesWithReader(32, randomReader)
 	if err != nil {
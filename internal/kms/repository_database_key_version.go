@@ -62,6 +62,42 @@ func (r *Repository) CreateDatabaseKeyVersion(ctx context.Context, rkvWrapper wr
 	return returnedKey.(*DatabaseKeyVersion), err
 }
 
+// createDatabaseKeyVersionTx inserts a new version of the database key databaseKeyId within
+// the transaction of w, encrypted with the root key version of rkvWrapper.
+// The database assigns the version number.
+func createDatabaseKeyVersionTx(ctx context.Context, w db.Writer, rkvWrapper wrapping.Wrapper, databaseKeyId string, key []byte) (*DatabaseKeyVersion, error) {
+	if rkvWrapper == nil {
+		return nil, fmt.Errorf("create database key version: missing root key version wrapper: %w", errors.ErrInvalidParameter)
+	}
+	rootKeyVersionId := rkvWrapper.KeyID()
+	if !strings.HasPrefix(rootKeyVersionId, RootKeyVersionPrefix) {
+		return nil, fmt.Errorf("create database key version: root key version id %s doesn't start with prefix %s: %w", rootKeyVersionId, RootKeyVersionPrefix, errors.ErrInvalidParameter)
+	}
+	if databaseKeyId == "" {
+		return nil, fmt.Errorf("create database key version: missing database key id: %w", errors.ErrInvalidParameter)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("create database key version: missing key: %w", errors.ErrInvalidParameter)
+	}
+	kv := AllocDatabaseKeyVersion()
+	id, err := newDatabaseKeyVersionId()
+	if err != nil {
+		return nil, fmt.Errorf("create database key version: %w", err)
+	}
+	kv.PrivateId = id
+	kv.RootKeyVersionId = rootKeyVersionId
+	kv.Key = key
+	kv.DatabaseKeyId = databaseKeyId
+	if err := kv.Encrypt(ctx, rkvWrapper); err != nil {
+		return nil, fmt.Errorf("create database key version: encrypt: %w", err)
+	}
+	// no oplog entries for key versions
+	if err := w.Create(ctx, &kv); err != nil {
+		return nil, fmt.Errorf("create database key version: %w for %s database key id", err, databaseKeyId)
+	}
+	return &kv, nil
+}
+
 // LookupDatabaseKeyVersion will look up a key version in the repository.  If
 // the key version is not found, it will return nil, nil.
 func (r *Repository) LookupDatabaseKeyVersion(ctx context.Context, keyWrapper wrapping.Wrapper, privateId string, opt ...Option) (*DatabaseKeyVersion, error) {
@@ -62,6 +62,42 @@ func (r *Repository) CreateOplogKeyVersion(ctx context.Context, rkvWrapper wrapp
 	return returnedKey.(*OplogKeyVersion), err
 }
 
+// createOplogKeyVersionTx inserts a new version of the oplog key oplogKeyId within
+// the transaction of w, encrypted with the root key version of rkvWrapper.
+// The database assigns the version number.
+func createOplogKeyVersionTx(ctx context.Context, w db.Writer, rkvWrapper wrapping.Wrapper, oplogKeyId string, key []byte) (*OplogKeyVersion, error) {
+	if rkvWrapper == nil {
+		return nil, fmt.Errorf("create oplog key version: missing root key version wrapper: %w", errors.ErrInvalidParameter)
+	}
+	rootKeyVersionId := rkvWrapper.KeyID()
+	if !strings.HasPrefix(rootKeyVersionId, RootKeyVersionPrefix) {
+		return nil, fmt.Errorf("create oplog key version: root key version id %s doesn't start with prefix %s: %w", rootKeyVersionId, RootKeyVersionPrefix, errors.ErrInvalidParameter)
+	}
+	if oplogKeyId == "" {
+		return nil, fmt.Errorf("create oplog key version: missing oplog key id: %w", errors.ErrInvalidParameter)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("create oplog key version: missing key: %w", errors.ErrInvalidParameter)
+	}
+	kv := AllocOplogKeyVersion()
+	id, err := newOplogKeyVersionId()
+	if err != nil {
+		return nil, fmt.Errorf("create oplog key version: %w", err)
+	}
+	kv.PrivateId = id
+	kv.RootKeyVersionId = rootKeyVersionId
+	kv.Key = key
+	kv.OplogKeyId = oplogKeyId
+	if err := kv.Encrypt(ctx, rkvWrapper); err != nil {
+		return nil, fmt.Errorf("create oplog key version: encrypt: %w", err)
+	}
+	// no oplog entries for key versions
+	if err := w.Create(ctx, &kv); err != nil {
+		return nil, fmt.Errorf("create oplog key version: %w for %s oplog key id", err, oplogKeyId)
+	}
+	return &kv, nil
+}
+
 // LookupOplogKeyVersion will look up a key version in the repository.  If
 // the key version is not found, it will return nil, nil.
 func (r *Repository) LookupOplogKeyVersion(ctx context.Context, keyWrapper wrapping.Wrapper, privateId string, opt ...Option) (*OplogKeyVersion, error) {
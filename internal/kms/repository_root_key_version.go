@@ -53,6 +53,37 @@ func (r *Repository) CreateRootKeyVersion(ctx context.Context, keyWrapper wrappi
 	return returnedKey.(*RootKeyVersion), err
 }
 
+// createRootKeyVersionTx inserts a new version of the root key rootKeyId
+// within the transaction of w, encrypted with keyWrapper. The database assigns
+// the version number.
+func createRootKeyVersionTx(ctx context.Context, w db.Writer, keyWrapper wrapping.Wrapper, rootKeyId string, key []byte) (*RootKeyVersion, error) {
+	if rootKeyId == "" {
+		return nil, fmt.Errorf("create root key version: missing root key id: %w", errors.ErrInvalidParameter)
+	}
+	if keyWrapper == nil {
+		return nil, fmt.Errorf("create root key version: missing key wrapper: %w", errors.ErrInvalidParameter)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("create root key version: missing key: %w", errors.ErrInvalidParameter)
+	}
+	kv := AllocRootKeyVersion()
+	id, err := newRootKeyVersionId()
+	if err != nil {
+		return nil, fmt.Errorf("create root key version: %w", err)
+	}
+	kv.PrivateId = id
+	kv.RootKeyId = rootKeyId
+	kv.Key = key
+	if err := kv.Encrypt(ctx, keyWrapper); err != nil {
+		return nil, fmt.Errorf("create root key version: encrypt: %w", err)
+	}
+	// no oplog entries for root key versions
+	if err := w.Create(ctx, &kv); err != nil {
+		return nil, fmt.Errorf("create root key version: %w for %s root key id", err, rootKeyId)
+	}
+	return &kv, nil
+}
+
 // LookupRootKeyVersion will look up a root key version in the repository.  If
 // the key version is not found, it will return nil, nil.
 func (r *Repository) LookupRootKeyVersion(ctx context.Context, keyWrapper wrapping.Wrapper, privateId string, opt ...Option) (*RootKeyVersion, error) {
@@ -62,6 +62,42 @@ func (r *Repository) CreateSessionKeyVersion(ctx context.Context, rkvWrapper wra
 	return returnedKey.(*SessionKeyVersion), err
 }
 
+// createSessionKeyVersionTx inserts a new version of the session key sessionKeyId within
+// the transaction of w, encrypted with the root key version of rkvWrapper.
+// The database assigns the version number.
+func createSessionKeyVersionTx(ctx context.Context, w db.Writer, rkvWrapper wrapping.Wrapper, sessionKeyId string, key []byte) (*SessionKeyVersion, error) {
+	if rkvWrapper == nil {
+		return nil, fmt.Errorf("create session key version: missing root key version wrapper: %w", errors.ErrInvalidParameter)
+	}
+	rootKeyVersionId := rkvWrapper.KeyID()
+	if !strings.HasPrefix(rootKeyVersionId, RootKeyVersionPrefix) {
+		return nil, fmt.Errorf("create session key version: root key version id %s doesn't start with prefix %s: %w", rootKeyVersionId, RootKeyVersionPrefix, errors.ErrInvalidParameter)
+	}
+	if sessionKeyId == "" {
+		return nil, fmt.Errorf("create session key version: missing session key id: %w", errors.ErrInvalidParameter)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("create session key version: missing key: %w", errors.ErrInvalidParameter)
+	}
+	kv := AllocSessionKeyVersion()
+	id, err := newSessionKeyVersionId()
+	if err != nil {
+		return nil, fmt.Errorf("create session key version: %w", err)
+	}
+	kv.PrivateId = id
+	kv.RootKeyVersionId = rootKeyVersionId
+	kv.Key = key
+	kv.SessionKeyId = sessionKeyId
+	if err := kv.Encrypt(ctx, rkvWrapper); err != nil {
+		return nil, fmt.Errorf("create session key version: encrypt: %w", err)
+	}
+	// no oplog entries for key versions
+	if err := w.Create(ctx, &kv); err != nil {
+		return nil, fmt.Errorf("create session key version: %w for %s session key id", err, sessionKeyId)
+	}
+	return &kv, nil
+}
+
 // LookupSessionKeyVersion will look up a key version in the repository.  If
 // the key version is not found, it will return nil, nil.
 func (r *Repository) LookupSessionKeyVersion(ctx context.Context, keyWrapper wrapping.Wrapper, privateId string, opt ...Option) (*SessionKeyVersion, error) {
@@ -62,6 +62,42 @@ func (r *Repository) CreateTokenKeyVersion(ctx context.Context, rkvWrapper wrapp
 	return returnedKey.(*TokenKeyVersion), err
 }
 
+// createTokenKeyVersionTx inserts a new version of the token key tokenKeyId within
+// the transaction of w, encrypted with the root key version of rkvWrapper.
+// The database assigns the version number.
+func createTokenKeyVersionTx(ctx context.Context, w db.Writer, rkvWrapper wrapping.Wrapper, tokenKeyId string, key []byte) (*TokenKeyVersion, error) {
+	if rkvWrapper == nil {
+		return nil, fmt.Errorf("create token key version: missing root key version wrapper: %w", errors.ErrInvalidParameter)
+	}
+	rootKeyVersionId := rkvWrapper.KeyID()
+	if !strings.HasPrefix(rootKeyVersionId, RootKeyVersionPrefix) {
+		return nil, fmt.Errorf("create token key version: root key version id %s doesn't start with prefix %s: %w", rootKeyVersionId, RootKeyVersionPrefix, errors.ErrInvalidParameter)
+	}
+	if tokenKeyId == "" {
+		return nil, fmt.Errorf("create token key version: missing token key id: %w", errors.ErrInvalidParameter)
+	}
+	if len(key) == 0 {
+		return nil, fmt.Errorf("create token key version: missing key: %w", errors.ErrInvalidParameter)
+	}
+	kv := AllocTokenKeyVersion()
+	id, err := newTokenKeyVersionId()
+	if err != nil {
+		return nil, fmt.Errorf("create token key version: %w", err)
+	}
+	kv.PrivateId = id
+	kv.RootKeyVersionId = rootKeyVersionId
+	kv.Key = key
+	kv.TokenKeyId = tokenKeyId
+	if err := kv.Encrypt(ctx, rkvWrapper); err != nil {
+		return nil, fmt.Errorf("create token key version: encrypt: %w", err)
+	}
+	// no oplog entries for key versions
+	if err := w.Create(ctx, &kv); err != nil {
+		return nil, fmt.Errorf("create token key version: %w for %s token key id", err, tokenKeyId)
+	}
+	return &kv, nil
+}
+
 // LookupTokenKeyVersion will look up a key version in the repository.  If
 // the key version is not found, it will return nil, nil.
 func (r *Repository) LookupTokenKeyVersion(ctx context.Context, keyWrapper wrapping.Wrapper, privateId string, opt ...Option) (*TokenKeyVersion, error) {
@@ -0,0 +1,461 @@
+package kms
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+const (
+	// rewrapBatchSize is the number of rows a RewrapFn re-encrypts in each
+	// transaction of a rewrap job.
+	rewrapBatchSize = 100
+
+	// rewrapJobTimeout is the time after which a running rewrap job which did
+	// not report progress is assumed abandoned and can be claimed again.
+	rewrapJobTimeout = 5 * time.Minute
+)
+
+// errRewrapJobSuperseded is returned when the keys of the scope of a running
+// rewrap job were rotated again, which queued a new job.
+var errRewrapJobSuperseded = stderrors.New("rewrap job superseded by a new rotation")
+
+// RewrapFn re-encrypts with wrapper at most limit rows of a table which are
+// encrypted with one of the key versions in keyVersionIds, and returns the
+// number of rows it updated. wrapper is the scope's multiwrapper for the
+// purpose the table was registered with: it decrypts with any version of the
+// key and encrypts with the newest one. The updates must set the key_id
+// column of the rows to the ID of the newest version, so the rows are not
+// returned again.
+type RewrapFn func(ctx context.Context, keyVersionIds []string, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, limit int) (int, error)
+
+type rewrapTable struct {
+	tableName string
+	purpose   KeyPurpose
+	fn        RewrapFn
+	// rewrappable is the condition selecting the rows of the table which fn
+	// re-encrypts.
+	rewrappable string
+}
+
+var (
+	rewrapTablesMutex sync.RWMutex
+	rewrapTables      []rewrapTable
+)
+
+// RegisterTableRewrapFn registers fn to re-encrypt the rows of tableName,
+// whose values are encrypted with the key of their scope for purpose and
+// whose key_id column holds the ID of the key version which encrypted them.
+// Packages owning encrypted tables call it from their init functions.
+func RegisterTableRewrapFn(tableName string, purpose KeyPurpose, fn RewrapFn) {
+	registerTableRewrapFn(tableName, purpose, fn, "true")
+}
+
+// registerTableRewrapFn registers fn to re-encrypt the rows of tableName
+// selected by the condition rewrappable. The other rows keep depending on the
+// key version which encrypted them.
+func registerTableRewrapFn(tableName string, purpose KeyPurpose, fn RewrapFn, rewrappable string) {
+	rewrapTablesMutex.Lock()
+	defer rewrapTablesMutex.Unlock()
+	for _, t := range rewrapTables {
+		if t.tableName == tableName {
+			panic(fmt.Sprintf("rewrap function for table %s registered twice", tableName))
+		}
+	}
+	rewrapTables = append(rewrapTables, rewrapTable{
+		tableName:   tableName,
+		purpose:     purpose,
+		fn:          fn,
+		rewrappable: rewrappable,
+	})
+}
+
+func registeredRewrapTables() []rewrapTable {
+	rewrapTablesMutex.RLock()
+	defer rewrapTablesMutex.RUnlock()
+	ret := make([]rewrapTable, len(rewrapTables))
+	copy(ret, rewrapTables)
+	return ret
+}
+
+// RewrapJobState is the state of a rewrap job.
+type RewrapJobState string
+
+const (
+	// RewrapJobPending jobs were queued by a rotation and have not been
+	// picked up by a controller yet.
+	RewrapJobPending RewrapJobState = "pending"
+	// RewrapJobRunning jobs are re-encrypting the data of their scope.
+	RewrapJobRunning RewrapJobState = "running"
+	// RewrapJobCompleted jobs re-encrypted all the data of their scope.
+	RewrapJobCompleted RewrapJobState = "completed"
+	// RewrapJobFailed jobs stopped on an error.
+	RewrapJobFailed RewrapJobState = "failed"
+)
+
+// String returns the string representation of the rewrap job state.
+func (s RewrapJobState) String() string {
+	return string(s)
+}
+
+// A RewrapJob re-encrypts the data of a scope with the newest versions of its
+// keys. Rotating the keys of a scope queues a job, which is then run in the
+// background by one of the controllers.
+type RewrapJob struct {
+	ScopeId string
+	State   RewrapJobState
+	// RewrappedCount is the number of rows re-encrypted so far.
+	RewrappedCount int64
+	// RemainingCount is the number of rows of the scope still encrypted with
+	// an older version of its keys which the job re-encrypts.
+	RemainingCount int64
+	// Error is the reason a failed job stopped.
+	Error      string
+	RotateTime time.Time
+	UpdateTime time.Time
+	// EndTime is zero until the job completed or failed.
+	EndTime time.Time
+}
+
+// dekName returns the name of the DEK for purpose in the names of its tables.
+func dekName(purpose KeyPurpose) (string, error) {
+	switch purpose {
+	case KeyPurposeDatabase:
+		return "database", nil
+	case KeyPurposeOplog:
+		return "oplog", nil
+	case KeyPurposeTokens:
+		return "token", nil
+	case KeyPurposeSessions:
+		return "session", nil
+	default:
+		return "", fmt.Errorf("unsupported purpose %q: %w", purpose, errors.ErrInvalidParameter)
+	}
+}
+
+// KeyVersionIds returns the IDs of the versions of the scope's key for
+// purpose, newest first.
+func (k *Kms) KeyVersionIds(ctx context.Context, scopeId string, purpose KeyPurpose) ([]string, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("key version ids: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	name, err := dekName(purpose)
+	if err != nil {
+		return nil, fmt.Errorf("key version ids: %w", err)
+	}
+	rows, err := k.repo.reader.Query(ctx, fmt.Sprintf(dekVersionIdsQuery, name), []interface{}{scopeId})
+	if err != nil {
+		return nil, fmt.Errorf("key version ids: %w", err)
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("key version ids: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("key version ids: %w", err)
+	}
+	return ids, nil
+}
+
+// rootWrapper returns the external wrapper of the root keys.
+func (k *Kms) rootWrapper() (wrapping.Wrapper, error) {
+	k.externalScopeCacheMutex.RLock()
+	ext := k.externalScopeCache[scope.Global.String()]
+	k.externalScopeCacheMutex.RUnlock()
+	if ext == nil {
+		return nil, stderrors.New("could not find kms information at either the needed scope or global fallback")
+	}
+	root := ext.Root()
+	if root == nil {
+		return nil, stderrors.New("root key wrapper is nil")
+	}
+	return root, nil
+}
+
+// clearScopeCache drops the cached wrappers of the scope, so they are loaded
+// again with the newest key versions.
+func (k *Kms) clearScopeCache(scopeId string) {
+	for _, purpose := range []KeyPurpose{KeyPurposeDatabase, KeyPurposeOplog, KeyPurposeTokens, KeyPurposeSessions} {
+		k.scopePurposeCache.Delete(scopeId + purpose.String())
+	}
+}
+
+// RotateKeys creates new versions of the root key and DEKs of the scope and
+// queues a job to re-encrypt the existing data of the scope with them, which
+// is returned. The new versions are used right away by this Kms; the other
+// controllers drop their cached wrappers of the scope the next time they call
+// RunRewrapJobs.
+func (k *Kms) RotateKeys(ctx context.Context, scopeId string, randomReader io.Reader) (*RewrapJob, error) {
+	rootWrapper, err := k.rootWrapper()
+	if err != nil {
+		return nil, fmt.Errorf("rotate keys: %w", err)
+	}
+	if _, err := k.repo.RotateKeys(ctx, rootWrapper, randomReader, scopeId); err != nil {
+		return nil, err
+	}
+	k.clearScopeCache(scopeId)
+	job, err := k.LookupRewrapJob(ctx, scopeId)
+	if err != nil {
+		return nil, fmt.Errorf("rotate keys: %w", err)
+	}
+	return job, nil
+}
+
+// LookupRewrapJob returns the latest rewrap job of the scope along with the
+// number of rows still to re-encrypt. If the keys of the scope were never
+// rotated, it returns nil, nil.
+func (k *Kms) LookupRewrapJob(ctx context.Context, scopeId string) (*RewrapJob, error) {
+	if scopeId == "" {
+		return nil, fmt.Errorf("lookup rewrap job: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	rows, err := k.repo.reader.Query(ctx, lookupRewrapJobQuery, []interface{}{scopeId})
+	if err != nil {
+		return nil, fmt.Errorf("lookup rewrap job: %w", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("lookup rewrap job: %w", err)
+		}
+		return nil, nil
+	}
+	var (
+		job     RewrapJob
+		state   string
+		errMsg  sql.NullString
+		endTime sql.NullTime
+	)
+	if err := rows.Scan(&job.ScopeId, &state, &job.RewrappedCount, &errMsg, &job.RotateTime, &job.UpdateTime, &endTime); err != nil {
+		return nil, fmt.Errorf("lookup rewrap job: %w", err)
+	}
+	job.State = RewrapJobState(state)
+	job.Error = errMsg.String
+	job.EndTime = endTime.Time
+	rows.Close()
+	if job.RemainingCount, err = k.remainingCount(ctx, scopeId); err != nil {
+		return nil, fmt.Errorf("lookup rewrap job: %w", err)
+	}
+	return &job, nil
+}
+
+// remainingCount returns the number of rows of the registered tables which
+// are encrypted with an older version of the keys of the scope and which the
+// rewrap functions re-encrypt.
+func (k *Kms) remainingCount(ctx context.Context, scopeId string) (int64, error) {
+	var total int64
+	for _, t := range registeredRewrapTables() {
+		ids, err := k.KeyVersionIds(ctx, scopeId, t.purpose)
+		if err != nil {
+			return 0, err
+		}
+		if len(ids) < 2 {
+			continue
+		}
+		for _, id := range ids[1:] {
+			count, err := countRows(ctx, k.repo.reader, fmt.Sprintf(countRewrappableRowsQuery, t.tableName, t.rewrappable), id)
+			if err != nil {
+				return 0, fmt.Errorf("unable to count rows of %s encrypted with %s: %w", t.tableName, id, err)
+			}
+			total += count
+		}
+	}
+	return total, nil
+}
+
+func (k *Kms) countEncryptedRows(ctx context.Context, r db.Reader, tableName, keyVersionId string) (int64, error) {
+	rows, err := r.Query(ctx, fmt.Sprintf(countEncryptedRowsQuery, tableName), []interface{}{keyVersionId})
+	if err != nil {
+		return 0, fmt.Errorf("unable to count rows of %s encrypted with %s: %w", tableName, keyVersionId, err)
+	}
+	defer rows.Close()
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, fmt.Errorf("unable to count rows of %s encrypted with %s: %w", tableName, keyVersionId, err)
+		}
+	}
+	return count, rows.Err()
+}
+
+// RunRewrapJobs runs the pending rewrap jobs, one after the other, until there
+// are none left, and returns the number of jobs it ran. Jobs which fail are
+// recorded as failed and do not stop the others. Before that, it drops the
+// cached wrappers of the scopes whose keys were rotated since it last ran, and
+// the key versions destroyed since, so each controller picks up these changes
+// even when another controller made them.
+func (k *Kms) RunRewrapJobs(ctx context.Context) (int, error) {
+	if err := k.refreshRotatedScopes(ctx); err != nil {
+		return 0, fmt.Errorf("run rewrap jobs: %w", err)
+	}
+	if err := k.refreshDestroyedKeyVersions(ctx); err != nil {
+		return 0, fmt.Errorf("run rewrap jobs: %w", err)
+	}
+	var ran int
+	for {
+		scopeId, rotateTime, err := k.claimRewrapJob(ctx)
+		if err != nil {
+			return ran, fmt.Errorf("run rewrap jobs: %w", err)
+		}
+		if scopeId == "" {
+			return ran, nil
+		}
+		ran++
+		k.logger.Info("rewrap job started", "scope_id", scopeId)
+		count, jobErr := k.runRewrapJob(ctx, scopeId, rotateTime)
+		switch {
+		case stderrors.Is(jobErr, errRewrapJobSuperseded):
+			k.logger.Info("rewrap job superseded by a new rotation", "scope_id", scopeId, "rewrapped", count)
+			continue
+		case jobErr != nil:
+			k.logger.Error("rewrap job failed", "scope_id", scopeId, "rewrapped", count, "error", jobErr)
+			if err := k.endRewrapJob(ctx, scopeId, rotateTime, RewrapJobFailed, jobErr.Error()); err != nil {
+				return ran, fmt.Errorf("run rewrap jobs: %w", err)
+			}
+		default:
+			k.logger.Info("rewrap job completed", "scope_id", scopeId, "rewrapped", count)
+			if err := k.endRewrapJob(ctx, scopeId, rotateTime, RewrapJobCompleted, ""); err != nil {
+				return ran, fmt.Errorf("run rewrap jobs: %w", err)
+			}
+		}
+	}
+}
+
+func (k *Kms) refreshRotatedScopes(ctx context.Context) error {
+	k.lastRotateTimeMutex.Lock()
+	defer k.lastRotateTimeMutex.Unlock()
+	rows, err := k.repo.reader.Query(ctx, rotatedScopesQuery, []interface{}{k.lastRotateTime})
+	if err != nil {
+		return fmt.Errorf("unable to list rotated scopes: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var scopeId string
+		var rotateTime time.Time
+		if err := rows.Scan(&scopeId, &rotateTime); err != nil {
+			return fmt.Errorf("unable to list rotated scopes: %w", err)
+		}
+		k.clearScopeCache(scopeId)
+		if rotateTime.After(k.lastRotateTime) {
+			k.lastRotateTime = rotateTime
+		}
+	}
+	return rows.Err()
+}
+
+// claimRewrapJob sets the oldest pending job to running and returns its scope
+// and rotate time, or an empty scope ID when there is no job to run.
+func (k *Kms) claimRewrapJob(ctx context.Context) (string, time.Time, error) {
+	var scopeId string
+	var rotateTime time.Time
+	_, err := k.repo.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(r db.Reader, _ db.Writer) error {
+			scopeId = ""
+			rows, err := r.Query(ctx, claimRewrapJobQuery, []interface{}{int(rewrapJobTimeout.Seconds())})
+			if err != nil {
+				return err
+			}
+			defer rows.Close()
+			for rows.Next() {
+				if err := rows.Scan(&scopeId, &rotateTime); err != nil {
+					return err
+				}
+			}
+			return rows.Err()
+		},
+	)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("unable to claim rewrap job: %w", err)
+	}
+	return scopeId, rotateTime, nil
+}
+
+// runRewrapJob re-encrypts the rows of the registered tables of the scope
+// which are encrypted with older versions of its keys, in batches, and
+// returns the number of rows re-encrypted. The progress of the job is
+// recorded in the same transaction as each batch.
+func (k *Kms) runRewrapJob(ctx context.Context, scopeId string, rotateTime time.Time) (int64, error) {
+	// This controller may have cached wrappers of the previous versions
+	k.clearScopeCache(scopeId)
+
+	var total int64
+	for _, t := range registeredRewrapTables() {
+		ids, err := k.KeyVersionIds(ctx, scopeId, t.purpose)
+		if err != nil {
+			return total, err
+		}
+		if len(ids) < 2 {
+			continue
+		}
+		wrapper, err := k.GetWrapper(ctx, scopeId, t.purpose)
+		if err != nil {
+			return total, fmt.Errorf("unable to get %s wrapper: %w", t.purpose, err)
+		}
+		if wrapper.KeyID() != ids[0] {
+			return total, fmt.Errorf("%s wrapper encrypts with %s instead of the newest key version %s", t.purpose, wrapper.KeyID(), ids[0])
+		}
+		var tableTotal int64
+		for {
+			var count int
+			_, err := k.repo.writer.DoTx(
+				ctx,
+				db.StdRetryCnt,
+				db.ExpBackoff{},
+				func(r db.Reader, w db.Writer) error {
+					var err error
+					count, err = t.fn(ctx, ids[1:], r, w, wrapper, rewrapBatchSize)
+					if err != nil {
+						return err
+					}
+					if count == 0 {
+						return nil
+					}
+					rowsUpdated, err := w.Exec(ctx, updateRewrapJobProgressQuery, []interface{}{scopeId, rotateTime, count})
+					if err != nil {
+						return err
+					}
+					if rowsUpdated == 0 {
+						return errRewrapJobSuperseded
+					}
+					return nil
+				},
+			)
+			if err != nil {
+				if stderrors.Is(err, errRewrapJobSuperseded) {
+					return total, err
+				}
+				return total, fmt.Errorf("unable to rewrap %s: %w", t.tableName, err)
+			}
+			if count == 0 {
+				break
+			}
+			total += int64(count)
+			tableTotal += int64(count)
+			k.logger.Debug("rewrap job progress", "scope_id", scopeId, "table", t.tableName, "rewrapped", tableTotal)
+		}
+	}
+	return total, nil
+}
+
+func (k *Kms) endRewrapJob(ctx context.Context, scopeId string, rotateTime time.Time, state RewrapJobState, errMsg string) error {
+	if _, err := k.repo.writer.Exec(ctx, endRewrapJobQuery, []interface{}{scopeId, rotateTime, state.String(), errMsg}); err != nil {
+		return fmt.Errorf("unable to end rewrap job of scope %s: %w", scopeId, err)
+	}
+	return nil
+}
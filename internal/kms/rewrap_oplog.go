@@ -0,0 +1,59 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	registerTableRewrapFn("oplog_entry", KeyPurposeOplog, oplogEntryRewrapFn, "hash is null")
+}
+
+// oplogEntryRewrapFn re-encrypts the data of oplog entries. The key_id of the
+// entries written before it was recorded is set first, from the key ID in
+// their encrypted data, or to an empty string when it can't be read; these
+// updates are included in the returned count. Hash chained entries are not
+// re-encrypted, since their hash covers their ciphertext: they keep depending
+// on the key version which encrypted them.
+func oplogEntryRewrapFn(ctx context.Context, keyVersionIds []string, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, limit int) (int, error) {
+	var legacy []*store.Entry
+	if err := r.SearchWhere(ctx, &legacy, "key_id is null", nil, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap oplog entries: %w", err)
+	}
+	if len(legacy) > 0 {
+		for _, e := range legacy {
+			keyId := oplog.DataKeyId(e.CtData)
+			if _, err := w.Exec(ctx, setOplogEntryKeyIdQuery, []interface{}{keyId, e.Id}); err != nil {
+				return 0, fmt.Errorf("rewrap oplog entries: unable to set key id of entry %d: %w", e.Id, err)
+			}
+		}
+		return len(legacy), nil
+	}
+
+	var entries []*store.Entry
+	if err := r.SearchWhere(ctx, &entries, "key_id in (?) and hash is null", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap oplog entries: %w", err)
+	}
+	var updated int
+	for _, e := range entries {
+		oldKeyId := e.KeyId
+		entry := &oplog.Entry{Entry: e, Cipherer: wrapper}
+		if err := entry.DecryptData(ctx); err != nil {
+			return updated, fmt.Errorf("rewrap oplog entries: entry %d: %w", e.Id, err)
+		}
+		if err := entry.EncryptData(ctx); err != nil {
+			return updated, fmt.Errorf("rewrap oplog entries: entry %d: %w", e.Id, err)
+		}
+		rowsUpdated, err := w.Exec(ctx, rewrapOplogEntryQuery, []interface{}{e.CtData, e.KeyId, e.Id, oldKeyId})
+		if err != nil {
+			return updated, fmt.Errorf("rewrap oplog entries: unable to update entry %d: %w", e.Id, err)
+		}
+		updated += rowsUpdated
+	}
+	return updated, nil
+}
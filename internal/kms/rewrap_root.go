@@ -0,0 +1,95 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// RewrapRootKeyVersions re-encrypts the keys of all the root key versions of
+// all the scopes, which are encrypted with oldWrapper, with newWrapper. The
+// keys are read back and decrypted with newWrapper before the transaction is
+// committed, so either all of them are rewrapped and verified or none are.
+// Versions already encrypted with newWrapper are only verified, so rewrapping
+// can safely be retried. It returns the number of versions rewrapped.
+func (r *Repository) RewrapRootKeyVersions(ctx context.Context, oldWrapper, newWrapper wrapping.Wrapper) (int, error) {
+	if oldWrapper == nil {
+		return db.NoRowsAffected, fmt.Errorf("rewrap root key versions: missing old key wrapper: %w", errors.ErrInvalidParameter)
+	}
+	if newWrapper == nil {
+		return db.NoRowsAffected, fmt.Errorf("rewrap root key versions: missing new key wrapper: %w", errors.ErrInvalidParameter)
+	}
+	newKeyId := newWrapper.KeyID()
+	if newKeyId == "" {
+		return db.NoRowsAffected, fmt.Errorf("rewrap root key versions: new key wrapper has no key id: %w", errors.ErrInvalidParameter)
+	}
+	if oldWrapper.KeyID() == newKeyId {
+		return db.NoRowsAffected, fmt.Errorf("rewrap root key versions: old and new key wrappers have the same key id %q: %w", newKeyId, errors.ErrInvalidParameter)
+	}
+
+	var rewrapped int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			rewrapped = 0
+			var versions []*RootKeyVersion
+			if err := reader.SearchWhere(ctx, &versions, "", nil, db.WithLimit(-1)); err != nil {
+				return fmt.Errorf("unable to list root key versions: %w", err)
+			}
+			keys := make(map[string][]byte, len(versions))
+			for _, kv := range versions {
+				if kv.KeyId == newKeyId {
+					if err := kv.Decrypt(ctx, newWrapper); err != nil {
+						return fmt.Errorf("unable to decrypt %s with the new key wrapper: %w", kv.PrivateId, err)
+					}
+					keys[kv.PrivateId] = kv.Key
+					continue
+				}
+				if err := kv.Decrypt(ctx, oldWrapper); err != nil {
+					return fmt.Errorf("unable to decrypt %s with the old key wrapper: %w", kv.PrivateId, err)
+				}
+				keys[kv.PrivateId] = kv.Key
+				if err := kv.Encrypt(ctx, newWrapper); err != nil {
+					return fmt.Errorf("unable to encrypt %s with the new key wrapper: %w", kv.PrivateId, err)
+				}
+				rowsUpdated, err := w.Exec(ctx, rewrapRootKeyVersionQuery, []interface{}{kv.CtKey, kv.KeyId, kv.PrivateId})
+				if err != nil {
+					return fmt.Errorf("unable to update %s: %w", kv.PrivateId, err)
+				}
+				if rowsUpdated != 1 {
+					return fmt.Errorf("unable to update %s: %d rows updated", kv.PrivateId, rowsUpdated)
+				}
+				rewrapped++
+			}
+
+			// Verify every key can be decrypted with the new wrapper, and is
+			// unchanged, before committing
+			var updated []*RootKeyVersion
+			if err := reader.SearchWhere(ctx, &updated, "", nil, db.WithLimit(-1)); err != nil {
+				return fmt.Errorf("unable to list root key versions: %w", err)
+			}
+			if len(updated) != len(versions) {
+				return fmt.Errorf("verification failed: %d root key versions found, expected %d", len(updated), len(versions))
+			}
+			for _, kv := range updated {
+				if err := kv.Decrypt(ctx, newWrapper); err != nil {
+					return fmt.Errorf("verification failed: unable to decrypt %s with the new key wrapper: %w", kv.PrivateId, err)
+				}
+				if !bytes.Equal(kv.Key, keys[kv.PrivateId]) {
+					return fmt.Errorf("verification failed: key of %s changed", kv.PrivateId)
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("rewrap root key versions: %w", err)
+	}
+	return rewrapped, nil
+}
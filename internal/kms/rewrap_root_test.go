@@ -0,0 +1,74 @@
+package kms_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dberrors "github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_RewrapRootKeyVersions(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	oldWrapper := db.TestWrapper(t)
+	newWrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	repo, err := kms.NewRepository(rw, rw)
+	require.NoError(t, err)
+	org, proj := iam.TestScopes(t, iam.TestRepo(t, conn, oldWrapper))
+
+	var count int
+	require.NoError(t, conn.Model(kms.AllocRootKeyVersion()).Count(&count).Error)
+	require.NotZero(t, count)
+	orgKey := kms.AllocRootKey()
+	require.NoError(t, conn.Where("scope_id = ?", org.PublicId).First(&orgKey).Error)
+	orgVersion, err := repo.LatestRootKeyVersion(ctx, oldWrapper, orgKey.PrivateId)
+	require.NoError(t, err)
+
+	t.Run("same-key-id", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.RewrapRootKeyVersions(ctx, oldWrapper, oldWrapper)
+		assert.Error(err)
+		assert.True(errors.Is(err, dberrors.ErrInvalidParameter))
+	})
+	t.Run("wrong-old-wrapper", func(t *testing.T) {
+		assert := assert.New(t)
+		_, err := repo.RewrapRootKeyVersions(ctx, db.TestWrapper(t), newWrapper)
+		assert.Error(err)
+		// nothing was rewrapped
+		_, err = repo.LatestRootKeyVersion(ctx, oldWrapper, orgKey.PrivateId)
+		assert.NoError(err)
+	})
+	t.Run("valid", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rewrapped, err := repo.RewrapRootKeyVersions(ctx, oldWrapper, newWrapper)
+		require.NoError(err)
+		assert.Equal(count, rewrapped)
+
+		_, err = repo.LatestRootKeyVersion(ctx, oldWrapper, orgKey.PrivateId)
+		assert.Error(err)
+		got, err := repo.LatestRootKeyVersion(ctx, newWrapper, orgKey.PrivateId)
+		require.NoError(err)
+		assert.Equal(orgVersion.Key, got.Key)
+		assert.Equal(newWrapper.KeyID(), got.KeyId)
+
+		// the keys of the scopes can still be used
+		k, err := kms.NewKms(repo)
+		require.NoError(err)
+		require.NoError(k.AddExternalWrappers(kms.WithRootWrapper(newWrapper)))
+		_, err = k.GetWrapper(ctx, proj.PublicId, kms.KeyPurposeDatabase)
+		assert.NoError(err)
+	})
+	t.Run("retry", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		rewrapped, err := repo.RewrapRootKeyVersions(ctx, oldWrapper, newWrapper)
+		require.NoError(err)
+		assert.Zero(rewrapped)
+	})
+}
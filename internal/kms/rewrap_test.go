@@ -0,0 +1,66 @@
+package kms_test
+
+import (
+	"context"
+	"crypto/rand"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKms_RotateKeys(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	job, err := kmsCache.LookupRewrapJob(ctx, org.GetPublicId())
+	require.NoError(err)
+	assert.Nil(job)
+
+	oldIds, err := kmsCache.KeyVersionIds(ctx, org.GetPublicId(), kms.KeyPurposeOplog)
+	require.NoError(err)
+	require.Len(oldIds, 1)
+
+	job, err = kmsCache.RotateKeys(ctx, org.GetPublicId(), rand.Reader)
+	require.NoError(err)
+	require.NotNil(job)
+	assert.Equal(org.GetPublicId(), job.ScopeId)
+	assert.Equal(kms.RewrapJobPending, job.State)
+	assert.True(job.EndTime.IsZero())
+
+	for _, purpose := range []kms.KeyPurpose{kms.KeyPurposeDatabase, kms.KeyPurposeOplog, kms.KeyPurposeTokens, kms.KeyPurposeSessions} {
+		ids, err := kmsCache.KeyVersionIds(ctx, org.GetPublicId(), purpose)
+		require.NoError(err)
+		require.Len(ids, 2, "purpose %s", purpose)
+		w, err := kmsCache.GetWrapper(ctx, org.GetPublicId(), purpose)
+		require.NoError(err)
+		assert.Equal(ids[0], w.KeyID(), "purpose %s", purpose)
+	}
+	ids, err := kmsCache.KeyVersionIds(ctx, org.GetPublicId(), kms.KeyPurposeOplog)
+	require.NoError(err)
+	assert.Equal(oldIds[0], ids[1])
+
+	ran, err := kmsCache.RunRewrapJobs(ctx)
+	require.NoError(err)
+	assert.Equal(1, ran)
+
+	job, err = kmsCache.LookupRewrapJob(ctx, org.GetPublicId())
+	require.NoError(err)
+	require.NotNil(job)
+	assert.Equal(kms.RewrapJobCompleted, job.State)
+	assert.Empty(job.Error)
+	assert.Zero(job.RemainingCount)
+	assert.False(job.EndTime.IsZero())
+
+	ran, err = kmsCache.RunRewrapJobs(ctx)
+	require.NoError(err)
+	assert.Equal(0, ran)
+}
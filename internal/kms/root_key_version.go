@@ -97,6 +97,7 @@ func (k *RootKeyVersion) Encrypt(ctx context.Context, cipher wrapping.Wrapper) e
 	if err := structwrapping.WrapStruct(ctx, cipher, k.RootKeyVersion, nil); err != nil {
 		return fmt.Errorf("error encrypting kms root key version: %w", err)
 	}
+	k.KeyId = cipher.KeyID()
 	return nil
 }
 
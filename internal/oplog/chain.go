@@ -0,0 +1,236 @@
+package oplog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/jinzhu/gorm"
+)
+
+// verifyBatchSize is the number of entries read at a time when verifying the
+// hash chain of an aggregate.
+const verifyBatchSize = 1000
+
+const (
+	lastChainedEntryHashQuery = `
+select hash
+  from oplog_entry
+ where aggregate_name = $1
+   and hash is not null
+ order by id desc
+ limit 1
+`
+
+	chainAnchorQuery = `
+select entry_id, hash
+  from oplog_chain_anchor
+ where aggregate_name = $1
+`
+
+	aggregateNamesQuery = `
+select distinct aggregate_name
+  from oplog_entry
+ order by aggregate_name
+`
+)
+
+// HashChainer provides the hash of the last entry written for an aggregate,
+// which the next entry written for it is chained to. Ticketers which
+// implement it hash chain the entries written with their tickets.
+type HashChainer interface {
+	// PreviousHash returns the hash of the last hash chained entry of the
+	// aggregate, or nil if there is none. It MUST be called in the same
+	// transaction and after redeeming the ticket of the aggregate, which
+	// serializes the writers of the aggregate.
+	PreviousHash(aggregateName string) ([]byte, error)
+}
+
+// PreviousHash returns the hash of the last hash chained entry of the
+// aggregate, or nil if there is none. If all the chained entries of the
+// aggregate were archived and deleted, it returns the hash of the chain
+// anchor of the aggregate.
+func (ticketer *GormTicketer) PreviousHash(aggregateName string) ([]byte, error) {
+	if aggregateName == "" {
+		return nil, errors.New("bad aggregate name")
+	}
+	var hash []byte
+	err := ticketer.tx.Raw(lastChainedEntryHashQuery, aggregateName).Row().Scan(&hash)
+	if err == sql.ErrNoRows {
+		_, hash, err = chainAnchor(ticketer.tx, aggregateName)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading previous hash from storage: %w", err)
+	}
+	return hash, nil
+}
+
+// chainAnchor returns the id and hash of the last hash chained entry of the
+// aggregate deleted by the oplog retention policy, or zero and nil if there is
+// none.
+func chainAnchor(tx *gorm.DB, aggregateName string) (uint32, []byte, error) {
+	var id uint32
+	var hash []byte
+	if err := tx.Raw(chainAnchorQuery, aggregateName).Row().Scan(&id, &hash); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil, nil
+		}
+		return 0, nil, err
+	}
+	return id, hash, nil
+}
+
+// ChainHash returns the hash chaining the entry e to the entry whose hash is
+// prevHash: the SHA-256 hash of prevHash and of the aggregate name, version,
+// key ID and ciphertext data of e, each prefixed with its length. The
+// ciphertext of hash chained entries must not change, so they are not
+// re-encrypted when the keys of their scope are rotated.
+func ChainHash(prevHash []byte, e *store.Entry) []byte {
+	h := sha256.New()
+	for _, b := range [][]byte{prevHash, []byte(e.AggregateName), []byte(e.Version), []byte(e.KeyId), e.CtData} {
+		var l [8]byte
+		binary.BigEndian.PutUint64(l[:], uint64(len(b)))
+		h.Write(l[:])
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+// chain sets the hash of the entry and the hash of the previous entry of its
+// aggregate, if the entry's Ticketer is a HashChainer. The ticket of the
+// aggregate must have been redeemed and the data encrypted first.
+func (e *Entry) chain() error {
+	chainer, ok := e.Ticketer.(HashChainer)
+	if !ok {
+		return nil
+	}
+	prevHash, err := chainer.PreviousHash(e.AggregateName)
+	if err != nil {
+		return err
+	}
+	e.PrevHash = prevHash
+	e.Hash = ChainHash(prevHash, e.Entry)
+	return nil
+}
+
+// ChainStatus is the result of verifying the hash chain of an aggregate.
+type ChainStatus struct {
+	AggregateName string
+	// UnchainedCount is the number of entries written before entries were
+	// hash chained, which precede the chain.
+	UnchainedCount int
+	// VerifiedCount is the number of hash chained entries verified, up to
+	// the first break if there is one.
+	VerifiedCount int
+	// HeadId and HeadHash are the id and hash of the last verified entry.
+	// Recording them allows detecting later that entries were removed from
+	// the end of the chain.
+	HeadId   uint32
+	HeadHash []byte
+	// AnchorId and AnchorHash are the id and hash of the last chained entry
+	// of the aggregate deleted by the oplog retention policy, which the first
+	// remaining entry follows. AnchorId is zero if no chained entry was
+	// deleted.
+	AnchorId   uint32
+	AnchorHash []byte
+	// BreakId is the id of the first entry which does not chain to the
+	// entries before it, and BreakReason describes why. BreakId is zero if
+	// the chain is intact.
+	BreakId     uint32
+	BreakReason string
+}
+
+// VerifyHashChains walks the hash chain of every aggregate in the oplog, or
+// only of the given aggregates, and returns their status. The walk of an
+// aggregate stops at its first break.
+func VerifyHashChains(ctx context.Context, tx *gorm.DB, aggregateNames ...string) ([]*ChainStatus, error) {
+	if tx == nil {
+		return nil, errors.New("tx is nil")
+	}
+	if len(aggregateNames) == 0 {
+		rows, err := tx.Raw(aggregateNamesQuery).Rows()
+		if err != nil {
+			return nil, fmt.Errorf("error reading aggregate names from storage: %w", err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var name string
+			if err := rows.Scan(&name); err != nil {
+				return nil, fmt.Errorf("error reading aggregate names from storage: %w", err)
+			}
+			aggregateNames = append(aggregateNames, name)
+		}
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("error reading aggregate names from storage: %w", err)
+		}
+	}
+	var ret []*ChainStatus
+	for _, name := range aggregateNames {
+		status, err := verifyHashChain(ctx, tx, name)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, status)
+	}
+	return ret, nil
+}
+
+func verifyHashChain(ctx context.Context, tx *gorm.DB, aggregateName string) (*ChainStatus, error) {
+	status := &ChainStatus{AggregateName: aggregateName}
+	var err error
+	if status.AnchorId, status.AnchorHash, err = chainAnchor(tx, aggregateName); err != nil {
+		return nil, fmt.Errorf("error reading chain anchor of %s from storage: %w", aggregateName, err)
+	}
+	var lastId uint32
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var entries []*store.Entry
+		if err := tx.Where("aggregate_name = ? and id > ?", aggregateName, lastId).Order("id asc").Limit(verifyBatchSize).Find(&entries).Error; err != nil {
+			return nil, fmt.Errorf("error reading entries of %s from storage: %w", aggregateName, err)
+		}
+		for _, e := range entries {
+			if reason := status.check(e); reason != "" {
+				status.BreakId = e.Id
+				status.BreakReason = reason
+				return status, nil
+			}
+		}
+		if len(entries) < verifyBatchSize {
+			return status, nil
+		}
+		lastId = entries[len(entries)-1].Id
+	}
+}
+
+// check verifies that the entry e follows the entries checked before it. It
+// returns the reason it doesn't, or an empty string if it does.
+func (s *ChainStatus) check(e *store.Entry) string {
+	switch {
+	case len(e.Hash) == 0 && s.VerifiedCount == 0 && s.AnchorId == 0:
+		s.UnchainedCount++
+		return ""
+	case len(e.Hash) == 0 && s.VerifiedCount == 0:
+		return fmt.Sprintf("entry %d is not hash chained but follows archived hash chained entry %d", e.Id, s.AnchorId)
+	case len(e.Hash) == 0:
+		return fmt.Sprintf("entry %d is not hash chained but follows hash chained entry %d", e.Id, s.HeadId)
+	case s.VerifiedCount == 0 && s.AnchorId != 0 && !bytes.Equal(e.PrevHash, s.AnchorHash):
+		return fmt.Sprintf("entry %d does not follow archived entry %d: entries were removed, inserted or reordered", e.Id, s.AnchorId)
+	case s.VerifiedCount == 0 && s.AnchorId == 0 && len(e.PrevHash) != 0:
+		return fmt.Sprintf("entry %d follows an entry which is missing", e.Id)
+	case s.VerifiedCount > 0 && !bytes.Equal(e.PrevHash, s.HeadHash):
+		return fmt.Sprintf("entry %d does not follow entry %d: entries were removed, inserted or reordered", e.Id, s.HeadId)
+	case !bytes.Equal(e.Hash, ChainHash(e.PrevHash, e)):
+		return fmt.Sprintf("entry %d does not match its hash: the entry was modified", e.Id)
+	}
+	s.VerifiedCount++
+	s.HeadId = e.Id
+	s.HeadHash = e.Hash
+	return ""
+}
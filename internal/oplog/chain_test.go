@@ -0,0 +1,189 @@
+package oplog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/oplog/oplog_test"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testChain(t *testing.T, n int) []*store.Entry {
+	t.Helper()
+	var entries []*store.Entry
+	var prevHash []byte
+	for i := 0; i < n; i++ {
+		e := &store.Entry{
+			Id:            uint32(i + 1),
+			AggregateName: "test-users",
+			Version:       Version,
+			KeyId:         "key",
+			CtData:        []byte{byte(i)},
+			PrevHash:      prevHash,
+		}
+		e.Hash = ChainHash(prevHash, e)
+		prevHash = e.Hash
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+func Test_ChainHash(t *testing.T) {
+	assert := assert.New(t)
+	entry := func() *store.Entry {
+		return &store.Entry{AggregateName: "test-users", Version: Version, KeyId: "key", CtData: []byte("data")}
+	}
+	e := entry()
+	h := ChainHash(nil, e)
+	assert.Len(h, 32)
+	assert.Equal(h, ChainHash(nil, e))
+	assert.Equal(h, ChainHash([]byte{}, e))
+	assert.NotEqual(h, ChainHash(h, e))
+
+	other := entry()
+	other.CtData = []byte("other")
+	assert.NotEqual(h, ChainHash(nil, other))
+	other = entry()
+	other.KeyId = "other"
+	assert.NotEqual(h, ChainHash(nil, other))
+
+	// fields are length prefixed, so moving bytes between them changes the hash
+	other = entry()
+	other.AggregateName, other.Version = "test-user", "s"+Version
+	assert.NotEqual(h, ChainHash(nil, other))
+}
+
+func Test_ChainStatus_check(t *testing.T) {
+	verifyFrom := func(s *ChainStatus, entries []*store.Entry) *ChainStatus {
+		for _, e := range entries {
+			if reason := s.check(e); reason != "" {
+				s.BreakId = e.Id
+				s.BreakReason = reason
+				break
+			}
+		}
+		return s
+	}
+	verify := func(entries []*store.Entry) *ChainStatus {
+		return verifyFrom(&ChainStatus{AggregateName: "test-users"}, entries)
+	}
+
+	t.Run("intact", func(t *testing.T) {
+		assert := assert.New(t)
+		entries := testChain(t, 3)
+		s := verify(entries)
+		assert.Zero(s.BreakId)
+		assert.Equal(3, s.VerifiedCount)
+		assert.Equal(uint32(3), s.HeadId)
+		assert.Equal(entries[2].Hash, s.HeadHash)
+	})
+	t.Run("unchained entries first", func(t *testing.T) {
+		assert := assert.New(t)
+		entries := append([]*store.Entry{{Id: 0, AggregateName: "test-users"}}, testChain(t, 2)...)
+		s := verify(entries)
+		assert.Zero(s.BreakId)
+		assert.Equal(1, s.UnchainedCount)
+		assert.Equal(2, s.VerifiedCount)
+	})
+	t.Run("unchained entry after chained ones", func(t *testing.T) {
+		assert := assert.New(t)
+		entries := append(testChain(t, 2), &store.Entry{Id: 3, AggregateName: "test-users"})
+		s := verify(entries)
+		assert.Equal(uint32(3), s.BreakId)
+		assert.Equal(2, s.VerifiedCount)
+	})
+	t.Run("modified", func(t *testing.T) {
+		assert := assert.New(t)
+		entries := testChain(t, 3)
+		entries[1].CtData = []byte("modified")
+		s := verify(entries)
+		assert.Equal(uint32(2), s.BreakId)
+		assert.Contains(s.BreakReason, "modified")
+		assert.Equal(1, s.VerifiedCount)
+	})
+	t.Run("removed", func(t *testing.T) {
+		assert := assert.New(t)
+		entries := testChain(t, 3)
+		s := verify([]*store.Entry{entries[0], entries[2]})
+		assert.Equal(uint32(3), s.BreakId)
+		assert.Contains(s.BreakReason, "does not follow entry 1")
+	})
+	t.Run("first removed", func(t *testing.T) {
+		assert := assert.New(t)
+		entries := testChain(t, 3)
+		s := verify(entries[1:])
+		assert.Equal(uint32(2), s.BreakId)
+		assert.Contains(s.BreakReason, "missing")
+	})
+	t.Run("archived", func(t *testing.T) {
+		assert := assert.New(t)
+		entries := testChain(t, 3)
+		s := verifyFrom(&ChainStatus{AggregateName: "test-users", AnchorId: 1, AnchorHash: entries[0].Hash}, entries[1:])
+		assert.Zero(s.BreakId)
+		assert.Equal(2, s.VerifiedCount)
+		assert.Equal(uint32(3), s.HeadId)
+	})
+	t.Run("removed after archived", func(t *testing.T) {
+		assert := assert.New(t)
+		entries := testChain(t, 3)
+		s := verifyFrom(&ChainStatus{AggregateName: "test-users", AnchorId: 1, AnchorHash: entries[0].Hash}, entries[2:])
+		assert.Equal(uint32(3), s.BreakId)
+		assert.Contains(s.BreakReason, "does not follow archived entry 1")
+	})
+	t.Run("unchained entry after archived", func(t *testing.T) {
+		assert := assert.New(t)
+		entries := testChain(t, 1)
+		s := verifyFrom(&ChainStatus{AggregateName: "test-users", AnchorId: 1, AnchorHash: entries[0].Hash}, []*store.Entry{{Id: 2, AggregateName: "test-users"}})
+		assert.Equal(uint32(2), s.BreakId)
+	})
+}
+
+// Test_HashChain provides unit tests for hash chaining the entries written
+// for an aggregate
+func Test_HashChain(t *testing.T) {
+	cleanup, db := setup(t)
+	defer testCleanup(t, cleanup, db)
+	assert, require := assert.New(t), require.New(t)
+	ctx := context.Background()
+	cipherer := testWrapper(t)
+
+	ticketer, err := NewGormTicketer(db, WithAggregateNames(true))
+	require.NoError(err)
+
+	aggregateName := "test-chain-" + testId(t)
+	var entries []*Entry
+	for i := 0; i < 3; i++ {
+		ticket, err := ticketer.GetTicket("default")
+		require.NoError(err)
+		e, err := NewEntry(aggregateName, Metadata{"deployment": []string{"amex"}}, cipherer, ticketer)
+		require.NoError(err)
+		u := oplog_test.TestUser{Name: "foo-" + testId(t)}
+		require.NoError(e.WriteEntryWith(ctx, &GormWriter{db}, ticket, &Message{Message: &u, TypeName: "user", OpType: OpType_OP_TYPE_CREATE}))
+		entries = append(entries, e)
+	}
+	assert.Empty(entries[0].PrevHash)
+	assert.Equal(entries[0].Hash, entries[1].PrevHash)
+	assert.Equal(entries[1].Hash, entries[2].PrevHash)
+
+	prevHash, err := ticketer.PreviousHash(aggregateName)
+	require.NoError(err)
+	assert.Equal(entries[2].Hash, prevHash)
+
+	chains, err := VerifyHashChains(ctx, db, aggregateName)
+	require.NoError(err)
+	require.Len(chains, 1)
+	assert.Zero(chains[0].BreakId)
+	assert.Equal(3, chains[0].VerifiedCount)
+	assert.Equal(entries[2].Id, chains[0].HeadId)
+
+	// the data of an entry can be changed in the database along with its key
+	// id, but not without breaking the chain
+	require.NoError(db.Exec("update oplog_entry set data = ?, key_id = ? where id = ?", []byte("tampered"), "tampered", entries[1].Id).Error)
+	chains, err = VerifyHashChains(ctx, db, aggregateName)
+	require.NoError(err)
+	require.Len(chains, 1)
+	assert.Equal(entries[1].Id, chains[0].BreakId)
+	assert.Equal(1, chains[0].VerifiedCount)
+}
@@ -143,10 +143,7 @@ func (e *Entry) WriteEntryWith(ctx context.Context, tx Writer, ticket *store.Tic
 			return fmt.Errorf("error encrypting entry: %w", err)
 		}
 	}
-	if err := tx.Create(e); err != nil {
-		return fmt.Errorf("error writing data to storage: %w", err)
-	}
-	return e.Ticketer.Redeem(ticket)
+	return e.create(tx, ticket)
 }
 
 // Write the entry as is with whatever it has for e.Data marshaled into a FIFO QueueBuffer
@@ -163,21 +160,47 @@ func (e *Entry) Write(ctx context.Context, tx Writer, ticket *store.Ticket) erro
 			return fmt.Errorf("error encrypting entry: %w", err)
 		}
 	}
+	return e.create(tx, ticket)
+}
+
+// create redeems the ticket, which serializes the writers of the aggregate
+// until the transaction ends, and then writes the entry chained to the last
+// entry of the aggregate.
+func (e *Entry) create(tx Writer, ticket *store.Ticket) error {
+	if err := e.Ticketer.Redeem(ticket); err != nil {
+		return err
+	}
+	if err := e.chain(); err != nil {
+		return fmt.Errorf("error chaining entry: %w", err)
+	}
 	if err := tx.Create(e); err != nil {
 		return fmt.Errorf("error writing data to storage: %w", err)
 	}
-	return e.Ticketer.Redeem(ticket)
+	return nil
 }
 
-// EncryptData the entry's data using its Cipherer (wrapping.Wrapper)
+// EncryptData the entry's data using its Cipherer (wrapping.Wrapper) and sets
+// the entry's KeyId to the ID of the key which encrypted it
 func (e *Entry) EncryptData(ctx context.Context) error {
 	// structwrapping doesn't support embedding, so we'll pass in the store.Entry directly
 	if err := structwrapping.WrapStruct(ctx, e.Cipherer, e.Entry, nil); err != nil {
 		return fmt.Errorf("error encrypting entry: %w", err)
 	}
+	e.KeyId = e.Cipherer.KeyID()
 	return nil
 }
 
+// DataKeyId returns the ID of the key which encrypted the data ctData of an
+// entry, or an empty string if it can't be read. Entries written before their
+// KeyId was recorded only have it in their data.
+func DataKeyId(ctData []byte) string {
+	blobInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(ctData, blobInfo); err != nil || blobInfo.KeyInfo == nil {
+		return ""
+	}
+	return blobInfo.KeyInfo.KeyID
+}
+
 // DecryptData will decrypt the entry's data using its Cipherer (wrapping.Wrapper)
 func (e *Entry) DecryptData(ctx context.Context) error {
 	// structwrapping doesn't support embedding, so we'll pass in the store.Entry directly
@@ -189,18 +212,26 @@ func (e *Entry) DecryptData(ctx context.Context) error {
 
 // Replay provides the ability to replay an entry.  you must initialize any new tables ending with the tableSuffix before
 // calling Replay, otherwise you'll get "a table doesn't exist" error.
+// Messages which are not a ReplayableMessage are replayed into the table
+// their type is named after in the catalog, which requires tx to be a
+// *GormWriter.
 func (e *Entry) Replay(ctx context.Context, tx Writer, types *TypeCatalog, tableSuffix string) error {
 	msgs, err := e.UnmarshalData(types)
 	if err != nil {
 		return fmt.Errorf("error on UnmarshalData: %w", err)
 	}
 	for _, m := range msgs {
+		var origTableName string
 		em, ok := m.Message.(ReplayableMessage)
-		if !ok {
+		switch {
+		case ok:
+			origTableName = em.TableName()
+			defer em.SetTableName(origTableName)
+		case m.TypeName != "":
+			origTableName = m.TypeName
+		default:
 			return fmt.Errorf("%T is not a ReplayableMessage", m.Message)
 		}
-		origTableName := em.TableName()
-		defer em.SetTableName(origTableName)
 
 		/*
 			how replay will be implemented for snapshots is still very much under discussion.
@@ -220,18 +251,27 @@ func (e *Entry) Replay(ctx context.Context, tx Writer, types *TypeCatalog, table
 			}
 		}
 
-		em.SetTableName(replayTable)
+		w := tx
+		if em != nil {
+			em.SetTableName(replayTable)
+		} else {
+			gw, ok := tx.(*GormWriter)
+			if !ok {
+				return fmt.Errorf("replay: %T is not a ReplayableMessage and %T is not a GormWriter", m.Message, tx)
+			}
+			w = &GormWriter{Tx: gw.Tx.Table(replayTable)}
+		}
 		switch m.OpType {
 		case OpType_OP_TYPE_CREATE:
-			if err := tx.Create(m.Message); err != nil {
+			if err := w.Create(m.Message); err != nil {
 				return fmt.Errorf("replay error: %w", err)
 			}
 		case OpType_OP_TYPE_UPDATE:
-			if err := tx.Update(m.Message, m.FieldMaskPaths, m.SetToNullPaths); err != nil {
+			if err := w.Update(m.Message, m.FieldMaskPaths, m.SetToNullPaths); err != nil {
 				return fmt.Errorf("replay error: %w", err)
 			}
 		case OpType_OP_TYPE_DELETE:
-			if err := tx.Delete(m.Message); err != nil {
+			if err := w.Delete(m.Message); err != nil {
 				return fmt.Errorf("replay error: %w", err)
 			}
 		default:
@@ -0,0 +1,23 @@
+package replication
+
+import (
+	"github.com/armon/go-metrics"
+)
+
+var (
+	replayedKey   = []string{"oplog", "replication", "replayed"}
+	lagEntriesKey = []string{"oplog", "replication", "lag", "entries"}
+	lagSecondsKey = []string{"oplog", "replication", "lag", "seconds"}
+)
+
+// recordLag reports the lag of the replication as gauges.
+func (r *Replicator) recordLag(l *Lag) {
+	labels := []metrics.Label{{Name: "replication", Value: r.name}}
+	metrics.SetGaugeWithLabels(lagEntriesKey, float32(l.Entries), labels)
+	metrics.SetGaugeWithLabels(lagSecondsKey, float32(l.Duration.Seconds()), labels)
+}
+
+// recordReplayed counts replayed entries.
+func (r *Replicator) recordReplayed(n int) {
+	metrics.IncrCounterWithLabels(replayedKey, float32(n), []metrics.Label{{Name: "replication", Value: r.name}})
+}
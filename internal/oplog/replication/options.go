@@ -0,0 +1,65 @@
+package replication
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withTableSuffix string
+	withBatchSize   int
+	withGapTimeout  time.Duration
+	withLogger      hclog.Logger
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithTableSuffix provides an option to replay the entries into tables named
+// after the tables of the primary database with the suffix appended, which
+// are created if they don't exist. It allows replaying into the primary
+// database itself.
+func WithTableSuffix(suffix string) Option {
+	return func(o *options) {
+		o.withTableSuffix = suffix
+	}
+}
+
+// WithBatchSize provides an option to set the number of entries replayed in
+// each transaction. If WithBatchSize <= 0, the default batch size is used.
+func WithBatchSize(size int) Option {
+	return func(o *options) {
+		o.withBatchSize = size
+	}
+}
+
+// WithGapTimeout provides an option to set how long a missing entry is waited
+// for before it is skipped. If WithGapTimeout <= 0, the default timeout is
+// used.
+func WithGapTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.withGapTimeout = d
+	}
+}
+
+// WithLogger provides an option to set the logger of the replicator.
+func WithLogger(l hclog.Logger) Option {
+	return func(o *options) {
+		o.withLogger = l
+	}
+}
@@ -0,0 +1,58 @@
+package replication
+
+const (
+	insertCheckpointQuery = `
+insert into oplog_replication_checkpoint
+  (name)
+values
+  ($1)
+on conflict (name) do nothing
+`
+
+	checkpointQuery = `
+select entry_id, entry_create_time, replayed_count, update_time
+  from oplog_replication_checkpoint
+ where name = $1
+`
+
+	lockCheckpointQuery = `
+select entry_id, entry_create_time, replayed_count, update_time
+  from oplog_replication_checkpoint
+ where name = $1
+   for update
+`
+
+	updateCheckpointQuery = `
+update oplog_replication_checkpoint
+   set entry_id = $2,
+       entry_create_time = $3,
+       replayed_count = replayed_count + $4
+ where name = $1
+`
+
+	// oplogKeyVersionScopeQuery returns the scope of a version of an oplog
+	// DEK.
+	oplogKeyVersionScopeQuery = `
+select rk.scope_id
+  from kms_oplog_key_version kv
+  join kms_oplog_key k
+    on k.private_id = kv.oplog_key_id
+  join kms_root_key rk
+    on rk.private_id = k.root_key_id
+ where kv.private_id = $1
+`
+
+	entryScopeQuery = `
+select value
+  from oplog_metadata
+ where entry_id = $1
+   and key = $2
+ limit 1
+`
+
+	lagQuery = `
+select count(*), min(create_time)
+  from oplog_entry
+ where id > $1
+`
+)
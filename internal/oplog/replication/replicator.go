@@ -0,0 +1,375 @@
+// Package replication replays the oplog of a primary Boundary database into a
+// standby database, to keep a warm standby or a reporting copy of the
+// resources written through the oplog.
+package replication
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/search"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	"github.com/hashicorp/go-hclog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/jinzhu/gorm"
+)
+
+const (
+	// defaultBatchSize is the number of entries replayed in each
+	// transaction.
+	defaultBatchSize = 100
+
+	// defaultGapTimeout is how long a missing entry is waited for before it
+	// is skipped. Entry ids are allocated when the entries are written, so a
+	// transaction still running on the primary can commit an entry with a
+	// lower id than entries already committed. An id is also missing for
+	// good when the transaction which allocated it rolled back, or when the
+	// entry was deleted by the oplog retention policy.
+	defaultGapTimeout = time.Minute
+)
+
+// Checkpoint is the progress of a replication, stored in the standby
+// database.
+type Checkpoint struct {
+	Name string
+	// EntryId and EntryCreateTime are the id and create time of the last
+	// entry replayed. EntryId is zero if no entry was replayed yet.
+	EntryId         uint32
+	EntryCreateTime time.Time
+	ReplayedCount   int64
+	UpdateTime      time.Time
+}
+
+// Lag is how far a replication is behind the primary database.
+type Lag struct {
+	// Entries is the number of entries of the primary not replayed yet.
+	Entries int64
+	// Duration is the age of the oldest entry not replayed yet, or zero if
+	// the replication caught up.
+	Duration time.Duration
+}
+
+// Replicator replays the entries of the oplog of a primary database into a
+// standby database, in the order of their ids, which for each aggregate is
+// the order of its tickets. The checkpoint of the replication is updated in
+// the same transaction as the changes of the entries it replays, so a
+// restarted replication resumes after the last entry it replayed.
+type Replicator struct {
+	primary     db.Reader
+	kms         *kms.Kms
+	standby     *gorm.DB
+	name        string
+	catalog     *oplog.TypeCatalog
+	tableSuffix string
+	batchSize   int
+	gapTimeout  time.Duration
+	logger      hclog.Logger
+
+	// gap is the missing entry the replication is waiting for, if any.
+	gap *gap
+
+	keyScopes map[string]string
+	wrappers  map[string]wrapping.Wrapper
+}
+
+// gap is a missing entry following the entry afterId, first seen at since.
+type gap struct {
+	afterId uint32
+	since   time.Time
+}
+
+// NewReplicator creates a Replicator named name, which reads the entries of
+// primary, decrypts them with the oplog keys of kms, and replays them into
+// standby. The standby database must have the schema of the primary.
+// Supports the options: WithTableSuffix, WithBatchSize, WithGapTimeout and
+// WithLogger.
+func NewReplicator(primary db.Reader, kms *kms.Kms, standby *gorm.DB, name string, opt ...Option) (*Replicator, error) {
+	switch {
+	case primary == nil:
+		return nil, stderrors.New("error creating replicator with nil primary reader")
+	case kms == nil:
+		return nil, stderrors.New("error creating replicator with nil kms")
+	case standby == nil:
+		return nil, stderrors.New("error creating replicator with nil standby db")
+	case name == "":
+		return nil, fmt.Errorf("error creating replicator: missing name: %w", errors.ErrInvalidParameter)
+	}
+	catalog, err := search.NewTypeCatalog()
+	if err != nil {
+		return nil, fmt.Errorf("error creating oplog type catalog: %w", err)
+	}
+	opts := getOpts(opt...)
+	if opts.withBatchSize <= 0 {
+		opts.withBatchSize = defaultBatchSize
+	}
+	if opts.withGapTimeout <= 0 {
+		opts.withGapTimeout = defaultGapTimeout
+	}
+	if opts.withLogger == nil {
+		opts.withLogger = hclog.NewNullLogger()
+	}
+	return &Replicator{
+		primary:     primary,
+		kms:         kms,
+		standby:     standby,
+		name:        name,
+		catalog:     catalog,
+		tableSuffix: opts.withTableSuffix,
+		batchSize:   opts.withBatchSize,
+		gapTimeout:  opts.withGapTimeout,
+		logger:      opts.withLogger,
+		keyScopes:   map[string]string{},
+		wrappers:    map[string]wrapping.Wrapper{},
+	}, nil
+}
+
+// Checkpoint returns the checkpoint of the replication.
+func (r *Replicator) Checkpoint(ctx context.Context) (*Checkpoint, error) {
+	cp, err := r.checkpoint(r.standby, checkpointQuery)
+	if err != nil {
+		return nil, fmt.Errorf("read replication checkpoint: %w", err)
+	}
+	return cp, nil
+}
+
+func (r *Replicator) checkpoint(tx *gorm.DB, query string) (*Checkpoint, error) {
+	cp := &Checkpoint{Name: r.name}
+	var entryCreateTime, updateTime sql.NullTime
+	err := tx.Raw(query, r.name).Row().Scan(&cp.EntryId, &entryCreateTime, &cp.ReplayedCount, &updateTime)
+	switch {
+	case err == sql.ErrNoRows:
+		return cp, nil
+	case err != nil:
+		return nil, err
+	}
+	cp.EntryCreateTime = entryCreateTime.Time
+	cp.UpdateTime = updateTime.Time
+	return cp, nil
+}
+
+// ReplicateBatch replays the next batch of entries of the primary after the
+// checkpoint into the standby, and returns the number of entries replayed.
+// It stops before an entry which does not follow the last one replayed,
+// until the missing entries are waited for longer than the gap timeout of
+// the replicator, after which they are skipped.
+func (r *Replicator) ReplicateBatch(ctx context.Context) (int, error) {
+	tx := r.standby.BeginTx(ctx, nil)
+	if err := tx.Error; err != nil {
+		return 0, fmt.Errorf("replicate oplog: unable to begin standby transaction: %w", err)
+	}
+	replayed, err := r.replicateBatch(ctx, tx)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("replicate oplog: %w", err)
+	}
+	if err := tx.Commit().Error; err != nil {
+		return 0, fmt.Errorf("replicate oplog: unable to commit standby transaction: %w", err)
+	}
+	if replayed > 0 {
+		r.recordReplayed(replayed)
+	}
+	return replayed, nil
+}
+
+func (r *Replicator) replicateBatch(ctx context.Context, tx *gorm.DB) (int, error) {
+	// Locking the checkpoint keeps two replicators with the same name from
+	// replaying the same entries
+	if err := tx.Exec(insertCheckpointQuery, r.name).Error; err != nil {
+		return 0, fmt.Errorf("unable to create checkpoint: %w", err)
+	}
+	cp, err := r.checkpoint(tx, lockCheckpointQuery)
+	if err != nil {
+		return 0, fmt.Errorf("unable to lock checkpoint: %w", err)
+	}
+
+	var entries []*store.Entry
+	if err := r.primary.SearchWhere(ctx, &entries, "id > ?", []interface{}{cp.EntryId}, db.WithLimit(r.batchSize), db.WithOrder("id asc")); err != nil {
+		return 0, fmt.Errorf("unable to read entries from primary: %w", err)
+	}
+
+	now := time.Now()
+	prevId := cp.EntryId
+	var lastCreateTime time.Time
+	var replayed int
+	for _, e := range entries {
+		if prevId != 0 && e.Id != prevId+1 {
+			if !r.gapExpired(prevId, now) {
+				break
+			}
+			r.logger.Warn("skipping missing oplog entries", "first_id", prevId+1, "last_id", e.Id-1)
+		}
+		if err := r.replay(ctx, tx, e); err != nil {
+			return 0, fmt.Errorf("unable to replay entry %d: %w", e.Id, err)
+		}
+		if e.CreateTime != nil {
+			if lastCreateTime, err = ptypes.Timestamp(e.CreateTime.Timestamp); err != nil {
+				return 0, fmt.Errorf("entry %d: %w", e.Id, err)
+			}
+		}
+		prevId = e.Id
+		replayed++
+	}
+	if replayed == 0 {
+		return 0, nil
+	}
+	if err := tx.Exec(updateCheckpointQuery, r.name, prevId, lastCreateTime, replayed).Error; err != nil {
+		return 0, fmt.Errorf("unable to update checkpoint: %w", err)
+	}
+	return replayed, nil
+}
+
+// gapExpired reports whether the entries missing after the entry afterId
+// were waited for longer than the gap timeout.
+func (r *Replicator) gapExpired(afterId uint32, now time.Time) bool {
+	if r.gap == nil || r.gap.afterId != afterId {
+		r.gap = &gap{afterId: afterId, since: now}
+	}
+	return now.Sub(r.gap.since) >= r.gapTimeout
+}
+
+// replay decrypts the entry e and replays its changes into the standby
+// transaction tx.
+func (r *Replicator) replay(ctx context.Context, tx *gorm.DB, e *store.Entry) error {
+	scopeId, err := r.scope(ctx, e)
+	if err != nil {
+		return err
+	}
+	wrapper, ok := r.wrappers[scopeId]
+	if !ok {
+		if wrapper, err = r.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog); err != nil {
+			return fmt.Errorf("unable to get oplog wrapper of scope %s: %w", scopeId, err)
+		}
+		r.wrappers[scopeId] = wrapper
+	}
+	entry := &oplog.Entry{Entry: e, Cipherer: wrapper}
+	if err := entry.DecryptData(ctx); err != nil {
+		return err
+	}
+	return entry.Replay(ctx, &oplog.GormWriter{Tx: tx}, r.catalog, r.tableSuffix)
+}
+
+// scope returns the scope whose oplog key encrypted the entry e: the scope of
+// its key version, read from its encrypted data for entries written before
+// key versions were recorded, or the scope in its metadata when its key
+// version is unknown.
+func (r *Replicator) scope(ctx context.Context, e *store.Entry) (string, error) {
+	keyId := e.KeyId
+	if keyId == "" {
+		keyId = oplog.DataKeyId(e.CtData)
+	}
+	if keyId != "" {
+		if scopeId, ok := r.keyScopes[keyId]; ok {
+			return scopeId, nil
+		}
+	}
+	query, arg := entryScopeQuery, []interface{}{e.Id, search.ScopeIdKey}
+	if keyId != "" {
+		query, arg = oplogKeyVersionScopeQuery, []interface{}{keyId}
+	}
+	rows, err := r.primary.Query(ctx, query, arg)
+	if err != nil {
+		return "", fmt.Errorf("unable to look up the scope of entry %d: %w", e.Id, err)
+	}
+	defer rows.Close()
+	var scopeId string
+	if rows.Next() {
+		if err := rows.Scan(&scopeId); err != nil {
+			return "", fmt.Errorf("unable to look up the scope of entry %d: %w", e.Id, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("unable to look up the scope of entry %d: %w", e.Id, err)
+	}
+	if scopeId == "" {
+		return "", fmt.Errorf("unknown scope of entry %d: %w", e.Id, errors.ErrRecordNotFound)
+	}
+	if keyId != "" {
+		r.keyScopes[keyId] = scopeId
+	}
+	return scopeId, nil
+}
+
+// CatchUp replays batches of entries until none is left to replay, or until
+// a missing entry is waited for, and returns the number of entries
+// replayed.
+func (r *Replicator) CatchUp(ctx context.Context) (int, error) {
+	var total int
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		n, err := r.ReplicateBatch(ctx)
+		total += n
+		if err != nil || n == 0 {
+			return total, err
+		}
+	}
+}
+
+// Lag returns how far the replication is behind the primary database.
+func (r *Replicator) Lag(ctx context.Context) (*Lag, error) {
+	cp, err := r.checkpoint(r.standby, checkpointQuery)
+	if err != nil {
+		return nil, fmt.Errorf("replication lag: unable to read checkpoint: %w", err)
+	}
+	rows, err := r.primary.Query(ctx, lagQuery, []interface{}{cp.EntryId})
+	if err != nil {
+		return nil, fmt.Errorf("replication lag: %w", err)
+	}
+	defer rows.Close()
+	lag := &Lag{}
+	var oldest sql.NullTime
+	if rows.Next() {
+		if err := rows.Scan(&lag.Entries, &oldest); err != nil {
+			return nil, fmt.Errorf("replication lag: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("replication lag: %w", err)
+	}
+	if oldest.Valid {
+		lag.Duration = time.Since(oldest.Time)
+	}
+	return lag, nil
+}
+
+// Run replicates until ctx is done. Each round it catches up, records the
+// lag of the replication in the lag gauges, and waits interval. Errors are
+// logged and the round retried after interval.
+func (r *Replicator) Run(ctx context.Context, interval time.Duration) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			if n, err := r.CatchUp(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				r.logger.Error("error replicating oplog", "error", err)
+			} else if n > 0 {
+				r.logger.Debug("replayed oplog entries", "entries_replayed", n)
+			}
+			if lag, err := r.Lag(ctx); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				r.logger.Error("error reading replication lag", "error", err)
+			} else {
+				r.recordLag(lag)
+				r.logger.Trace("replication lag", "lag_entries", lag.Entries, "lag", lag.Duration)
+			}
+			timer.Reset(interval)
+		}
+	}
+}
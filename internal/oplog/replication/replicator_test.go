@@ -0,0 +1,111 @@
+package replication
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicator_CatchUp(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	user := iam.TestUser(t, iamRepo, org.PublicId, iam.WithName("alice"))
+
+	// replaying into tables of the same database with a suffix
+	r, err := NewReplicator(rw, kmsCache, conn, "test", WithTableSuffix("_replica"), WithBatchSize(2))
+	require.NoError(err)
+
+	replayed, err := r.CatchUp(ctx)
+	require.NoError(err)
+	assert.NotZero(replayed)
+
+	var name string
+	require.NoError(conn.Raw("select name from iam_user_replica where public_id = ?", user.PublicId).Row().Scan(&name))
+	assert.Equal("alice", name)
+
+	cp, err := r.Checkpoint(ctx)
+	require.NoError(err)
+	assert.Equal(int64(replayed), cp.ReplayedCount)
+	assert.NotZero(cp.EntryId)
+
+	lag, err := r.Lag(ctx)
+	require.NoError(err)
+	assert.Zero(lag.Entries)
+	assert.Zero(lag.Duration)
+
+	// a new replicator with the same name resumes from the checkpoint
+	user.Name = "bob"
+	_, _, _, err = iamRepo.UpdateUser(ctx, user, user.Version, []string{"Name"})
+	require.NoError(err)
+	lag, err = r.Lag(ctx)
+	require.NoError(err)
+	assert.Equal(int64(1), lag.Entries)
+
+	r, err = NewReplicator(rw, kmsCache, conn, "test", WithTableSuffix("_replica"))
+	require.NoError(err)
+	replayed, err = r.CatchUp(ctx)
+	require.NoError(err)
+	assert.Equal(1, replayed)
+	require.NoError(conn.Raw("select name from iam_user_replica where public_id = ?", user.PublicId).Row().Scan(&name))
+	assert.Equal("bob", name)
+}
+
+func TestReplicator_CatchUpLegacyEntries(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	_, proj := iam.TestScopes(t, iamRepo)
+
+	staticRepo, err := static.NewRepository(rw, rw, kmsCache)
+	require.NoError(err)
+	catalog := static.TestCatalogs(t, conn, proj.PublicId, 1)[0]
+	newHost, err := static.NewHost(catalog.PublicId, static.WithAddress("127.0.0.1"))
+	require.NoError(err)
+	host, err := staticRepo.CreateHost(ctx, proj.PublicId, newHost)
+	require.NoError(err)
+
+	// Entries written before key versions were recorded have no key_id, and
+	// static hosts have no scope-id metadata
+	_, err = rw.Exec(ctx, "update oplog_entry set key_id = null", nil)
+	require.NoError(err)
+
+	r, err := NewReplicator(rw, kmsCache, conn, "test", WithTableSuffix("_replica"))
+	require.NoError(err)
+	replayed, err := r.CatchUp(ctx)
+	require.NoError(err)
+	assert.NotZero(replayed)
+
+	var address string
+	require.NoError(conn.Raw("select address from static_host_replica where public_id = ?", host.PublicId).Row().Scan(&address))
+	assert.Equal("127.0.0.1", address)
+}
+
+func TestReplicator_gapExpired(t *testing.T) {
+	assert := assert.New(t)
+	r := &Replicator{gapTimeout: time.Minute}
+	now := time.Now()
+	assert.False(r.gapExpired(10, now))
+	assert.False(r.gapExpired(10, now.Add(30*time.Second)))
+	assert.True(r.gapExpired(10, now.Add(time.Minute)))
+
+	// a gap after another entry is waited for from when it is first seen
+	assert.False(r.gapExpired(20, now.Add(time.Minute)))
+	assert.True(r.gapExpired(20, now.Add(2*time.Minute)))
+}
@@ -0,0 +1,161 @@
+package retention
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// Record is an archived oplog entry, as written on its own line to the
+// archive file. Data is still encrypted with the oplog key version KeyId of
+// the scope ScopeId. An entry can be written to more than one archive if
+// deleting it failed after it was archived.
+type Record struct {
+	Id            uint32         `json:"id"`
+	CreateTime    time.Time      `json:"create_time"`
+	Version       string         `json:"version"`
+	AggregateName string         `json:"aggregate_name"`
+	KeyId         string         `json:"key_id,omitempty"`
+	ScopeId       string         `json:"scope_id,omitempty"`
+	Data          []byte         `json:"data"`
+	Hash          []byte         `json:"hash,omitempty"`
+	PrevHash      []byte         `json:"prev_hash,omitempty"`
+	Metadata      oplog.Metadata `json:"metadata,omitempty"`
+}
+
+// Manifest describes an archive. It is written next to the archive file once
+// all the archived entries were deleted from the database.
+type Manifest struct {
+	// Name is the name of the archive: the archive file is Name.json.gz and
+	// the manifest Name.manifest.json.
+	Name       string    `json:"name"`
+	CreateTime time.Time `json:"create_time"`
+	// ControllerName is the name of the controller whose archive_dir the
+	// archive was written to.
+	ControllerName         string `json:"controller_name"`
+	MaxAge                 string `json:"max_age,omitempty"`
+	MaxEntriesPerAggregate int    `json:"max_entries_per_aggregate,omitempty"`
+	EntryCount             int    `json:"entry_count"`
+	// Aggregates are the aggregates which had entries archived, in the order
+	// they were archived.
+	Aggregates []*AggregateManifest `json:"aggregates"`
+	// KeyVersions are the oplog key versions needed to decrypt the archived
+	// entries.
+	KeyVersions []*KeyVersion `json:"key_versions"`
+}
+
+// AggregateManifest describes the archived entries of an aggregate.
+type AggregateManifest struct {
+	AggregateName string `json:"aggregate_name"`
+	EntryCount    int    `json:"entry_count"`
+	FirstId       uint32 `json:"first_id"`
+	LastId        uint32 `json:"last_id"`
+	// AnchorId and AnchorHash are the id and hash of the last archived hash
+	// chained entry, which the first remaining entry of the aggregate
+	// follows.
+	AnchorId   uint32 `json:"anchor_id,omitempty"`
+	AnchorHash []byte `json:"anchor_hash,omitempty"`
+}
+
+// KeyVersion is an oplog key version which encrypted archived entries.
+type KeyVersion struct {
+	KeyId      string `json:"key_id"`
+	ScopeId    string `json:"scope_id"`
+	EntryCount int    `json:"entry_count"`
+}
+
+// archiveFile is a gzip compressed archive file with one Record per line.
+// Each batch is written as its own gzip member, so that a batch written by a
+// transaction which is rolled back and retried can be truncated away.
+type archiveFile struct {
+	file *os.File
+	// committed is the size of the file up to the last batch whose entries
+	// were deleted from the database
+	committed int64
+}
+
+func createArchiveFile(dir, name string) (*archiveFile, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("unable to create archive directory: %w", err)
+	}
+	file, err := os.OpenFile(filepath.Join(dir, name+".json.gz"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create archive file: %w", err)
+	}
+	return &archiveFile{file: file}, nil
+}
+
+// write replaces any batch written since the last commit with the records
+// and syncs them to disk, so they can be deleted from the database.
+func (a *archiveFile) write(records []*Record) error {
+	if err := a.file.Truncate(a.committed); err != nil {
+		return fmt.Errorf("unable to truncate archive: %w", err)
+	}
+	if _, err := a.file.Seek(a.committed, io.SeekStart); err != nil {
+		return fmt.Errorf("unable to truncate archive: %w", err)
+	}
+	gz := gzip.NewWriter(a.file)
+	enc := json.NewEncoder(gz)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			return fmt.Errorf("unable to write entry %d to archive: %w", r.Id, err)
+		}
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("unable to write archive: %w", err)
+	}
+	if err := a.file.Sync(); err != nil {
+		return fmt.Errorf("unable to sync archive: %w", err)
+	}
+	return nil
+}
+
+// commit keeps the batch written last, once its entries were deleted from
+// the database.
+func (a *archiveFile) commit() error {
+	size, err := a.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("unable to commit archive: %w", err)
+	}
+	a.committed = size
+	return nil
+}
+
+func (a *archiveFile) close() error {
+	return a.file.Close()
+}
+
+// writeManifest writes the manifest to a temporary file first, so a
+// manifest file is always complete.
+func writeManifest(dir string, m *Manifest) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode manifest: %w", err)
+	}
+	path := filepath.Join(dir, m.Name+".manifest.json")
+	tmp, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create manifest: %w", err)
+	}
+	if _, err := tmp.Write(append(b, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write manifest: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to sync manifest: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write manifest: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("unable to write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package retention
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_archiveFile(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	dir, err := ioutil.TempDir("", "oplog-archive")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+	dir = filepath.Join(dir, "archive")
+
+	records := []*Record{
+		{Id: 1, CreateTime: time.Now().UTC(), Version: oplog.Version, AggregateName: "iam_user", KeyId: "kopkv_1", ScopeId: "o_1", Data: []byte("ct-1")},
+		{Id: 2, CreateTime: time.Now().UTC(), Version: oplog.Version, AggregateName: "iam_user", KeyId: "kopkv_1", ScopeId: "o_1", Data: []byte("ct-2"), Metadata: oplog.Metadata{"op-type": []string{"OP_TYPE_CREATE"}}},
+	}
+	a, err := createArchiveFile(dir, "oplog-test")
+	require.NoError(err)
+	require.NoError(a.write(records[:1]))
+	require.NoError(a.commit())
+	// a batch written again, as when its transaction is retried, replaces the
+	// batch written since the last commit
+	require.NoError(a.write(records[1:]))
+	require.NoError(a.write(records[1:]))
+	require.NoError(a.commit())
+	require.NoError(a.close())
+
+	// archives are never overwritten
+	_, err = createArchiveFile(dir, "oplog-test")
+	assert.Error(err)
+
+	m := &Manifest{Name: "oplog-test", EntryCount: 2}
+	require.NoError(writeManifest(dir, m))
+	b, err := ioutil.ReadFile(filepath.Join(dir, "oplog-test.manifest.json"))
+	require.NoError(err)
+	var gotManifest Manifest
+	require.NoError(json.Unmarshal(b, &gotManifest))
+	assert.Equal(2, gotManifest.EntryCount)
+
+	f, err := os.Open(filepath.Join(dir, "oplog-test.json.gz"))
+	require.NoError(err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(err)
+	scanner := bufio.NewScanner(gz)
+	var got []*Record
+	for scanner.Scan() {
+		r := new(Record)
+		require.NoError(json.Unmarshal(scanner.Bytes(), r))
+		got = append(got, r)
+	}
+	require.NoError(scanner.Err())
+	assert.Equal(records, got)
+}
@@ -0,0 +1,31 @@
+package retention
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withBatchSize int
+}
+
+func getDefaultOptions() options {
+	return options{}
+}
+
+// WithBatchSize provides an option to set the number of entries archived and
+// deleted in each transaction. If WithBatchSize <= 0, the default batch size
+// is used.
+func WithBatchSize(size int) Option {
+	return func(o *options) {
+		o.withBatchSize = size
+	}
+}
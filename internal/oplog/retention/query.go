@@ -0,0 +1,95 @@
+package retention
+
+const (
+	aggregateNamesQuery = `
+select distinct aggregate_name
+  from oplog_entry
+ order by aggregate_name
+`
+
+	// lastEntryBeforeQuery returns the id of the last entry of an aggregate
+	// written before a time, or zero if there is none.
+	lastEntryBeforeQuery = `
+select coalesce(max(id), 0)
+  from oplog_entry
+ where aggregate_name = $1
+   and create_time < $2
+`
+
+	// lastEntryBeyondCountQuery returns the id of the newest entry of an
+	// aggregate which is not one of its newest $2 entries.
+	lastEntryBeyondCountQuery = `
+select id
+  from oplog_entry
+ where aggregate_name = $1
+ order by id desc
+offset $2
+ limit 1
+`
+
+	// lockTicketsQuery locks the tickets an entry of an aggregate can be
+	// written with, which serializes deleting entries of the aggregate with
+	// writing them.
+	lockTicketsQuery = `
+select version
+  from oplog_ticket
+ where name in ($1, $2)
+ order by name
+   for update
+`
+
+	// oplogKeyVersionScopeQuery returns the scope of a version of an oplog
+	// DEK.
+	oplogKeyVersionScopeQuery = `
+select rk.scope_id
+  from kms_oplog_key_version kv
+  join kms_oplog_key k
+    on k.private_id = kv.oplog_key_id
+  join kms_root_key rk
+    on rk.private_id = k.root_key_id
+ where kv.private_id = $1
+`
+
+	deleteEntriesQuery = `
+delete from oplog_entry
+ where aggregate_name = $1
+   and id >= $2
+   and id <= $3
+`
+
+	insertArchiveQuery = `
+insert into oplog_archive
+  (name, controller_name)
+values
+  ($1, $2)
+on conflict (name) do nothing
+`
+
+	// upsertArchiveKeyVersionQuery adds to the number of entries of an
+	// archive encrypted with a key version. Entries whose key version is not
+	// known are counted with an empty key id.
+	upsertArchiveKeyVersionQuery = `
+insert into oplog_archive_key_version
+  (archive_name, key_id, entry_count)
+values
+  ($1, $2, $3)
+on conflict (archive_name, key_id) do update
+   set entry_count = oplog_archive_key_version.entry_count + excluded.entry_count
+`
+
+	deleteArchiveQuery = `
+delete from oplog_archive
+ where name = $1
+`
+
+	upsertChainAnchorQuery = `
+insert into oplog_chain_anchor
+  (aggregate_name, entry_id, hash)
+values
+  ($1, $2, $3)
+on conflict (aggregate_name) do update
+   set entry_id = excluded.entry_id,
+       hash = excluded.hash
+ where oplog_chain_anchor.entry_id < excluded.entry_id
+`
+)
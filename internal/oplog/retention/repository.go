@@ -0,0 +1,435 @@
+// Package retention enforces the retention policy of the oplog: it archives
+// the expired entries of each aggregate to a compressed file, still
+// encrypted, and then deletes them from the database in batches.
+package retention
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+)
+
+// defaultBatchSize is the number of entries archived and deleted in each
+// transaction, which bounds how long the tickets of an aggregate are locked.
+const defaultBatchSize = 1000
+
+// Policy is the retention policy of the oplog. Entries older than MaxAge, and
+// entries of an aggregate beyond its newest MaxEntriesPerAggregate entries,
+// expire. A zero MaxAge or MaxEntriesPerAggregate disables that limit.
+// Expired entries are archived to ArchiveDir before they are deleted.
+// ArchiveDir is local to the controller enforcing the policy, whose name,
+// ControllerName, is recorded with each of its archives.
+type Policy struct {
+	MaxAge                 time.Duration
+	MaxEntriesPerAggregate int
+	ArchiveDir             string
+	ControllerName         string
+}
+
+// Repository archives and deletes the expired entries of the oplog.
+type Repository struct {
+	reader    db.Reader
+	writer    db.Writer
+	batchSize int
+}
+
+// NewRepository creates a new oplog retention Repository. Supports the
+// options: WithBatchSize which sets the number of entries archived and
+// deleted in each transaction.
+func NewRepository(r db.Reader, w db.Writer, opt ...Option) (*Repository, error) {
+	if r == nil {
+		return nil, stderrors.New("error creating db repository with nil reader")
+	}
+	if w == nil {
+		return nil, stderrors.New("error creating db repository with nil writer")
+	}
+	opts := getOpts(opt...)
+	if opts.withBatchSize <= 0 {
+		opts.withBatchSize = defaultBatchSize
+	}
+	return &Repository{
+		reader:    r,
+		writer:    w,
+		batchSize: opts.withBatchSize,
+	}, nil
+}
+
+// ArchiveExpired archives the entries of the oplog expired under the policy p
+// to a new archive in p.ArchiveDir, and deletes them. The entries of each
+// aggregate are archived oldest first, and each batch is synced to disk
+// before it is deleted. The manifest of the archive is written last and
+// returned. If no entries expired, no archive is written and nil is
+// returned.
+//
+// The archive and the number of its entries encrypted with each oplog key
+// version are recorded in the database as the entries are deleted, and the
+// key versions can't be destroyed until the archive is forgotten with
+// ForgetArchive.
+func (r *Repository) ArchiveExpired(ctx context.Context, p Policy) (*Manifest, error) {
+	switch {
+	case p.ArchiveDir == "":
+		return nil, fmt.Errorf("archive expired oplog entries: missing archive dir: %w", errors.ErrInvalidParameter)
+	case p.ControllerName == "":
+		return nil, fmt.Errorf("archive expired oplog entries: missing controller name: %w", errors.ErrInvalidParameter)
+	case p.MaxAge < 0:
+		return nil, fmt.Errorf("archive expired oplog entries: negative max age: %w", errors.ErrInvalidParameter)
+	case p.MaxEntriesPerAggregate < 0:
+		return nil, fmt.Errorf("archive expired oplog entries: negative max entries per aggregate: %w", errors.ErrInvalidParameter)
+	case p.MaxAge == 0 && p.MaxEntriesPerAggregate == 0:
+		return nil, fmt.Errorf("archive expired oplog entries: missing max age and max entries per aggregate: %w", errors.ErrInvalidParameter)
+	}
+
+	now := time.Now().UTC()
+	a := &archiver{
+		repo:      r,
+		dir:       p.ArchiveDir,
+		keyScopes: map[string]string{},
+		manifest: &Manifest{
+			Name:                   "oplog-" + now.Format("20060102T150405.000000000Z"),
+			CreateTime:             now,
+			ControllerName:         p.ControllerName,
+			MaxEntriesPerAggregate: p.MaxEntriesPerAggregate,
+		},
+	}
+	if p.MaxAge > 0 {
+		a.manifest.MaxAge = p.MaxAge.String()
+	}
+
+	names, err := r.aggregateNames(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("archive expired oplog entries: %w", err)
+	}
+	for _, name := range names {
+		lastId, err := r.lastExpiredId(ctx, name, p, now)
+		if err != nil {
+			a.abort()
+			return nil, fmt.Errorf("archive expired oplog entries: %w", err)
+		}
+		if lastId == 0 {
+			continue
+		}
+		if err := a.archiveAggregate(ctx, name, lastId); err != nil {
+			a.abort()
+			return nil, fmt.Errorf("archive expired oplog entries: %w", err)
+		}
+	}
+	if a.file == nil {
+		return nil, nil
+	}
+	if err := a.file.close(); err != nil {
+		return nil, fmt.Errorf("archive expired oplog entries: %w", err)
+	}
+	if err := writeManifest(a.dir, a.manifest); err != nil {
+		return nil, fmt.Errorf("archive expired oplog entries: %w", err)
+	}
+	return a.manifest, nil
+}
+
+// ForgetArchive deletes the record of the archive name, once its files are
+// no longer kept, so that the oplog key versions its entries are encrypted
+// with can be destroyed. It returns the number of archives deleted: 1, or 0
+// if the archive is not recorded.
+func (r *Repository) ForgetArchive(ctx context.Context, name string) (int, error) {
+	if name == "" {
+		return db.NoRowsAffected, fmt.Errorf("forget oplog archive: missing name: %w", errors.ErrInvalidParameter)
+	}
+	rowsDeleted, err := r.writer.Exec(ctx, deleteArchiveQuery, []interface{}{name})
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("forget oplog archive: %s: %w", name, err)
+	}
+	return rowsDeleted, nil
+}
+
+func (r *Repository) aggregateNames(ctx context.Context) ([]string, error) {
+	rows, err := r.reader.Query(ctx, aggregateNamesQuery, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read aggregate names: %w", err)
+	}
+	defer rows.Close()
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("unable to read aggregate names: %w", err)
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read aggregate names: %w", err)
+	}
+	return names, nil
+}
+
+// lastExpiredId returns the id of the newest expired entry of the aggregate,
+// or zero if none expired. Entries expire in the order they were written, so
+// all the entries of the aggregate up to this id expired.
+func (r *Repository) lastExpiredId(ctx context.Context, aggregateName string, p Policy, now time.Time) (uint32, error) {
+	var lastId uint32
+	if p.MaxAge > 0 {
+		id, err := r.queryId(ctx, lastEntryBeforeQuery, aggregateName, now.Add(-p.MaxAge))
+		if err != nil {
+			return 0, fmt.Errorf("unable to find entries of %s older than %s: %w", aggregateName, p.MaxAge, err)
+		}
+		lastId = id
+	}
+	if p.MaxEntriesPerAggregate > 0 {
+		id, err := r.queryId(ctx, lastEntryBeyondCountQuery, aggregateName, p.MaxEntriesPerAggregate)
+		if err != nil {
+			return 0, fmt.Errorf("unable to find entries of %s beyond the newest %d: %w", aggregateName, p.MaxEntriesPerAggregate, err)
+		}
+		if id > lastId {
+			lastId = id
+		}
+	}
+	return lastId, nil
+}
+
+func (r *Repository) queryId(ctx context.Context, query string, values ...interface{}) (uint32, error) {
+	rows, err := r.reader.Query(ctx, query, values)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+	var id uint32
+	if rows.Next() {
+		if err := rows.Scan(&id); err != nil {
+			return 0, err
+		}
+	}
+	return id, rows.Err()
+}
+
+// archiver writes the expired entries of a run to its archive, which is
+// created when the first entries are archived.
+type archiver struct {
+	repo      *Repository
+	dir       string
+	file      *archiveFile
+	manifest  *Manifest
+	keyScopes map[string]string
+}
+
+// archiveAggregate archives and deletes the entries of the aggregate up to
+// lastId, in batches.
+func (a *archiver) archiveAggregate(ctx context.Context, aggregateName string, lastId uint32) error {
+	agg := &AggregateManifest{AggregateName: aggregateName}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var records []*Record
+		_, err := a.repo.writer.DoTx(
+			ctx,
+			db.StdRetryCnt,
+			db.ExpBackoff{},
+			func(r db.Reader, w db.Writer) error {
+				rows, err := r.Query(ctx, lockTicketsQuery, []interface{}{aggregateName, oplog.DefaultAggregateName})
+				if err != nil {
+					return fmt.Errorf("unable to lock tickets of %s: %w", aggregateName, err)
+				}
+				rows.Close()
+
+				records, err = a.readBatch(ctx, r, aggregateName, lastId)
+				if err != nil || len(records) == 0 {
+					return err
+				}
+				if a.file == nil {
+					if a.file, err = createArchiveFile(a.dir, a.manifest.Name); err != nil {
+						return err
+					}
+				}
+				if err := a.file.write(records); err != nil {
+					return err
+				}
+
+				first, last := records[0].Id, records[len(records)-1].Id
+				if _, err := w.Exec(ctx, deleteEntriesQuery, []interface{}{aggregateName, first, last}); err != nil {
+					return fmt.Errorf("unable to delete entries of %s: %w", aggregateName, err)
+				}
+				if err := a.recordKeyVersions(ctx, w, records); err != nil {
+					return err
+				}
+				for i := len(records) - 1; i >= 0; i-- {
+					if len(records[i].Hash) == 0 {
+						continue
+					}
+					if _, err := w.Exec(ctx, upsertChainAnchorQuery, []interface{}{aggregateName, records[i].Id, records[i].Hash}); err != nil {
+						return fmt.Errorf("unable to update chain anchor of %s: %w", aggregateName, err)
+					}
+					break
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			break
+		}
+		// The batch is only kept once its entries were deleted, as each retry
+		// of the transaction writes it again
+		if err := a.file.commit(); err != nil {
+			return err
+		}
+		if agg.EntryCount == 0 {
+			agg.FirstId = records[0].Id
+			a.manifest.Aggregates = append(a.manifest.Aggregates, agg)
+		}
+		agg.EntryCount += len(records)
+		agg.LastId = records[len(records)-1].Id
+		a.manifest.EntryCount += len(records)
+		for _, rec := range records {
+			if len(rec.Hash) != 0 {
+				agg.AnchorId, agg.AnchorHash = rec.Id, rec.Hash
+			}
+			if rec.KeyId != "" {
+				a.addKeyVersion(rec.KeyId, rec.ScopeId)
+			}
+		}
+		if len(records) < a.repo.batchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// readBatch reads the next batch of entries of the aggregate up to lastId,
+// with their metadata and the scopes of their key versions.
+func (a *archiver) readBatch(ctx context.Context, r db.Reader, aggregateName string, lastId uint32) ([]*Record, error) {
+	var entries []*store.Entry
+	if err := r.SearchWhere(ctx, &entries, "aggregate_name = ? and id <= ?", []interface{}{aggregateName, lastId}, db.WithLimit(a.repo.batchSize), db.WithOrder("id asc")); err != nil {
+		return nil, fmt.Errorf("unable to read entries of %s: %w", aggregateName, err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint32, 0, len(entries))
+	for _, e := range entries {
+		ids = append(ids, e.Id)
+	}
+	var metadata []*store.Metadata
+	if err := r.SearchWhere(ctx, &metadata, "entry_id in (?)", []interface{}{ids}, db.WithLimit(-1)); err != nil {
+		return nil, fmt.Errorf("unable to read metadata of entries of %s: %w", aggregateName, err)
+	}
+	entryMetadata := make(map[uint32]oplog.Metadata, len(entries))
+	for _, m := range metadata {
+		md := entryMetadata[m.EntryId]
+		if md == nil {
+			md = oplog.Metadata{}
+			entryMetadata[m.EntryId] = md
+		}
+		md[m.Key] = append(md[m.Key], m.Value)
+	}
+
+	records := make([]*Record, 0, len(entries))
+	for _, e := range entries {
+		rec := &Record{
+			Id:            e.Id,
+			Version:       e.Version,
+			AggregateName: e.AggregateName,
+			KeyId:         e.KeyId,
+			Data:          e.CtData,
+			Hash:          e.Hash,
+			PrevHash:      e.PrevHash,
+			Metadata:      entryMetadata[e.Id],
+		}
+		if e.CreateTime != nil {
+			t, err := ptypes.Timestamp(e.CreateTime.Timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("entry %d: %w", e.Id, err)
+			}
+			rec.CreateTime = t
+		}
+		if e.KeyId != "" {
+			scopeId, err := a.keyScope(ctx, r, e.KeyId)
+			if err != nil {
+				return nil, err
+			}
+			rec.ScopeId = scopeId
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// keyScope returns the scope of the oplog key version keyId, or an empty
+// string if the key version does not exist anymore.
+func (a *archiver) keyScope(ctx context.Context, r db.Reader, keyId string) (string, error) {
+	if scopeId, ok := a.keyScopes[keyId]; ok {
+		return scopeId, nil
+	}
+	rows, err := r.Query(ctx, oplogKeyVersionScopeQuery, []interface{}{keyId})
+	if err != nil {
+		return "", fmt.Errorf("unable to look up the scope of key version %s: %w", keyId, err)
+	}
+	defer rows.Close()
+	var scopeId string
+	if rows.Next() {
+		if err := rows.Scan(&scopeId); err != nil {
+			return "", fmt.Errorf("unable to look up the scope of key version %s: %w", keyId, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", fmt.Errorf("unable to look up the scope of key version %s: %w", keyId, err)
+	}
+	a.keyScopes[keyId] = scopeId
+	return scopeId, nil
+}
+
+// recordKeyVersions records, in the transaction deleting them, the number of
+// the archived records encrypted with each key version.
+func (a *archiver) recordKeyVersions(ctx context.Context, w db.Writer, records []*Record) error {
+	if _, err := w.Exec(ctx, insertArchiveQuery, []interface{}{a.manifest.Name, a.manifest.ControllerName}); err != nil {
+		return fmt.Errorf("unable to record archive %s: %w", a.manifest.Name, err)
+	}
+	var keyIds []string
+	counts := map[string]int{}
+	for _, rec := range records {
+		if _, ok := counts[rec.KeyId]; !ok {
+			keyIds = append(keyIds, rec.KeyId)
+		}
+		counts[rec.KeyId]++
+	}
+	for _, keyId := range keyIds {
+		if _, err := w.Exec(ctx, upsertArchiveKeyVersionQuery, []interface{}{a.manifest.Name, keyId, counts[keyId]}); err != nil {
+			return fmt.Errorf("unable to record key versions of archive %s: %w", a.manifest.Name, err)
+		}
+	}
+	return nil
+}
+
+// addKeyVersion counts an archived entry encrypted with the key version
+// keyId of the scope scopeId.
+func (a *archiver) addKeyVersion(keyId, scopeId string) {
+	for _, kv := range a.manifest.KeyVersions {
+		if kv.KeyId == keyId {
+			kv.EntryCount++
+			return
+		}
+	}
+	a.manifest.KeyVersions = append(a.manifest.KeyVersions, &KeyVersion{
+		KeyId:      keyId,
+		ScopeId:    scopeId,
+		EntryCount: 1,
+	})
+}
+
+// abort closes the archive after a failure, writing the manifest of the
+// entries which were archived and deleted so far.
+func (a *archiver) abort() {
+	if a.file == nil {
+		return
+	}
+	if err := a.file.close(); err != nil {
+		return
+	}
+	_ = writeManifest(a.dir, a.manifest)
+}
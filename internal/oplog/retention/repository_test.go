@@ -0,0 +1,120 @@
+package retention_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/retention"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ArchiveExpired(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	for i := 0; i < 5; i++ {
+		iam.TestUser(t, iamRepo, org.PublicId)
+	}
+
+	dir, err := ioutil.TempDir("", "oplog-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	repo, err := retention.NewRepository(rw, rw, retention.WithBatchSize(2))
+	require.NoError(t, err)
+
+	t.Run("invalid-policy", func(t *testing.T) {
+		_, err := repo.ArchiveExpired(ctx, retention.Policy{MaxEntriesPerAggregate: 1, ControllerName: "controller"})
+		assert.Error(t, err)
+		_, err = repo.ArchiveExpired(ctx, retention.Policy{ArchiveDir: dir, ControllerName: "controller"})
+		assert.Error(t, err)
+		_, err = repo.ArchiveExpired(ctx, retention.Policy{MaxEntriesPerAggregate: 1, ArchiveDir: dir, ControllerName: "controller"})
+		assert.Error(t, err)
+	})
+
+	t.Run("nothing-expired", func(t *testing.T) {
+		manifest, err := repo.ArchiveExpired(ctx, retention.Policy{MaxEntriesPerAggregate: 1000, ArchiveDir: dir, ControllerName: "controller"})
+		require.NoError(t, err)
+		assert.Nil(t, manifest)
+	})
+
+	t.Run("max-entries", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		manifest, err := repo.ArchiveExpired(ctx, retention.Policy{MaxEntriesPerAggregate: 1, ArchiveDir: dir})
+		require.NoError(err)
+		require.NotNil(manifest)
+		assert.FileExists(filepath.Join(dir, manifest.Name+".json.gz"))
+		assert.FileExists(filepath.Join(dir, manifest.Name+".manifest.json"))
+
+		var users *retention.AggregateManifest
+		for _, a := range manifest.Aggregates {
+			if a.AggregateName == "iam_user" {
+				users = a
+			}
+		}
+		require.NotNil(users)
+		assert.Equal(4, users.EntryCount)
+		assert.Equal(users.LastId, users.AnchorId)
+		assert.Equal("controller", manifest.ControllerName)
+		require.NotEmpty(manifest.KeyVersions)
+		for _, kv := range manifest.KeyVersions {
+			assert.NotEmpty(kv.ScopeId)
+
+			// the key versions of the archive are recorded
+			var entryCount int
+			require.NoError(conn.Raw("select entry_count from oplog_archive_key_version where archive_name = ? and key_id = ?", manifest.Name, kv.KeyId).Row().Scan(&entryCount))
+			assert.Equal(kv.EntryCount, entryCount)
+		}
+
+		var count int
+		require.NoError(conn.Raw("select count(*) from oplog_entry where aggregate_name = 'iam_user'").Row().Scan(&count))
+		assert.Equal(1, count)
+
+		// the remaining entries are chained to the archived ones
+		chains, err := oplog.VerifyHashChains(ctx, conn)
+		require.NoError(err)
+		for _, s := range chains {
+			assert.Zero(s.BreakId, s.BreakReason)
+		}
+
+		// new entries are chained to the remaining ones
+		iam.TestUser(t, iamRepo, org.PublicId)
+		chains, err = oplog.VerifyHashChains(ctx, conn, "iam_user")
+		require.NoError(err)
+		require.Len(chains, 1)
+		assert.Zero(chains[0].BreakId, chains[0].BreakReason)
+		assert.Equal(users.AnchorId, chains[0].AnchorId)
+		assert.Equal(2, chains[0].VerifiedCount)
+	})
+
+	t.Run("forget-archive", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		var name string
+		require.NoError(conn.Raw("select name from oplog_archive").Row().Scan(&name))
+
+		_, err := repo.ForgetArchive(ctx, "")
+		assert.Error(err)
+		forgotten, err := repo.ForgetArchive(ctx, name)
+		require.NoError(err)
+		assert.Equal(1, forgotten)
+		forgotten, err = repo.ForgetArchive(ctx, name)
+		require.NoError(err)
+		assert.Zero(forgotten)
+
+		var count int
+		require.NoError(conn.Raw("select count(*) from oplog_archive_key_version").Row().Scan(&count))
+		assert.Zero(count)
+	})
+}
@@ -0,0 +1,83 @@
+package search
+
+import (
+	"reflect"
+
+	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	authstore "github.com/hashicorp/boundary/internal/auth/store"
+	staticstore "github.com/hashicorp/boundary/internal/host/static/store"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/target"
+	targetstore "github.com/hashicorp/boundary/internal/target/store"
+	"github.com/hashicorp/boundary/internal/webhook"
+	webhookstore "github.com/hashicorp/boundary/internal/webhook/store"
+	"google.golang.org/protobuf/proto"
+)
+
+// catalogTypes are the types of the messages written to the oplog, named
+// after the tables they are written to. Messages are decoded into the store
+// messages embedded in the types of the repositories, since those are the
+// ones marshaled into the oplog.
+var catalogTypes = []oplog.Type{
+	{Interface: new(iamstore.Scope), Name: "iam_scope"},
+	{Interface: new(iamstore.User), Name: "iam_user"},
+	{Interface: new(iamstore.Group), Name: "iam_group"},
+	{Interface: new(iamstore.GroupMemberUser), Name: "iam_group_member_user"},
+	{Interface: new(iamstore.GroupMemberView), Name: "iam_group_member"},
+	{Interface: new(iamstore.Role), Name: "iam_role"},
+	{Interface: new(iamstore.RoleGrant), Name: "iam_role_grant"},
+	{Interface: new(iamstore.UserRole), Name: "iam_user_role"},
+	{Interface: new(iamstore.GroupRole), Name: "iam_group_role"},
+	{Interface: new(iamstore.PrincipalRoleView), Name: "iam_principal_role"},
+	{Interface: new(authstore.Account), Name: "auth_account"},
+	{Interface: new(pwstore.AuthMethod), Name: "auth_password_method"},
+	{Interface: new(pwstore.Account), Name: "auth_password_account"},
+	{Interface: new(pwstore.Argon2Configuration), Name: "auth_password_argon2_conf"},
+	{Interface: new(pwstore.Argon2Credential), Name: "auth_password_argon2_cred"},
+	{Interface: new(pwstore.Credential), Name: "auth_password_credential"},
+	{Interface: new(staticstore.HostCatalog), Name: "static_host_catalog"},
+	{Interface: new(staticstore.Host), Name: "static_host"},
+	{Interface: new(staticstore.HostLabel), Name: "static_host_label"},
+	{Interface: new(staticstore.HostSet), Name: "static_host_set"},
+	{Interface: new(staticstore.HostSetMember), Name: "static_host_set_member"},
+	{Interface: new(targetstore.TcpTarget), Name: target.DefaultTcpTableName},
+	{Interface: new(targetstore.TargetHostSet), Name: target.DefaultTargetHostSetTableName},
+	{Interface: new(webhookstore.Webhook), Name: webhook.DefaultWebhookTableName},
+	{Interface: new(webhookstore.WebhookEventType), Name: webhook.DefaultWebhookEventTypeTableName},
+	{Interface: new(webhookstore.Delivery), Name: webhook.DefaultDeliveryTableName},
+}
+
+// redactedFields are the fields of the messages, besides those encrypted in
+// the database, which hold secrets.
+var redactedFields = map[reflect.Type][]string{
+	reflect.TypeOf(pwstore.Argon2Credential{}): {"DerivedKey"},
+}
+
+// NewTypeCatalog returns the catalog of the types of the messages written to
+// the oplog.
+func NewTypeCatalog() (*oplog.TypeCatalog, error) {
+	return oplog.NewTypeCatalog(catalogTypes...)
+}
+
+// redact clears the fields of the decoded message m which hold secrets: the
+// fields encrypted in the database, both their plaintext and ciphertext, and
+// the fields listed in redactedFields.
+func redact(m proto.Message) {
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	v = v.Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("wrapping"); ok {
+			v.Field(i).Set(reflect.Zero(t.Field(i).Type))
+		}
+	}
+	for _, name := range redactedFields[t] {
+		if f := v.FieldByName(name); f.IsValid() {
+			f.Set(reflect.Zero(f.Type()))
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package search
+
+import (
+	"testing"
+
+	pwstore "github.com/hashicorp/boundary/internal/auth/password/store"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	webhookstore "github.com/hashicorp/boundary/internal/webhook/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTypeCatalog(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	catalog, err := NewTypeCatalog()
+	require.NoError(err)
+	assert.Len(*catalog, len(catalogTypes))
+
+	m, err := catalog.Get("iam_user")
+	require.NoError(err)
+	assert.IsType(&iamstore.User{}, m)
+
+	_, err = catalog.Get("unknown_table")
+	assert.Error(err)
+}
+
+func Test_redact(t *testing.T) {
+	t.Run("wrapped-fields", func(t *testing.T) {
+		assert := assert.New(t)
+		w := &webhookstore.Webhook{
+			PublicId: "wh_1234567890",
+			Url:      "https://example.com/hook",
+			Secret:   "secret",
+			CtSecret: []byte("ciphertext"),
+		}
+		redact(w)
+		assert.Equal("wh_1234567890", w.PublicId)
+		assert.Equal("https://example.com/hook", w.Url)
+		assert.Empty(w.Secret)
+		assert.Empty(w.CtSecret)
+	})
+	t.Run("listed-fields", func(t *testing.T) {
+		assert := assert.New(t)
+		c := &pwstore.Argon2Credential{
+			PrivateId:  "arg2cred_1234567890",
+			Salt:       []byte("salt"),
+			CtSalt:     []byte("ciphertext"),
+			DerivedKey: []byte("derived"),
+		}
+		redact(c)
+		assert.Equal("arg2cred_1234567890", c.PrivateId)
+		assert.Empty(c.Salt)
+		assert.Empty(c.CtSalt)
+		assert.Empty(c.DerivedKey)
+	})
+	t.Run("nil", func(t *testing.T) {
+		assert.NotPanics(t, func() { redact((*iamstore.User)(nil)) })
+	})
+}
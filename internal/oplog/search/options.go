@@ -0,0 +1,99 @@
+package search
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/internal/oplog"
+)
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withAggregateName string
+	withResourceType  string
+	withResourceId    string
+	withOpType        oplog.OpType
+	withStartTime     time.Time
+	withEndTime       time.Time
+	withAfterId       uint32
+	withLimit         int
+}
+
+func getDefaultOptions() options {
+	return options{
+		withOpType: oplog.OpType_OP_TYPE_UNSPECIFIED,
+	}
+}
+
+// WithAggregateName only returns entries of the aggregate name, e.g.
+// iam_user.
+func WithAggregateName(name string) Option {
+	return func(o *options) {
+		o.withAggregateName = name
+	}
+}
+
+// WithResourceType only returns entries with resource-type metadata of typ,
+// e.g. user.
+func WithResourceType(typ string) Option {
+	return func(o *options) {
+		o.withResourceType = typ
+	}
+}
+
+// WithResourceId only returns entries with resource-public-id metadata of
+// id.
+func WithResourceId(id string) Option {
+	return func(o *options) {
+		o.withResourceId = id
+	}
+}
+
+// WithOpType only returns entries with op-type metadata of opType.
+func WithOpType(opType oplog.OpType) Option {
+	return func(o *options) {
+		o.withOpType = opType
+	}
+}
+
+// WithStartTime only returns entries written at or after t.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.withStartTime = t
+	}
+}
+
+// WithEndTime only returns entries written before t.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.withEndTime = t
+	}
+}
+
+// WithAfterId only returns entries with an id greater than id, to continue
+// a previous search.
+func WithAfterId(id uint32) Option {
+	return func(o *options) {
+		o.withAfterId = id
+	}
+}
+
+// WithLimit provides an option to provide a limit. Intentionally allowing
+// negative integers. If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(limit int) Option {
+	return func(o *options) {
+		o.withLimit = limit
+	}
+}
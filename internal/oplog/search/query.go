@@ -0,0 +1,34 @@
+package search
+
+const (
+	// oplogKeyVersionScopeQuery returns the scope of a version of an oplog
+	// DEK.
+	oplogKeyVersionScopeQuery = `
+select rk.scope_id
+  from kms_oplog_key_version kv
+  join kms_oplog_key k
+    on k.private_id = kv.oplog_key_id
+  join kms_root_key rk
+    on rk.private_id = k.root_key_id
+ where kv.private_id = $1
+`
+
+	// entryMetadataCondition is the condition of entries having a metadata
+	// key with one of a list of values.
+	entryMetadataCondition = `id in (select entry_id from oplog_metadata where key = ? and value in (?))`
+
+	// entryScopeCondition is the condition of entries in one of a list of
+	// scopes: entries encrypted by a version of an oplog DEK of one of the
+	// scopes, and entries written before key_id was recorded with scope-id
+	// metadata of one of the scopes. The metadata of other entries is not
+	// relied on, since some repositories write the scope of the parent.
+	entryScopeCondition = `(key_id in (
+  select kv.private_id
+    from kms_oplog_key_version kv
+    join kms_oplog_key k
+      on k.private_id = kv.oplog_key_id
+    join kms_root_key rk
+      on rk.private_id = k.root_key_id
+   where rk.scope_id in (?)
+) or ((key_id is null or key_id = '') and ` + entryMetadataCondition + `))`
+)
@@ -0,0 +1,288 @@
+package search
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// Metadata keys written by the oplog helpers of the repositories.
+const (
+	ScopeIdKey          = "scope-id"
+	ResourceTypeKey     = "resource-type"
+	ResourcePublicIdKey = "resource-public-id"
+	OpTypeKey           = "op-type"
+)
+
+// Repository searches and decrypts the entries of the oplog.
+type Repository struct {
+	reader  db.Reader
+	kms     *kms.Kms
+	catalog *oplog.TypeCatalog
+
+	// defaultLimit provides a default for limiting the number of results returned from the repo
+	defaultLimit int
+}
+
+// NewRepository creates a new oplog search Repository. Supports the options:
+// WithLimit which sets a default limit on results returned by repo
+// operations.
+func NewRepository(r db.Reader, kms *kms.Kms, opt ...Option) (*Repository, error) {
+	if r == nil {
+		return nil, stderrors.New("error creating db repository with nil reader")
+	}
+	if kms == nil {
+		return nil, stderrors.New("error creating db repository with nil kms")
+	}
+	catalog, err := NewTypeCatalog()
+	if err != nil {
+		return nil, fmt.Errorf("error creating oplog type catalog: %w", err)
+	}
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &Repository{
+		reader:       r,
+		kms:          kms,
+		catalog:      catalog,
+		defaultLimit: opts.withLimit,
+	}, nil
+}
+
+// Entry is a decrypted oplog entry.
+type Entry struct {
+	Id            uint32
+	CreateTime    time.Time
+	Version       string
+	AggregateName string
+	// ScopeId is the scope of the changed resources, whose oplog key
+	// encrypted the entry.
+	ScopeId  string
+	Metadata oplog.Metadata
+	// Messages are the changes of the entry. Fields holding secrets are
+	// cleared.
+	Messages []oplog.Message
+	// DataError is the reason the data of the entry could not be decrypted or
+	// decoded, if it could not.
+	DataError error
+}
+
+// SearchEntries returns the entries of the oplog in one of scopeIds, those
+// encrypted with an oplog key of one of the scopes, in the order they were
+// written. The scope of entries written before key versions were recorded is
+// read from their data, or from their scope-id metadata. Supports the options:
+// WithAggregateName, WithResourceType, WithResourceId, WithOpType,
+// WithStartTime, WithEndTime and WithAfterId to filter the entries, and
+// WithLimit to override the default limit of the repository. Entries whose
+// data can't be decrypted or decoded are returned with their DataError set.
+func (r *Repository) SearchEntries(ctx context.Context, scopeIds []string, opt ...Option) ([]*Entry, error) {
+	if len(scopeIds) == 0 {
+		return nil, fmt.Errorf("search oplog entries: missing scope ids: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+
+	conds := []string{entryScopeCondition}
+	args := []interface{}{scopeIds, ScopeIdKey, scopeIds}
+	if opts.withAggregateName != "" {
+		conds = append(conds, "aggregate_name = ?")
+		args = append(args, opts.withAggregateName)
+	}
+	if opts.withResourceType != "" {
+		conds = append(conds, entryMetadataCondition)
+		args = append(args, ResourceTypeKey, []string{opts.withResourceType})
+	}
+	if opts.withResourceId != "" {
+		conds = append(conds, entryMetadataCondition)
+		args = append(args, ResourcePublicIdKey, []string{opts.withResourceId})
+	}
+	if opts.withOpType != oplog.OpType_OP_TYPE_UNSPECIFIED {
+		conds = append(conds, entryMetadataCondition)
+		args = append(args, OpTypeKey, []string{opts.withOpType.String()})
+	}
+	if !opts.withStartTime.IsZero() {
+		conds = append(conds, "create_time >= ?")
+		args = append(args, opts.withStartTime)
+	}
+	if !opts.withEndTime.IsZero() {
+		conds = append(conds, "create_time < ?")
+		args = append(args, opts.withEndTime)
+	}
+
+	// Entries matched by their scope-id metadata can belong to another scope,
+	// so the entries out of scopeIds are skipped and more are read to fill
+	// the limit
+	inScope := make(map[string]bool, len(scopeIds))
+	for _, id := range scopeIds {
+		inScope[id] = true
+	}
+	d := &decrypter{repo: r, keyScopes: map[string]string{}, wrappers: map[string]wrapping.Wrapper{}}
+	var entries []*Entry
+	afterId := opts.withAfterId
+	for {
+		batchLimit := limit
+		if limit > 0 {
+			batchLimit = limit - len(entries)
+		}
+		batch, lastId, err := r.searchBatch(ctx, d, inScope, conds, args, afterId, batchLimit)
+		if err != nil {
+			return nil, fmt.Errorf("search oplog entries: %w", err)
+		}
+		entries = append(entries, batch...)
+		if lastId == 0 || limit <= 0 || len(entries) >= limit {
+			break
+		}
+		afterId = lastId
+	}
+	return entries, nil
+}
+
+// searchBatch reads up to limit entries matching conds after afterId, and
+// returns those in one of the scopes of inScope along with the id of the
+// last entry read, or zero if fewer than limit entries were read.
+func (r *Repository) searchBatch(ctx context.Context, d *decrypter, inScope map[string]bool, conds []string, args []interface{}, afterId uint32, limit int) ([]*Entry, uint32, error) {
+	if afterId > 0 {
+		conds = append(conds[:len(conds):len(conds)], "id > ?")
+		args = append(args[:len(args):len(args)], afterId)
+	}
+	var storeEntries []*store.Entry
+	if err := r.reader.SearchWhere(ctx, &storeEntries, strings.Join(conds, " and "), args, db.WithLimit(limit), db.WithOrder("id asc")); err != nil {
+		return nil, 0, err
+	}
+	if len(storeEntries) == 0 {
+		return nil, 0, nil
+	}
+	var lastId uint32
+	if limit > 0 && len(storeEntries) == limit {
+		lastId = storeEntries[len(storeEntries)-1].Id
+	}
+
+	ids := make([]uint32, 0, len(storeEntries))
+	for _, e := range storeEntries {
+		ids = append(ids, e.Id)
+	}
+	var metadata []*store.Metadata
+	if err := r.reader.SearchWhere(ctx, &metadata, "entry_id in (?)", []interface{}{ids}, db.WithLimit(-1)); err != nil {
+		return nil, 0, fmt.Errorf("unable to read metadata: %w", err)
+	}
+	entryMetadata := make(map[uint32]oplog.Metadata, len(storeEntries))
+	for _, m := range metadata {
+		md := entryMetadata[m.EntryId]
+		if md == nil {
+			md = oplog.Metadata{}
+			entryMetadata[m.EntryId] = md
+		}
+		md[m.Key] = append(md[m.Key], m.Value)
+	}
+
+	entries := make([]*Entry, 0, len(storeEntries))
+	for _, e := range storeEntries {
+		var err error
+		entry := &Entry{
+			Id:            e.Id,
+			Version:       e.Version,
+			AggregateName: e.AggregateName,
+			Metadata:      entryMetadata[e.Id],
+		}
+		if entry.ScopeId, err = d.scope(ctx, e, entry.Metadata); err != nil {
+			return nil, 0, fmt.Errorf("entry %d: %w", e.Id, err)
+		}
+		if !inScope[entry.ScopeId] {
+			continue
+		}
+		if e.CreateTime != nil {
+			if entry.CreateTime, err = ptypes.Timestamp(e.CreateTime.Timestamp); err != nil {
+				return nil, 0, fmt.Errorf("entry %d: %w", e.Id, err)
+			}
+		}
+		entry.Messages, entry.DataError = d.decrypt(ctx, entry.ScopeId, e)
+		entries = append(entries, entry)
+	}
+	return entries, lastId, nil
+}
+
+// decrypter decrypts the data of oplog entries, caching the scopes of the
+// key versions and the wrappers of the scopes it looks up.
+type decrypter struct {
+	repo      *Repository
+	keyScopes map[string]string
+	wrappers  map[string]wrapping.Wrapper
+}
+
+// scope returns the scope of the oplog key version which encrypted the entry
+// e, read from its data for entries written before key versions were
+// recorded, or the scope in its metadata when its key version is unknown.
+func (d *decrypter) scope(ctx context.Context, e *store.Entry, md oplog.Metadata) (string, error) {
+	keyId := e.KeyId
+	if keyId == "" {
+		keyId = oplog.DataKeyId(e.CtData)
+	}
+	if keyId != "" {
+		scopeId, ok := d.keyScopes[keyId]
+		if !ok {
+			rows, err := d.repo.reader.Query(ctx, oplogKeyVersionScopeQuery, []interface{}{keyId})
+			if err != nil {
+				return "", fmt.Errorf("unable to look up the scope of key version %s: %w", keyId, err)
+			}
+			defer rows.Close()
+			for rows.Next() {
+				if err := rows.Scan(&scopeId); err != nil {
+					return "", fmt.Errorf("unable to look up the scope of key version %s: %w", keyId, err)
+				}
+			}
+			if err := rows.Err(); err != nil {
+				return "", fmt.Errorf("unable to look up the scope of key version %s: %w", keyId, err)
+			}
+			d.keyScopes[keyId] = scopeId
+		}
+		if scopeId != "" {
+			return scopeId, nil
+		}
+	}
+	if len(md[ScopeIdKey]) > 0 {
+		return md[ScopeIdKey][0], nil
+	}
+	return "", nil
+}
+
+func (d *decrypter) decrypt(ctx context.Context, scopeId string, e *store.Entry) ([]oplog.Message, error) {
+	if scopeId == "" {
+		return nil, stderrors.New("unknown scope")
+	}
+	wrapper, ok := d.wrappers[scopeId]
+	if !ok {
+		var err error
+		if wrapper, err = d.repo.kms.GetWrapper(ctx, scopeId, kms.KeyPurposeOplog); err != nil {
+			return nil, fmt.Errorf("unable to get oplog wrapper of scope %s: %w", scopeId, err)
+		}
+		d.wrappers[scopeId] = wrapper
+	}
+	entry := &oplog.Entry{Entry: e, Cipherer: wrapper}
+	if err := entry.DecryptData(ctx); err != nil {
+		return nil, err
+	}
+	msgs, err := entry.UnmarshalData(d.repo.catalog)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range msgs {
+		redact(m.Message)
+	}
+	return msgs, nil
+}
@@ -0,0 +1,250 @@
+package search_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	iamstore "github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/search"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_SearchEntries(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	start := time.Now().Add(-time.Minute)
+
+	user := iam.TestUser(t, iamRepo, org.PublicId, iam.WithName("alice"))
+	user.Name = "bob"
+	_, _, _, err := iamRepo.UpdateUser(ctx, user, user.Version, []string{"Name"})
+	require.NoError(t, err)
+	other := iam.TestUser(t, iamRepo, org.PublicId)
+
+	repo, err := search.NewRepository(rw, kmsCache)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		scopeIds  []string
+		opt       []search.Option
+		wantCount int
+		wantErr   bool
+	}{
+		{
+			name:    "missing-scope-ids",
+			wantErr: true,
+		},
+		{
+			name:      "resource-id",
+			scopeIds:  []string{org.PublicId},
+			opt:       []search.Option{search.WithResourceId(user.PublicId)},
+			wantCount: 2,
+		},
+		{
+			name:      "resource-type-and-op-type",
+			scopeIds:  []string{org.PublicId},
+			opt:       []search.Option{search.WithResourceType(resource.User.String()), search.WithOpType(oplog.OpType_OP_TYPE_CREATE)},
+			wantCount: 2,
+		},
+		{
+			name:      "aggregate-name",
+			scopeIds:  []string{org.PublicId},
+			opt:       []search.Option{search.WithAggregateName("iam_user"), search.WithResourceId(other.PublicId)},
+			wantCount: 1,
+		},
+		{
+			name:      "time-range",
+			scopeIds:  []string{org.PublicId},
+			opt:       []search.Option{search.WithResourceId(user.PublicId), search.WithStartTime(start), search.WithEndTime(start.Add(time.Hour))},
+			wantCount: 2,
+		},
+		{
+			name:      "after-time-range",
+			scopeIds:  []string{org.PublicId},
+			opt:       []search.Option{search.WithResourceId(user.PublicId), search.WithEndTime(start)},
+			wantCount: 0,
+		},
+		{
+			name:      "other-scope",
+			scopeIds:  []string{"o_1234567890"},
+			opt:       []search.Option{search.WithResourceId(user.PublicId)},
+			wantCount: 0,
+		},
+		{
+			name:      "limit",
+			scopeIds:  []string{org.PublicId},
+			opt:       []search.Option{search.WithResourceType(resource.User.String()), search.WithLimit(1)},
+			wantCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.SearchEntries(ctx, tt.scopeIds, tt.opt...)
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			require.NoError(err)
+			assert.Len(got, tt.wantCount)
+		})
+	}
+
+	t.Run("decrypted", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.SearchEntries(ctx, []string{org.PublicId}, search.WithResourceId(user.PublicId))
+		require.NoError(err)
+		require.Len(got, 2)
+		assert.Less(got[0].Id, got[1].Id)
+		for _, e := range got {
+			assert.NoError(e.DataError)
+			assert.Equal(org.PublicId, e.ScopeId)
+			assert.Equal([]string{user.PublicId}, e.Metadata[search.ResourcePublicIdKey])
+			require.Len(e.Messages, 1)
+			u, ok := e.Messages[0].Message.(*iamstore.User)
+			require.True(ok)
+			assert.Equal(user.PublicId, u.PublicId)
+		}
+		assert.Equal(oplog.OpType_OP_TYPE_CREATE, got[0].Messages[0].OpType)
+		assert.Equal("alice", got[0].Messages[0].Message.(*iamstore.User).Name)
+		assert.Equal(oplog.OpType_OP_TYPE_UPDATE, got[1].Messages[0].OpType)
+		assert.Equal([]string{"Name"}, got[1].Messages[0].FieldMaskPaths)
+
+		// continue after the first entry
+		next, err := repo.SearchEntries(ctx, []string{org.PublicId}, search.WithResourceId(user.PublicId), search.WithAfterId(got[0].Id))
+		require.NoError(err)
+		require.Len(next, 1)
+		assert.Equal(got[1].Id, next[0].Id)
+	})
+}
+
+func TestRepository_SearchEntriesWithoutScopeMetadata(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	// Neither static hosts nor password accounts have scope-id metadata
+	staticRepo, err := static.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	catalog := static.TestCatalogs(t, conn, proj.PublicId, 1)[0]
+	newHost, err := static.NewHost(catalog.PublicId, static.WithAddress("127.0.0.1"))
+	require.NoError(t, err)
+	host, err := staticRepo.CreateHost(ctx, proj.PublicId, newHost)
+	require.NoError(t, err)
+
+	passwordRepo, err := password.NewRepository(rw, rw, kmsCache)
+	require.NoError(t, err)
+	authMethod := password.TestAuthMethods(t, conn, org.PublicId, 1)[0]
+	newAccount, err := password.NewAccount(authMethod.PublicId, password.WithLoginName("alice"))
+	require.NoError(t, err)
+	account, err := passwordRepo.CreateAccount(ctx, org.PublicId, newAccount)
+	require.NoError(t, err)
+
+	repo, err := search.NewRepository(rw, kmsCache)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		scopeIds   []string
+		resourceId string
+		wantScope  string
+	}{
+		{
+			name:       "static-host",
+			scopeIds:   []string{proj.PublicId},
+			resourceId: host.PublicId,
+			wantScope:  proj.PublicId,
+		},
+		{
+			name:       "static-host-other-scope",
+			scopeIds:   []string{org.PublicId},
+			resourceId: host.PublicId,
+		},
+		{
+			name:       "password-account",
+			scopeIds:   []string{org.PublicId},
+			resourceId: account.PublicId,
+			wantScope:  org.PublicId,
+		},
+		{
+			name:       "password-account-other-scope",
+			scopeIds:   []string{proj.PublicId},
+			resourceId: account.PublicId,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.SearchEntries(ctx, tt.scopeIds, search.WithResourceId(tt.resourceId))
+			require.NoError(err)
+			if tt.wantScope == "" {
+				assert.Empty(got)
+				return
+			}
+			require.Len(got, 1)
+			assert.Empty(got[0].Metadata[search.ScopeIdKey])
+			assert.Equal(tt.wantScope, got[0].ScopeId)
+			assert.NoError(got[0].DataError)
+			require.NotEmpty(got[0].Messages)
+			assert.Equal(oplog.OpType_OP_TYPE_CREATE, got[0].Messages[0].OpType)
+		})
+	}
+}
+
+func TestRepository_SearchEntriesOtherScopeMetadata(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	kmsCache := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, proj := iam.TestScopes(t, iamRepo)
+
+	// The update of a project is encrypted with the project's key but has
+	// the org as its scope-id metadata
+	proj.Name = "project"
+	_, _, err := iamRepo.UpdateScope(ctx, proj, proj.Version, []string{"Name"})
+	require.NoError(t, err)
+
+	repo, err := search.NewRepository(rw, kmsCache)
+	require.NoError(t, err)
+
+	check := func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := repo.SearchEntries(ctx, []string{org.PublicId}, search.WithResourceId(proj.PublicId), search.WithOpType(oplog.OpType_OP_TYPE_UPDATE))
+		require.NoError(err)
+		assert.Empty(got)
+
+		got, err = repo.SearchEntries(ctx, []string{proj.PublicId}, search.WithResourceId(proj.PublicId), search.WithOpType(oplog.OpType_OP_TYPE_UPDATE))
+		require.NoError(err)
+		require.Len(got, 1)
+		assert.Equal([]string{org.PublicId}, got[0].Metadata[search.ScopeIdKey])
+		assert.Equal(proj.PublicId, got[0].ScopeId)
+		assert.NoError(got[0].DataError)
+	}
+	t.Run("key-id", check)
+
+	// Entries written before key versions were recorded are matched by
+	// their metadata, and then by the key version in their data
+	_, err = rw.Exec(ctx, "update oplog_entry set key_id = null", nil)
+	require.NoError(t, err)
+	t.Run("legacy", check)
+}
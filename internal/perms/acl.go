@@ -133,7 +133,9 @@ func topLevelType(typ resource.Type) bool {
 		resource.Scope,
 		resource.Session,
 		resource.Target,
-		resource.User:
+		resource.User,
+		resource.Webhook,
+		resource.Worker:
 		return true
 	}
 	return false
@@ -336,7 +336,9 @@ func (g Grant) validateType() error {
 		resource.HostSet,
 		resource.Host,
 		resource.Target,
-		resource.Session:
+		resource.Session,
+		resource.Webhook,
+		resource.Worker:
 		return nil
 	}
 	return fmt.Errorf("unknown type specifier %q", g.typ)
@@ -0,0 +1,60 @@
+package reports
+
+import "time"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withGroupBy   GroupBy
+	withPeriod    Period
+	withStartTime time.Time
+	withEndTime   time.Time
+}
+
+func getDefaultOptions() options {
+	return options{
+		withGroupBy: GroupByTarget,
+		withPeriod:  PeriodDay,
+	}
+}
+
+// WithGroupBy groups the sessions by their user, target or host. The default
+// is GroupByTarget.
+func WithGroupBy(g GroupBy) Option {
+	return func(o *options) {
+		o.withGroupBy = g
+	}
+}
+
+// WithPeriod groups the sessions by the day, week or month they were started
+// in. The default is PeriodDay.
+func WithPeriod(p Period) Option {
+	return func(o *options) {
+		o.withPeriod = p
+	}
+}
+
+// WithStartTime only reports the sessions started at or after t.
+func WithStartTime(t time.Time) Option {
+	return func(o *options) {
+		o.withStartTime = t
+	}
+}
+
+// WithEndTime only reports the sessions started before t.
+func WithEndTime(t time.Time) Option {
+	return func(o *options) {
+		o.withEndTime = t
+	}
+}
@@ -0,0 +1,36 @@
+package reports
+
+const (
+	// sessionReportQuery groups the sessions of the warehouse by the period of
+	// the date dimension they were pending in and by a resource of their host
+	// or user dimension. The first three verbs are the columns of the
+	// resource ID, name and scope ID, and the fourth the conditions on the
+	// sessions. The name and scope are those of the dimension row of the
+	// last session of the period, since the dimensions keep a row per
+	// version of a resource.
+	sessionReportQuery = `
+select date_trunc(?, d.date)::date                                    as period_start,
+       %[1]s                                                          as id,
+       (array_agg(%[2]s order by f.session_pending_time desc))[1]     as name,
+       (array_agg(%[3]s order by f.session_pending_time desc))[1]     as scope_id,
+       count(*)                                                       as session_count,
+       coalesce(sum(f.total_connection_count), 0)                     as connection_count,
+       coalesce(sum(extract(epoch from
+         case when f.session_terminated_time = 'infinity'
+              then greatest(now(), f.session_pending_time)
+              else f.session_terminated_time
+         end - f.session_pending_time)), 0)                           as total_duration_seconds,
+       coalesce(sum(f.total_bytes_up), 0)                             as bytes_up,
+       coalesce(sum(f.total_bytes_down), 0)                           as bytes_down
+  from wh_session_accumulating_fact f
+  join wh_date_dimension d
+    on d.id = f.session_pending_date_id
+  join wh_host_dimension h
+    on h.id = f.host_id
+  join wh_user_dimension u
+    on u.id = f.user_id
+ where %[4]s
+ group by 1, 2
+ order by 1, 2
+`
+)
@@ -0,0 +1,134 @@
+package reports
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// GroupBy is the type of the resources sessions are reported by.
+type GroupBy string
+
+const (
+	GroupByUser   GroupBy = "user"
+	GroupByTarget GroupBy = "target"
+	GroupByHost   GroupBy = "host"
+)
+
+// Period is the length of the periods sessions are reported by.
+type Period string
+
+const (
+	PeriodDay   Period = "day"
+	PeriodWeek  Period = "week"
+	PeriodMonth Period = "month"
+)
+
+// groupByColumns are the columns of the warehouse dimensions holding the ID,
+// name and scope ID of the resources sessions are reported by.
+var groupByColumns = map[GroupBy][3]string{
+	GroupByUser:   {"u.user_id", "u.user_name", "u.user_organization_id"},
+	GroupByTarget: {"h.target_id", "h.target_name", "h.project_id"},
+	GroupByHost:   {"h.host_id", "h.host_name", "h.project_id"},
+}
+
+// Repository reports on the sessions recorded in the data warehouse tables.
+type Repository struct {
+	reader db.Reader
+}
+
+// NewRepository creates a new reports Repository.
+func NewRepository(r db.Reader) (*Repository, error) {
+	if r == nil {
+		return nil, stderrors.New("error creating db repository with nil reader")
+	}
+	return &Repository{reader: r}, nil
+}
+
+// SessionReport summarizes the sessions of a user, target or host started
+// during a period.
+type SessionReport struct {
+	// PeriodStart is the first day of the period, at midnight UTC. Weeks
+	// start on Monday.
+	PeriodStart time.Time
+	Type        GroupBy
+	Id          string
+	Name        string
+	ScopeId     string
+
+	SessionCount    uint64
+	ConnectionCount uint64
+	// TotalDuration is the sum of the durations of the sessions. The duration
+	// of a session which is not terminated yet is the time since it was
+	// started.
+	TotalDuration time.Duration
+	BytesUp       uint64
+	BytesDown     uint64
+}
+
+// AverageDuration returns the average duration of the sessions.
+func (r *SessionReport) AverageDuration() time.Duration {
+	if r.SessionCount == 0 {
+		return 0
+	}
+	return r.TotalDuration / time.Duration(r.SessionCount)
+}
+
+// ReportSessions returns the reports of the sessions of the targets of the
+// projects projectIds, ordered by period and then by ID. Supports the
+// options: WithGroupBy and WithPeriod to choose the reported resources and
+// periods, and WithStartTime and WithEndTime to only report the sessions
+// started during a time range.
+func (r *Repository) ReportSessions(ctx context.Context, projectIds []string, opt ...Option) ([]*SessionReport, error) {
+	if len(projectIds) == 0 {
+		return nil, fmt.Errorf("report sessions: missing project ids: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	cols, ok := groupByColumns[opts.withGroupBy]
+	if !ok {
+		return nil, fmt.Errorf("report sessions: unknown group by %q: %w", opts.withGroupBy, errors.ErrInvalidParameter)
+	}
+	switch opts.withPeriod {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+	default:
+		return nil, fmt.Errorf("report sessions: unknown period %q: %w", opts.withPeriod, errors.ErrInvalidParameter)
+	}
+
+	conds := []string{"h.project_id in (?)"}
+	args := []interface{}{string(opts.withPeriod), projectIds}
+	if !opts.withStartTime.IsZero() {
+		conds = append(conds, "f.session_pending_time >= ?")
+		args = append(args, opts.withStartTime)
+	}
+	if !opts.withEndTime.IsZero() {
+		conds = append(conds, "f.session_pending_time < ?")
+		args = append(args, opts.withEndTime)
+	}
+	query := fmt.Sprintf(sessionReportQuery, cols[0], cols[1], cols[2], strings.Join(conds, " and "))
+
+	rows, err := r.reader.Query(ctx, query, args)
+	if err != nil {
+		return nil, fmt.Errorf("report sessions: %w", err)
+	}
+	defer rows.Close()
+	var reports []*SessionReport
+	for rows.Next() {
+		rep := &SessionReport{Type: opts.withGroupBy}
+		var seconds float64
+		if err := rows.Scan(&rep.PeriodStart, &rep.Id, &rep.Name, &rep.ScopeId, &rep.SessionCount,
+			&rep.ConnectionCount, &seconds, &rep.BytesUp, &rep.BytesDown); err != nil {
+			return nil, fmt.Errorf("report sessions: %w", err)
+		}
+		rep.TotalDuration = time.Duration(seconds * float64(time.Second))
+		reports = append(reports, rep)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("report sessions: %w", err)
+	}
+	return reports, nil
+}
@@ -0,0 +1,109 @@
+package reports_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/reports"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_ReportSessions(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	composedOf := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	s1 := session.TestSession(t, conn, wrapper, composedOf)
+	_ = session.TestSession(t, conn, wrapper, composedOf)
+	_ = session.TestConnection(t, conn, s1.PublicId, "127.0.0.1", 22, "127.0.0.1", 2222)
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	repo, err := reports.NewRepository(rw)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		projectIds []string
+		opt        []reports.Option
+		wantId     string
+		wantCount  int
+		wantErr    bool
+	}{
+		{
+			name:    "missing-project-ids",
+			wantErr: true,
+		},
+		{
+			name:       "unknown-group-by",
+			projectIds: []string{composedOf.ScopeId},
+			opt:        []reports.Option{reports.WithGroupBy("scope")},
+			wantErr:    true,
+		},
+		{
+			name:       "unknown-period",
+			projectIds: []string{composedOf.ScopeId},
+			opt:        []reports.Option{reports.WithPeriod("year")},
+			wantErr:    true,
+		},
+		{
+			name:       "target",
+			projectIds: []string{composedOf.ScopeId},
+			wantId:     composedOf.TargetId,
+			wantCount:  1,
+		},
+		{
+			name:       "user-by-month",
+			projectIds: []string{composedOf.ScopeId},
+			opt:        []reports.Option{reports.WithGroupBy(reports.GroupByUser), reports.WithPeriod(reports.PeriodMonth)},
+			wantId:     composedOf.UserId,
+			wantCount:  1,
+		},
+		{
+			name:       "host-by-week",
+			projectIds: []string{composedOf.ScopeId},
+			opt:        []reports.Option{reports.WithGroupBy(reports.GroupByHost), reports.WithPeriod(reports.PeriodWeek)},
+			wantId:     composedOf.HostId,
+			wantCount:  1,
+		},
+		{
+			name:       "before-start-time",
+			projectIds: []string{composedOf.ScopeId},
+			opt:        []reports.Option{reports.WithStartTime(time.Now().Add(time.Hour))},
+		},
+		{
+			name:       "other-project",
+			projectIds: []string{"p_1234567890"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := repo.ReportSessions(ctx, tt.projectIds, tt.opt...)
+			if tt.wantErr {
+				require.Error(err)
+				return
+			}
+			require.NoError(err)
+			require.Len(got, tt.wantCount)
+			if tt.wantCount == 0 {
+				return
+			}
+			rep := got[0]
+			assert.Equal(tt.wantId, rep.Id)
+			assert.Equal(composedOf.ScopeId == rep.ScopeId, tt.wantId != composedOf.UserId)
+			assert.False(rep.PeriodStart.After(today))
+			assert.Equal(uint64(2), rep.SessionCount)
+			assert.Equal(uint64(1), rep.ConnectionCount)
+			assert.True(rep.TotalDuration > 0)
+			assert.Equal(rep.TotalDuration/2, rep.AverageDuration())
+		})
+	}
+}
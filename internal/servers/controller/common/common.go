@@ -5,17 +5,25 @@ import (
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/oplog/retention"
+	"github.com/hashicorp/boundary/internal/oplog/search"
+	"github.com/hashicorp/boundary/internal/reports"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/webhook"
 )
 
 type (
-	AuthTokenRepoFactory    func() (*authtoken.Repository, error)
-	IamRepoFactory          func() (*iam.Repository, error)
-	PasswordAuthRepoFactory func() (*password.Repository, error)
-	ServersRepoFactory      func() (*servers.Repository, error)
-	StaticRepoFactory       func() (*static.Repository, error)
-	SessionRepoFactory      func() (*session.Repository, error)
-	TargetRepoFactory       func() (*target.Repository, error)
+	AuthTokenRepoFactory      func() (*authtoken.Repository, error)
+	IamRepoFactory            func() (*iam.Repository, error)
+	OplogRetentionRepoFactory func() (*retention.Repository, error)
+	OplogSearchRepoFactory    func() (*search.Repository, error)
+	PasswordAuthRepoFactory   func() (*password.Repository, error)
+	ReportsRepoFactory        func() (*reports.Repository, error)
+	ServersRepoFactory        func() (*servers.Repository, error)
+	StaticRepoFactory         func() (*static.Repository, error)
+	SessionRepoFactory        func() (*session.Repository, error)
+	TargetRepoFactory         func() (*target.Repository, error)
+	WebhookRepoFactory        func() (*webhook.Repository, error)
 )
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/hashicorp/boundary/internal/auth/password"
 	"github.com/hashicorp/boundary/internal/authtoken"
@@ -13,10 +14,14 @@ import (
 	"github.com/hashicorp/boundary/internal/host/static"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog/retention"
+	"github.com/hashicorp/boundary/internal/oplog/search"
+	"github.com/hashicorp/boundary/internal/reports"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/webhook"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/vault/sdk/helper/base62"
 	"github.com/hashicorp/vault/sdk/helper/mlock"
@@ -32,19 +37,31 @@ type Controller struct {
 	baseCancel  context.CancelFunc
 	started     ua.Bool
 
+	// shuttingDown is set while Shutdown stops the controller
+	shuttingDown ua.Bool
+
 	workerAuthCache *cache.Cache
 
+	// reloadable holds the *reloadableSettings currently in effect
+	reloadable *atomic.Value
+
+	watchBroker *watchBroker
+
 	// Used for testing
 	workerStatusUpdateTimes *sync.Map
 
 	// Repo factory methods
-	AuthTokenRepoFn    common.AuthTokenRepoFactory
-	IamRepoFn          common.IamRepoFactory
-	PasswordAuthRepoFn common.PasswordAuthRepoFactory
-	ServersRepoFn      common.ServersRepoFactory
-	SessionRepoFn      common.SessionRepoFactory
-	StaticHostRepoFn   common.StaticRepoFactory
-	TargetRepoFn       common.TargetRepoFactory
+	AuthTokenRepoFn      common.AuthTokenRepoFactory
+	IamRepoFn            common.IamRepoFactory
+	OplogRetentionRepoFn common.OplogRetentionRepoFactory
+	OplogSearchRepoFn    common.OplogSearchRepoFactory
+	PasswordAuthRepoFn   common.PasswordAuthRepoFactory
+	ReportsRepoFn        common.ReportsRepoFactory
+	ServersRepoFn        common.ServersRepoFactory
+	SessionRepoFn        common.SessionRepoFactory
+	StaticHostRepoFn     common.StaticRepoFactory
+	TargetRepoFn         common.TargetRepoFactory
+	WebhookRepoFn        common.WebhookRepoFactory
 
 	kms *kms.Kms
 }
@@ -54,9 +71,10 @@ func New(conf *Config) (*Controller, error) {
 		conf:                    conf,
 		logger:                  conf.Logger.Named("controller"),
 		workerStatusUpdateTimes: new(sync.Map),
+		reloadable:              new(atomic.Value),
 	}
-
 	c.started.Store(false)
+	c.registerReadinessChecks()
 
 	if conf.SecureRandomReader == nil {
 		conf.SecureRandomReader = rand.Reader
@@ -72,6 +90,8 @@ func New(conf *Config) (*Controller, error) {
 		}
 	}
 
+	c.reloadable.Store(newReloadableSettings(conf.RawConfig))
+
 	if !conf.RawConfig.DisableMlock {
 		// Ensure our memory usage is locked into physical RAM
 		if err := mlock.LockMemory(); err != nil {
@@ -88,13 +108,30 @@ func New(conf *Config) (*Controller, error) {
 		}
 	}
 
+	// Refuse to run against a schema this binary wasn't built for
+	schemaState, err := db.GetSchemaState(context.Background(), c.conf.Database.DB(), "postgres")
+	if err != nil {
+		return nil, fmt.Errorf("error checking database schema: %w", err)
+	}
+	if err := schemaState.Check(); err != nil {
+		return nil, err
+	}
+
 	// Set up repo stuff
 	dbase := db.New(c.conf.Database)
+	// The watch broker registers its listener with the Db of the
+	// repositories, so that their oplog writes publish changes right away
+	c.watchBroker = newWatchBroker(c.logger.Named("watch"), conf.DatabaseUrl, dbase, conf.RawConfig.Controller.Name)
 	kmsRepo, err := kms.NewRepository(dbase, dbase)
 	if err != nil {
 		return nil, fmt.Errorf("error creating kms repository: %w", err)
 	}
-	c.kms, err = kms.NewKms(kmsRepo, kms.WithLogger(c.logger.Named("kms")))
+	c.kms, err = kms.NewKms(kmsRepo,
+		kms.WithLogger(c.logger.Named("kms")),
+		// Controllers drop superseded and destroyed key versions from their
+		// caches when they run the rewrap jobs
+		kms.WithKeyVersionGracePeriod(6*rewrapJobInterval),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("error creating kms cache: %w", err)
 	}
@@ -113,8 +150,8 @@ func New(conf *Config) (*Controller, error) {
 	}
 	c.AuthTokenRepoFn = func() (*authtoken.Repository, error) {
 		return authtoken.NewRepository(dbase, dbase, c.kms,
-			authtoken.WithTokenTimeToLiveDuration(c.conf.RawConfig.Controller.AuthTokenTimeToLiveDuration),
-			authtoken.WithTokenTimeToStaleDuration(c.conf.RawConfig.Controller.AuthTokenTimeToStaleDuration))
+			authtoken.WithTokenTimeToLiveDuration(c.settings().authTokenTimeToLive),
+			authtoken.WithTokenTimeToStaleDuration(c.settings().authTokenTimeToStale))
 	}
 	c.ServersRepoFn = func() (*servers.Repository, error) {
 		return servers.NewRepository(dbase, dbase, c.kms)
@@ -128,6 +165,18 @@ func New(conf *Config) (*Controller, error) {
 	c.SessionRepoFn = func() (*session.Repository, error) {
 		return session.NewRepository(dbase, dbase, c.kms)
 	}
+	c.WebhookRepoFn = func() (*webhook.Repository, error) {
+		return webhook.NewRepository(dbase, dbase, c.kms)
+	}
+	c.OplogSearchRepoFn = func() (*search.Repository, error) {
+		return search.NewRepository(dbase, c.kms)
+	}
+	c.OplogRetentionRepoFn = func() (*retention.Repository, error) {
+		return retention.NewRepository(dbase, dbase)
+	}
+	c.ReportsRepoFn = func() (*reports.Repository, error) {
+		return reports.NewRepository(dbase)
+	}
 
 	c.workerAuthCache = cache.New(0, 0)
 
@@ -148,6 +197,11 @@ func (c *Controller) Start() error {
 	c.startStatusTicking(c.baseContext)
 	c.startRecoveryNonceCleanupTicking(c.baseContext)
 	c.startTerminateCompletedSessionsTicking(c.baseContext)
+	c.startWebhookDeliveryTicking(c.baseContext)
+	c.startRewrapJobTicking(c.baseContext)
+	if c.conf.RawConfig.Controller.OplogRetention != nil {
+		c.startOplogRetentionTicking(c.baseContext)
+	}
 	c.started.Store(true)
 
 	return nil
@@ -158,6 +212,8 @@ func (c *Controller) Shutdown(serversOnly bool) error {
 		c.logger.Info("already shut down, skipping")
 		return nil
 	}
+	c.shuttingDown.Store(true)
+	defer c.shuttingDown.Store(false)
 	c.baseCancel()
 	if err := c.stopListeners(serversOnly); err != nil {
 		return fmt.Errorf("error stopping controller listeners: %w", err)
@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/event"
+)
+
+// RequestIdHeader is the header of API responses carrying the ID under which
+// the events of the request were recorded.
+const RequestIdHeader = "X-Request-Id"
+
+// eventResponseWriter records the status and body of a response.
+type eventResponseWriter struct {
+	http.ResponseWriter
+	status  int
+	body    bytes.Buffer
+	maxBody int64
+}
+
+func (w *eventResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *eventResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if remaining := w.maxBody - int64(w.body.Len()); remaining > 0 {
+		if int64(len(b)) > remaining {
+			w.body.Write(b[:remaining])
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *eventResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// redactedBody returns the JSON body b with the values of sensitive fields
+// redacted, or nil if b is empty or not JSON.
+func redactedBody(b []byte) interface{} {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil
+	}
+	var body interface{}
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil
+	}
+	event.RedactJSON(body)
+	return body
+}
+
+// serveHTTPWithEvents serves an API request with h, recording a request
+// event and a response event. Request and response bodies are included with
+// the values of sensitive fields redacted. The handler receives a context
+// carrying the eventer and the request ID, which is returned to the client
+// in the RequestIdHeader header.
+func serveHTTPWithEvents(h http.Handler, c *Controller, w http.ResponseWriter, r *http.Request, authTokenId string, maxBodySize int64) {
+	eventer := c.conf.Eventer
+	id, err := event.NewRequestId()
+	if err != nil {
+		c.logger.Error("error generating request id", "error", err)
+		h.ServeHTTP(w, r)
+		return
+	}
+	ctx := event.NewContext(r.Context(), eventer, id)
+	w.Header().Set(RequestIdHeader, id)
+
+	data := map[string]interface{}{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"remote_addr": r.RemoteAddr,
+	}
+	if r.URL.RawQuery != "" {
+		data["query"] = r.URL.RawQuery
+	}
+	if authTokenId != "" {
+		data["auth_token_id"] = authTokenId
+	}
+	if r.Body != nil {
+		// Read no more than the limit enforced when decoding the request;
+		// the rest, if any, is left for the handler to reject.
+		b, err := ioutil.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+		if err == nil && int64(len(b)) <= maxBodySize {
+			if body := redactedBody(b); body != nil {
+				data["body"] = body
+			}
+		}
+		r.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(b), r.Body))
+	}
+	eventer.Emit(ctx, event.RequestType, data)
+
+	start := time.Now()
+	ew := &eventResponseWriter{ResponseWriter: w, maxBody: maxBodySize}
+	h.ServeHTTP(ew, r.WithContext(ctx))
+	if ew.status == 0 {
+		ew.status = http.StatusOK
+	}
+
+	data = map[string]interface{}{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"status":      ew.status,
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	if int64(ew.body.Len()) < maxBodySize {
+		if body := redactedBody(ew.body.Bytes()); body != nil {
+			data["body"] = body
+		}
+	}
+	eventer.Emit(ctx, event.ResponseType, data)
+}
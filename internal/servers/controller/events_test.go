@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeHTTPWithEvents(t *testing.T) {
+	var buf bytes.Buffer
+	c := &Controller{
+		conf: &Config{Server: &base.Server{
+			Eventer: event.NewEventer(hclog.NewNullLogger(), "controller", event.NewWriterSink(&buf)),
+		}},
+		logger: hclog.NewNullLogger(),
+	}
+
+	var handlerRequestId string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerRequestId = event.RequestId(r.Context())
+		// The handler still sees the whole body
+		b, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Contains(t, string(b), "password123")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"item":{"id":"at_1234567890","token":"at_1234567890_secret"}}`))
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/auth-methods/ampw_1234567890:authenticate",
+		strings.NewReader(`{"credentials":{"login_name":"admin","password":"password123"}}`))
+	rec := httptest.NewRecorder()
+	serveHTTPWithEvents(h, c, rec, req, "", 1024)
+
+	requestId := rec.Header().Get(RequestIdHeader)
+	require.NotEmpty(t, requestId)
+	assert.Equal(t, requestId, handlerRequestId)
+
+	var events []*event.Event
+	s := bufio.NewScanner(&buf)
+	for s.Scan() {
+		var e event.Event
+		require.NoError(t, json.Unmarshal(s.Bytes(), &e))
+		events = append(events, &e)
+	}
+	require.Len(t, events, 2)
+	assert.Equal(t, event.RequestType, events[0].Type)
+	assert.Equal(t, event.ResponseType, events[1].Type)
+	for _, e := range events {
+		assert.Equal(t, requestId, e.RequestId)
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"credentials": map[string]interface{}{
+			"login_name": "admin",
+			"password":   event.Redacted,
+		},
+	}, events[0].Data["body"])
+	assert.Equal(t, map[string]interface{}{
+		"item": map[string]interface{}{
+			"id":    "at_1234567890",
+			"token": event.Redacted,
+		},
+	}, events[1].Data["body"])
+	assert.EqualValues(t, http.StatusOK, events[1].Data["status"])
+}
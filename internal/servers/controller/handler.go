@@ -31,6 +31,8 @@ import (
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/roles"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scopes"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/users"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/webhooks"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/workers"
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
@@ -49,10 +51,11 @@ func (c *Controller) handler(props HandlerProperties) (http.Handler, error) {
 	if err != nil {
 		return nil, err
 	}
-	mux.Handle("/v1/", h)
+	mux.Handle(watchPath, c.handleWatch())
+	mux.Handle("/v1/", wrapHandlerWithMetrics(h))
 	mux.Handle("/", handleUi(c))
 
-	corsWrappedHandler := wrapHandlerWithCors(mux, props)
+	corsWrappedHandler := wrapHandlerWithCors(mux, c, props)
 	commonWrappedHandler := wrapHandlerWithCommonFuncs(corsWrappedHandler, c, props)
 	printablePathCheckHandler := cleanhttp.PrintablePathCheckHandler(commonWrappedHandler, nil)
 
@@ -80,6 +83,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 		}),
 		runtime.WithErrorHandler(handlers.ErrorHandler(c.logger)),
 		runtime.WithForwardResponseOption(handlers.OutgoingInterceptor),
+		runtime.WithMetadata(recordApiMethod),
 	)
 	hcs, err := host_catalogs.NewService(c.StaticHostRepoFn, c.IamRepoFn)
 	if err != nil {
@@ -123,7 +127,7 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterAuthTokenServiceHandlerServer(ctx, mux, authtoks); err != nil {
 		return nil, fmt.Errorf("failed to register auth token service handler: %w", err)
 	}
-	os, err := scopes.NewService(c.IamRepoFn)
+	os, err := scopes.NewService(c.kms, c.IamRepoFn, c.OplogSearchRepoFn, c.ReportsRepoFn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create scope handler service: %w", err)
 	}
@@ -171,6 +175,20 @@ func handleGrpcGateway(c *Controller, props HandlerProperties) (http.Handler, er
 	if err := services.RegisterSessionServiceHandlerServer(ctx, mux, ss); err != nil {
 		return nil, fmt.Errorf("failed to register session service handler: %w", err)
 	}
+	ws, err := workers.NewService(c.ServersRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create worker handler service: %w", err)
+	}
+	if err := services.RegisterWorkerServiceHandlerServer(ctx, mux, ws); err != nil {
+		return nil, fmt.Errorf("failed to register worker service handler: %w", err)
+	}
+	whs, err := webhooks.NewService(c.WebhookRepoFn, c.IamRepoFn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook handler service: %w", err)
+	}
+	if err := services.RegisterWebhookServiceHandlerServer(ctx, mux, whs); err != nil {
+		return nil, fmt.Errorf("failed to register webhook service handler: %w", err)
+	}
 
 	return mux, nil
 }
@@ -205,8 +223,12 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 		// Set the Cache-Control header for all responses returned
 		w.Header().Set("Cache-Control", "no-store")
 
-		// Start with the request context and our timeout
+		// Start with the request context and our timeout. Watch streams
+		// last until the client or the controller goes away.
 		ctx, cancelFunc := context.WithTimeout(r.Context(), maxRequestDuration)
+		if r.URL.Path == watchPath {
+			ctx, cancelFunc = context.WithCancel(r.Context())
+		}
 		defer cancelFunc()
 
 		// Add a size limiter if desired
@@ -227,11 +249,16 @@ func wrapHandlerWithCommonFuncs(h http.Handler, c *Controller, props HandlerProp
 		// Set the context back on the request
 		r = r.WithContext(ctx)
 
+		if c.conf.Eventer.Enabled() && strings.HasPrefix(r.URL.Path, "/v1/") {
+			serveHTTPWithEvents(h, c, w, r, requestInfo.PublicId, maxRequestSize)
+			return
+		}
+
 		h.ServeHTTP(w, r)
 	})
 }
 
-func wrapHandlerWithCors(h http.Handler, props HandlerProperties) http.Handler {
+func wrapHandlerWithCors(h http.Handler, c *Controller, props HandlerProperties) http.Handler {
 	allowedMethods := []string{
 		http.MethodDelete,
 		http.MethodGet,
@@ -240,20 +267,24 @@ func wrapHandlerWithCors(h http.Handler, props HandlerProperties) http.Handler {
 		http.MethodPatch,
 	}
 
-	allowedOrigins := props.ListenerConfig.CorsAllowedOrigins
-
-	allowedHeaders := append([]string{
-		"Content-Type",
-		"X-Requested-With",
-		"Authorization",
-	}, props.ListenerConfig.CorsAllowedHeaders...)
+	key := listenerKey(props.ListenerConfig)
 
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
-		if !props.ListenerConfig.CorsEnabled {
+		// The settings are looked up on each request so that reloading the
+		// configuration takes effect immediately
+		cors := c.settings().cors[key]
+		if cors == nil || !cors.enabled {
 			h.ServeHTTP(w, req)
 			return
 		}
 
+		allowedOrigins := cors.allowedOrigins
+		allowedHeaders := append([]string{
+			"Content-Type",
+			"X-Requested-With",
+			"Authorization",
+		}, cors.allowedHeaders...)
+
 		origin := req.Header.Get("Origin")
 
 		if origin == "" {
@@ -55,14 +55,20 @@ func (s Service) ListAccounts(ctx context.Context, req *pbs.ListAccountsRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ul, err := s.listFromRepo(ctx, req.GetAuthMethodId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		ul, err := s.listFromRepo(ctx, req.GetAuthMethodId(), afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range ul {
+			item.Scope = authResults.Scope
+		}
+		return ul, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
-	}
-	return &pbs.ListAccountsResponse{Items: ul}, nil
+	return &pbs.ListAccountsResponse{Items: page.([]*pb.Account), NextPageToken: next}, nil
 }
 
 // GetAccount implements the interface pbs.AccountServiceServer.
@@ -281,12 +287,12 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, authMethodId string) ([]*pb.Account, error) {
+func (s Service) listFromRepo(ctx context.Context, authMethodId string, afterId string, limit int) ([]*pb.Account, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAccounts(ctx, authMethodId)
+	ul, err := repo.ListAccounts(ctx, authMethodId, password.WithAfterId(afterId), password.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/boundary/internal/auth/password/store"
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/authmethods"
 	pba "github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
@@ -74,17 +75,33 @@ func (s Service) ListAuthMethods(ctx context.Context, req *pbs.ListAuthMethodsRe
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
+	scps := []*scopes.ScopeInfo{authResults.Scope}
+	if req.GetRecursive() {
+		var err error
+		if scps, err = authResults.RecursiveScopes(ctx, resource.AuthMethod, action.List, scope.Global, scope.Org); err != nil {
+			return nil, err
+		}
+	} else if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		var ul []*pb.AuthMethod
+		for _, scp := range scps {
+			sl, err := s.listFromRepo(ctx, scp.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range sl {
+				item.Scope = scp
+			}
+			ul = append(ul, sl...)
+		}
+		return ul, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
-	}
-	return &pbs.ListAuthMethodsResponse{Items: ul}, nil
+	return &pbs.ListAuthMethodsResponse{Items: page.([]*pb.AuthMethod), NextPageToken: next}, nil
 }
 
 // GetAuthMethod implements the interface pbs.AuthMethodServiceServer.
@@ -165,9 +182,19 @@ func (s Service) Authenticate(ctx context.Context, req *pbs.AuthenticateRequest)
 	}
 	creds := req.GetCredentials().GetFields()
 	tok, err := s.authenticateWithRepo(ctx, authResults.Scope.GetId(), req.GetAuthMethodId(), creds[loginNameKey].GetStringValue(), creds[pwKey].GetStringValue())
+	evt := map[string]interface{}{
+		"auth_method_id": req.GetAuthMethodId(),
+		"login_name":     creds[loginNameKey].GetStringValue(),
+		"success":        err == nil,
+	}
 	if err != nil {
+		evt["error"] = err.Error()
+		event.Emit(ctx, event.AuthenticationType, evt)
 		return nil, err
 	}
+	evt["user_id"] = tok.GetUserId()
+	evt["auth_token_id"] = tok.GetId()
+	event.Emit(ctx, event.AuthenticationType, evt)
 	return &pbs.AuthenticateResponse{Item: tok, TokenType: req.GetTokenType()}, nil
 }
 
@@ -189,12 +216,12 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.AuthMethod, er
 	return toAuthMethodProto(u)
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.AuthMethod, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId string, afterId string, limit int) ([]*pb.AuthMethod, error) {
 	repo, err := s.pwRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAuthMethods(ctx, scopeId)
+	ul, err := repo.ListAuthMethods(ctx, scopeId, password.WithAfterId(afterId), password.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
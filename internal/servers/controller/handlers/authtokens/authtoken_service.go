@@ -8,6 +8,7 @@ import (
 	"github.com/hashicorp/boundary/internal/authtoken"
 	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/authtokens"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
@@ -43,17 +44,33 @@ func (s Service) ListAuthTokens(ctx context.Context, req *pbs.ListAuthTokensRequ
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
+	scps := []*scopes.ScopeInfo{authResults.Scope}
+	if req.GetRecursive() {
+		var err error
+		if scps, err = authResults.RecursiveScopes(ctx, resource.AuthToken, action.List, scope.Global, scope.Org); err != nil {
+			return nil, err
+		}
+	} else if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ul, err := s.listFromRepo(ctx, req.GetScopeId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		var ul []*pb.AuthToken
+		for _, scp := range scps {
+			sl, err := s.listFromRepo(ctx, scp.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range sl {
+				item.Scope = scp
+			}
+			ul = append(ul, sl...)
+		}
+		return ul, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
-	}
-	return &pbs.ListAuthTokensResponse{Items: ul}, nil
+	return &pbs.ListAuthTokensResponse{Items: page.([]*pb.AuthToken), NextPageToken: next}, nil
 }
 
 // GetAuthToken implements the interface pbs.AuthTokenServiceServer.
@@ -122,13 +139,13 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, orgId string) ([]*pb.AuthToken, error) {
+func (s Service) listFromRepo(ctx context.Context, orgId string, afterId string, limit int) ([]*pb.AuthToken, error) {
 	repo, err := s.repoFn()
 	_ = repo
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListAuthTokens(ctx, orgId)
+	ul, err := repo.ListAuthTokens(ctx, orgId, authtoken.WithAfterId(afterId), authtoken.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/groups"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
@@ -54,17 +55,33 @@ func (s Service) ListGroups(ctx context.Context, req *pbs.ListGroupsRequest) (*p
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
+	scps := []*scopes.ScopeInfo{authResults.Scope}
+	if req.GetRecursive() {
+		var err error
+		if scps, err = authResults.RecursiveScopes(ctx, resource.Group, action.List, scope.Global, scope.Org, scope.Project); err != nil {
+			return nil, err
+		}
+	} else if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	gl, err := s.listFromRepo(ctx, req.GetScopeId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		var gl []*pb.Group
+		for _, scp := range scps {
+			sl, err := s.listFromRepo(ctx, scp.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range sl {
+				item.Scope = scp
+			}
+			gl = append(gl, sl...)
+		}
+		return gl, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range gl {
-		item.Scope = authResults.Scope
-	}
-	return &pbs.ListGroupsResponse{Items: gl}, nil
+	return &pbs.ListGroupsResponse{Items: page.([]*pb.Group), NextPageToken: next}, nil
 }
 
 // GetGroups implements the interface pbs.GroupServiceServer.
@@ -276,12 +293,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Group, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId string, afterId string, limit int) ([]*pb.Group, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	gl, err := repo.ListGroups(ctx, scopeId)
+	gl, err := repo.ListGroups(ctx, scopeId, iam.WithAfterId(afterId), iam.WithLimit(limit))
 	if err != nil {
 		return nil, fmt.Errorf("unable to list groups: %w", err)
 	}
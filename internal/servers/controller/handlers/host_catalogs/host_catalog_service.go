@@ -3,9 +3,12 @@ package host_catalogs
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/hostcatalogs"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
 	"github.com/hashicorp/boundary/internal/host/static"
@@ -15,6 +18,7 @@ import (
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/sdk/strutil"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
@@ -56,17 +60,33 @@ func (s Service) ListHostCatalogs(ctx context.Context, req *pbs.ListHostCatalogs
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
+	scps := []*scopes.ScopeInfo{authResults.Scope}
+	if req.GetRecursive() {
+		var err error
+		if scps, err = authResults.RecursiveScopes(ctx, resource.HostCatalog, action.List, scope.Project); err != nil {
+			return nil, err
+		}
+	} else if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		var ul []*pb.HostCatalog
+		for _, scp := range scps {
+			sl, err := s.listFromRepo(ctx, scp.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range sl {
+				item.Scope = scp
+			}
+			ul = append(ul, sl...)
+		}
+		return ul, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
-	}
-	return &pbs.ListHostCatalogsResponse{Items: ul}, nil
+	return &pbs.ListHostCatalogsResponse{Items: page.([]*pb.HostCatalog), NextPageToken: next}, nil
 }
 
 // GetHostCatalog implements the interface pbs.HostCatalogServiceServer.
@@ -139,6 +159,38 @@ func (s Service) DeleteHostCatalog(ctx context.Context, req *pbs.DeleteHostCatal
 	return &pbs.DeleteHostCatalogResponse{}, nil
 }
 
+// ImportHosts implements the interface pbs.HostCatalogServiceServer.
+func (s Service) ImportHosts(ctx context.Context, req *pbs.ImportHostsRequest) (*pbs.ImportHostsResponse, error) {
+	if err := validateImportHostsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ImportHosts)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	items, err := s.importInRepo(ctx, authResults.Scope.GetId(), req.GetId(), req.GetHosts(), req.GetDryRun())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ImportHostsResponse{Items: items}, nil
+}
+
+// ExportHosts implements the interface pbs.HostCatalogServiceServer.
+func (s Service) ExportHosts(ctx context.Context, req *pbs.ExportHostsRequest) (*pbs.ExportHostsResponse, error) {
+	if err := validateExportHostsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ExportHosts)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	items, err := s.exportFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ExportHostsResponse{Items: items}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostCatalog, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
@@ -154,12 +206,12 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostCatalog, e
 	return toProto(hc), nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.HostCatalog, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId string, afterId string, limit int) ([]*pb.HostCatalog, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListCatalogs(ctx, scopeId)
+	ul, err := repo.ListCatalogs(ctx, scopeId, static.WithAfterId(afterId), static.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -240,6 +292,65 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
+func (s Service) importInRepo(ctx context.Context, scopeId, id string, items []*pb.BulkHost, dryRun bool) ([]*pb.HostImportResult, error) {
+	var hosts []*static.BulkHost
+	for _, item := range items {
+		hosts = append(hosts, &static.BulkHost{
+			Name:        item.GetName(),
+			Description: item.GetDescription(),
+			Address:     item.GetAddress(),
+			HostSetIds:  strutil.RemoveDuplicates(item.GetHostSetIds(), false),
+		})
+	}
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	results, err := repo.ImportHosts(ctx, scopeId, id, hosts, static.WithDryRun(dryRun))
+	if err != nil {
+		switch {
+		case errors.Is(err, errors.ErrInvalidParameter), errors.Is(err, errors.ErrNotUnique), errors.Is(err, static.ErrInvalidAddress):
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.InvalidArgument, "Unable to import hosts into host catalog: %v.", err)
+		}
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to import hosts into host catalog: %v.", err)
+	}
+	var out []*pb.HostImportResult
+	for _, r := range results {
+		res := &pb.HostImportResult{
+			Action:          string(r.Action),
+			Host:            toBulkHostProto(r.Host),
+			AddedHostSetIds: r.AddedSetIds,
+		}
+		for _, f := range r.ChangedFields {
+			res.ChangedFields = append(res.ChangedFields, strings.ToLower(f))
+		}
+		out = append(out, res)
+	}
+	return out, nil
+}
+
+func (s Service) exportFromRepo(ctx context.Context, id string) ([]*pb.BulkHost, error) {
+	repo, err := s.staticRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	hosts, err := repo.ExportHosts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var out []*pb.BulkHost
+	for _, h := range hosts {
+		out = append(out, &pb.BulkHost{
+			Id:          h.PublicId,
+			Name:        h.Name,
+			Description: h.Description,
+			Address:     h.Address,
+			HostSetIds:  h.HostSetIds,
+		})
+	}
+	return out, nil
+}
+
 func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
 	res := auth.VerifyResults{}
 
@@ -302,6 +413,15 @@ func toProto(in *static.HostCatalog) *pb.HostCatalog {
 	return &out
 }
 
+func toBulkHostProto(in *static.Host) *pb.BulkHost {
+	return &pb.BulkHost{
+		Id:          in.GetPublicId(),
+		Name:        in.GetName(),
+		Description: in.GetDescription(),
+		Address:     in.GetAddress(),
+	}
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -347,8 +467,59 @@ func validateDeleteRequest(req *pbs.DeleteHostCatalogRequest) error {
 
 func validateListRequest(req *pbs.ListHostCatalogsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
-		badFields["scope_id"] = "This field must be a valid project scope id."
+	switch {
+	case handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()):
+	case req.GetRecursive() && (req.GetScopeId() == scope.Global.String() || handlers.ValidId(scope.Org.Prefix(), req.GetScopeId())):
+	default:
+		badFields["scope_id"] = "This field must be a valid project scope id. Recursive requests may also use 'global' or an org scope id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateImportHostsRequest(req *pbs.ImportHostsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(static.HostCatalogPrefix, req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if len(req.GetHosts()) == 0 {
+		badFields["hosts"] = "Must be non-empty."
+	}
+	names := make(map[string]bool, len(req.GetHosts()))
+	for i, h := range req.GetHosts() {
+		field := fmt.Sprintf("hosts[%d]", i)
+		name := strings.TrimSpace(h.GetName())
+		switch {
+		case h.GetId() != "":
+			badFields[field+".id"] = "This is a read only field."
+		case name == "":
+			badFields[field+".name"] = "This is a required field."
+		case names[name]:
+			badFields[field+".name"] = "Names must be unique within the request."
+		}
+		names[name] = true
+		if addr := strings.TrimSpace(h.GetAddress()); len(addr) < static.MinHostAddressLength || len(addr) > static.MaxHostAddressLength {
+			badFields[field+".address"] = fmt.Sprintf("Address length must be between %d and %d characters.", static.MinHostAddressLength, static.MaxHostAddressLength)
+		}
+		for _, id := range h.GetHostSetIds() {
+			if !handlers.ValidId(static.HostSetPrefix, id) {
+				badFields[field+".host_set_ids"] = "Incorrectly formatted host set identifier."
+				break
+			}
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
+
+func validateExportHostsRequest(req *pbs.ExportHostsRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(static.HostCatalogPrefix, req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
 	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
@@ -750,3 +750,104 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestImportExportHosts(t *testing.T) {
+	t.Parallel()
+	hc, proj, repoFn, iamRepoFn := createDefaultHostCatalogAndRepo(t)
+	repo, err := repoFn()
+	require.NoError(t, err)
+	set, err := static.NewHostSet(hc.GetPublicId())
+	require.NoError(t, err)
+	set, err = repo.CreateSet(context.Background(), proj.GetPublicId(), set)
+	require.NoError(t, err)
+
+	s, err := host_catalogs.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err, "Couldn't create a new host catalog service.")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	hosts := []*pb.BulkHost{
+		{Name: "web1", Address: "10.0.0.1", HostSetIds: []string{set.GetPublicId()}},
+		{Name: "web2", Address: "10.0.0.2", Description: "second"},
+	}
+
+	cases := []struct {
+		name string
+		req  *pbs.ImportHostsRequest
+		err  error
+	}{
+		{
+			name: "Bad catalog id",
+			req:  &pbs.ImportHostsRequest{Id: "bad_id", Hosts: hosts},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "No hosts",
+			req:  &pbs.ImportHostsRequest{Id: hc.GetPublicId()},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Missing name",
+			req:  &pbs.ImportHostsRequest{Id: hc.GetPublicId(), Hosts: []*pb.BulkHost{{Address: "10.0.0.1"}}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Duplicate name",
+			req:  &pbs.ImportHostsRequest{Id: hc.GetPublicId(), Hosts: []*pb.BulkHost{{Name: "a", Address: "10.0.0.1"}, {Name: "a", Address: "10.0.0.2"}}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Id provided",
+			req:  &pbs.ImportHostsRequest{Id: hc.GetPublicId(), Hosts: []*pb.BulkHost{{Id: static.HostPrefix + "_1234567890", Name: "a", Address: "10.0.0.1"}}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Bad host set id",
+			req:  &pbs.ImportHostsRequest{Id: hc.GetPublicId(), Hosts: []*pb.BulkHost{{Name: "a", Address: "10.0.0.1", HostSetIds: []string{"bad_id"}}}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+		{
+			name: "Unknown host set id",
+			req:  &pbs.ImportHostsRequest{Id: hc.GetPublicId(), Hosts: []*pb.BulkHost{{Name: "a", Address: "10.0.0.1", HostSetIds: []string{static.HostSetPrefix + "_1234567890"}}}},
+			err:  handlers.ApiErrorWithCode(codes.InvalidArgument),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, gErr := s.ImportHosts(ctx, tc.req)
+			require.Error(gErr)
+			assert.True(errors.Is(gErr, tc.err), "ImportHosts(%+v) got error %v, wanted %v", tc.req, gErr, tc.err)
+			assert.Nil(got)
+		})
+	}
+
+	t.Run("Dry run then import", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		got, err := s.ImportHosts(ctx, &pbs.ImportHostsRequest{Id: hc.GetPublicId(), Hosts: hosts, DryRun: true})
+		require.NoError(err)
+		require.Len(got.GetItems(), 2)
+		for _, item := range got.GetItems() {
+			assert.Equal("create", item.GetAction())
+			assert.Empty(item.GetHost().GetId())
+		}
+
+		exported, err := s.ExportHosts(ctx, &pbs.ExportHostsRequest{Id: hc.GetPublicId()})
+		require.NoError(err)
+		assert.Empty(exported.GetItems())
+
+		got, err = s.ImportHosts(ctx, &pbs.ImportHostsRequest{Id: hc.GetPublicId(), Hosts: hosts})
+		require.NoError(err)
+		require.Len(got.GetItems(), 2)
+		assert.Equal([]string{set.GetPublicId()}, got.GetItems()[0].GetAddedHostSetIds())
+
+		exported, err = s.ExportHosts(ctx, &pbs.ExportHostsRequest{Id: hc.GetPublicId()})
+		require.NoError(err)
+		require.Len(exported.GetItems(), 2)
+		for _, h := range exported.GetItems() {
+			assert.True(strings.HasPrefix(h.GetId(), static.HostPrefix))
+			if h.GetName() == "web1" {
+				assert.Equal([]string{set.GetPublicId()}, h.GetHostSetIds())
+			}
+		}
+	})
+}
@@ -26,7 +26,7 @@ var (
 
 func init() {
 	var err error
-	if maskManager, err = handlers.NewMaskManager(&store.HostSet{}, &pb.HostSet{}); err != nil {
+	if maskManager, err = handlers.NewMaskManager(&store.HostSet{}, &pb.HostSet{}, &pb.StaticHostSetAttributes{}); err != nil {
 		panic(err)
 	}
 }
@@ -56,14 +56,20 @@ func (s Service) ListHostSets(ctx context.Context, req *pbs.ListHostSetsRequest)
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, err := s.listFromRepo(ctx, req.GetHostCatalogId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		hl, err := s.listFromRepo(ctx, req.GetHostCatalogId(), afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range hl {
+			item.Scope = authResults.Scope
+		}
+		return hl, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range hl {
-		item.Scope = authResults.Scope
-	}
-	return &pbs.ListHostSetsResponse{Items: hl}, nil
+	return &pbs.ListHostSetsResponse{Items: page.([]*pb.HostSet), NextPageToken: next}, nil
 }
 
 // GetHostSet implements the interface pbs.HostSetServiceServer.
@@ -199,11 +205,18 @@ func (s Service) getFromRepo(ctx context.Context, id string) (*pb.HostSet, error
 	if h == nil {
 		return nil, handlers.NotFoundErrorf("Host Set %q doesn't exist.", id)
 	}
-	return toProto(h, m), nil
+	return toProto(h, m)
 }
 
 func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, item *pb.HostSet) (*pb.HostSet, error) {
+	ha := &pb.StaticHostSetAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), ha); err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Failed converting attributes to subtype proto: %s", err)
+	}
 	var opts []static.Option
+	if ha.GetSelector() != nil {
+		opts = append(opts, static.WithSelector(ha.GetSelector().GetValue()))
+	}
 	if item.GetName() != nil {
 		opts = append(opts, static.WithName(item.GetName().GetValue()))
 	}
@@ -229,11 +242,18 @@ func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, it
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create host set but no error returned from repository.")
 	}
-	return toProto(out, nil), nil
+	return toProto(out, nil)
 }
 
 func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string, mask []string, item *pb.HostSet) (*pb.HostSet, error) {
+	ha := &pb.StaticHostSetAttributes{}
+	if err := handlers.StructToProto(item.GetAttributes(), ha); err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Failed converting attributes to subtype proto: %s", err)
+	}
 	var opts []static.Option
+	if selector := ha.GetSelector(); selector != nil {
+		opts = append(opts, static.WithSelector(selector.GetValue()))
+	}
 	if desc := item.GetDescription(); desc != nil {
 		opts = append(opts, static.WithDescription(desc.GetValue()))
 	}
@@ -260,7 +280,7 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string
 	if rowsUpdated == 0 {
 		return nil, handlers.NotFoundErrorf("Host Set %q doesn't exist or incorrect version provided.", id)
 	}
-	return toProto(out, m), nil
+	return toProto(out, m)
 }
 
 func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool, error) {
@@ -275,18 +295,22 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]*pb.HostSet, error) {
+func (s Service) listFromRepo(ctx context.Context, catalogId string, afterId string, limit int) ([]*pb.HostSet, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	hl, err := repo.ListSets(ctx, catalogId)
+	hl, err := repo.ListSets(ctx, catalogId, static.WithAfterId(afterId), static.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
 	var outH []*pb.HostSet
 	for _, h := range hl {
-		outH = append(outH, toProto(h, nil))
+		p, err := toProto(h, nil)
+		if err != nil {
+			return nil, err
+		}
+		outH = append(outH, p)
 	}
 	return outH, nil
 }
@@ -308,7 +332,7 @@ func (s Service) addInRepo(ctx context.Context, scopeId, setId string, hostIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup host set after adding hosts to it.")
 	}
-	return toProto(out, m), nil
+	return toProto(out, m)
 }
 
 func (s Service) setInRepo(ctx context.Context, scopeId, setId string, hostIds []string, version uint32) (*pb.HostSet, error) {
@@ -329,7 +353,7 @@ func (s Service) setInRepo(ctx context.Context, scopeId, setId string, hostIds [
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup host set after setting hosts for it.")
 	}
-	return toProto(out, m), nil
+	return toProto(out, m)
 }
 
 func (s Service) removeInRepo(ctx context.Context, scopeId, setId string, hostIds []string, version uint32) (*pb.HostSet, error) {
@@ -349,7 +373,7 @@ func (s Service) removeInRepo(ctx context.Context, scopeId, setId string, hostId
 	if out == nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to lookup host set after removing hosts from it.")
 	}
-	return toProto(out, m), nil
+	return toProto(out, m)
 }
 
 func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Type) (*static.HostCatalog, auth.VerifyResults) {
@@ -392,7 +416,7 @@ func (s Service) parentAndAuthResult(ctx context.Context, id string, a action.Ty
 	return cat, auth.Verify(ctx, opts...)
 }
 
-func toProto(in *static.HostSet, hs []*static.Host) *pb.HostSet {
+func toProto(in *static.HostSet, hs []*static.Host) (*pb.HostSet, error) {
 	out := pb.HostSet{
 		Id:            in.GetPublicId(),
 		HostCatalogId: in.GetCatalogId(),
@@ -410,7 +434,14 @@ func toProto(in *static.HostSet, hs []*static.Host) *pb.HostSet {
 	for _, h := range hs {
 		out.HostIds = append(out.HostIds, h.GetPublicId())
 	}
-	return &out
+	if in.GetSelector() != "" {
+		st, err := handlers.ProtoToStruct(&pb.StaticHostSetAttributes{Selector: wrapperspb.String(in.GetSelector())})
+		if err != nil {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert static attribute to struct: %s", err)
+		}
+		out.Attributes = st
+	}
+	return &out, nil
 }
 
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
@@ -420,6 +451,8 @@ func toProto(in *static.HostSet, hs []*static.Host) *pb.HostSet {
 //  * There are no conflicting parameters provided
 //  * The type asserted by the ID and/or field is known
 //  * If relevant, the type derived from the id prefix matches what is claimed by the type field
+const selectorErrMsg = `Selector must be a comma separated list of "key=value", "key!=value" or "key" terms using valid label keys and values.`
+
 func validateGetRequest(req *pbs.GetHostSetRequest) error {
 	return handlers.ValidateGetRequest(static.HostSetPrefix, req, handlers.NoopValidatorFn)
 }
@@ -435,6 +468,13 @@ func validateCreateRequest(req *pbs.CreateHostSetRequest) error {
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.StaticSubtype.String() {
 				badFields["type"] = "Doesn't match the parent resource's type."
 			}
+			attrs := &pb.StaticHostSetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+			}
+			if attrs.GetSelector() != nil && !static.ValidSelector(attrs.GetSelector().GetValue()) {
+				badFields["attributes.selector"] = selectorErrMsg
+			}
 		}
 		return badFields
 	})
@@ -448,6 +488,14 @@ func validateUpdateRequest(req *pbs.UpdateHostSetRequest) error {
 			if req.GetItem().GetType() != "" && req.GetItem().GetType() != host.StaticSubtype.String() {
 				badFields["type"] = "Cannot modify the resource type."
 			}
+			attrs := &pb.StaticHostSetAttributes{}
+			if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+				badFields["attributes"] = "Attribute fields do not match the expected format."
+			}
+			if handlers.MaskContains(req.GetUpdateMask().GetPaths(), "attributes.selector") &&
+				attrs.GetSelector() != nil && !static.ValidSelector(attrs.GetSelector().GetValue()) {
+				badFields["attributes.selector"] = selectorErrMsg
+			}
 		}
 		return badFields
 	})
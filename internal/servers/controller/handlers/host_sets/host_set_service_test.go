@@ -1110,3 +1110,69 @@ func TestRemoveHostSetHosts(t *testing.T) {
 		})
 	}
 }
+
+func TestSelector(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	repo, err := repoFn()
+	require.NoError(t, err)
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+	var hIds []string
+	for i, labels := range []map[string]string{
+		{"env": "prod", "role": "db"},
+		{"env": "prod", "role": "web"},
+		{"env": "dev", "role": "db"},
+	} {
+		h, err := static.NewHost(hc.GetPublicId(), static.WithAddress(fmt.Sprintf("10.0.0.%d", i+1)), static.WithLabels(labels))
+		require.NoError(t, err)
+		h, err = repo.CreateHost(context.Background(), proj.GetPublicId(), h)
+		require.NoError(t, err)
+		hIds = append(hIds, h.GetPublicId())
+	}
+
+	s, err := host_sets.NewService(repoFn)
+	require.NoError(t, err, "Couldn't create a new host set service.")
+
+	attrs, err := handlers.ProtoToStruct(&pb.StaticHostSetAttributes{Selector: &wrappers.StringValue{Value: "env=prod, role=db"}})
+	require.NoError(t, err)
+	created, err := s.CreateHostSet(ctx, &pbs.CreateHostSetRequest{Item: &pb.HostSet{
+		HostCatalogId: hc.GetPublicId(),
+		Attributes:    attrs,
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "env=prod,role=db", created.GetItem().GetAttributes().GetFields()["selector"].GetStringValue())
+
+	got, err := s.GetHostSet(ctx, &pbs.GetHostSetRequest{Id: created.GetItem().GetId()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{hIds[0]}, got.GetItem().GetHostIds())
+
+	attrs, err = handlers.ProtoToStruct(&pb.StaticHostSetAttributes{Selector: &wrappers.StringValue{Value: "role=db"}})
+	require.NoError(t, err)
+	updated, err := s.UpdateHostSet(ctx, &pbs.UpdateHostSetRequest{
+		Id:         created.GetItem().GetId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"attributes.selector"}},
+		Item:       &pb.HostSet{Version: created.GetItem().GetVersion(), Attributes: attrs},
+	})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{hIds[0], hIds[2]}, updated.GetItem().GetHostIds())
+
+	attrs, err = handlers.ProtoToStruct(&pb.StaticHostSetAttributes{Selector: &wrappers.StringValue{Value: "env=prod=db"}})
+	require.NoError(t, err)
+	_, err = s.CreateHostSet(ctx, &pbs.CreateHostSetRequest{Item: &pb.HostSet{
+		HostCatalogId: hc.GetPublicId(),
+		Attributes:    attrs,
+	}})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", err)
+}
@@ -57,14 +57,20 @@ func (s Service) ListHosts(ctx context.Context, req *pbs.ListHostsRequest) (*pbs
 	if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	hl, err := s.listFromRepo(ctx, req.GetHostCatalogId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		hl, err := s.listFromRepo(ctx, req.GetHostCatalogId(), afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range hl {
+			item.Scope = authResults.Scope
+		}
+		return hl, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range hl {
-		item.Scope = authResults.Scope
-	}
-	return &pbs.ListHostsResponse{Items: hl}, nil
+	return &pbs.ListHostsResponse{Items: page.([]*pb.Host), NextPageToken: next}, nil
 }
 
 // GetHost implements the interface pbs.HostServiceServer.
@@ -161,6 +167,9 @@ func (s Service) createInRepo(ctx context.Context, scopeId, catalogId string, it
 	if ha.GetAddress() != nil {
 		opts = append(opts, static.WithAddress(ha.GetAddress().GetValue()))
 	}
+	if len(ha.GetLabels()) > 0 {
+		opts = append(opts, static.WithLabels(ha.GetLabels()))
+	}
 	if item.GetName() != nil {
 		opts = append(opts, static.WithName(item.GetName().GetValue()))
 	}
@@ -205,12 +214,19 @@ func (s Service) updateInRepo(ctx context.Context, scopeId, catalogId, id string
 	if addr := ha.GetAddress(); addr != nil {
 		opts = append(opts, static.WithAddress(addr.GetValue()))
 	}
+	if labels := ha.GetLabels(); len(labels) > 0 {
+		opts = append(opts, static.WithLabels(labels))
+	}
 	h, err := static.NewHost(catalogId, opts...)
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build host for update: %v.", err)
 	}
 	h.PublicId = id
 	dbMask := maskManager.Translate(mask)
+	// Labels are not stored with the host so they have no mask mapping.
+	if maskContainsLabels(mask) {
+		dbMask = append(dbMask, "Labels")
+	}
 	if len(dbMask) == 0 {
 		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
 	}
@@ -240,12 +256,12 @@ func (s Service) deleteFromRepo(ctx context.Context, scopeId, id string) (bool,
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, catalogId string) ([]*pb.Host, error) {
+func (s Service) listFromRepo(ctx context.Context, catalogId string, afterId string, limit int) ([]*pb.Host, error) {
 	repo, err := s.staticRepoFn()
 	if err != nil {
 		return nil, err
 	}
-	hl, err := repo.ListHosts(ctx, catalogId)
+	hl, err := repo.ListHosts(ctx, catalogId, static.WithAfterId(afterId), static.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -318,7 +334,7 @@ func toProto(in *static.Host, members []*static.HostSet) (*pb.Host, error) {
 	for _, m := range members {
 		out.HostSetIds = append(out.HostSetIds, m.GetPublicId())
 	}
-	st, err := handlers.ProtoToStruct(&pb.StaticHostAttributes{Address: wrapperspb.String(in.GetAddress())})
+	st, err := handlers.ProtoToStruct(&pb.StaticHostAttributes{Address: wrapperspb.String(in.GetAddress()), Labels: in.Labels})
 	if err != nil {
 		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to convert static attribute to struct: %s", err)
 	}
@@ -373,6 +389,9 @@ func validateCreateRequest(req *pbs.CreateHostRequest) error {
 			default:
 				badFields["attributes.address"] = fmt.Sprintf("Error parsing address: %v.", err)
 			}
+			if msg := validateLabels(attrs.GetLabels()); msg != "" {
+				badFields["attributes.labels"] = msg
+			}
 		}
 		return badFields
 	})
@@ -399,6 +418,15 @@ func validateUpdateRequest(req *pbs.UpdateHostRequest) error {
 					}
 				}
 			}
+			if maskContainsLabels(req.GetUpdateMask().GetPaths()) {
+				attrs := &pb.StaticHostAttributes{}
+				if err := handlers.StructToProto(req.GetItem().GetAttributes(), attrs); err != nil {
+					badFields["attributes"] = "Attribute fields do not match the expected format."
+				}
+				if msg := validateLabels(attrs.GetLabels()); msg != "" {
+					badFields["attributes.labels"] = msg
+				}
+			}
 		default:
 			badFields["id"] = "Improperly formatted identifier used."
 		}
@@ -406,6 +434,33 @@ func validateUpdateRequest(req *pbs.UpdateHostRequest) error {
 	})
 }
 
+func validateLabels(labels map[string]string) string {
+	for k, v := range labels {
+		if !static.ValidLabelKey(k) {
+			return fmt.Sprintf("Label key %q is invalid. Keys must be between 1 and 63 characters and contain only letters, digits, '-', '_', '.' and '/'.", k)
+		}
+		if !static.ValidLabelValue(v) {
+			return fmt.Sprintf("Label value %q is invalid. Values must be at most 255 characters and contain only letters, digits, '-', '_', '.' and '/'.", v)
+		}
+	}
+	return ""
+}
+
+// maskContainsLabels reports whether the labels are included in the field
+// mask. Since attributes are a Struct, the gateway builds paths down to the
+// individual label keys, e.g. "attributes.labels.env".
+func maskContainsLabels(paths []string) bool {
+	for _, p := range paths {
+		for _, f := range strings.Split(p, ",") {
+			f = strings.TrimSpace(f)
+			if f == "attributes.labels" || strings.HasPrefix(f, "attributes.labels.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func validateDeleteRequest(req *pbs.DeleteHostRequest) error {
 	return handlers.ValidateDeleteRequest(static.HostPrefix, req, handlers.NoopValidatorFn)
 }
@@ -821,3 +821,65 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestLabels(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	kms := kms.TestKms(t, conn, wrapper)
+
+	_, proj := iam.TestScopes(t, iam.TestRepo(t, conn, wrapper))
+
+	rw := db.New(conn)
+	repoFn := func() (*static.Repository, error) {
+		return static.NewRepository(rw, rw, kms)
+	}
+	hc := static.TestCatalogs(t, conn, proj.GetPublicId(), 1)[0]
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+
+	s, err := hosts.NewService(repoFn)
+	require.NoError(t, err, "Couldn't create a new host service.")
+
+	attrs, err := handlers.ProtoToStruct(&pb.StaticHostAttributes{
+		Address: &wrappers.StringValue{Value: "10.0.0.1"},
+		Labels:  map[string]string{"env": "prod", "role": "db"},
+	})
+	require.NoError(t, err)
+	created, err := s.CreateHost(ctx, &pbs.CreateHostRequest{Item: &pb.Host{
+		HostCatalogId: hc.GetPublicId(),
+		Attributes:    attrs,
+	}})
+	require.NoError(t, err)
+
+	got, err := s.GetHost(ctx, &pbs.GetHostRequest{Id: created.GetItem().GetId()})
+	require.NoError(t, err)
+	gotAttrs := &pb.StaticHostAttributes{}
+	require.NoError(t, handlers.StructToProto(got.GetItem().GetAttributes(), gotAttrs))
+	assert.Equal(t, map[string]string{"env": "prod", "role": "db"}, gotAttrs.GetLabels())
+
+	attrs, err = handlers.ProtoToStruct(&pb.StaticHostAttributes{Labels: map[string]string{"env": "dev"}})
+	require.NoError(t, err)
+	updated, err := s.UpdateHost(ctx, &pbs.UpdateHostRequest{
+		Id:         created.GetItem().GetId(),
+		UpdateMask: &field_mask.FieldMask{Paths: []string{"attributes.labels"}},
+		Item:       &pb.Host{Version: got.GetItem().GetVersion(), Attributes: attrs},
+	})
+	require.NoError(t, err)
+	gotAttrs = &pb.StaticHostAttributes{}
+	require.NoError(t, handlers.StructToProto(updated.GetItem().GetAttributes(), gotAttrs))
+	assert.Equal(t, map[string]string{"env": "dev"}, gotAttrs.GetLabels())
+	assert.Equal(t, "10.0.0.1", gotAttrs.GetAddress().GetValue())
+	assert.Equal(t, got.GetItem().GetVersion()+1, updated.GetItem().GetVersion())
+
+	attrs, err = handlers.ProtoToStruct(&pb.StaticHostAttributes{
+		Address: &wrappers.StringValue{Value: "10.0.0.2"},
+		Labels:  map[string]string{"env": "prod,dev"},
+	})
+	require.NoError(t, err)
+	_, err = s.CreateHost(ctx, &pbs.CreateHostRequest{Item: &pb.Host{
+		HostCatalogId: hc.GetPublicId(),
+		Attributes:    attrs,
+	}})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, handlers.ApiErrorWithCode(codes.InvalidArgument)), "got error %v, wanted invalid argument", err)
+}
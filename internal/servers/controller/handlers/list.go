@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/filter"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ListRequest is implemented by the List requests of all services.
+type ListRequest interface {
+	GetFilter() string
+	GetPageSize() uint32
+	GetPageToken() string
+}
+
+// pageToken is the decoded form of the opaque token returned with a page of
+// items. Items are paged in order of their IDs.
+type pageToken struct {
+	LastId     string `json:"last_id"`
+	FilterHash uint64 `json:"filter_hash"`
+}
+
+// A ListFunc lists the items of a List request as a slice of API resources
+// with IDs. Only the items with IDs greater than afterId are listed, in order
+// of their IDs. If limit is greater than zero at most limit items are listed
+// from each of the repository queries the ListFunc makes; zero means the
+// repository's default limit.
+type ListFunc func(afterId string, limit int) (interface{}, error)
+
+// minFilterBatch is the smallest number of items requested from a ListFunc
+// when a filter is applied, so a selective filter does not cause a query per
+// item.
+const minFilterBatch = 100
+
+// ListPage lists the items for req with list and applies the filter and
+// pagination of req to them. It returns a slice of the type returned by list
+// holding the items of the requested page, and the token for the next page,
+// which is empty when there are no more items. The page size and the last ID
+// of the previous page are passed to list so only the items needed for the
+// page are read from the repository. Without pagination items keep the order
+// returned by list; otherwise they are ordered by ID.
+func ListPage(req ListRequest, list ListFunc) (interface{}, string, error) {
+	if req.GetFilter() == "" && req.GetPageSize() == 0 && req.GetPageToken() == "" {
+		items, err := list("", 0)
+		return items, "", err
+	}
+
+	var f *filter.Filter
+	if req.GetFilter() != "" {
+		var err error
+		if f, err = filter.New(req.GetFilter()); err != nil {
+			return nil, "", InvalidArgumentErrorf("Invalid filter.", map[string]string{"filter": err.Error()})
+		}
+	}
+
+	var lastId string
+	if req.GetPageToken() != "" {
+		var err error
+		if lastId, err = DecodePageToken(req.GetPageToken(), req.GetFilter()); err != nil {
+			return nil, "", err
+		}
+	}
+	paging := req.GetPageSize() > 0 || lastId != ""
+	size := int(req.GetPageSize())
+
+	var sliceType reflect.Type
+	var matching []idItem
+	for cursor := lastId; ; {
+		// One more item than the page size is needed to know if there is
+		// a next page.
+		limit := 0
+		if size > 0 {
+			limit = size + 1 - len(matching)
+			if f != nil && limit < minFilterBatch {
+				limit = minFilterBatch
+			}
+		}
+		items, err := list(cursor, limit)
+		if err != nil {
+			return nil, "", err
+		}
+		in := reflect.ValueOf(items)
+		if in.Kind() != reflect.Slice {
+			return nil, "", fmt.Errorf("list page: expected a slice, got %T", items)
+		}
+		sliceType = in.Type()
+		batch, err := toIdItems(in)
+		if err != nil {
+			return nil, "", err
+		}
+		if paging {
+			// Items listed from several repository queries have to be
+			// merged before the batch can be cut to the limit.
+			sort.Slice(batch, func(i, j int) bool { return batch[i].id < batch[j].id })
+			if limit > 0 && len(batch) > limit {
+				batch = batch[:limit]
+			}
+		}
+		for _, it := range batch {
+			ok, err := matches(f, it.msg)
+			if err != nil {
+				return nil, "", err
+			}
+			if ok {
+				matching = append(matching, it)
+			}
+		}
+		if limit == 0 || len(batch) < limit || len(matching) > size {
+			break
+		}
+		cursor = batch[len(batch)-1].id
+	}
+
+	var nextToken string
+	if size > 0 && len(matching) > size {
+		matching = matching[:size]
+		var err error
+		nextToken, err = EncodePageToken(matching[size-1].id, req.GetFilter())
+		if err != nil {
+			return nil, "", fmt.Errorf("list page: %w", err)
+		}
+	}
+
+	out := reflect.MakeSlice(sliceType, 0, len(matching))
+	for _, m := range matching {
+		out = reflect.Append(out, m.item)
+	}
+	return out.Interface(), nextToken, nil
+}
+
+type idItem struct {
+	id   string
+	msg  proto.Message
+	item reflect.Value
+}
+
+func toIdItems(in reflect.Value) ([]idItem, error) {
+	ret := make([]idItem, 0, in.Len())
+	for i := 0; i < in.Len(); i++ {
+		item := in.Index(i)
+		msg, ok := item.Interface().(interface {
+			proto.Message
+			GetId() string
+		})
+		if !ok {
+			return nil, fmt.Errorf("list page: item of type %s has no id", item.Type())
+		}
+		ret = append(ret, idItem{id: msg.GetId(), msg: msg, item: item})
+	}
+	return ret, nil
+}
+
+// matches reports whether msg matches f. Every message matches a nil filter.
+func matches(f *filter.Filter, msg proto.Message) (bool, error) {
+	if f == nil {
+		return true, nil
+	}
+	js, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return false, fmt.Errorf("list page: %w", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(js, &decoded); err != nil {
+		return false, fmt.Errorf("list page: %w", err)
+	}
+	return f.Match(decoded), nil
+}
+
+// EncodePageToken returns the token of the page of items following the item
+// lastId, for requests filtering items with filterExpr.
+func EncodePageToken(lastId, filterExpr string) (string, error) {
+	return encodePageToken(&pageToken{LastId: lastId, FilterHash: hashFilter(filterExpr)})
+}
+
+// DecodePageToken returns the ID of the last item of the page preceding the
+// one requested with token. It returns an invalid argument error if token was
+// not returned by EncodePageToken for the same filterExpr.
+func DecodePageToken(token, filterExpr string) (string, error) {
+	t, err := decodePageToken(token)
+	if err != nil || t.FilterHash != hashFilter(filterExpr) {
+		return "", InvalidArgumentErrorf("Invalid page token.", map[string]string{"page_token": "Must be the token returned by a previous list request with the same filter."})
+	}
+	return t.LastId, nil
+}
+
+func hashFilter(expr string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(expr))
+	return h.Sum64()
+}
+
+func encodePageToken(t *pageToken) (string, error) {
+	js, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(js), nil
+}
+
+func decodePageToken(s string) (*pageToken, error) {
+	js, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	t := new(pageToken)
+	if err := json.Unmarshal(js, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/workers"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestListPage(t *testing.T) {
+	items := []*pb.Worker{
+		{Id: "w_3", Name: "east-2"},
+		{Id: "w_1", Name: "east-1"},
+		{Id: "w_4", Name: "west-1"},
+		{Id: "w_2", Name: "west-2"},
+	}
+	// list behaves like a repository listing items in order of their IDs and
+	// records the limits it was called with.
+	var limits []int
+	list := func(afterId string, limit int) (interface{}, error) {
+		limits = append(limits, limit)
+		sorted := append([]*pb.Worker(nil), items...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].GetId() < sorted[j].GetId() })
+		var ret []*pb.Worker
+		for _, w := range sorted {
+			if w.GetId() > afterId && (limit == 0 || len(ret) < limit) {
+				ret = append(ret, w)
+			}
+		}
+		return ret, nil
+	}
+	unordered := func(string, int) (interface{}, error) { return items, nil }
+	ids := func(page interface{}) []string {
+		var ret []string
+		for _, w := range page.([]*pb.Worker) {
+			ret = append(ret, w.GetId())
+		}
+		return ret
+	}
+
+	t.Run("unfiltered", func(t *testing.T) {
+		page, next, err := ListPage(&pbs.ListWorkersRequest{}, unordered)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"w_3", "w_1", "w_4", "w_2"}, ids(page))
+		assert.Empty(t, next)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		page, next, err := ListPage(&pbs.ListWorkersRequest{Filter: `name contains "east"`}, unordered)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"w_3", "w_1"}, ids(page))
+		assert.Empty(t, next)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		limits = nil
+		req := &pbs.ListWorkersRequest{PageSize: 3}
+		page, next, err := ListPage(req, list)
+		require.NoError(err)
+		assert.Equal([]string{"w_1", "w_2", "w_3"}, ids(page))
+		require.NotEmpty(next)
+
+		req.PageToken = next
+		page, next, err = ListPage(req, list)
+		require.NoError(err)
+		assert.Equal([]string{"w_4"}, ids(page))
+		assert.Empty(next)
+
+		// Only one item more than the page size is listed
+		assert.Equal([]int{4, 4}, limits)
+	})
+
+	t.Run("filtered-pages", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		req := &pbs.ListWorkersRequest{Filter: `name contains "west"`, PageSize: 1}
+		page, next, err := ListPage(req, list)
+		require.NoError(err)
+		assert.Equal([]string{"w_2"}, ids(page))
+
+		// The token is only valid with the filter it was issued for
+		_, _, err = ListPage(&pbs.ListWorkersRequest{PageSize: 1, PageToken: next}, list)
+		assert.True(errors.Is(err, ApiErrorWithCode(codes.InvalidArgument)))
+
+		req.PageToken = next
+		page, next, err = ListPage(req, list)
+		require.NoError(err)
+		assert.Equal([]string{"w_4"}, ids(page))
+		assert.Empty(next)
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		// Items listed from several queries are merged before the page is
+		// cut.
+		twice := func(afterId string, limit int) (interface{}, error) {
+			a, _ := list(afterId, limit)
+			b, _ := list(afterId, limit)
+			return append(a.([]*pb.Worker), b.([]*pb.Worker)...), nil
+		}
+		page, next, err := ListPage(&pbs.ListWorkersRequest{PageSize: 1}, twice)
+		require.NoError(err)
+		assert.Equal([]string{"w_1"}, ids(page))
+		assert.NotEmpty(next)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := ListPage(&pbs.ListWorkersRequest{Filter: `name =`}, list)
+		assert.True(t, errors.Is(err, ApiErrorWithCode(codes.InvalidArgument)))
+		_, _, err = ListPage(&pbs.ListWorkersRequest{PageToken: "not-a-token"}, list)
+		assert.True(t, errors.Is(err, ApiErrorWithCode(codes.InvalidArgument)))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/errors"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/roles"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
@@ -55,17 +56,33 @@ func (s Service) ListRoles(ctx context.Context, req *pbs.ListRolesRequest) (*pbs
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
+	scps := []*scopes.ScopeInfo{authResults.Scope}
+	if req.GetRecursive() {
+		var err error
+		if scps, err = authResults.RecursiveScopes(ctx, resource.Role, action.List, scope.Global, scope.Org, scope.Project); err != nil {
+			return nil, err
+		}
+	} else if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	gl, err := s.listFromRepo(ctx, req.GetScopeId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		var gl []*pb.Role
+		for _, scp := range scps {
+			sl, err := s.listFromRepo(ctx, scp.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range sl {
+				item.Scope = scp
+			}
+			gl = append(gl, sl...)
+		}
+		return gl, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range gl {
-		item.Scope = authResults.Scope
-	}
-	return &pbs.ListRolesResponse{Items: gl}, nil
+	return &pbs.ListRolesResponse{Items: page.([]*pb.Role), NextPageToken: next}, nil
 }
 
 // GetRoles implements the interface pbs.RoleServiceServer.
@@ -335,12 +352,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Role, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId string, afterId string, limit int) ([]*pb.Role, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	rl, err := repo.ListRoles(ctx, scopeId)
+	rl, err := repo.ListRoles(ctx, scopeId, iam.WithAfterId(afterId), iam.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
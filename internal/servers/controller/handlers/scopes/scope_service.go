@@ -2,25 +2,45 @@ package scopes
 
 import (
 	"context"
+	"crypto/rand"
+	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/hashicorp/boundary/internal/auth"
+	pboplog "github.com/hashicorp/boundary/internal/gen/controller/api/resources/oplog"
+	pbreports "github.com/hashicorp/boundary/internal/gen/controller/api/resources/reports"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
 	"github.com/hashicorp/boundary/internal/iam/store"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/oplog/search"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/reports"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/types/action"
 	"github.com/hashicorp/boundary/internal/types/resource"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+// defaultOplogPageSize is the number of oplog entries returned by a read
+// which doesn't set a page size.
+const defaultOplogPageSize = 1000
+
+// opTypePrefix is the prefix of the names of the oplog.OpType values, which
+// the requests and responses omit.
+const opTypePrefix = "OP_TYPE_"
+
 var (
 	maskManager handlers.MaskManager
 )
@@ -36,15 +56,27 @@ func init() {
 type Service struct {
 	pbs.UnimplementedScopeServiceServer
 
-	repoFn common.IamRepoFactory
+	repoFn        common.IamRepoFactory
+	oplogRepoFn   common.OplogSearchRepoFactory
+	reportsRepoFn common.ReportsRepoFactory
+	kms           *kms.Kms
 }
 
 // NewService returns a project service which handles project related requests to boundary.
-func NewService(repo common.IamRepoFactory) (Service, error) {
+func NewService(kms *kms.Kms, repo common.IamRepoFactory, oplogRepo common.OplogSearchRepoFactory, reportsRepo common.ReportsRepoFactory) (Service, error) {
+	if kms == nil {
+		return Service{}, fmt.Errorf("nil kms provided")
+	}
 	if repo == nil {
 		return Service{}, fmt.Errorf("nil iam repository provided")
 	}
-	return Service{repoFn: repo}, nil
+	if oplogRepo == nil {
+		return Service{}, fmt.Errorf("nil oplog search repository provided")
+	}
+	if reportsRepo == nil {
+		return Service{}, fmt.Errorf("nil reports repository provided")
+	}
+	return Service{repoFn: repo, oplogRepoFn: oplogRepo, reportsRepoFn: reportsRepo, kms: kms}, nil
 }
 
 var _ pbs.ScopeServiceServer = Service{}
@@ -58,19 +90,33 @@ func (s Service) ListScopes(ctx context.Context, req *pbs.ListScopesRequest) (*p
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
+	scps := []*pb.ScopeInfo{authResults.Scope}
+	if req.GetRecursive() {
+		var err error
+		if scps, err = authResults.RecursiveScopes(ctx, resource.Scope, action.List, scope.Global, scope.Org); err != nil {
+			return nil, err
+		}
+	} else if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	pl, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		var pl []*pb.Scope
+		for _, scp := range scps {
+			sl, err := s.listFromRepo(ctx, scp.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range sl {
+				item.Scope = scp
+			}
+			pl = append(pl, sl...)
+		}
+		return pl, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	for _, item := range pl {
-		item.Scope = authResults.Scope
-	}
-
-	return &pbs.ListScopesResponse{Items: pl}, nil
+	return &pbs.ListScopesResponse{Items: page.([]*pb.Scope), NextPageToken: next}, nil
 }
 
 // GetScopes implements the interface pbs.ScopeServiceServer.
@@ -140,6 +186,235 @@ func (s Service) DeleteScope(ctx context.Context, req *pbs.DeleteScopeRequest) (
 	return &pbs.DeleteScopeResponse{}, nil
 }
 
+// RotateScopeKeys implements the interface pbs.ScopeServiceServer.
+func (s Service) RotateScopeKeys(ctx context.Context, req *pbs.RotateScopeKeysRequest) (*pbs.RotateScopeKeysResponse, error) {
+	if err := validateRotateKeysRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RotateKeys)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	j, err := s.kms.RotateKeys(ctx, req.GetId(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to rotate scope keys: %w", err)
+	}
+	item, err := toRewrapJobProto(j)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.RotateScopeKeysResponse{Item: item}, nil
+}
+
+// GetScopeRewrapJob implements the interface pbs.ScopeServiceServer.
+func (s Service) GetScopeRewrapJob(ctx context.Context, req *pbs.GetScopeRewrapJobRequest) (*pbs.GetScopeRewrapJobResponse, error) {
+	if err := validateGetRewrapJobRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.RewrapStatus)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	j, err := s.kms.LookupRewrapJob(ctx, req.GetId())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get scope rewrap job: %w", err)
+	}
+	if j == nil {
+		return nil, handlers.NotFoundErrorf("The keys of scope %q have not been rotated.", req.GetId())
+	}
+	item, err := toRewrapJobProto(j)
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.GetScopeRewrapJobResponse{Item: item}, nil
+}
+
+// ListScopeKeyVersions implements the interface pbs.ScopeServiceServer.
+func (s Service) ListScopeKeyVersions(ctx context.Context, req *pbs.ListScopeKeyVersionsRequest) (*pbs.ListScopeKeyVersionsResponse, error) {
+	if err := validateListKeyVersionsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListKeyVersions)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	purpose := kms.KeyPurposeUnknown
+	if req.GetPurpose() != "" {
+		// Validated above
+		purpose, _ = kms.ParseKeyPurpose(req.GetPurpose())
+	}
+	kvs, err := s.kms.ListKeyVersions(ctx, req.GetId(), purpose)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list scope key versions: %w", err)
+	}
+	var items []*pb.KeyVersion
+	for _, kv := range kvs {
+		item, err := toKeyVersionProto(kv)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return &pbs.ListScopeKeyVersionsResponse{Items: items}, nil
+}
+
+// ReadScopeOplog implements the interface pbs.ScopeServiceServer.
+func (s Service) ReadScopeOplog(ctx context.Context, req *pbs.ReadScopeOplogRequest) (*pbs.ReadScopeOplogResponse, error) {
+	if err := validateReadOplogRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ReadOplog)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	scopeIds := []string{req.GetId()}
+	if req.GetRecursive() {
+		descendants, err := s.descendantScopeIds(ctx, &authResults, req.GetId(), action.ReadOplog)
+		if err != nil {
+			return nil, err
+		}
+		scopeIds = append(scopeIds, descendants...)
+	}
+
+	// The page token is only valid for the same filters
+	filters := fmt.Sprintf("%t|%s|%s|%s|%s|%v|%v", req.GetRecursive(), req.GetAggregateName(), req.GetResourceType(),
+		req.GetResourceId(), req.GetOpType(), req.GetStartTime().AsTime(), req.GetEndTime().AsTime())
+	pageSize := int(req.GetPageSize())
+	if pageSize == 0 {
+		pageSize = defaultOplogPageSize
+	}
+	opts := []search.Option{
+		search.WithAggregateName(req.GetAggregateName()),
+		search.WithResourceType(req.GetResourceType()),
+		search.WithResourceId(req.GetResourceId()),
+		search.WithLimit(pageSize),
+	}
+	if req.GetOpType() != "" {
+		// Validated above
+		opts = append(opts, search.WithOpType(oplog.OpType(oplog.OpType_value[opTypePrefix+strings.ToUpper(req.GetOpType())])))
+	}
+	if req.GetStartTime() != nil {
+		opts = append(opts, search.WithStartTime(req.GetStartTime().AsTime()))
+	}
+	if req.GetEndTime() != nil {
+		opts = append(opts, search.WithEndTime(req.GetEndTime().AsTime()))
+	}
+	if req.GetPageToken() != "" {
+		lastId, err := handlers.DecodePageToken(req.GetPageToken(), filters)
+		if err != nil {
+			return nil, err
+		}
+		afterId, err := strconv.ParseUint(lastId, 10, 32)
+		if err != nil {
+			return nil, handlers.InvalidArgumentErrorf("Invalid page token.", map[string]string{"page_token": "Must be the token returned by a previous request with the same filters."})
+		}
+		opts = append(opts, search.WithAfterId(uint32(afterId)))
+	}
+
+	repo, err := s.oplogRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := repo.SearchEntries(ctx, scopeIds, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read oplog: %w", err)
+	}
+	var items []*pboplog.OplogEntry
+	for _, e := range entries {
+		item, err := toOplogEntryProto(e)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	var next string
+	if len(entries) == pageSize {
+		if next, err = handlers.EncodePageToken(strconv.FormatUint(uint64(entries[len(entries)-1].Id), 10), filters); err != nil {
+			return nil, fmt.Errorf("unable to read oplog: %w", err)
+		}
+	}
+	return &pbs.ReadScopeOplogResponse{Items: items, NextPageToken: next}, nil
+}
+
+// ReportScopeSessions implements the interface pbs.ScopeServiceServer.
+func (s Service) ReportScopeSessions(ctx context.Context, req *pbs.ReportScopeSessionsRequest) (*pbs.ReportScopeSessionsResponse, error) {
+	if err := validateReportSessionsRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	scopeIds := []string{req.GetId()}
+	if req.GetRecursive() {
+		descendants, err := s.descendantScopeIds(ctx, &authResults, req.GetId(), action.Read)
+		if err != nil {
+			return nil, err
+		}
+		scopeIds = append(scopeIds, descendants...)
+	}
+	// Sessions are only ever in projects
+	var projectIds []string
+	for _, id := range scopeIds {
+		if strings.HasPrefix(id, scope.Project.Prefix()) {
+			projectIds = append(projectIds, id)
+		}
+	}
+	if len(projectIds) == 0 {
+		return &pbs.ReportScopeSessionsResponse{}, nil
+	}
+
+	opts := []reports.Option{}
+	if req.GetGroupBy() != "" {
+		opts = append(opts, reports.WithGroupBy(reports.GroupBy(req.GetGroupBy())))
+	}
+	if req.GetPeriod() != "" {
+		opts = append(opts, reports.WithPeriod(reports.Period(req.GetPeriod())))
+	}
+	if req.GetStartTime() != nil {
+		opts = append(opts, reports.WithStartTime(req.GetStartTime().AsTime()))
+	}
+	if req.GetEndTime() != nil {
+		opts = append(opts, reports.WithEndTime(req.GetEndTime().AsTime()))
+	}
+
+	repo, err := s.reportsRepoFn()
+	if err != nil {
+		return nil, err
+	}
+	reps, err := repo.ReportSessions(ctx, projectIds, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to report sessions: %w", err)
+	}
+	var items []*pbreports.SessionReport
+	for _, r := range reps {
+		item, err := toSessionReportProto(r)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return &pbs.ReportScopeSessionsResponse{Items: items}, nil
+}
+
+// DestroyScopeKeyVersion implements the interface pbs.ScopeServiceServer.
+func (s Service) DestroyScopeKeyVersion(ctx context.Context, req *pbs.DestroyScopeKeyVersionRequest) (*pbs.DestroyScopeKeyVersionResponse, error) {
+	if err := validateDestroyKeyVersionRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.DestroyKeyVersion)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	if err := s.kms.DestroyKeyVersion(ctx, req.GetId(), req.GetKeyVersionId()); err != nil {
+		if errors.Is(err, kms.ErrKeyVersionInUse) {
+			return nil, handlers.ApiErrorWithCodeAndMessage(codes.FailedPrecondition, "Key version %q can't be destroyed: %v.", req.GetKeyVersionId(), err)
+		}
+		return nil, fmt.Errorf("unable to destroy scope key version: %w", err)
+	}
+	return &pbs.DestroyScopeKeyVersionResponse{}, nil
+}
+
 func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
@@ -253,7 +528,7 @@ func SortScopes(scps []*pb.Scope) {
 	})
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Scope, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId string, afterId string, limit int) ([]*pb.Scope, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
@@ -262,9 +537,9 @@ func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Scope,
 	var scps []*iam.Scope
 	switch {
 	case scopeId == "global":
-		scps, err = repo.ListOrgs(ctx)
+		scps, err = repo.ListOrgs(ctx, iam.WithAfterId(afterId), iam.WithLimit(limit))
 	case strings.HasPrefix(scopeId, scope.Org.Prefix()):
-		scps, err = repo.ListProjects(ctx, scopeId)
+		scps, err = repo.ListProjects(ctx, scopeId, iam.WithAfterId(afterId), iam.WithLimit(limit))
 	default:
 		return nil, handlers.InvalidArgumentErrorf("Error in provided request.",
 			map[string]string{"scope_id": "This field must be 'global' or a valid org scope id."})
@@ -338,6 +613,144 @@ func ToProto(in *iam.Scope) *pb.Scope {
 	return &out
 }
 
+func toRewrapJobProto(in *kms.RewrapJob) (*pb.RewrapJob, error) {
+	out := pb.RewrapJob{
+		ScopeId:        in.ScopeId,
+		State:          in.State.String(),
+		RewrappedCount: uint64(in.RewrappedCount),
+		RemainingCount: uint64(in.RemainingCount),
+		Error:          in.Error,
+	}
+	var err error
+	if out.RotatedTime, err = ptypes.TimestampProto(in.RotateTime); err != nil {
+		return nil, fmt.Errorf("unable to convert rotate time: %w", err)
+	}
+	if out.UpdatedTime, err = ptypes.TimestampProto(in.UpdateTime); err != nil {
+		return nil, fmt.Errorf("unable to convert update time: %w", err)
+	}
+	if !in.EndTime.IsZero() {
+		if out.EndedTime, err = ptypes.TimestampProto(in.EndTime); err != nil {
+			return nil, fmt.Errorf("unable to convert end time: %w", err)
+		}
+	}
+	return &out, nil
+}
+
+func toKeyVersionProto(in *kms.KeyVersion) (*pb.KeyVersion, error) {
+	created, err := ptypes.TimestampProto(in.CreateTime)
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert create time: %w", err)
+	}
+	return &pb.KeyVersion{
+		Id:             in.Id,
+		Purpose:        in.Purpose.String(),
+		Version:        in.Version,
+		CreatedTime:    created,
+		EncryptedCount: uint64(in.EncryptedCount),
+		IssuedCount:    uint64(in.IssuedCount),
+	}, nil
+}
+
+// descendantScopeIds returns the ids of the descendants of the scope id in
+// which the caller verified in authResults is allowed to perform act.
+func (s Service) descendantScopeIds(ctx context.Context, authResults *auth.VerifyResults, id string, act action.Type) ([]string, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	var ids, orgIds []string
+	allowed := func(scp *iam.Scope) {
+		if authResults.Allowed(perms.Resource{ScopeId: scp.GetParentId(), Id: scp.GetPublicId(), Type: resource.Scope}, act) {
+			ids = append(ids, scp.GetPublicId())
+		}
+	}
+	switch {
+	case id == scope.Global.String():
+		orgs, err := repo.ListOrgs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, org := range orgs {
+			allowed(org)
+			orgIds = append(orgIds, org.GetPublicId())
+		}
+	case strings.HasPrefix(id, scope.Org.Prefix()):
+		orgIds = []string{id}
+	}
+	for _, orgId := range orgIds {
+		projects, err := repo.ListProjects(ctx, orgId)
+		if err != nil {
+			return nil, err
+		}
+		for _, proj := range projects {
+			allowed(proj)
+		}
+	}
+	return ids, nil
+}
+
+func toSessionReportProto(in *reports.SessionReport) (*pbreports.SessionReport, error) {
+	out := pbreports.SessionReport{
+		Type:                   string(in.Type),
+		Id:                     in.Id,
+		Name:                   in.Name,
+		ScopeId:                in.ScopeId,
+		SessionCount:           in.SessionCount,
+		ConnectionCount:        in.ConnectionCount,
+		TotalDurationSeconds:   in.TotalDuration.Seconds(),
+		AverageDurationSeconds: in.AverageDuration().Seconds(),
+		BytesUp:                in.BytesUp,
+		BytesDown:              in.BytesDown,
+	}
+	var err error
+	if out.PeriodStart, err = ptypes.TimestampProto(in.PeriodStart); err != nil {
+		return nil, fmt.Errorf("unable to convert period start: %w", err)
+	}
+	return &out, nil
+}
+
+func toOplogEntryProto(in *search.Entry) (*pboplog.OplogEntry, error) {
+	out := pboplog.OplogEntry{
+		Id:            uint64(in.Id),
+		ScopeId:       in.ScopeId,
+		AggregateName: in.AggregateName,
+		Version:       in.Version,
+	}
+	var err error
+	if out.CreatedTime, err = ptypes.TimestampProto(in.CreateTime); err != nil {
+		return nil, fmt.Errorf("unable to convert create time: %w", err)
+	}
+	md := make(map[string]interface{}, len(in.Metadata))
+	for k, vals := range in.Metadata {
+		l := make([]interface{}, 0, len(vals))
+		for _, v := range vals {
+			l = append(l, v)
+		}
+		md[k] = l
+	}
+	if out.Metadata, err = structpb.NewStruct(md); err != nil {
+		return nil, fmt.Errorf("unable to convert metadata: %w", err)
+	}
+	if in.DataError != nil {
+		out.DataError = in.DataError.Error()
+	}
+	for _, m := range in.Messages {
+		msg := &pboplog.OplogMessage{
+			TypeName:       m.TypeName,
+			OpType:         strings.ToLower(strings.TrimPrefix(m.OpType.String(), opTypePrefix)),
+			FieldMaskPaths: m.FieldMaskPaths,
+			SetToNullPaths: m.SetToNullPaths,
+		}
+		if m.Message != nil {
+			if msg.Value, err = handlers.ProtoToStruct(m.Message); err != nil {
+				return nil, fmt.Errorf("unable to convert message of type %q: %w", m.TypeName, err)
+			}
+		}
+		out.Messages = append(out.Messages, msg)
+	}
+	return &out, nil
+}
+
 // A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
 // requirements on the structure of the request.  They verify that:
 //  * The path passed in is correctly formatted
@@ -488,3 +901,89 @@ func validateListRequest(req *pbs.ListScopesRequest) error {
 	}
 	return nil
 }
+
+func validateRotateKeysRequest(req *pbs.RotateScopeKeysRequest) error {
+	return validateGetRequest(&pbs.GetScopeRequest{Id: req.GetId()})
+}
+
+func validateGetRewrapJobRequest(req *pbs.GetScopeRewrapJobRequest) error {
+	return validateGetRequest(&pbs.GetScopeRequest{Id: req.GetId()})
+}
+
+func validateListKeyVersionsRequest(req *pbs.ListScopeKeyVersionsRequest) error {
+	if err := validateGetRequest(&pbs.GetScopeRequest{Id: req.GetId()}); err != nil {
+		return err
+	}
+	switch req.GetPurpose() {
+	case "", kms.KeyPurposeDatabase.String(), kms.KeyPurposeOplog.String(), kms.KeyPurposeTokens.String(), kms.KeyPurposeSessions.String():
+	default:
+		return handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{
+			"purpose": `Must be one of "database", "oplog", "tokens" or "sessions".`,
+		})
+	}
+	return nil
+}
+
+func validateReadOplogRequest(req *pbs.ReadScopeOplogRequest) error {
+	if err := validateGetRequest(&pbs.GetScopeRequest{Id: req.GetId()}); err != nil {
+		return err
+	}
+	badFields := map[string]string{}
+	switch req.GetOpType() {
+	case "", "create", "update", "delete":
+	default:
+		badFields["op_type"] = `Must be one of "create", "update" or "delete".`
+	}
+	if req.GetResourceId() != "" && req.GetResourceType() == "" {
+		badFields["resource_type"] = "Must be set when filtering by resource id."
+	}
+	if req.GetStartTime() != nil && req.GetEndTime() != nil && !req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		badFields["end_time"] = "Must be after the start time."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateReportSessionsRequest(req *pbs.ReportScopeSessionsRequest) error {
+	if err := validateGetRequest(&pbs.GetScopeRequest{Id: req.GetId()}); err != nil {
+		return err
+	}
+	badFields := map[string]string{}
+	switch reports.GroupBy(req.GetGroupBy()) {
+	case "", reports.GroupByUser, reports.GroupByTarget, reports.GroupByHost:
+	default:
+		badFields["group_by"] = `Must be one of "user", "target" or "host".`
+	}
+	switch reports.Period(req.GetPeriod()) {
+	case "", reports.PeriodDay, reports.PeriodWeek, reports.PeriodMonth:
+	default:
+		badFields["period"] = `Must be one of "day", "week" or "month".`
+	}
+	if req.GetStartTime() != nil && req.GetEndTime() != nil && !req.GetStartTime().AsTime().Before(req.GetEndTime().AsTime()) {
+		badFields["end_time"] = "Must be after the start time."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+func validateDestroyKeyVersionRequest(req *pbs.DestroyScopeKeyVersionRequest) error {
+	if err := validateGetRequest(&pbs.GetScopeRequest{Id: req.GetId()}); err != nil {
+		return err
+	}
+	badFields := map[string]string{}
+	kvId := req.GetKeyVersionId()
+	if !handlers.ValidId(kms.DatabaseKeyVersionPrefix, kvId) &&
+		!handlers.ValidId(kms.OplogKeyVersionPrefix, kvId) &&
+		!handlers.ValidId(kms.TokenKeyVersionPrefix, kvId) &&
+		!handlers.ValidId(kms.SessionKeyVersionPrefix, kvId) {
+		badFields["key_version_id"] = "Invalidly formatted key version id."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
@@ -15,8 +15,12 @@ import (
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog/search"
+	"github.com/hashicorp/boundary/internal/reports"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/scopes"
+	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
@@ -28,14 +32,21 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error)) {
+func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*iam.Repository, error), *kms.Kms, func() (*search.Repository, error), func() (*reports.Repository, error)) {
 	t.Helper()
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
 	iamRepo := iam.TestRepo(t, conn, wrap)
+	kmsCache := kms.TestKms(t, conn, wrap)
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
+	oplogRepoFn := func() (*search.Repository, error) {
+		return search.NewRepository(db.New(conn), kmsCache)
+	}
+	reportsRepoFn := func() (*reports.Repository, error) {
+		return reports.NewRepository(db.New(conn))
+	}
 
 	oRes, pRes := iam.TestScopes(t, iamRepo)
 
@@ -52,11 +63,11 @@ func createDefaultScopesAndRepo(t *testing.T) (*iam.Scope, *iam.Scope, func() (*
 	require.NoError(t, err)
 	pRes, _, err = repo.UpdateScope(context.Background(), pRes, 1, []string{"Name", "Description"})
 	require.NoError(t, err)
-	return oRes, pRes, repoFn
+	return oRes, pRes, repoFn, kmsCache, oplogRepoFn, reportsRepoFn
 }
 
 func TestGet(t *testing.T) {
-	org, proj, repo := createDefaultScopesAndRepo(t)
+	org, proj, repo, kmsCache, oplogRepoFn, reportsRepoFn := createDefaultScopesAndRepo(t)
 	toMerge := &pbs.GetScopeRequest{
 		Id: proj.GetPublicId(),
 	}
@@ -140,7 +151,7 @@ func TestGet(t *testing.T) {
 			req := proto.Clone(toMerge).(*pbs.GetScopeRequest)
 			proto.Merge(req, tc.req)
 
-			s, err := scopes.NewService(repo)
+			s, err := scopes.NewService(kmsCache, repo, oplogRepoFn, reportsRepoFn)
 			require.NoError(err, "Couldn't create new project service.")
 
 			got, gErr := s.GetScope(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), req)
@@ -157,9 +168,16 @@ func TestList(t *testing.T) {
 	conn, _ := db.TestSetup(t, "postgres")
 	wrap := db.TestWrapper(t)
 	iamRepo := iam.TestRepo(t, conn, wrap)
+	kmsCache := kms.TestKms(t, conn, wrap)
 	repoFn := func() (*iam.Repository, error) {
 		return iamRepo, nil
 	}
+	oplogRepoFn := func() (*search.Repository, error) {
+		return search.NewRepository(db.New(conn), kmsCache)
+	}
+	reportsRepoFn := func() (*reports.Repository, error) {
+		return reports.NewRepository(db.New(conn))
+	}
 	repo, err := repoFn()
 	require.NoError(t, err)
 
@@ -208,7 +226,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(kmsCache, repoFn, oplogRepoFn, reportsRepoFn)
 			require.NoError(err, "Couldn't create new role service.")
 
 			got, gErr := s.ListScopes(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), tc.req)
@@ -280,7 +298,7 @@ func TestList(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			assert, require := assert.New(t), require.New(t)
-			s, err := scopes.NewService(repoFn)
+			s, err := scopes.NewService(kmsCache, repoFn, oplogRepoFn, reportsRepoFn)
 			require.NoError(err, "Couldn't create new role service.")
 
 			got, gErr := s.ListScopes(auth.DisabledAuthTestContext(auth.WithScopeId(tc.scopeId)), tc.req)
@@ -294,9 +312,9 @@ func TestList(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	org, proj, repo := createDefaultScopesAndRepo(t)
+	org, proj, repo, kmsCache, oplogRepoFn, reportsRepoFn := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repo)
+	s, err := scopes.NewService(kmsCache, repo, oplogRepoFn, reportsRepoFn)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	cases := []struct {
@@ -370,9 +388,9 @@ func TestDelete(t *testing.T) {
 
 func TestDelete_twice(t *testing.T) {
 	assert, require := assert.New(t), require.New(t)
-	org, proj, repo := createDefaultScopesAndRepo(t)
+	org, proj, repo, kmsCache, oplogRepoFn, reportsRepoFn := createDefaultScopesAndRepo(t)
 
-	s, err := scopes.NewService(repo)
+	s, err := scopes.NewService(kmsCache, repo, oplogRepoFn, reportsRepoFn)
 	require.NoError(err, "Error when getting new scopes service")
 	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(org.GetPublicId()))
 	req := &pbs.DeleteScopeRequest{
@@ -397,7 +415,7 @@ func TestDelete_twice(t *testing.T) {
 
 func TestCreate(t *testing.T) {
 	ctx := context.Background()
-	defaultOrg, defaultProj, repoFn := createDefaultScopesAndRepo(t)
+	defaultOrg, defaultProj, repoFn, kmsCache, oplogRepoFn, reportsRepoFn := createDefaultScopesAndRepo(t)
 	defaultProjCreated, err := ptypes.Timestamp(defaultProj.GetCreateTime().GetTimestamp())
 	require.NoError(t, err, "Error converting proto to timestamp.")
 	toMerge := &pbs.CreateScopeRequest{}
@@ -573,7 +591,7 @@ func TestCreate(t *testing.T) {
 				req := proto.Clone(toMerge).(*pbs.CreateScopeRequest)
 				proto.Merge(req, tc.req)
 
-				s, err := scopes.NewService(repoFn)
+				s, err := scopes.NewService(kmsCache, repoFn, oplogRepoFn, reportsRepoFn)
 				require.NoError(err, "Error when getting new project service.")
 
 				if name != "" {
@@ -647,8 +665,8 @@ func TestCreate(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
-	org, proj, repoFn := createDefaultScopesAndRepo(t)
-	tested, err := scopes.NewService(repoFn)
+	org, proj, repoFn, kmsCache, oplogRepoFn, reportsRepoFn := createDefaultScopesAndRepo(t)
+	tested, err := scopes.NewService(kmsCache, repoFn, oplogRepoFn, reportsRepoFn)
 	require.NoError(t, err, "Error when getting new project service.")
 
 	var orgVersion uint32 = 2
@@ -1014,3 +1032,185 @@ func TestUpdate(t *testing.T) {
 		})
 	}
 }
+
+func TestRotateKeys(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	org, proj, repo, kmsCache, oplogRepoFn, reportsRepoFn := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(kmsCache, repo, oplogRepoFn, reportsRepoFn)
+	require.NoError(err, "Error when getting new scopes service")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(org.GetPublicId()))
+
+	_, gErr := s.GetScopeRewrapJob(ctx, &pbs.GetScopeRewrapJobRequest{Id: proj.GetPublicId()})
+	require.Error(gErr)
+	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.NotFound)), "Expected not found before the keys were rotated.")
+
+	_, gErr = s.RotateScopeKeys(ctx, &pbs.RotateScopeKeysRequest{Id: "p_invalid"})
+	require.Error(gErr)
+	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Expected invalid argument for a malformed id.")
+
+	rotated, gErr := s.RotateScopeKeys(ctx, &pbs.RotateScopeKeysRequest{Id: proj.GetPublicId()})
+	require.NoError(gErr)
+	assert.Equal(proj.GetPublicId(), rotated.GetItem().GetScopeId())
+	assert.Equal("pending", rotated.GetItem().GetState())
+	assert.NotNil(rotated.GetItem().GetRotatedTime())
+	assert.Nil(rotated.GetItem().GetEndedTime())
+
+	got, gErr := s.GetScopeRewrapJob(ctx, &pbs.GetScopeRewrapJobRequest{Id: proj.GetPublicId()})
+	require.NoError(gErr)
+	assert.Empty(cmp.Diff(rotated, &pbs.RotateScopeKeysResponse{Item: got.GetItem()}, protocmp.Transform()))
+}
+
+func TestKeyVersions(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	org, _, repo, kmsCache, oplogRepoFn, reportsRepoFn := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(kmsCache, repo, oplogRepoFn, reportsRepoFn)
+	require.NoError(err, "Error when getting new scopes service")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(scope.Global.String()))
+
+	got, gErr := s.ListScopeKeyVersions(ctx, &pbs.ListScopeKeyVersionsRequest{Id: org.GetPublicId()})
+	require.NoError(gErr)
+	assert.Len(got.GetItems(), 4)
+
+	got, gErr = s.ListScopeKeyVersions(ctx, &pbs.ListScopeKeyVersionsRequest{Id: org.GetPublicId(), Purpose: "oplog"})
+	require.NoError(gErr)
+	require.Len(got.GetItems(), 1)
+	kv := got.GetItems()[0]
+	assert.Equal("oplog", kv.GetPurpose())
+	assert.Equal(uint32(1), kv.GetVersion())
+	assert.NotNil(kv.GetCreatedTime())
+
+	_, gErr = s.ListScopeKeyVersions(ctx, &pbs.ListScopeKeyVersionsRequest{Id: org.GetPublicId(), Purpose: "recovery"})
+	require.Error(gErr)
+	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Expected invalid argument for an unsupported purpose.")
+
+	_, gErr = s.DestroyScopeKeyVersion(ctx, &pbs.DestroyScopeKeyVersionRequest{Id: org.GetPublicId(), KeyVersionId: "krkv_1234567890"})
+	require.Error(gErr)
+	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Expected invalid argument for a root key version id.")
+
+	_, gErr = s.DestroyScopeKeyVersion(ctx, &pbs.DestroyScopeKeyVersionRequest{Id: org.GetPublicId(), KeyVersionId: kv.GetId()})
+	require.Error(gErr)
+	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.FailedPrecondition)), "Expected failed precondition for the newest key version.")
+}
+
+func TestReadOplog(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	org, proj, repoFn, kmsCache, oplogRepoFn, reportsRepoFn := createDefaultScopesAndRepo(t)
+
+	s, err := scopes.NewService(kmsCache, repoFn, oplogRepoFn, reportsRepoFn)
+	require.NoError(err, "Error when getting new scopes service")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(scope.Global.String()))
+
+	repo, err := repoFn()
+	require.NoError(err)
+	var userIds []string
+	for i := 0; i < 2; i++ {
+		u, err := iam.NewUser(org.GetPublicId(), iam.WithName(fmt.Sprintf("user-%d", i)))
+		require.NoError(err)
+		u, err = repo.CreateUser(context.Background(), u)
+		require.NoError(err)
+		userIds = append(userIds, u.GetPublicId())
+	}
+
+	got, gErr := s.ReadScopeOplog(ctx, &pbs.ReadScopeOplogRequest{Id: org.GetPublicId(), AggregateName: "iam_user"})
+	require.NoError(gErr)
+	require.Len(got.GetItems(), 2)
+	assert.Empty(got.GetNextPageToken())
+	for i, e := range got.GetItems() {
+		assert.Equal(org.GetPublicId(), e.GetScopeId())
+		assert.Empty(e.GetDataError())
+		require.Len(e.GetMessages(), 1)
+		m := e.GetMessages()[0]
+		assert.Equal("iam_user", m.GetTypeName())
+		assert.Equal("create", m.GetOpType())
+		assert.Equal(userIds[i], m.GetValue().GetFields()["public_id"].GetStringValue())
+	}
+
+	got, gErr = s.ReadScopeOplog(ctx, &pbs.ReadScopeOplogRequest{Id: scope.Global.String(), Recursive: true, ResourceType: "user", OpType: "create", PageSize: 1})
+	require.NoError(gErr)
+	require.Len(got.GetItems(), 1)
+	require.NotEmpty(got.GetNextPageToken())
+	next, gErr := s.ReadScopeOplog(ctx, &pbs.ReadScopeOplogRequest{Id: scope.Global.String(), Recursive: true, ResourceType: "user", OpType: "create", PageSize: 1, PageToken: got.GetNextPageToken()})
+	require.NoError(gErr)
+	require.Len(next.GetItems(), 1)
+	assert.Greater(next.GetItems()[0].GetId(), got.GetItems()[0].GetId())
+
+	_, gErr = s.ReadScopeOplog(ctx, &pbs.ReadScopeOplogRequest{Id: scope.Global.String(), Recursive: true, OpType: "create", PageSize: 1, PageToken: got.GetNextPageToken()})
+	require.Error(gErr)
+	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Expected invalid argument for a page token of other filters.")
+
+	_, gErr = s.ReadScopeOplog(ctx, &pbs.ReadScopeOplogRequest{Id: org.GetPublicId(), OpType: "upsert"})
+	require.Error(gErr)
+	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Expected invalid argument for an unsupported op type.")
+
+	// Entries of the project are only read with the project, including the
+	// update of the project, whose scope-id metadata is the org
+	r, err := iam.NewRole(proj.GetPublicId(), iam.WithName("project-role"))
+	require.NoError(err)
+	r, err = repo.CreateRole(context.Background(), r)
+	require.NoError(err)
+	got, gErr = s.ReadScopeOplog(ctx, &pbs.ReadScopeOplogRequest{Id: org.GetPublicId()})
+	require.NoError(gErr)
+	for _, e := range got.GetItems() {
+		assert.Equal(org.GetPublicId(), e.GetScopeId())
+		for _, id := range e.GetMetadata().GetFields()["resource-public-id"].GetListValue().GetValues() {
+			assert.NotEqual(r.GetPublicId(), id.GetStringValue())
+			assert.NotEqual(proj.GetPublicId(), id.GetStringValue())
+		}
+	}
+	got, gErr = s.ReadScopeOplog(ctx, &pbs.ReadScopeOplogRequest{Id: proj.GetPublicId(), ResourceId: r.GetPublicId()})
+	require.NoError(gErr)
+	require.Len(got.GetItems(), 1)
+	assert.Equal(proj.GetPublicId(), got.GetItems()[0].GetScopeId())
+}
+
+func TestReportSessions(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	wrap := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrap)
+	kmsCache := kms.TestKms(t, conn, wrap)
+	repoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	oplogRepoFn := func() (*search.Repository, error) {
+		return search.NewRepository(db.New(conn), kmsCache)
+	}
+	reportsRepoFn := func() (*reports.Repository, error) {
+		return reports.NewRepository(db.New(conn))
+	}
+
+	composedOf := session.TestSessionParams(t, conn, wrap, iamRepo)
+	_ = session.TestSession(t, conn, wrap, composedOf)
+
+	s, err := scopes.NewService(kmsCache, repoFn, oplogRepoFn, reportsRepoFn)
+	require.NoError(err, "Error when getting new scopes service")
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(scope.Global.String()))
+
+	got, gErr := s.ReportScopeSessions(ctx, &pbs.ReportScopeSessionsRequest{Id: composedOf.ScopeId, GroupBy: "user"})
+	require.NoError(gErr)
+	require.Len(got.GetItems(), 1)
+	item := got.GetItems()[0]
+	assert.Equal("user", item.GetType())
+	assert.Equal(composedOf.UserId, item.GetId())
+	assert.Equal(uint64(1), item.GetSessionCount())
+	assert.Equal(item.GetTotalDurationSeconds(), item.GetAverageDurationSeconds())
+
+	got, gErr = s.ReportScopeSessions(ctx, &pbs.ReportScopeSessionsRequest{Id: scope.Global.String()})
+	require.NoError(gErr)
+	assert.Empty(got.GetItems(), "Sessions are only reported in projects without recursive.")
+
+	got, gErr = s.ReportScopeSessions(ctx, &pbs.ReportScopeSessionsRequest{Id: scope.Global.String(), Recursive: true, Period: "month"})
+	require.NoError(gErr)
+	require.Len(got.GetItems(), 1)
+	assert.Equal(composedOf.TargetId, got.GetItems()[0].GetId())
+
+	_, gErr = s.ReportScopeSessions(ctx, &pbs.ReportScopeSessionsRequest{Id: composedOf.ScopeId, GroupBy: "scope"})
+	require.Error(gErr)
+	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Expected invalid argument for an unsupported group by.")
+
+	_, gErr = s.ReportScopeSessions(ctx, &pbs.ReportScopeSessionsRequest{Id: composedOf.ScopeId, Period: "year"})
+	require.Error(gErr)
+	assert.True(errors.Is(gErr, handlers.ApiErrorWithCode(codes.InvalidArgument)), "Expected invalid argument for an unsupported period.")
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/hashicorp/boundary/internal/auth"
 	"github.com/hashicorp/boundary/internal/db/timestamp"
 	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/event"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
 	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
 	"github.com/hashicorp/boundary/internal/host"
@@ -98,17 +100,33 @@ func (s Service) ListTargets(ctx context.Context, req *pbs.ListTargetsRequest) (
 		return nil, err
 	}
 	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
-	if authResults.Error != nil {
+	scps := []*scopes.ScopeInfo{authResults.Scope}
+	if req.GetRecursive() {
+		var err error
+		if scps, err = authResults.RecursiveScopes(ctx, resource.Target, action.List, scope.Project); err != nil {
+			return nil, err
+		}
+	} else if authResults.Error != nil {
 		return nil, authResults.Error
 	}
-	ul, err := s.listFromRepo(ctx, authResults.Scope.GetId())
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		var ul []*pb.Target
+		for _, scp := range scps {
+			sl, err := s.listFromRepo(ctx, scp.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range sl {
+				item.Scope = scp
+			}
+			ul = append(ul, sl...)
+		}
+		return ul, nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	for _, item := range ul {
-		item.Scope = authResults.Scope
-	}
-	return &pbs.ListTargetsResponse{Items: ul}, nil
+	return &pbs.ListTargetsResponse{Items: page.([]*pb.Target), NextPageToken: next}, nil
 }
 
 // GetTargets implements the interface pbs.TargetServiceServer.
@@ -404,6 +422,10 @@ HostSetIterationLoop:
 		return nil, err
 	}
 	for _, v := range servers {
+		// Draining workers only serve the sessions they already have
+		if v.Draining {
+			continue
+		}
 		workers = append(workers, &pb.WorkerInfo{Address: v.Address})
 	}
 
@@ -436,6 +458,14 @@ HostSetIterationLoop:
 		HostId:             chosenId.hostId,
 		HostSetId:          chosenId.hostSetId,
 	}
+	event.Emit(ctx, event.SessionAuthorizationType, map[string]interface{}{
+		"session_id":  ret.GetSessionId(),
+		"target_id":   ret.GetTargetId(),
+		"scope_id":    ret.GetScope().GetId(),
+		"user_id":     ret.GetUserId(),
+		"host_id":     ret.GetHostId(),
+		"host_set_id": ret.GetHostSetId(),
+	})
 	return &pbs.AuthorizeSessionResponse{Item: ret}, nil
 }
 
@@ -553,12 +583,12 @@ func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
 	return rows > 0, nil
 }
 
-func (s Service) listFromRepo(ctx context.Context, scopeId string) ([]*pb.Target, error) {
+func (s Service) listFromRepo(ctx context.Context, scopeId string, afterId string, limit int) ([]*pb.Target, error) {
 	repo, err := s.repoFn()
 	if err != nil {
 		return nil, err
 	}
-	ul, err := repo.ListTargets(ctx, target.WithScopeId(scopeId))
+	ul, err := repo.ListTargets(ctx, target.WithScopeId(scopeId), target.WithAfterId(afterId), target.WithLimit(limit))
 	if err != nil {
 		return nil, err
 	}
@@ -811,8 +841,11 @@ func validateDeleteRequest(req *pbs.DeleteTargetRequest) error {
 
 func validateListRequest(req *pbs.ListTargetsRequest) error {
 	badFields := map[string]string{}
-	if !handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) {
-		badFields["scope_id"] = "This field is required to have a properly formatted project scope id."
+	switch {
+	case handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()):
+	case req.GetRecursive() && (req.GetScopeId() == scope.Global.String() || handlers.ValidId(scope.Org.Prefix(), req.GetScopeId())):
+	default:
+		badFields["scope_id"] = "This field is required to have a properly formatted project scope id. Recursive requests may also use 'global' or an org scope id."
 	}
 	if len(badFields) > 0 {
 		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
@@ -0,0 +1,481 @@
+package webhooks
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/scopes"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/webhooks"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/boundary/internal/webhook"
+	"github.com/hashicorp/boundary/internal/webhook/store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var (
+	maskManager handlers.MaskManager
+)
+
+func init() {
+	var err error
+	if maskManager, err = handlers.NewMaskManager(&store.Webhook{}, &pb.Webhook{}); err != nil {
+		panic(err)
+	}
+}
+
+// Service handles request as described by the pbs.WebhookServiceServer interface.
+type Service struct {
+	pbs.UnimplementedWebhookServiceServer
+
+	repoFn    common.WebhookRepoFactory
+	iamRepoFn common.IamRepoFactory
+}
+
+// NewService returns a webhook service which handles webhook related requests to boundary.
+func NewService(repoFn common.WebhookRepoFactory, iamRepoFn common.IamRepoFactory) (Service, error) {
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil webhook repository provided")
+	}
+	if iamRepoFn == nil {
+		return Service{}, fmt.Errorf("nil iam repository provided")
+	}
+	return Service{repoFn: repoFn, iamRepoFn: iamRepoFn}, nil
+}
+
+var _ pbs.WebhookServiceServer = Service{}
+
+// ListWebhooks implements the interface pbs.WebhookServiceServer.
+func (s Service) ListWebhooks(ctx context.Context, req *pbs.ListWebhooksRequest) (*pbs.ListWebhooksResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	scps := []*scopes.ScopeInfo{authResults.Scope}
+	if req.GetRecursive() {
+		var err error
+		if scps, err = authResults.RecursiveScopes(ctx, resource.Webhook, action.List, scope.Global, scope.Org, scope.Project); err != nil {
+			return nil, err
+		}
+	} else if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		var wl []*pb.Webhook
+		for _, scp := range scps {
+			sl, err := s.listFromRepo(ctx, scp.GetId(), afterId, limit)
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range sl {
+				item.Scope = scp
+			}
+			wl = append(wl, sl...)
+		}
+		return wl, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListWebhooksResponse{Items: page.([]*pb.Webhook), NextPageToken: next}, nil
+}
+
+// GetWebhook implements the interface pbs.WebhookServiceServer.
+func (s Service) GetWebhook(ctx context.Context, req *pbs.GetWebhookRequest) (*pbs.GetWebhookResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	w.Scope = authResults.Scope
+	return &pbs.GetWebhookResponse{Item: w}, nil
+}
+
+// CreateWebhook implements the interface pbs.WebhookServiceServer.
+func (s Service) CreateWebhook(ctx context.Context, req *pbs.CreateWebhookRequest) (*pbs.CreateWebhookResponse, error) {
+	if err := validateCreateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetItem().GetScopeId(), action.Create)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.createInRepo(ctx, authResults.Scope.GetId(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	w.Scope = authResults.Scope
+	return &pbs.CreateWebhookResponse{Item: w, Uri: fmt.Sprintf("webhooks/%s", w.GetId())}, nil
+}
+
+// UpdateWebhook implements the interface pbs.WebhookServiceServer.
+func (s Service) UpdateWebhook(ctx context.Context, req *pbs.UpdateWebhookRequest) (*pbs.UpdateWebhookResponse, error) {
+	if err := validateUpdateRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Update)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.updateInRepo(ctx, req.GetId(), req.GetUpdateMask().GetPaths(), req.GetItem())
+	if err != nil {
+		return nil, err
+	}
+	w.Scope = authResults.Scope
+	return &pbs.UpdateWebhookResponse{Item: w}, nil
+}
+
+// DeleteWebhook implements the interface pbs.WebhookServiceServer.
+func (s Service) DeleteWebhook(ctx context.Context, req *pbs.DeleteWebhookRequest) (*pbs.DeleteWebhookResponse, error) {
+	if err := validateDeleteRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Delete)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	_, err := s.deleteFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.DeleteWebhookResponse{}, nil
+}
+
+// ListWebhookDeliveries implements the interface pbs.WebhookServiceServer.
+func (s Service) ListWebhookDeliveries(ctx context.Context, req *pbs.ListWebhookDeliveriesRequest) (*pbs.ListWebhookDeliveriesResponse, error) {
+	if err := validateListDeliveriesRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.ListDeliveries)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	dl, err := repo.ListDeliveries(ctx, req.GetId(), webhook.WithState(webhook.DeliveryState(req.GetState())))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list webhook deliveries: %w", err)
+	}
+	var items []*pb.Delivery
+	for _, d := range dl {
+		items = append(items, toDeliveryProto(d))
+	}
+	return &pbs.ListWebhookDeliveriesResponse{Items: items}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Webhook, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	w, err := repo.LookupWebhook(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get webhook: %w", err)
+	}
+	if w == nil {
+		return nil, handlers.NotFoundErrorf("Webhook %q doesn't exist.", id)
+	}
+	return toProto(w), nil
+}
+
+func (s Service) createInRepo(ctx context.Context, scopeId string, item *pb.Webhook) (*pb.Webhook, error) {
+	opts := []webhook.Option{
+		webhook.WithSecret(item.GetSecret().GetValue()),
+		webhook.WithEventTypes(item.GetEventTypes()),
+	}
+	if item.GetName() != nil {
+		opts = append(opts, webhook.WithName(item.GetName().GetValue()))
+	}
+	if item.GetDescription() != nil {
+		opts = append(opts, webhook.WithDescription(item.GetDescription().GetValue()))
+	}
+	w, err := webhook.NewWebhook(scopeId, item.GetUrl().GetValue(), opts...)
+	if err != nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to build webhook for creation: %v.", err)
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, err := repo.CreateWebhook(ctx, w)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create webhook: %w", err)
+	}
+	if out == nil {
+		return nil, handlers.ApiErrorWithCodeAndMessage(codes.Internal, "Unable to create webhook but no error returned from repository.")
+	}
+	return toProto(out), nil
+}
+
+func (s Service) updateInRepo(ctx context.Context, id string, mask []string, item *pb.Webhook) (*pb.Webhook, error) {
+	dbMask := maskManager.Translate(mask)
+	if len(dbMask) == 0 {
+		return nil, handlers.InvalidArgumentErrorf("No valid fields included in the update mask.", map[string]string{"update_mask": "No valid fields provided in the update mask."})
+	}
+	w := &webhook.Webhook{
+		Webhook: &store.Webhook{
+			PublicId:    id,
+			Name:        item.GetName().GetValue(),
+			Description: item.GetDescription().GetValue(),
+			Url:         item.GetUrl().GetValue(),
+			Secret:      item.GetSecret().GetValue(),
+			EventTypes:  item.GetEventTypes(),
+		},
+	}
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	out, rowsUpdated, err := repo.UpdateWebhook(ctx, w, item.GetVersion(), dbMask)
+	if err != nil {
+		return nil, fmt.Errorf("unable to update webhook: %w", err)
+	}
+	if rowsUpdated == 0 {
+		return nil, handlers.NotFoundErrorf("Webhook %q doesn't exist or incorrect version provided.", id)
+	}
+	return toProto(out), nil
+}
+
+func (s Service) deleteFromRepo(ctx context.Context, id string) (bool, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return false, err
+	}
+	rows, err := repo.DeleteWebhook(ctx, id)
+	if err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to delete webhook: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, scopeId string, afterId string, limit int) ([]*pb.Webhook, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	wl, err := repo.ListWebhooks(ctx, scopeId, webhook.WithAfterId(afterId), webhook.WithLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list webhooks: %w", err)
+	}
+	var outWl []*pb.Webhook
+	for _, w := range wl {
+		outWl = append(outWl, toProto(w))
+	}
+	return outWl, nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	var parentId string
+	opts := []auth.Option{auth.WithType(resource.Webhook), auth.WithAction(a)}
+	switch a {
+	case action.List, action.Create:
+		parentId = id
+		iamRepo, err := s.iamRepoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		scp, err := iamRepo.LookupScope(ctx, parentId)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if scp == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+	case action.Read, action.Update, action.Delete, action.ListDeliveries:
+		repo, err := s.repoFn()
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		w, err := repo.LookupWebhook(ctx, id)
+		if err != nil {
+			res.Error = err
+			return res
+		}
+		if w == nil {
+			res.Error = handlers.NotFoundError()
+			return res
+		}
+		parentId = w.GetScopeId()
+		opts = append(opts, auth.WithId(id))
+	default:
+		res.Error = stderrors.New("unsupported action")
+		return res
+	}
+	opts = append(opts, auth.WithScopeId(parentId))
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(in *webhook.Webhook) *pb.Webhook {
+	out := pb.Webhook{
+		Id:          in.GetPublicId(),
+		ScopeId:     in.GetScopeId(),
+		CreatedTime: in.GetCreateTime().GetTimestamp(),
+		UpdatedTime: in.GetUpdateTime().GetTimestamp(),
+		Version:     in.GetVersion(),
+		Url:         &wrapperspb.StringValue{Value: in.GetUrl()},
+		EventTypes:  in.GetEventTypes(),
+	}
+	if in.GetDescription() != "" {
+		out.Description = &wrapperspb.StringValue{Value: in.GetDescription()}
+	}
+	if in.GetName() != "" {
+		out.Name = &wrapperspb.StringValue{Value: in.GetName()}
+	}
+	return &out
+}
+
+func toDeliveryProto(in *webhook.Delivery) *pb.Delivery {
+	return &pb.Delivery{
+		Id:              in.GetPublicId(),
+		WebhookId:       in.GetWebhookId(),
+		EventType:       in.GetEventType(),
+		Payload:         in.GetPayload(),
+		State:           in.GetState(),
+		Attempts:        in.GetAttempts(),
+		NextAttemptTime: in.GetNextAttemptTime().GetTimestamp(),
+		LastAttemptTime: in.GetLastAttemptTime().GetTimestamp(),
+		LastStatusCode:  in.GetLastStatusCode(),
+		LastError:       in.GetLastError(),
+		CreatedTime:     in.GetCreateTime().GetTimestamp(),
+	}
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetWebhookRequest) error {
+	return handlers.ValidateGetRequest(webhook.WebhookPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateCreateRequest(req *pbs.CreateWebhookRequest) error {
+	return handlers.ValidateCreateRequest(req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		item := req.GetItem()
+		if !handlers.ValidId(scope.Org.Prefix(), item.GetScopeId()) &&
+			!handlers.ValidId(scope.Project.Prefix(), item.GetScopeId()) &&
+			scope.Global.String() != item.GetScopeId() {
+			badFields["scope_id"] = "This field is missing or improperly formatted."
+		}
+		if item.GetUrl() == nil {
+			badFields["url"] = "This is a required field."
+		}
+		if item.GetSecret().GetValue() == "" {
+			badFields["secret"] = "This is a required field."
+		}
+		if len(item.GetEventTypes()) == 0 {
+			badFields["event_types"] = "At least one event type is required."
+		}
+		validateItem(item, badFields)
+		return badFields
+	})
+}
+
+func validateUpdateRequest(req *pbs.UpdateWebhookRequest) error {
+	if err := handlers.ValidateUpdateRequest(webhook.WebhookPrefix, req, req.GetItem(), func() map[string]string {
+		badFields := map[string]string{}
+		if req.GetItem().GetScopeId() != "" {
+			badFields["scope_id"] = "This is a read only field and cannot be specified in an update request."
+		}
+		validateItem(req.GetItem(), badFields)
+		return badFields
+	}); err != nil {
+		return err
+	}
+	// The item may be omitted to unset the fields in the mask, which is not
+	// allowed for these fields.
+	badFields := map[string]string{}
+	item := req.GetItem()
+	for _, p := range req.GetUpdateMask().GetPaths() {
+		switch p {
+		case "url":
+			if item.GetUrl() == nil {
+				badFields["url"] = "This field cannot be unset."
+			}
+		case "secret":
+			if item.GetSecret().GetValue() == "" {
+				badFields["secret"] = "This field cannot be unset."
+			}
+		case "event_types":
+			if len(item.GetEventTypes()) == 0 {
+				badFields["event_types"] = "At least one event type is required."
+			}
+		}
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Error in provided request.", badFields)
+	}
+	return nil
+}
+
+// validateItem checks the values of the fields specific to webhooks.
+func validateItem(item *pb.Webhook, badFields map[string]string) {
+	if item.GetUrl() != nil {
+		if err := webhook.ValidUrl(item.GetUrl().GetValue()); err != nil {
+			badFields["url"] = fmt.Sprintf("Must be an http or https URL: %v.", err)
+		}
+	}
+	for _, t := range item.GetEventTypes() {
+		if !webhook.ValidEventType(t) {
+			badFields["event_types"] = fmt.Sprintf("Unknown event type %q.", t)
+			break
+		}
+	}
+}
+
+func validateDeleteRequest(req *pbs.DeleteWebhookRequest) error {
+	return handlers.ValidateDeleteRequest(webhook.WebhookPrefix, req, handlers.NoopValidatorFn)
+}
+
+func validateListRequest(req *pbs.ListWebhooksRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(scope.Org.Prefix(), req.GetScopeId()) &&
+		!handlers.ValidId(scope.Project.Prefix(), req.GetScopeId()) &&
+		req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Incorrectly formatted identifier."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateListDeliveriesRequest(req *pbs.ListWebhookDeliveriesRequest) error {
+	badFields := map[string]string{}
+	if !handlers.ValidId(webhook.WebhookPrefix, req.GetId()) {
+		badFields["id"] = "Incorrectly formatted identifier."
+	}
+	if req.GetState() != "" && !webhook.ValidDeliveryState(req.GetState()) {
+		badFields["state"] = "Must be one of pending, succeeded or failed."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Errors in provided fields.", badFields)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package webhooks_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/db"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/webhooks"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers/webhooks"
+	"github.com/hashicorp/boundary/internal/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/protobuf/field_mask"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func testService(t *testing.T) (webhooks.Service, *iam.Scope, *iam.Scope) {
+	t.Helper()
+	conn, _ := db.TestSetup(t, "postgres")
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	kms := kms.TestKms(t, conn, wrapper)
+	repoFn := func() (*webhook.Repository, error) {
+		rw := db.New(conn)
+		return webhook.NewRepository(rw, rw, kms)
+	}
+	iamRepoFn := func() (*iam.Repository, error) {
+		return iamRepo, nil
+	}
+	s, err := webhooks.NewService(repoFn, iamRepoFn)
+	require.NoError(t, err)
+	o, p := iam.TestScopes(t, iamRepo)
+	return s, o, p
+}
+
+func TestCreate(t *testing.T) {
+	s, _, proj := testService(t)
+
+	cases := []struct {
+		name string
+		item *pb.Webhook
+		code codes.Code
+	}{
+		{
+			name: "valid",
+			item: &pb.Webhook{
+				ScopeId:    proj.GetPublicId(),
+				Name:       wrapperspb.String("name"),
+				Url:        wrapperspb.String("https://example.com/hook"),
+				Secret:     wrapperspb.String("secret"),
+				EventTypes: []string{webhook.SessionAuthorized, webhook.ConnectionClosed},
+			},
+			code: codes.OK,
+		},
+		{
+			name: "bad url",
+			item: &pb.Webhook{
+				ScopeId:    proj.GetPublicId(),
+				Url:        wrapperspb.String("ftp://example.com/hook"),
+				Secret:     wrapperspb.String("secret"),
+				EventTypes: []string{webhook.SessionAuthorized},
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "no secret",
+			item: &pb.Webhook{
+				ScopeId:    proj.GetPublicId(),
+				Url:        wrapperspb.String("https://example.com/hook"),
+				EventTypes: []string{webhook.SessionAuthorized},
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "unknown event type",
+			item: &pb.Webhook{
+				ScopeId:    proj.GetPublicId(),
+				Url:        wrapperspb.String("https://example.com/hook"),
+				Secret:     wrapperspb.String("secret"),
+				EventTypes: []string{"session.unknown"},
+			},
+			code: codes.InvalidArgument,
+		},
+		{
+			name: "id set",
+			item: &pb.Webhook{
+				Id:         webhook.WebhookPrefix + "_1234567890",
+				ScopeId:    proj.GetPublicId(),
+				Url:        wrapperspb.String("https://example.com/hook"),
+				Secret:     wrapperspb.String("secret"),
+				EventTypes: []string{webhook.SessionAuthorized},
+			},
+			code: codes.InvalidArgument,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			got, err := s.CreateWebhook(auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId())), &pbs.CreateWebhookRequest{Item: tc.item})
+			if tc.code != codes.OK {
+				require.Error(err)
+				assert.Equal(tc.code, status.Code(err))
+				return
+			}
+			require.NoError(err)
+			assert.True(handlers.ValidId(webhook.WebhookPrefix, got.GetItem().GetId()))
+			assert.Equal(tc.item.GetUrl().GetValue(), got.GetItem().GetUrl().GetValue())
+			assert.Equal([]string{webhook.ConnectionClosed, webhook.SessionAuthorized}, got.GetItem().GetEventTypes())
+			assert.Nil(got.GetItem().GetSecret(), "the secret must never be returned")
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	s, _, proj := testService(t)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+	created, err := s.CreateWebhook(ctx, &pbs.CreateWebhookRequest{Item: &pb.Webhook{
+		ScopeId:    proj.GetPublicId(),
+		Url:        wrapperspb.String("https://example.com/hook"),
+		Secret:     wrapperspb.String("secret"),
+		EventTypes: []string{webhook.SessionAuthorized},
+	}})
+	require.NoError(t, err)
+	id := created.GetItem().GetId()
+
+	cases := []struct {
+		name  string
+		paths []string
+		item  *pb.Webhook
+		code  codes.Code
+	}{
+		{
+			name:  "unset url",
+			paths: []string{"url"},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "unset event types",
+			paths: []string{"event_types"},
+			item:  &pb.Webhook{},
+			code:  codes.InvalidArgument,
+		},
+		{
+			name:  "event types",
+			paths: []string{"event_types"},
+			item:  &pb.Webhook{EventTypes: []string{webhook.SessionTerminated}},
+			code:  codes.OK,
+		},
+	}
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			cur, err := s.GetWebhook(ctx, &pbs.GetWebhookRequest{Id: id})
+			require.NoError(err)
+			item := tc.item
+			if item != nil {
+				item.Version = cur.GetItem().GetVersion()
+			}
+			got, err := s.UpdateWebhook(ctx, &pbs.UpdateWebhookRequest{
+				Id:         id,
+				UpdateMask: &field_mask.FieldMask{Paths: tc.paths},
+				Item:       item,
+			})
+			if tc.code != codes.OK {
+				require.Error(err)
+				assert.Equal(tc.code, status.Code(err))
+				return
+			}
+			require.NoError(err)
+			assert.Equal(tc.item.GetEventTypes(), got.GetItem().GetEventTypes())
+			assert.Greater(got.GetItem().GetVersion(), cur.GetItem().GetVersion())
+		})
+	}
+}
+
+func TestListDeliveries(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	s, _, proj := testService(t)
+	ctx := auth.DisabledAuthTestContext(auth.WithScopeId(proj.GetPublicId()))
+	created, err := s.CreateWebhook(ctx, &pbs.CreateWebhookRequest{Item: &pb.Webhook{
+		ScopeId:    proj.GetPublicId(),
+		Url:        wrapperspb.String("https://example.com/hook"),
+		Secret:     wrapperspb.String("secret"),
+		EventTypes: []string{webhook.SessionAuthorized},
+	}})
+	require.NoError(err)
+
+	got, err := s.ListWebhookDeliveries(ctx, &pbs.ListWebhookDeliveriesRequest{Id: created.GetItem().GetId()})
+	require.NoError(err)
+	assert.Empty(got.GetItems())
+
+	_, err = s.ListWebhookDeliveries(ctx, &pbs.ListWebhookDeliveriesRequest{Id: created.GetItem().GetId(), State: "bogus"})
+	require.Error(err)
+	assert.Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,203 @@
+package workers
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/auth"
+	pb "github.com/hashicorp/boundary/internal/gen/controller/api/resources/workers"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/internal/servers/controller/common"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+)
+
+// Service handles request as described by the pbs.WorkerServiceServer interface.
+type Service struct {
+	pbs.UnimplementedWorkerServiceServer
+
+	repoFn common.ServersRepoFactory
+}
+
+// NewService returns a worker service which handles worker related requests to boundary.
+func NewService(repoFn common.ServersRepoFactory) (Service, error) {
+	if repoFn == nil {
+		return Service{}, fmt.Errorf("nil servers repository provided")
+	}
+	return Service{repoFn: repoFn}, nil
+}
+
+var _ pbs.WorkerServiceServer = Service{}
+
+// GetWorker implements the interface pbs.WorkerServiceServer.
+func (s Service) GetWorker(ctx context.Context, req *pbs.GetWorkerRequest) (*pbs.GetWorkerResponse, error) {
+	if err := validateGetRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Read)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.getFromRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	w.Scope = authResults.Scope
+	return &pbs.GetWorkerResponse{Item: w}, nil
+}
+
+// ListWorkers implements the interface pbs.WorkerServiceServer.
+func (s Service) ListWorkers(ctx context.Context, req *pbs.ListWorkersRequest) (*pbs.ListWorkersResponse, error) {
+	if err := validateListRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetScopeId(), action.List)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	page, next, err := handlers.ListPage(req, func(afterId string, limit int) (interface{}, error) {
+		wl, err := s.listFromRepo(ctx, afterId, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range wl {
+			item.Scope = authResults.Scope
+		}
+		return wl, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &pbs.ListWorkersResponse{Items: page.([]*pb.Worker), NextPageToken: next}, nil
+}
+
+// DrainWorker implements the interface pbs.WorkerServiceServer.
+func (s Service) DrainWorker(ctx context.Context, req *pbs.DrainWorkerRequest) (*pbs.DrainWorkerResponse, error) {
+	if err := validateDrainRequest(req); err != nil {
+		return nil, err
+	}
+	authResults := s.authResult(ctx, req.GetId(), action.Drain)
+	if authResults.Error != nil {
+		return nil, authResults.Error
+	}
+	w, err := s.drainInRepo(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	w.Scope = authResults.Scope
+	return &pbs.DrainWorkerResponse{Item: w}, nil
+}
+
+func (s Service) getFromRepo(ctx context.Context, id string) (*pb.Worker, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	w, err := repo.LookupServer(ctx, id, servers.ServerTypeWorker)
+	if err != nil {
+		return nil, err
+	}
+	if w == nil {
+		return nil, handlers.NotFoundErrorf("Worker %q doesn't exist.", id)
+	}
+	return toProto(w), nil
+}
+
+func (s Service) listFromRepo(ctx context.Context, afterId string, limit int) ([]*pb.Worker, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	wl, err := repo.ListServers(ctx, servers.ServerTypeWorker, servers.WithAfterId(afterId), servers.WithLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	var outWl []*pb.Worker
+	for _, w := range wl {
+		outWl = append(outWl, toProto(w))
+	}
+	return outWl, nil
+}
+
+func (s Service) drainInRepo(ctx context.Context, id string) (*pb.Worker, error) {
+	repo, err := s.repoFn()
+	if err != nil {
+		return nil, err
+	}
+	w, err := repo.RequestWorkerDrain(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to drain worker: %w", err)
+	}
+	if w == nil {
+		return nil, handlers.NotFoundErrorf("Worker %q doesn't exist.", id)
+	}
+	return toProto(w), nil
+}
+
+func (s Service) authResult(ctx context.Context, id string, a action.Type) auth.VerifyResults {
+	res := auth.VerifyResults{}
+
+	// Workers always belong to the global scope
+	opts := []auth.Option{auth.WithType(resource.Worker), auth.WithAction(a), auth.WithScopeId(scope.Global.String())}
+	switch a {
+	case action.List:
+	case action.Read, action.Drain:
+		opts = append(opts, auth.WithId(id))
+	default:
+		res.Error = stderrors.New("unsupported action")
+		return res
+	}
+	return auth.Verify(ctx, opts...)
+}
+
+func toProto(in *servers.Server) *pb.Worker {
+	return &pb.Worker{
+		Id:             in.GetPrivateId(),
+		Name:           in.GetName(),
+		Description:    in.GetDescription(),
+		Address:        in.GetAddress(),
+		CreatedTime:    in.GetCreateTime().GetTimestamp(),
+		UpdatedTime:    in.GetUpdateTime().GetTimestamp(),
+		Draining:       in.GetDraining(),
+		DrainRequested: in.GetDrainRequested(),
+	}
+}
+
+// A validateX method should exist for each method above.  These methods do not make calls to any backing service but enforce
+// requirements on the structure of the request.  They verify that:
+//  * The path passed in is correctly formatted
+//  * All required parameters are set
+//  * There are no conflicting parameters provided
+func validateGetRequest(req *pbs.GetWorkerRequest) error {
+	return validateId(req.GetId())
+}
+
+func validateListRequest(req *pbs.ListWorkersRequest) error {
+	badFields := map[string]string{}
+	if req.GetScopeId() != scope.Global.String() {
+		badFields["scope_id"] = "Workers only exist in the global scope."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
+
+func validateDrainRequest(req *pbs.DrainWorkerRequest) error {
+	return validateId(req.GetId())
+}
+
+func validateId(id string) error {
+	badFields := map[string]string{}
+	if id == "" {
+		badFields["id"] = "Required field."
+	}
+	if len(badFields) > 0 {
+		return handlers.InvalidArgumentErrorf("Improperly formatted identifier.", badFields)
+	}
+	return nil
+}
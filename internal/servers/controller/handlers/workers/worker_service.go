@@ -2,12 +2,17 @@ package workers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/event"
 	"github.com/hashicorp/boundary/internal/gen/controller/api/resources/targets"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller/common"
 	"github.com/hashicorp/boundary/internal/session"
 	"github.com/hashicorp/boundary/internal/types/resource"
@@ -61,10 +66,11 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 	}
 	ret := &pbs.StatusResponse{
 		Controllers: controllers,
+		Drain:       req.Worker.DrainRequested,
 	}
 
 	// Happy path
-	if len(req.GetJobs()) == 0 {
+	if len(req.GetJobs()) == 0 && len(req.GetConnectionTransitions()) == 0 {
 		return ret, nil
 	}
 
@@ -73,6 +79,12 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 		return nil, status.Errorf(codes.Internal, "Error getting session repo: %v", err)
 	}
 
+	// Apply the connection changes the worker made while it couldn't reach a
+	// controller before looking at the state of its sessions
+	for _, t := range req.GetConnectionTransitions() {
+		ret.ConnectionTransitionResults = append(ret.ConnectionTransitionResults, ws.applyConnectionTransition(ctx, sessRepo, req.Worker.Name, t))
+	}
+
 	for _, jobStatus := range req.GetJobs() {
 		switch jobStatus.Job.GetType() {
 		// Check for session cancelation
@@ -128,6 +140,91 @@ func (ws *workerServiceServer) Status(ctx context.Context, req *pbs.StatusReques
 	return ret, nil
 }
 
+// applyConnectionTransition records a connection state change made by a worker
+// while it couldn't reach a controller. Workers send transitions until they see
+// the result, so transitions that were already applied are acknowledged
+// without applying them again. A transition that cannot be applied is
+// rejected, which makes the worker close the connection.
+func (ws *workerServiceServer) applyConnectionTransition(ctx context.Context, sessRepo *session.Repository, workerId string, t *pbs.ConnectionTransition) *pbs.ConnectionTransitionResult {
+	ret := &pbs.ConnectionTransitionResult{
+		ConnectionId: t.GetConnectionId(),
+		Status:       t.GetStatus(),
+	}
+	reject := func(err error) *pbs.ConnectionTransitionResult {
+		ws.logger.Warn("rejected connection change made by worker without a controller",
+			"worker_id", workerId,
+			"session_id", t.GetSessionId(),
+			"connection_id", t.GetConnectionId(),
+			"status", t.GetStatus().String(),
+			"error", err)
+		ret.Rejected = true
+		ret.Error = err.Error()
+		return ret
+	}
+
+	if t.GetConnectionId() == "" {
+		return reject(errors.New("missing connection id"))
+	}
+	// Only the worker proxying the session may change its connections
+	sess, _, err := sessRepo.LookupSession(ctx, t.GetSessionId())
+	switch {
+	case err != nil:
+		return reject(err)
+	case sess == nil:
+		return reject(errors.New("unknown session"))
+	case sess.ServerId != workerId:
+		return reject(errors.New("session is not proxied by this worker"))
+	}
+	conn, states, err := sessRepo.LookupConnection(ctx, t.GetConnectionId())
+	if err != nil {
+		return reject(err)
+	}
+	switch {
+	case conn == nil && t.GetStatus() != pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED:
+		return reject(errors.New("unknown connection"))
+	case conn != nil && conn.SessionId != t.GetSessionId():
+		return reject(errors.New("connection belongs to another session"))
+	case conn != nil && len(states) > 0 && states[0].Status.ProtoVal() >= t.GetStatus():
+		// Already applied
+		return ret
+	}
+
+	switch t.GetStatus() {
+	case pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED:
+		_, _, _, err = sessRepo.AuthorizeConnection(ctx, t.GetSessionId(), session.WithConnectionId(t.GetConnectionId()))
+	case pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED:
+		_, _, err = sessRepo.ConnectConnection(ctx, session.ConnectWith{
+			ConnectionId:       t.GetConnectionId(),
+			ClientTcpAddress:   t.GetClientTcpAddress(),
+			ClientTcpPort:      t.GetClientTcpPort(),
+			EndpointTcpAddress: t.GetEndpointTcpAddress(),
+			EndpointTcpPort:    t.GetEndpointTcpPort(),
+		})
+	case pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED:
+		_, err = sessRepo.CloseConnections(ctx, []session.CloseWith{
+			{
+				ConnectionId: t.GetConnectionId(),
+				BytesUp:      t.GetBytesUp(),
+				BytesDown:    t.GetBytesDown(),
+				ClosedReason: session.ClosedReason(t.GetClosedReason()),
+			},
+		})
+	default:
+		err = fmt.Errorf("unknown connection status %q", t.GetStatus().String())
+	}
+	if err != nil {
+		return reject(err)
+	}
+
+	ws.logger.Info("applied connection change made by worker without a controller",
+		"worker_id", workerId,
+		"session_id", t.GetSessionId(),
+		"connection_id", t.GetConnectionId(),
+		"status", t.GetStatus().String(),
+		"time", t.GetTime().AsTime())
+	return ret
+}
+
 func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.LookupSessionRequest) (*pbs.LookupSessionResponse, error) {
 	ws.logger.Trace("got validate session request from worker", "session_id", req.GetSessionId())
 
@@ -168,14 +265,30 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 		resp.ConnectionsLeft -= int32(authzSummary.CurrentConnectionCount)
 	}
 
+	if req.GetWorkerId() != "" {
+		resp.WorkerPath, err = ws.workerPath(ctx, req.GetWorkerId(), sessionInfo.Endpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	wrapper, err := ws.kms.GetWrapper(ctx, sessionInfo.ScopeId, kms.KeyPurposeSessions)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error getting sessions wrapper: %v", err)
 	}
 
 	// Derive the private key, which should match. Deriving on both ends allows
-	// us to not store it in the DB.
-	_, resp.Authorization.PrivateKey, err = session.DeriveED25519Key(wrapper, sessionInfo.UserId, sessionInfo.GetPublicId())
+	// us to not store it in the DB. Sessions authorized before the session key
+	// of the scope was rotated need the version which issued their certificate.
+	resp.Authorization.PrivateKey, err = session.DeriveCertificateKey(wrapper, nil, sessionInfo.UserId, sessionInfo.GetPublicId(), sessionInfo.Certificate)
+	if errors.Is(err, session.ErrCertificateKeyNotFound) {
+		var keyVersionIds []string
+		keyVersionIds, err = ws.kms.KeyVersionIds(ctx, sessionInfo.ScopeId, kms.KeyPurposeSessions)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Error listing session key versions: %v", err)
+		}
+		resp.Authorization.PrivateKey, err = session.DeriveCertificateKey(wrapper, keyVersionIds, sessionInfo.UserId, sessionInfo.GetPublicId(), sessionInfo.Certificate)
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Error deriving session key: %v", err)
 	}
@@ -183,6 +296,43 @@ func (ws *workerServiceServer) LookupSession(ctx context.Context, req *pbs.Looku
 	return resp, nil
 }
 
+// workerPath returns the addresses of the workers a connection received by
+// workerId must be forwarded through to reach endpoint.
+func (ws *workerServiceServer) workerPath(ctx context.Context, workerId, endpoint string) ([]string, error) {
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error parsing session endpoint: %v", err)
+	}
+	serversRepo, err := ws.serversRepoFn()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error getting servers repo: %v", err)
+	}
+	workers, err := serversRepo.ListServers(ctx, servers.ServerTypeWorker)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Error listing workers: %v", err)
+	}
+	// Draining workers can't be used as hops for new connections
+	var candidates []*servers.Server
+	for _, w := range workers {
+		if w.Draining && w.Name != workerId {
+			continue
+		}
+		candidates = append(candidates, w)
+	}
+	path, err := servers.WorkerPath(candidates, workerId, endpointUrl.Host)
+	if err != nil {
+		if errors.Is(err, servers.ErrNoWorkerPath) {
+			return nil, status.Errorf(codes.FailedPrecondition, "No worker can reach the session endpoint from worker %q.", workerId)
+		}
+		return nil, status.Errorf(codes.Internal, "Error computing worker path: %v", err)
+	}
+	var addrs []string
+	for _, w := range path {
+		addrs = append(addrs, w.Address)
+	}
+	return addrs, nil
+}
+
 func (ws *workerServiceServer) ActivateSession(ctx context.Context, req *pbs.ActivateSessionRequest) (*pbs.ActivateSessionResponse, error) {
 	ws.logger.Trace("got activate session request from worker", "session_id", req.GetSessionId())
 
@@ -297,6 +447,14 @@ func (ws *workerServiceServer) ConnectConnection(ctx context.Context, req *pbs.C
 	}
 
 	ws.logger.Info("connection established", loggerPairs...)
+	event.Emit(ctx, event.ConnectionOpenType, map[string]interface{}{
+		"session_id":           connectionInfo.SessionId,
+		"connection_id":        req.GetConnectionId(),
+		"client_tcp_address":   req.GetClientTcpAddress(),
+		"client_tcp_port":      req.GetClientTcpPort(),
+		"endpoint_tcp_address": connectionInfo.EndpointTcpAddress,
+		"endpoint_tcp_port":    connectionInfo.EndpointTcpPort,
+	})
 
 	return ret, nil
 }
@@ -350,6 +508,12 @@ func (ws *workerServiceServer) CloseConnection(ctx context.Context, req *pbs.Clo
 
 	for _, v := range req.GetCloseRequestData() {
 		ws.logger.Info("connection closed", "connection_id", v.ConnectionId)
+		event.Emit(ctx, event.ConnectionCloseType, map[string]interface{}{
+			"connection_id": v.GetConnectionId(),
+			"bytes_up":      v.GetBytesUp(),
+			"bytes_down":    v.GetBytesDown(),
+			"reason":        v.GetReason(),
+		})
 	}
 
 	ret := &pbs.CloseConnectionResponse{
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/libs/alpnmux"
 	"github.com/hashicorp/boundary/internal/servers/controller/handlers/workers"
@@ -118,6 +119,10 @@ func (c *Controller) startListeners() error {
 		workerServer := grpc.NewServer(
 			grpc.MaxRecvMsgSize(math.MaxInt32),
 			grpc.MaxSendMsgSize(math.MaxInt32),
+			grpc.ChainUnaryInterceptor(
+				clusterMetricsInterceptor,
+				event.UnaryServerInterceptor(c.conf.Eventer),
+			),
 		)
 		workerService := workers.NewWorkerServiceServer(c.logger.Named("worker-handler"), c.ServersRepoFn, c.SessionRepoFn, c.workerStatusUpdateTimes, c.kms)
 		pbs.RegisterServerCoordinationServiceServer(workerServer, workerService)
@@ -143,6 +148,8 @@ func (c *Controller) startListeners() error {
 				err = configureForCluster(ln)
 			case "proxy":
 				// Do nothing, in a dev mode we might see it here
+			case "ops":
+				// Served by the server command
 			default:
 				err = fmt.Errorf("unknown listener purpose %q", purpose)
 			}
@@ -189,6 +196,9 @@ func (c *Controller) stopListeners(serversOnly bool) error {
 	}
 	var retErr *multierror.Error
 	for _, ln := range c.conf.Listeners {
+		if ln.IsOps() {
+			continue
+		}
 		if err := ln.Mux.Close(); err != nil {
 			if _, ok := err.(*os.PathError); ok && ln.Config.Type == "unix" {
 				// The rmListener probably tried to remove the file but it
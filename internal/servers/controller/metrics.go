@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// unknownMethod labels API requests not routed to a service method
+const unknownMethod = "unknown"
+
+var (
+	apiRequestKey  = []string{"controller", "api", "request"}
+	clusterRpcKey  = []string{"controller", "cluster", "request"}
+	methodPrefixes = []string{"/controller.api.services.v1.", "/controller.servers.services.v1."}
+)
+
+type apiMethodKey struct{}
+
+// methodLabel returns the name of a gRPC method without its package, e.g.
+// "TargetService/AuthorizeSession".
+func methodLabel(fullMethod string) string {
+	for _, p := range methodPrefixes {
+		if strings.HasPrefix(fullMethod, p) {
+			return strings.TrimPrefix(fullMethod, p)
+		}
+	}
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+// statusResponseWriter records the status of a response.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusResponseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *statusResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// recordApiMethod is a gateway metadata annotator. It records the gRPC method
+// the gateway routed a request to for wrapHandlerWithMetrics; the metadata is
+// left unchanged.
+func recordApiMethod(ctx context.Context, _ *http.Request) metadata.MD {
+	if m, ok := runtime.RPCMethod(ctx); ok {
+		if method, ok := ctx.Value(apiMethodKey{}).(*string); ok {
+			*method = m
+		}
+	}
+	return nil
+}
+
+// wrapHandlerWithMetrics records the latency of API requests handled by the
+// gateway h, labeled with the service method and the HTTP status of the
+// response.
+func wrapHandlerWithMetrics(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		var method string
+		sw := &statusResponseWriter{ResponseWriter: w}
+		h.ServeHTTP(sw, r.WithContext(context.WithValue(r.Context(), apiMethodKey{}, &method)))
+		if sw.status == 0 {
+			sw.status = http.StatusOK
+		}
+		label := unknownMethod
+		if method != "" {
+			label = methodLabel(method)
+		}
+		metrics.MeasureSinceWithLabels(apiRequestKey, start, []metrics.Label{
+			{Name: "method", Value: label},
+			{Name: "code", Value: strconv.Itoa(sw.status)},
+		})
+	})
+}
+
+// clusterMetricsInterceptor records the latency of the RPCs workers make to
+// the controller, labeled with the method and the gRPC status code.
+func clusterMetricsInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	metrics.MeasureSinceWithLabels(clusterRpcKey, start, []metrics.Label{
+		{Name: "method", Value: methodLabel(info.FullMethod)},
+		{Name: "code", Value: status.Code(err).String()},
+	})
+	return resp, err
+}
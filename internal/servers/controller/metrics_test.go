@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/armon/go-metrics"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/hashicorp/boundary/internal/gen/controller/api/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodLabel(t *testing.T) {
+	assert.Equal(t, "TargetService/AuthorizeSession", methodLabel("/controller.api.services.v1.TargetService/AuthorizeSession"))
+	assert.Equal(t, "ServerCoordinationService/Status", methodLabel("/controller.servers.services.v1.ServerCoordinationService/Status"))
+	assert.Equal(t, "other.Service/Method", methodLabel("/other.Service/Method"))
+}
+
+func TestWrapHandlerWithMetrics(t *testing.T) {
+	inm := metrics.NewInmemSink(time.Minute, time.Minute)
+	conf := metrics.DefaultConfig("boundary")
+	conf.EnableHostname = false
+	conf.EnableRuntimeMetrics = false
+	_, err := metrics.NewGlobal(conf, inm)
+	require.NoError(t, err)
+
+	mux := runtime.NewServeMux(runtime.WithMetadata(recordApiMethod))
+	require.NoError(t, services.RegisterWorkerServiceHandlerServer(context.Background(), mux, &services.UnimplementedWorkerServiceServer{}))
+	h := wrapHandlerWithMetrics(mux)
+
+	for _, path := range []string{"/v1/workers", "/v1/unknown"} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	intervals := inm.Data()
+	require.NotEmpty(t, intervals)
+	samples := intervals[len(intervals)-1].Samples
+	assert.Contains(t, samples, "boundary.controller.api.request;method=WorkerService/ListWorkers;code=501")
+	assert.Contains(t, samples, "boundary.controller.api.request;method=unknown;code=404")
+}
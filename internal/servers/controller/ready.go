@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"context"
+	"errors"
+)
+
+// registerReadinessChecks registers the components of the controller
+// reported by the /ready endpoint of ops listeners.
+func (c *Controller) registerReadinessChecks() {
+	c.conf.AddReadinessCheck("controller", func(context.Context) error {
+		switch {
+		case c.shuttingDown.Load():
+			return errors.New("shutting down")
+		case !c.started.Load():
+			return errors.New("not started")
+		}
+		return nil
+	})
+	c.conf.AddReadinessCheck("database", func(ctx context.Context) error {
+		if c.conf.Database == nil {
+			return errors.New("not connected")
+		}
+		return c.conf.Database.DB().PingContext(ctx)
+	})
+	c.conf.AddReadinessCheck("controller_kms", func(context.Context) error {
+		switch {
+		case c.conf.RootKms == nil:
+			return errors.New("root kms not loaded")
+		case c.conf.WorkerAuthKms == nil:
+			return errors.New("worker-auth kms not loaded")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/shared-secure-libs/configutil"
+)
+
+// reloadableSettings are the parts of the configuration which can be changed
+// while the controller runs. They are replaced as a whole by Reload, so a
+// snapshot is always consistent.
+type reloadableSettings struct {
+	description          string
+	publicClusterAddr    string
+	authTokenTimeToLive  time.Duration
+	authTokenTimeToStale time.Duration
+
+	// cors holds the CORS settings of each listener, keyed by listenerKey
+	cors map[string]*corsSettings
+}
+
+// corsSettings are the CORS settings of an API listener.
+type corsSettings struct {
+	enabled        bool
+	allowedOrigins []string
+	allowedHeaders []string
+}
+
+func newReloadableSettings(conf *config.Config) *reloadableSettings {
+	s := &reloadableSettings{
+		cors: make(map[string]*corsSettings, len(conf.Listeners)),
+	}
+	if conf.Controller != nil {
+		s.description = conf.Controller.Description
+		s.publicClusterAddr = conf.Controller.PublicClusterAddr
+		s.authTokenTimeToLive = conf.Controller.AuthTokenTimeToLiveDuration
+		s.authTokenTimeToStale = conf.Controller.AuthTokenTimeToStaleDuration
+	}
+	for _, l := range conf.Listeners {
+		s.cors[listenerKey(l)] = &corsSettings{
+			enabled:        l.CorsEnabled,
+			allowedOrigins: l.CorsAllowedOrigins,
+			allowedHeaders: l.CorsAllowedHeaders,
+		}
+	}
+	return s
+}
+
+// listenerKey identifies a listener across reloads of the configuration.
+func listenerKey(l *configutil.Listener) string {
+	return l.Type + "|" + l.Address
+}
+
+func (c *Controller) settings() *reloadableSettings {
+	return c.reloadable.Load().(*reloadableSettings)
+}
+
+// Reload applies the settings of conf which can be changed while the
+// controller runs: its description and public cluster address, the time to
+// live and time to stale of new auth tokens, and the CORS settings of the API
+// listeners. Listeners are matched by type and address; the settings of
+// listeners which are not in conf are kept, since adding or removing
+// listeners requires a restart.
+func (c *Controller) Reload(conf *config.Config) {
+	s := newReloadableSettings(conf)
+	for k, v := range c.settings().cors {
+		if _, ok := s.cors[k]; !ok {
+			s.cors[k] = v
+		}
+	}
+	c.reloadable.Store(s)
+}
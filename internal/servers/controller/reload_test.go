@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/shared-secure-libs/configutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReload(t *testing.T) {
+	assert := assert.New(t)
+	newConf := func(cors bool, origins ...string) *config.Config {
+		return &config.Config{
+			SharedConfig: &configutil.SharedConfig{
+				Listeners: []*configutil.Listener{
+					{
+						Type:               "tcp",
+						Address:            "127.0.0.1:9200",
+						Purpose:            []string{"api"},
+						CorsEnabled:        cors,
+						CorsAllowedOrigins: origins,
+					},
+				},
+			},
+			Controller: &config.Controller{
+				Description:                 "description",
+				AuthTokenTimeToLiveDuration: time.Hour,
+			},
+		}
+	}
+	conf := newConf(false)
+	c := &Controller{reloadable: new(atomic.Value)}
+	c.reloadable.Store(newReloadableSettings(conf))
+
+	h := wrapHandlerWithCors(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}), c, HandlerProperties{ListenerConfig: conf.Listeners[0]})
+	do := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/scopes", nil)
+		req.Header.Set("Origin", "https://example.com")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// CORS is disabled so the origin isn't checked
+	rec := do()
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Empty(rec.Header().Get("Access-Control-Allow-Origin"))
+
+	c.Reload(newConf(true, "https://other.com"))
+	assert.Equal(http.StatusForbidden, do().Code)
+
+	reloaded := newConf(true, "https://example.com")
+	reloaded.Controller.Description = "reloaded"
+	reloaded.Controller.AuthTokenTimeToLiveDuration = time.Minute
+	c.Reload(reloaded)
+	rec = do()
+	assert.Equal(http.StatusOK, rec.Code)
+	assert.Equal("https://example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal("reloaded", c.settings().description)
+	assert.Equal(time.Minute, c.settings().authTokenTimeToLive)
+
+	// Listeners missing from the new configuration keep their settings
+	reloaded.Listeners = nil
+	c.Reload(reloaded)
+	assert.Equal(http.StatusOK, do().Code)
+}
@@ -5,14 +5,18 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/hashicorp/boundary/internal/oplog/retention"
 	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/webhook"
 )
 
 // In the future we could make this configurable
 const (
-	statusInterval      = 10 * time.Second
-	terminationInterval = 1 * time.Minute
+	statusInterval          = 10 * time.Second
+	terminationInterval     = 1 * time.Minute
+	webhookDeliveryInterval = 5 * time.Second
+	rewrapJobInterval       = 10 * time.Second
 )
 
 // This is exported so it can be tweaked in tests
@@ -28,12 +32,13 @@ func (c *Controller) startStatusTicking(cancelCtx context.Context) {
 				return
 
 			case <-timer.C:
+				settings := c.settings()
 				server := &servers.Server{
 					PrivateId:   c.conf.RawConfig.Controller.Name,
 					Name:        c.conf.RawConfig.Controller.Name,
 					Type:        resource.Controller.String(),
-					Description: c.conf.RawConfig.Controller.Description,
-					Address:     c.conf.RawConfig.Controller.PublicClusterAddr,
+					Description: settings.description,
+					Address:     settings.publicClusterAddr,
 				}
 				repo, err := c.ServersRepoFn()
 				if err != nil {
@@ -116,3 +121,85 @@ func (c *Controller) startTerminateCompletedSessionsTicking(cancelCtx context.Co
 		}
 	}()
 }
+
+func (c *Controller) startWebhookDeliveryTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("webhook delivery ticking shutting down")
+				return
+
+			case <-timer.C:
+				repo, err := c.WebhookRepoFn()
+				if err != nil {
+					c.logger.Error("error fetching repository for webhook delivery", "error", err)
+				} else if d, err := webhook.NewDeliverer(repo); err != nil {
+					c.logger.Error("error creating webhook deliverer", "error", err)
+				} else {
+					attempted, err := d.DeliverPending(cancelCtx)
+					if err != nil {
+						c.logger.Error("error performing webhook delivery", "error", err)
+					} else if attempted > 0 {
+						c.logger.Debug("webhook deliveries attempted", "deliveries_attempted", attempted)
+					}
+				}
+				timer.Reset(webhookDeliveryInterval)
+			}
+		}
+	}()
+}
+
+func (c *Controller) startRewrapJobTicking(cancelCtx context.Context) {
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("rewrap job ticking shutting down")
+				return
+
+			case <-timer.C:
+				if _, err := c.kms.RunRewrapJobs(cancelCtx); err != nil {
+					c.logger.Error("error running rewrap jobs", "error", err)
+				}
+				timer.Reset(rewrapJobInterval)
+			}
+		}
+	}()
+}
+
+func (c *Controller) startOplogRetentionTicking(cancelCtx context.Context) {
+	conf := c.conf.RawConfig.Controller.OplogRetention
+	policy := retention.Policy{
+		MaxAge:                 conf.MaxAgeDuration,
+		MaxEntriesPerAggregate: conf.MaxEntriesPerAggregate,
+		ArchiveDir:             conf.ArchiveDir,
+		ControllerName:         c.conf.RawConfig.Controller.Name,
+	}
+	go func() {
+		timer := time.NewTimer(0)
+		for {
+			select {
+			case <-cancelCtx.Done():
+				c.logger.Info("oplog retention ticking shutting down")
+				return
+
+			case <-timer.C:
+				repo, err := c.OplogRetentionRepoFn()
+				if err != nil {
+					c.logger.Error("error fetching repository for oplog retention", "error", err)
+				} else {
+					manifest, err := repo.ArchiveExpired(cancelCtx, policy)
+					if err != nil {
+						c.logger.Error("error archiving expired oplog entries", "error", err)
+					} else if manifest != nil {
+						c.logger.Info("archived expired oplog entries", "archive", manifest.Name, "entries_archived", manifest.EntryCount)
+					}
+				}
+				timer.Reset(conf.IntervalDuration)
+			}
+		}
+	}()
+}
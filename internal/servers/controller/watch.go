@@ -0,0 +1,462 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/hashicorp/boundary/internal/auth"
+	"github.com/hashicorp/boundary/internal/auth/password"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/host/static"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/servers/controller/handlers"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/target"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/hashicorp/boundary/internal/types/scope"
+	"github.com/hashicorp/go-hclog"
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// watchPath is the path of the watch stream. It is served outside of the
+	// gateway since the in-process gateway can't stream responses.
+	watchPath = "/v1/watch"
+
+	// connectionEventType is the type of session connection events. Session
+	// connections aren't a resource of their own.
+	connectionEventType = "connection"
+
+	// watchBufferSize is the number of events buffered for a stream before it
+	// is considered lagging and closed.
+	watchBufferSize = 256
+
+	watchKeepaliveInterval = 30 * time.Second
+)
+
+// watchPrefixTypes maps the prefixes of public IDs to the type of the
+// resource, for events published by the oplog write path. Sessions and their
+// connections are published by the watch triggers instead.
+var watchPrefixTypes = map[string]resource.Type{
+	scope.Global.Prefix():     resource.Scope,
+	scope.Org.Prefix():        resource.Scope,
+	scope.Project.Prefix():    resource.Scope,
+	iam.UserPrefix:            resource.User,
+	iam.GroupPrefix:           resource.Group,
+	iam.RolePrefix:            resource.Role,
+	password.AuthMethodPrefix: resource.AuthMethod,
+	password.AccountPrefix:    resource.Account,
+	static.HostCatalogPrefix:  resource.HostCatalog,
+	static.HostSetPrefix:      resource.HostSet,
+	static.HostPrefix:         resource.Host,
+	target.TcpTargetPrefix:    resource.Target,
+	session.SessionPrefix:     resource.Session,
+	session.ConnectionPrefix:  resource.Session,
+}
+
+// watchEvent is a change published on db.WatchChannel.
+type watchEvent struct {
+	Type      string    `json:"type"`
+	Op        string    `json:"op"`
+	Id        string    `json:"id"`
+	ScopeId   string    `json:"scope_id,omitempty"`
+	SessionId string    `json:"session_id,omitempty"`
+	UserId    string    `json:"user_id,omitempty"`
+	TargetId  string    `json:"target_id,omitempty"`
+	State     string    `json:"state,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// parseWatchEvent parses a notification payload. Events published by the
+// oplog write path carry no type and get the type of their ID; false is
+// returned for events which are not published to watchers, including oplog
+// events for sessions, whose state changes are published separately.
+func parseWatchEvent(payload string) (*watchEvent, bool, error) {
+	e := new(watchEvent)
+	if err := json.Unmarshal([]byte(payload), e); err != nil {
+		return nil, false, err
+	}
+	if e.Type != "" {
+		return e, true, nil
+	}
+	prefix := e.Id
+	if i := strings.Index(prefix, "_"); i >= 0 {
+		prefix = prefix[:i]
+	}
+	typ, ok := watchPrefixTypes[prefix]
+	if !ok || typ == resource.Session {
+		return nil, false, nil
+	}
+	e.Type = typ.String()
+	return e, true, nil
+}
+
+// watchResource returns the resource the caller must be allowed to read to
+// see e.
+func watchResource(e *watchEvent) perms.Resource {
+	switch e.Type {
+	case connectionEventType:
+		return perms.Resource{ScopeId: e.ScopeId, Id: e.SessionId, Type: resource.Session}
+	default:
+		return perms.Resource{ScopeId: e.ScopeId, Id: e.Id, Type: resource.Map[e.Type]}
+	}
+}
+
+// watchSubscriber is a stream served by this controller. done is closed when
+// the subscriber is dropped by the broker. Changes are only guaranteed to be
+// sent from readyTime on.
+type watchSubscriber struct {
+	events    chan *watchEvent
+	done      chan struct{}
+	reason    string
+	readyTime time.Time
+}
+
+// watchBroker listens for notifications on db.WatchChannel while there are
+// watch streams and fans them out to the streams. A single listener is shared
+// by all streams of the controller. The listener is registered in the
+// database while there are streams, since resource changes are only
+// published while a listener is registered.
+type watchBroker struct {
+	logger   hclog.Logger
+	dbUrl    string
+	db       *db.Db
+	serverId string
+
+	l         sync.Mutex
+	listener  *pq.Listener
+	subs      map[*watchSubscriber]struct{}
+	readyTime time.Time
+}
+
+func newWatchBroker(logger hclog.Logger, dbUrl string, d *db.Db, serverId string) *watchBroker {
+	return &watchBroker{
+		logger:   logger,
+		dbUrl:    dbUrl,
+		db:       d,
+		serverId: serverId,
+		subs:     make(map[*watchSubscriber]struct{}),
+	}
+}
+
+// subscribe adds a subscriber, starting and registering the listener if
+// needed. The listener is stopped when ctx is done.
+func (b *watchBroker) subscribe(ctx context.Context) (*watchSubscriber, error) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	if b.readyTime.IsZero() {
+		// Other controllers may not check for the registration for a while,
+		// so streams are held until they have
+		if err := b.db.RegisterWatchListener(ctx, b.serverId); err != nil {
+			return nil, fmt.Errorf("error registering watch listener: %w", err)
+		}
+		b.readyTime = time.Now().Add(db.WatchListenerCheckInterval)
+	}
+	if b.listener == nil {
+		l := pq.NewListener(b.dbUrl, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+			if err != nil {
+				b.logger.Error("watch listener error", "error", err)
+			}
+		})
+		if err := l.Listen(db.WatchChannel); err != nil {
+			l.Close()
+			return nil, fmt.Errorf("error listening for changes: %w", err)
+		}
+		b.listener = l
+		go b.run(ctx, l)
+	}
+	sub := &watchSubscriber{
+		events:    make(chan *watchEvent, watchBufferSize),
+		done:      make(chan struct{}),
+		readyTime: b.readyTime,
+	}
+	b.subs[sub] = struct{}{}
+	return sub, nil
+}
+
+// unsubscribe removes sub if the broker has not dropped it already.
+func (b *watchBroker) unsubscribe(sub *watchSubscriber) {
+	b.l.Lock()
+	defer b.l.Unlock()
+	if _, ok := b.subs[sub]; ok {
+		delete(b.subs, sub)
+		close(sub.done)
+	}
+}
+
+// drop removes sub with reason; b.l must be held.
+func (b *watchBroker) drop(sub *watchSubscriber, reason string) {
+	delete(b.subs, sub)
+	sub.reason = reason
+	close(sub.done)
+}
+
+// refresh registers the listener again while there are subscribers and
+// unregisters it once there are none; b.l must be held.
+func (b *watchBroker) refresh(ctx context.Context) {
+	switch {
+	case len(b.subs) > 0:
+		if err := b.db.RegisterWatchListener(ctx, b.serverId); err != nil {
+			b.logger.Error("error registering watch listener", "error", err)
+		}
+	case !b.readyTime.IsZero():
+		if err := b.db.UnregisterWatchListener(ctx, b.serverId); err != nil {
+			b.logger.Error("error unregistering watch listener", "error", err)
+			return
+		}
+		b.readyTime = time.Time{}
+	}
+}
+
+func (b *watchBroker) run(ctx context.Context, l *pq.Listener) {
+	defer func() {
+		b.l.Lock()
+		defer b.l.Unlock()
+		for sub := range b.subs {
+			b.drop(sub, "controller shutting down")
+		}
+		b.refresh(context.Background())
+		b.listener = nil
+		if err := l.Close(); err != nil {
+			b.logger.Error("error closing watch listener", "error", err)
+		}
+	}()
+	ticker := time.NewTicker(db.WatchListenerTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.l.Lock()
+			b.refresh(ctx)
+			b.l.Unlock()
+		case n := <-l.Notify:
+			b.l.Lock()
+			if n == nil {
+				// The connection was lost and reestablished, so changes may
+				// have been missed; streams have to be reopened and their
+				// state read again
+				for sub := range b.subs {
+					b.drop(sub, "changes may have been missed")
+				}
+				b.l.Unlock()
+				continue
+			}
+			e, ok, err := parseWatchEvent(n.Extra)
+			switch {
+			case err != nil:
+				b.logger.Error("error parsing watch notification", "error", err, "payload", n.Extra)
+			case ok:
+				for sub := range b.subs {
+					select {
+					case sub.events <- e:
+					default:
+						b.drop(sub, "stream is not being read fast enough")
+					}
+				}
+			}
+			b.l.Unlock()
+		}
+	}
+}
+
+// watchFilter selects the events sent on a stream.
+type watchFilter struct {
+	scopeId   string
+	recursive bool
+	types     map[string]bool
+	allowed   func(perms.Resource, action.Type) bool
+	parentId  func(scopeId string) (string, error)
+}
+
+// matches reports whether e is in the scope of the stream, is of one of its
+// types, and can be read by the caller.
+func (f *watchFilter) matches(e *watchEvent) (bool, error) {
+	if len(f.types) > 0 && !f.types[e.Type] {
+		return false, nil
+	}
+	switch {
+	case e.ScopeId == f.scopeId:
+	case !f.recursive:
+		return false, nil
+	case f.scopeId == scope.Global.String():
+	default:
+		if !strings.HasPrefix(e.ScopeId, scope.Project.Prefix()+"_") {
+			return false, nil
+		}
+		parentId, err := f.parentId(e.ScopeId)
+		if err != nil {
+			return false, err
+		}
+		if parentId != f.scopeId {
+			return false, nil
+		}
+	}
+	return f.allowed(watchResource(e), action.Read), nil
+}
+
+// writeWatchEvent writes e as a server-sent event named after its type.
+func writeWatchEvent(w http.ResponseWriter, e *watchEvent) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	return err
+}
+
+// handleWatch serves the watch stream: server-sent events for changes to
+// resources in a scope, and optionally the scopes below it, which the caller
+// is allowed to read.
+func (c *Controller) handleWatch() http.Handler {
+	errorHandler := handlers.ErrorHandler(c.logger)
+	marshaler := &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			UseProtoNames: true,
+		},
+	}
+	// Parents of projects never change, so they are looked up once
+	var parents sync.Map
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		writeError := func(err error) {
+			errorHandler(ctx, nil, marshaler, w, r, err)
+		}
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", http.MethodGet)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			writeError(errors.New("watch: response writer does not support streaming"))
+			return
+		}
+
+		q := r.URL.Query()
+		f := &watchFilter{
+			scopeId: q.Get("scope_id"),
+			types:   make(map[string]bool),
+		}
+		if f.scopeId == "" {
+			f.scopeId = scope.Global.String()
+		}
+		if v := q.Get("recursive"); v != "" {
+			var err error
+			if f.recursive, err = strconv.ParseBool(v); err != nil {
+				writeError(handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"recursive": "Must be true or false."}))
+				return
+			}
+		}
+		for _, t := range strings.Split(q.Get("types"), ",") {
+			t = strings.TrimSpace(t)
+			if t == "" {
+				continue
+			}
+			if _, ok := resource.Map[t]; !ok && t != connectionEventType {
+				writeError(handlers.InvalidArgumentErrorf("Error in provided request.", map[string]string{"types": fmt.Sprintf("Unknown type %q.", t)}))
+				return
+			}
+			f.types[t] = true
+		}
+
+		authResults := auth.Verify(ctx, auth.WithScopeId(f.scopeId), auth.WithType(resource.Session), auth.WithAction(action.List))
+		switch {
+		case authResults.Error == nil:
+		case f.recursive && errors.Is(authResults.Error, handlers.ForbiddenError()):
+			// Resources in the scopes below may still be readable
+		default:
+			writeError(authResults.Error)
+			return
+		}
+		f.allowed = authResults.Allowed
+		f.parentId = func(scopeId string) (string, error) {
+			if p, ok := parents.Load(scopeId); ok {
+				return p.(string), nil
+			}
+			repo, err := c.IamRepoFn()
+			if err != nil {
+				return "", err
+			}
+			s, err := repo.LookupScope(c.baseContext, scopeId)
+			if err != nil {
+				return "", err
+			}
+			if s == nil {
+				// Deleted since the event was published
+				return "", nil
+			}
+			parents.Store(scopeId, s.GetParentId())
+			return s.GetParentId(), nil
+		}
+
+		sub, err := c.watchBroker.subscribe(c.baseContext)
+		if err != nil {
+			writeError(err)
+			return
+		}
+		defer c.watchBroker.unsubscribe(sub)
+
+		if d := time.Until(sub.readyTime); d > 0 {
+			timer := time.NewTimer(d)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+
+		keepalive := time.NewTicker(watchKeepaliveInterval)
+		defer keepalive.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sub.done:
+				// Tell the client why the stream ended so it can reopen it
+				if sub.reason != "" {
+					data, _ := json.Marshal(map[string]string{"message": sub.reason})
+					fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+					flusher.Flush()
+				}
+				return
+			case <-keepalive.C:
+				if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+			case e := <-sub.events:
+				ok, err := f.matches(e)
+				if err != nil {
+					c.logger.Error("error filtering watch event", "error", err, "id", e.Id)
+					continue
+				}
+				if !ok {
+					continue
+				}
+				if err := writeWatchEvent(w, e); err != nil {
+					return
+				}
+				flusher.Flush()
+			}
+		}
+	})
+}
@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/perms"
+	"github.com/hashicorp/boundary/internal/types/action"
+	"github.com/hashicorp/boundary/internal/types/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWatchEvent(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		want     *watchEvent
+		wantSkip bool
+	}{
+		{
+			name:    "oplog",
+			payload: `{"op":"update","id":"ttcp_1234567890","scope_id":"p_1234567890","time":"2020-11-02T10:00:00.5+00:00"}`,
+			want: &watchEvent{
+				Type:    "target",
+				Op:      "update",
+				Id:      "ttcp_1234567890",
+				ScopeId: "p_1234567890",
+				Time:    time.Date(2020, 11, 2, 10, 0, 0, 5e8, time.UTC),
+			},
+		},
+		{
+			name:    "scope",
+			payload: `{"op":"create","id":"o_1234567890","scope_id":"global","time":"2020-11-02T10:00:00+00:00"}`,
+			want: &watchEvent{
+				Type:    "scope",
+				Op:      "create",
+				Id:      "o_1234567890",
+				ScopeId: "global",
+				Time:    time.Date(2020, 11, 2, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "trigger",
+			payload: `{"type":"connection","op":"update","id":"sc_1234567890","session_id":"s_1234567890","scope_id":"p_1234567890","user_id":"u_1234567890","target_id":"ttcp_1234567890","state":"closed","time":"2020-11-02T10:00:00+00:00"}`,
+			want: &watchEvent{
+				Type:      "connection",
+				Op:        "update",
+				Id:        "sc_1234567890",
+				SessionId: "s_1234567890",
+				ScopeId:   "p_1234567890",
+				UserId:    "u_1234567890",
+				TargetId:  "ttcp_1234567890",
+				State:     "closed",
+				Time:      time.Date(2020, 11, 2, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "oplog-session",
+			payload:  `{"op":"create","id":"s_1234567890","scope_id":"p_1234567890","time":"2020-11-02T10:00:00+00:00"}`,
+			wantSkip: true,
+		},
+		{
+			name:     "unknown-prefix",
+			payload:  `{"op":"update","id":"at_1234567890","scope_id":"global","time":"2020-11-02T10:00:00+00:00"}`,
+			wantSkip: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := parseWatchEvent(tt.payload)
+			require.NoError(t, err)
+			if tt.wantSkip {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.True(t, tt.want.Time.Equal(got.Time))
+			got.Time = tt.want.Time
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, _, err := parseWatchEvent("not json")
+	assert.Error(t, err)
+}
+
+func TestWatchFilter(t *testing.T) {
+	parents := map[string]string{
+		"p_1": "o_1",
+		"p_2": "o_2",
+	}
+	// The caller can read everything but targets in p_2
+	allowed := func(res perms.Resource, act action.Type) bool {
+		assert.Equal(t, action.Read, act)
+		return !(res.ScopeId == "p_2" && res.Type == resource.Target)
+	}
+	newFilter := func(scopeId string, recursive bool, types ...string) *watchFilter {
+		f := &watchFilter{
+			scopeId:   scopeId,
+			recursive: recursive,
+			types:     make(map[string]bool),
+			allowed:   allowed,
+			parentId: func(id string) (string, error) {
+				return parents[id], nil
+			},
+		}
+		for _, t := range types {
+			f.types[t] = true
+		}
+		return f
+	}
+
+	tests := []struct {
+		name   string
+		filter *watchFilter
+		event  *watchEvent
+		want   bool
+	}{
+		{
+			name:   "same-scope",
+			filter: newFilter("o_1", false),
+			event:  &watchEvent{Type: "user", Id: "u_1", ScopeId: "o_1"},
+			want:   true,
+		},
+		{
+			name:   "child-scope-not-recursive",
+			filter: newFilter("o_1", false),
+			event:  &watchEvent{Type: "target", Id: "ttcp_1", ScopeId: "p_1"},
+		},
+		{
+			name:   "child-scope-recursive",
+			filter: newFilter("o_1", true),
+			event:  &watchEvent{Type: "target", Id: "ttcp_1", ScopeId: "p_1"},
+			want:   true,
+		},
+		{
+			name:   "other-org",
+			filter: newFilter("o_1", true),
+			event:  &watchEvent{Type: "target", Id: "ttcp_2", ScopeId: "p_2"},
+		},
+		{
+			name:   "sibling-org",
+			filter: newFilter("o_1", true),
+			event:  &watchEvent{Type: "user", Id: "u_2", ScopeId: "o_2"},
+		},
+		{
+			name:   "global-recursive",
+			filter: newFilter("global", true),
+			event:  &watchEvent{Type: "session", Id: "s_1", ScopeId: "p_1"},
+			want:   true,
+		},
+		{
+			name:   "not-allowed",
+			filter: newFilter("global", true),
+			event:  &watchEvent{Type: "target", Id: "ttcp_2", ScopeId: "p_2"},
+		},
+		{
+			name:   "type-filtered",
+			filter: newFilter("global", true, "session", "connection"),
+			event:  &watchEvent{Type: "target", Id: "ttcp_1", ScopeId: "p_1"},
+		},
+		{
+			name:   "type-included",
+			filter: newFilter("global", true, "session", "connection"),
+			event:  &watchEvent{Type: "connection", Id: "sc_1", SessionId: "s_1", ScopeId: "p_1"},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.filter.matches(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestWatchResource(t *testing.T) {
+	assert.Equal(t,
+		perms.Resource{ScopeId: "p_1", Id: "s_1", Type: resource.Session},
+		watchResource(&watchEvent{Type: "connection", Id: "sc_1", SessionId: "s_1", ScopeId: "p_1"}))
+	assert.Equal(t,
+		perms.Resource{ScopeId: "p_1", Id: "hst_1", Type: resource.Host},
+		watchResource(&watchEvent{Type: "host", Id: "hst_1", ScopeId: "p_1"}))
+}
+
+func TestWriteWatchEvent(t *testing.T) {
+	w := httptest.NewRecorder()
+	require.NoError(t, writeWatchEvent(w, &watchEvent{
+		Type:    "host",
+		Op:      "delete",
+		Id:      "hst_1",
+		ScopeId: "p_1",
+		Time:    time.Date(2020, 11, 2, 10, 0, 0, 0, time.UTC),
+	}))
+	assert.Equal(t,
+		"event: host\ndata: {\"type\":\"host\",\"op\":\"delete\",\"id\":\"hst_1\",\"scope_id\":\"p_1\",\"time\":\"2020-11-02T10:00:00Z\"}\n\n",
+		w.Body.String())
+}
@@ -3,3 +3,15 @@ package servers
 func (s *Server) TableName() string {
 	return "server"
 }
+
+// serverTag is the database representation of a ServerTag.
+type serverTag struct {
+	ServerId   string
+	ServerType string
+	Key        string
+	Value      string
+}
+
+func (t *serverTag) TableName() string {
+	return "server_tag"
+}
@@ -18,6 +18,7 @@ type Option func(*options)
 type options struct {
 	withLimit    int
 	withLiveness time.Duration
+	withAfterId  string
 }
 
 func getDefaultOptions() options {
@@ -43,3 +44,12 @@ func WithLiveness(liveness time.Duration) Option {
 		o.withLiveness = liveness
 	}
 }
+
+// WithAfterId provides an option to list only the items whose id sorts after
+// id. Lists are ordered by id so it can be used to list items a page at a
+// time.
+func WithAfterId(id string) Option {
+	return func(o *options) {
+		o.withAfterId = id
+	}
+}
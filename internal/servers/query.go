@@ -2,4 +2,26 @@ package servers
 
 const (
 	deleteWhereSql = `create_time < $1`
+
+	deleteServerTagsSql = `
+	delete from server_tag
+	 where server_id = $1
+	   and server_type = $2;
+	`
+
+	insertServerTagSql = `
+	insert into server_tag
+		(server_id, server_type, key, value)
+	values
+		($1, $2, $3, $4)
+	on conflict do nothing;
+	`
+
+	requestWorkerDrainSql = `
+	update server
+	   set drain_requested = true
+	 where private_id = $1
+	   and type = $2
+	   and update_time > $3;
+	`
 )
@@ -62,20 +62,53 @@ func (r *Repository) ListServers(ctx context.Context, serverType ServerType, opt
 		liveness = defaultLiveness
 	}
 	updateTime := time.Now().Add(-1 * liveness)
+	where, args := "type = $1 and update_time > $2", []interface{}{serverType, updateTime.Format(time.RFC3339)}
+	if opts.withAfterId != "" {
+		where, args = where+" and private_id > $3", append(args, opts.withAfterId)
+	}
+	limit := -1
+	if opts.withLimit > 0 {
+		limit = opts.withLimit
+	}
 	var servers []*Server
 	if err := r.reader.SearchWhere(
 		ctx,
 		&servers,
-		"type = $1 and update_time > $2",
-		[]interface{}{serverType, updateTime.Format(time.RFC3339)},
-		db.WithLimit(-1),
+		where,
+		args,
+		db.WithLimit(limit),
+		db.WithOrder("private_id"),
 	); err != nil {
 		return nil, fmt.Errorf("error listing servers: %w", err)
 	}
+	if err := r.loadTags(ctx, serverType, servers); err != nil {
+		return nil, fmt.Errorf("error listing servers: %w", err)
+	}
 	return servers, nil
 }
 
-// UpsertServer adds or updates a server in the DB
+// loadTags sets the Tags of each of servers from the database.
+func (r *Repository) loadTags(ctx context.Context, serverType ServerType, servers []*Server) error {
+	if len(servers) == 0 {
+		return nil
+	}
+	var tags []*serverTag
+	if err := r.reader.SearchWhere(ctx, &tags, "server_type = ?", []interface{}{serverType.String()}, db.WithLimit(-1)); err != nil {
+		return fmt.Errorf("error loading server tags: %w", err)
+	}
+	byServer := make(map[string][]*ServerTag)
+	for _, t := range tags {
+		byServer[t.ServerId] = append(byServer[t.ServerId], &ServerTag{Key: t.Key, Value: t.Value})
+	}
+	for _, s := range servers {
+		s.Tags = byServer[s.PrivateId]
+	}
+	return nil
+}
+
+// UpsertServer adds or updates a server in the DB. For workers, the
+// DrainRequested field of server is set from the DB; a pending drain request is
+// cleared once the worker reports that it is draining.
 func (r *Repository) UpsertServer(ctx context.Context, server *Server, opt ...Option) ([]*Server, int, error) {
 	if server == nil {
 		return nil, db.NoRowsAffected, errors.New("cannot update server that is nil")
@@ -85,36 +118,113 @@ func (r *Repository) UpsertServer(ctx context.Context, server *Server, opt ...Op
 	// Build query
 	q := `
 	insert into server
-		(private_id, type, name, description, address, update_time)
+		(private_id, type, name, description, address, update_time, draining)
 	values
-		($1, $2, $3, $4, $5, $6)
+		($1, $2, $3, $4, $5, $6, $7)
 	on conflict on constraint server_pkey
 	do update set
 		name = $3,
 		description = $4,
 		address = $5,
-		update_time = $6;
+		update_time = $6,
+		draining = $7,
+		drain_requested = server.drain_requested and not $7;
 	`
 
-	rowsAffected, err := r.writer.Exec(ctx, q,
-		[]interface{}{server.PrivateId,
-			server.Type,
-			server.Name,
-			server.Description,
-			server.Address,
-			time.Now().Format(time.RFC3339)})
+	var rowsAffected int
+	_, err := r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, w db.Writer) error {
+			var err error
+			rowsAffected, err = w.Exec(ctx, q,
+				[]interface{}{server.PrivateId,
+					server.Type,
+					server.Name,
+					server.Description,
+					server.Address,
+					time.Now().Format(time.RFC3339),
+					server.Draining})
+			if err != nil {
+				return fmt.Errorf("error performing status upsert: %w", err)
+			}
+			if server.Type == ServerTypeWorker.String() {
+				current := new(Server)
+				if err := reader.LookupWhere(ctx, current, "private_id = ? and type = ?", server.PrivateId, server.Type); err != nil {
+					return fmt.Errorf("error looking up upserted server: %w", err)
+				}
+				server.DrainRequested = current.DrainRequested
+			}
+			// Replace the tags with the ones currently reported
+			if _, err := w.Exec(ctx, deleteServerTagsSql, []interface{}{server.PrivateId, server.Type}); err != nil {
+				return fmt.Errorf("error deleting server tags: %w", err)
+			}
+			for _, t := range server.Tags {
+				if _, err := w.Exec(ctx, insertServerTagSql, []interface{}{server.PrivateId, server.Type, t.Key, t.Value}); err != nil {
+					return fmt.Errorf("error inserting server tag: %w", err)
+				}
+			}
+			return nil
+		},
+	)
 	if err != nil {
-		return nil, db.NoRowsAffected, fmt.Errorf("error performing status upsert: %w", err)
+		return nil, db.NoRowsAffected, err
 	}
 	// If updating a controller, done
 	if server.Type == resource.Controller.String() {
-		return nil, int(rowsAffected), nil
+		return nil, rowsAffected, nil
 	}
 	// Fetch current controllers to feed to the workers
 	controllers, err := r.ListServers(ctx, ServerTypeController)
 	return controllers, len(controllers), err
 }
 
+// LookupServer returns the server of serverType with privateId if it has
+// reported its status within the liveness period. Supports the WithLiveness
+// option. Returns nil if no such server exists.
+func (r *Repository) LookupServer(ctx context.Context, privateId string, serverType ServerType, opt ...Option) (*Server, error) {
+	if privateId == "" {
+		return nil, errors.New("cannot look up server with empty id")
+	}
+	servers, err := r.ListServers(ctx, serverType, opt...)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up server: %w", err)
+	}
+	for _, s := range servers {
+		if s.PrivateId == privateId {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+// RequestWorkerDrain requests that the worker with privateId drain. The
+// request is passed on to the worker in the response to its next status
+// request. Supports the WithLiveness option. Returns the updated worker, or
+// nil if no such worker has reported its status within the liveness period.
+func (r *Repository) RequestWorkerDrain(ctx context.Context, privateId string, opt ...Option) (*Server, error) {
+	if privateId == "" {
+		return nil, errors.New("cannot drain worker with empty id")
+	}
+	// Only live workers can be drained, otherwise a worker restarting later
+	// under the same name would drain immediately
+	opts := getOpts(opt...)
+	liveness := opts.withLiveness
+	if liveness == 0 {
+		liveness = defaultLiveness
+	}
+	updateTime := time.Now().Add(-1 * liveness)
+	rows, err := r.writer.Exec(ctx, requestWorkerDrainSql, []interface{}{privateId, ServerTypeWorker.String(), updateTime.Format(time.RFC3339)})
+	if err != nil {
+		return nil, fmt.Errorf("error requesting worker drain: %w", err)
+	}
+	if rows == 0 {
+		return nil, nil
+	}
+	return r.LookupServer(ctx, privateId, ServerTypeWorker, opt...)
+}
+
 type RecoveryNonce struct {
 	Nonce string
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/hashicorp/boundary/api/roles"
 	"github.com/hashicorp/boundary/globals"
 	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/boundary/internal/servers/controller"
 	"github.com/hashicorp/boundary/internal/types/scope"
 	"github.com/hashicorp/boundary/sdk/recovery"
@@ -78,3 +79,47 @@ func TestRecoveryNonces(t *testing.T) {
 		assert.Len(nonces, 0)
 	}
 }
+
+func TestRepository_RequestWorkerDrain(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+
+	tc := controller.NewTestController(t, nil)
+	defer tc.Shutdown()
+	repo := tc.ServersRepo()
+
+	worker := &servers.Server{
+		PrivateId: "test-drain-worker",
+		Name:      "test-drain-worker",
+		Type:      servers.ServerTypeWorker.String(),
+		Address:   "127.0.0.1:9202",
+	}
+	_, _, err := repo.UpsertServer(tc.Context(), worker)
+	require.NoError(err)
+	assert.False(worker.DrainRequested)
+
+	got, err := repo.RequestWorkerDrain(tc.Context(), "unknown-worker")
+	require.NoError(err)
+	assert.Nil(got)
+
+	got, err = repo.RequestWorkerDrain(tc.Context(), worker.PrivateId)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.True(got.DrainRequested)
+	assert.False(got.Draining)
+
+	// The request is passed back on the next status upsert
+	_, _, err = repo.UpsertServer(tc.Context(), worker)
+	require.NoError(err)
+	assert.True(worker.DrainRequested)
+
+	// And cleared once the worker reports it is draining
+	worker.Draining = true
+	_, _, err = repo.UpsertServer(tc.Context(), worker)
+	require.NoError(err)
+	assert.False(worker.DrainRequested)
+	got, err = repo.LookupServer(tc.Context(), worker.PrivateId, servers.ServerTypeWorker)
+	require.NoError(err)
+	require.NotNil(got)
+	assert.True(got.Draining)
+	assert.False(got.DrainRequested)
+}
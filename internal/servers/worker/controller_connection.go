@@ -8,7 +8,6 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"encoding/pem"
-	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -18,6 +17,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/vault/sdk/helper/base62"
 	"google.golang.org/grpc"
@@ -26,26 +26,9 @@ import (
 )
 
 func (w *Worker) startControllerConnections() error {
-	initialAddrs := make([]resolver.Address, 0, len(w.conf.RawConfig.Worker.Controllers))
-	for _, addr := range w.conf.RawConfig.Worker.Controllers {
-		switch {
-		case strings.HasPrefix(addr, "/"):
-			initialAddrs = append(initialAddrs, resolver.Address{Addr: addr})
-		default:
-			host, port, err := net.SplitHostPort(addr)
-			if err != nil && strings.Contains(err.Error(), "missing port in address") {
-				w.logger.Trace("missing port in controller address, using port 9201", "address", addr)
-				host, port, err = net.SplitHostPort(net.JoinHostPort(addr, "9201"))
-			}
-			if err != nil {
-				return fmt.Errorf("error parsing controller address: %w", err)
-			}
-			initialAddrs = append(initialAddrs, resolver.Address{Addr: net.JoinHostPort(host, port)})
-		}
-	}
-
-	if len(initialAddrs) == 0 {
-		return errors.New("no initial controller addresses found")
+	initialAddrs, err := controllerAddrs(w.settings().controllers, w.logger)
+	if err != nil {
+		return fmt.Errorf("error parsing initial controller addresses: %w", err)
 	}
 
 	w.Resolver().InitialState(resolver.State{
@@ -114,6 +97,7 @@ func (w *Worker) createClientConn(addr string) error {
 		grpc.WithContextDialer(w.controllerDialerFunc()),
 		grpc.WithInsecure(),
 		grpc.WithDefaultServiceConfig(defServiceConfig),
+		grpc.WithUnaryInterceptor(event.UnaryClientInterceptor()),
 		// Don't have the resolver reach out for a service config from the
 		// resolver, use the one specified as default
 		grpc.WithDisableServiceConfig(),
@@ -130,22 +114,36 @@ func (w *Worker) createClientConn(addr string) error {
 }
 
 func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error) {
-	var err error
 	info := &base.WorkerAuthInfo{
 		Name:        w.conf.RawConfig.Worker.Name,
-		Description: w.conf.RawConfig.Worker.Description,
+		Description: w.settings().description,
 	}
-	if info.ConnectionNonce, err = base62.Random(20); err != nil {
+	tlsConfig, err := w.encryptedAuthTLSConfig("v1workerauth", info, info)
+	if err != nil {
 		return nil, nil, err
 	}
+	return tlsConfig, info, nil
+}
+
+// encryptedAuthTLSConfig sets a connection nonce and a newly generated
+// self-signed certificate in info and returns a TLS config presenting that
+// certificate. payload, which must include info, is encrypted with the worker
+// auth KMS and carried in the ALPN protos of the config, prefixed with
+// protoPrefix. Only a peer able to decrypt payload can present the certificate
+// back, so both ends of the connection are authenticated.
+func (w Worker) encryptedAuthTLSConfig(protoPrefix string, info *base.WorkerAuthInfo, payload interface{}) (*tls.Config, error) {
+	var err error
+	if info.ConnectionNonce, err = base62.Random(20); err != nil {
+		return nil, err
+	}
 
 	pubKey, privKey, err := ed25519.GenerateKey(w.conf.SecureRandomReader)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	host, err := base62.Random(20)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	template := &x509.Certificate{
@@ -163,7 +161,7 @@ func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error)
 	}
 	certBytes, err := x509.CreateCertificate(w.conf.SecureRandomReader, template, template, pubKey, privKey)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	certPEMBlock := &pem.Block{
@@ -174,7 +172,7 @@ func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error)
 
 	marshaledKey, err := x509.MarshalPKCS8PrivateKey(privKey)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	keyPEMBlock := &pem.Block{
 		Type:  "PRIVATE KEY",
@@ -183,17 +181,17 @@ func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error)
 	info.KeyPEM = pem.EncodeToMemory(keyPEMBlock)
 
 	// Marshal and encrypt
-	marshaledInfo, err := json.Marshal(info)
+	marshaledInfo, err := json.Marshal(payload)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	encInfo, err := w.conf.WorkerAuthKms.Encrypt(context.Background(), marshaledInfo, nil)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	marshaledEncInfo, err := proto.Marshal(encInfo)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	b64alpn := base64.RawStdEncoding.EncodeToString(marshaledEncInfo)
 	var nextProtos []string
@@ -203,13 +201,13 @@ func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error)
 		if end > len(b64alpn) {
 			end = len(b64alpn)
 		}
-		nextProtos = append(nextProtos, fmt.Sprintf("v1workerauth-%02d-%s", count, b64alpn[i:end]))
+		nextProtos = append(nextProtos, fmt.Sprintf("%s-%02d-%s", protoPrefix, count, b64alpn[i:end]))
 		count++
 	}
 
 	cert, err := x509.ParseCertificate(certBytes)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 
 	// Build local tls config
@@ -217,7 +215,7 @@ func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error)
 	rootCAs.AddCert(cert)
 	tlsCert, err := tls.X509KeyPair(info.CertPEM, info.KeyPEM)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	tlsConfig := &tls.Config{
 		ServerName:   host,
@@ -227,5 +225,5 @@ func (w Worker) workerAuthTLSConfig() (*tls.Config, *base.WorkerAuthInfo, error)
 		MinVersion:   tls.VersionTLS13,
 	}
 
-	return tlsConfig, info, nil
+	return tlsConfig, nil
 }
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"time"
+)
+
+const (
+	// DefaultDrainTimeout is how long a draining worker waits for existing
+	// connections to finish if no drain timeout is configured
+	DefaultDrainTimeout = 10 * time.Minute
+
+	// drainCheckInterval is how often a draining worker checks for open
+	// connections
+	drainCheckInterval = time.Second
+)
+
+// Drain puts the worker into drain mode. A draining worker reports itself as
+// such to the controller, which stops handing it out for new sessions, and
+// refuses connections for sessions it does not already know about. The
+// returned channel is closed once all open connections have finished or
+// timeout, or DefaultDrainTimeout if it is not positive, has passed, at which
+// point the worker can be shut down. Calling Drain again has no effect other
+// than returning the same channel.
+func (w *Worker) Drain(timeout time.Duration) <-chan struct{} {
+	if timeout <= 0 {
+		timeout = DefaultDrainTimeout
+	}
+	if w.draining.CAS(false, true) {
+		w.logger.Info("draining worker", "timeout", timeout.String())
+		go func() {
+			defer close(w.drained)
+			deadline := time.NewTimer(timeout)
+			defer deadline.Stop()
+			ticker := time.NewTicker(drainCheckInterval)
+			defer ticker.Stop()
+			for {
+				open := w.openConnections()
+				if open == 0 {
+					w.logger.Info("worker drained")
+					return
+				}
+				select {
+				case <-deadline.C:
+					w.logger.Warn("drain timeout reached with connections still open", "open_connections", open)
+					return
+				case <-ticker.C:
+				}
+			}
+		}()
+	}
+	return w.drained
+}
+
+// Draining returns whether the worker is in drain mode.
+func (w *Worker) Draining() bool {
+	return w.draining.Load()
+}
+
+// Drained returns a channel that is closed once a draining worker has no open
+// connections left or its drain timeout has passed.
+func (w *Worker) Drained() <-chan struct{} {
+	return w.drained
+}
+
+// openConnections returns the number of session connections not yet closed
+// plus the number of connections being forwarded for other workers.
+func (w *Worker) openConnections() int64 {
+	open := w.activeHops.Load()
+	w.sessionInfoMap.Range(func(_, value interface{}) bool {
+		si := value.(*sessionInfo)
+		si.RLock()
+		for _, ci := range si.connInfoMap {
+			if ci.closeTime.IsZero() {
+				open++
+			}
+		}
+		si.RUnlock()
+		return true
+	})
+	return open
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"crypto/tls"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorker_Drain(t *testing.T) {
+	newWorker := func() (*Worker, *connInfo) {
+		w := &Worker{
+			logger:         hclog.New(&hclog.LoggerOptions{Name: "drain", Level: hclog.Trace}),
+			sessionInfoMap: new(sync.Map),
+			drained:        make(chan struct{}),
+		}
+		ci := &connInfo{id: "sc_1234567890"}
+		w.sessionInfoMap.Store("s_1234567890", &sessionInfo{
+			id:          "s_1234567890",
+			connInfoMap: map[string]*connInfo{ci.id: ci},
+		})
+		return w, ci
+	}
+
+	t.Run("connections-close", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w, ci := newWorker()
+		drained := w.Drain(time.Minute)
+		assert.True(w.Draining())
+		assert.Equal(drained, w.Drain(time.Minute))
+
+		_, err := w.getSessionTls(&tls.ClientHelloInfo{ServerName: "s_0987654321"})
+		require.Error(err)
+		assert.Contains(err.Error(), "draining")
+		_, err = w.validateHopTls(&tls.ClientHelloInfo{})
+		require.Error(err)
+		assert.Contains(err.Error(), "draining")
+
+		select {
+		case <-drained:
+			t.Fatal("drained with an open connection")
+		case <-time.After(2 * drainCheckInterval):
+		}
+
+		siRaw, _ := w.sessionInfoMap.Load("s_1234567890")
+		si := siRaw.(*sessionInfo)
+		si.Lock()
+		ci.closeTime = time.Now()
+		si.Unlock()
+
+		select {
+		case <-drained:
+		case <-time.After(3 * drainCheckInterval):
+			t.Fatal("not drained after connections closed")
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		w, _ := newWorker()
+		select {
+		case <-w.Drain(100 * time.Millisecond):
+		case <-time.After(3 * drainCheckInterval):
+			t.Fatal("not drained after timeout")
+		}
+	})
+}
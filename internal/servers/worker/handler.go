@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/hashicorp/boundary/globals"
+	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 	"github.com/hashicorp/boundary/internal/proxy"
 	"github.com/hashicorp/shared-secure-libs/configutil"
@@ -59,6 +60,19 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 
 		w.logger.Trace("received TLS connection")
 
+		// Events of this connection, here and in the controllers it is
+		// reported to, share a request ID
+		ctx := r.Context()
+		if w.conf.Eventer.Enabled() {
+			requestId, err := event.NewRequestId()
+			if err != nil {
+				w.logger.Error("unable to generate request id", "error", err)
+				wr.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			ctx = event.NewContext(ctx, w.conf.Eventer, requestId)
+		}
+
 		siRaw, valid := w.sessionInfoMap.Load(sessionId)
 		if !valid {
 			w.logger.Error("session not found in info map", "session_id", sessionId)
@@ -91,7 +105,7 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 
 		w.logger.Trace("websocket upgrade done")
 
-		connCtx, connCancel := context.WithDeadline(r.Context(), expiration.AsTime())
+		connCtx, connCancel := context.WithDeadline(ctx, expiration.AsTime())
 		defer connCancel()
 
 		var handshake proxy.ClientHandshake
@@ -121,7 +135,7 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 				return
 			}
 			w.logger.Trace("activating session")
-			sessStatus, err = w.activateSession(r.Context(), sessionId, handshake.GetTofuToken(), version)
+			sessStatus, err = w.activateSession(ctx, sessionId, handshake.GetTofuToken(), version)
 			if err != nil {
 				w.logger.Error("unable to validate session", "error", err)
 				conn.Close(websocket.StatusInternalError, "unable to activate session")
@@ -131,7 +145,11 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 
 		var ci *connInfo
 		var connsLeft int32
-		ci, connsLeft, err = w.authorizeConnection(r.Context(), sessionId)
+		ci, connsLeft, err = w.authorizeConnection(ctx, sessionId)
+		if err != nil && controllerUnreachable(err) && w.offlineAllowed() {
+			w.logger.Warn("controller unreachable, authorizing connection locally", "session_id", sessionId, "error", err)
+			ci, connsLeft, err = w.authorizeConnectionOffline(si)
+		}
 		if err != nil {
 			w.logger.Error("unable to authorize connection", "error", err)
 			conn.Close(websocket.StatusInternalError, "unable to authorize connection")
@@ -140,7 +158,7 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 
 		defer func() {
 			connectionId := ci.id
-			if err := w.closeConnections(r.Context(), map[string]string{
+			if err := w.closeConnections(ctx, map[string]string{
 				connectionId: si.id,
 			}); err != nil {
 				w.logger.Error("error marking connection closed", "error", err, "connection_id", connectionId)
@@ -152,6 +170,9 @@ func (w *Worker) handleProxy() http.HandlerFunc {
 		ci.connCancel = connCancel
 		si.connInfoMap[ci.id] = ci
 		si.status = sessStatus
+		// Keep the cached count current for authorizing connections without
+		// a controller
+		si.lookupSessionResponse.ConnectionsLeft = connsLeft
 		connectionLimit := si.lookupSessionResponse.GetConnectionLimit()
 		si.Unlock()
 
@@ -0,0 +1,314 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/patrickmn/go-cache"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// workerHopProto is the ALPN proto negotiated for connections forwarded
+	// from another worker. The encrypted hop information is carried in
+	// additional protos prefixed with it.
+	workerHopProto = "v1workerhop"
+
+	// hopInfoExpiration is how long the information of a forwarded connection
+	// is kept between the TLS handshake and the connection being accepted
+	hopInfoExpiration = 2 * time.Minute
+
+	// hopDialTimeout bounds how long dialing the next hop, and every hop after
+	// it, may take
+	hopDialTimeout = 30 * time.Second
+
+	// defaultProxyPort is used for worker addresses without a port
+	defaultProxyPort = "9202"
+)
+
+// hopInfo is sent, encrypted with the worker auth KMS, by a worker forwarding
+// a connection to the next worker in a session's worker path.
+type hopInfo struct {
+	*base.WorkerAuthInfo
+	SessionId  string    `json:"session_id"`
+	Endpoint   string    `json:"endpoint"`
+	Path       []string  `json:"path"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// hopResult is returned by a worker receiving a forwarded connection once it
+// has reached the endpoint, or failed to.
+type hopResult struct {
+	EndpointAddress string `json:"endpoint_address,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// dialEndpoint connects to endpoint, given as host:port, directly if path is
+// empty and otherwise by forwarding the connection through the workers in
+// path. It returns the connection and the address of the endpoint.
+func (w *Worker) dialEndpoint(ctx context.Context, sessionId, endpoint string, path []string, expiration time.Time) (net.Conn, *net.TCPAddr, error) {
+	dialer := &net.Dialer{}
+	if len(path) == 0 {
+		conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error dialing endpoint: %w", err)
+		}
+		return conn, conn.RemoteAddr().(*net.TCPAddr), nil
+	}
+
+	info := &hopInfo{
+		WorkerAuthInfo: &base.WorkerAuthInfo{
+			Name:        w.conf.RawConfig.Worker.Name,
+			Description: w.settings().description,
+		},
+		SessionId:  sessionId,
+		Endpoint:   endpoint,
+		Path:       path[1:],
+		Expiration: expiration,
+	}
+	tlsConf, err := w.encryptedAuthTLSConfig(workerHopProto, info.WorkerAuthInfo, info)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating tls config for worker hop: %w", err)
+	}
+	tlsConf.NextProtos = append([]string{workerHopProto}, tlsConf.NextProtos...)
+
+	addr := path[0]
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, defaultProxyPort)
+	}
+	nonTlsConn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error dialing worker %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := nonTlsConn.SetDeadline(deadline); err != nil {
+			nonTlsConn.Close()
+			return nil, nil, fmt.Errorf("error setting deadline for worker %s: %w", addr, err)
+		}
+	}
+	tlsConn := tls.Client(nonTlsConn, tlsConf)
+	if _, err := tlsConn.Write([]byte(info.ConnectionNonce)); err != nil {
+		tlsConn.Close()
+		return nil, nil, fmt.Errorf("unable to write connection nonce to worker %s: %w", addr, err)
+	}
+	result, err := readHopResult(tlsConn)
+	if err != nil {
+		tlsConn.Close()
+		return nil, nil, fmt.Errorf("error reading result from worker %s: %w", addr, err)
+	}
+	if result.Error != "" {
+		tlsConn.Close()
+		return nil, nil, fmt.Errorf("worker %s: %s", addr, result.Error)
+	}
+	endpointAddr, err := net.ResolveTCPAddr("tcp", result.EndpointAddress)
+	if err != nil {
+		tlsConn.Close()
+		return nil, nil, fmt.Errorf("error parsing endpoint address from worker %s: %w", addr, err)
+	}
+	if err := nonTlsConn.SetDeadline(time.Time{}); err != nil {
+		tlsConn.Close()
+		return nil, nil, fmt.Errorf("error clearing deadline for worker %s: %w", addr, err)
+	}
+	return tlsConn, endpointAddr, nil
+}
+
+// validateHopTls returns the TLS configuration for a connection forwarded
+// from another worker. The hop information must decrypt with the worker auth
+// KMS and the other worker must present the certificate contained in it.
+func (w *Worker) validateHopTls(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+	if w.draining.Load() {
+		return nil, errors.New("worker is draining")
+	}
+	var encString string
+	for _, p := range hello.SupportedProtos {
+		if strings.HasPrefix(p, workerHopProto+"-") {
+			// Strip that and the number
+			encString += strings.TrimPrefix(p, workerHopProto+"-")[3:]
+		}
+	}
+	if encString == "" {
+		return nil, errors.New("no worker hop information found")
+	}
+	marshaledEncInfo, err := base64.RawStdEncoding.DecodeString(encString)
+	if err != nil {
+		return nil, err
+	}
+	encInfo := new(wrapping.EncryptedBlobInfo)
+	if err := proto.Unmarshal(marshaledEncInfo, encInfo); err != nil {
+		return nil, err
+	}
+	marshaledInfo, err := w.conf.WorkerAuthKms.Decrypt(context.Background(), encInfo, nil)
+	if err != nil {
+		return nil, err
+	}
+	info := new(hopInfo)
+	if err := json.Unmarshal(marshaledInfo, info); err != nil {
+		return nil, err
+	}
+	if info.WorkerAuthInfo == nil || info.ConnectionNonce == "" {
+		return nil, errors.New("incomplete worker hop information")
+	}
+
+	rootCAs := x509.NewCertPool()
+	if ok := rootCAs.AppendCertsFromPEM(info.CertPEM); !ok {
+		return nil, errors.New("unable to add ca cert to cert pool")
+	}
+	tlsCert, err := tls.X509KeyPair(info.CertPEM, info.KeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{tlsCert},
+		ClientCAs:    rootCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		NextProtos:   []string{workerHopProto},
+		MinVersion:   tls.VersionTLS13,
+	}
+
+	w.hopInfoCache.Set(info.ConnectionNonce, info, cache.DefaultExpiration)
+	return tlsConfig, nil
+}
+
+// serveHops accepts connections forwarded from other workers on l until it is
+// closed.
+func (w *Worker) serveHops(l net.Listener) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			w.logger.Debug("worker hop listener closed", "error", err)
+			return
+		}
+		go w.handleHop(conn)
+	}
+}
+
+// handleHop forwards a connection from another worker on to the next worker
+// in its path or, at the end of the path, to the endpoint.
+func (w *Worker) handleHop(conn net.Conn) {
+	defer conn.Close()
+	w.activeHops.Inc()
+	defer w.activeHops.Dec()
+
+	if err := conn.SetReadDeadline(time.Now().Add(hopDialTimeout)); err != nil {
+		w.logger.Error("error setting deadline on forwarded connection", "error", err)
+		return
+	}
+	nonce := make([]byte, 20)
+	if _, err := io.ReadFull(conn, nonce); err != nil {
+		w.logger.Error("error reading connection nonce from worker", "error", err)
+		return
+	}
+	infoRaw, ok := w.hopInfoCache.Get(string(nonce))
+	if !ok {
+		w.logger.Error("unknown connection nonce from worker")
+		return
+	}
+	w.hopInfoCache.Delete(string(nonce))
+	info := infoRaw.(*hopInfo)
+	if tlsConn, ok := conn.(*tls.Conn); ok {
+		peerCerts := tlsConn.ConnectionState().PeerCertificates
+		if len(peerCerts) == 0 || !bytes.Equal(peerCerts[0].Raw, certBytes(info.CertPEM)) {
+			w.logger.Error("worker certificate does not match connection nonce", "worker", info.Name)
+			return
+		}
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		w.logger.Error("error clearing deadline on forwarded connection", "error", err)
+		return
+	}
+
+	w.logger.Trace("forwarding connection from worker", "worker", info.Name, "session_id", info.SessionId, "remaining_hops", len(info.Path))
+
+	connCtx, connCancel := context.WithDeadline(w.baseContext, info.Expiration)
+	defer connCancel()
+
+	dialCtx, dialCancel := context.WithTimeout(connCtx, hopDialTimeout)
+	remoteConn, endpointAddr, err := w.dialEndpoint(dialCtx, info.SessionId, info.Endpoint, info.Path, info.Expiration)
+	dialCancel()
+	if err != nil {
+		w.logger.Error("error forwarding connection", "error", err, "session_id", info.SessionId)
+		if err := writeHopResult(conn, &hopResult{Error: err.Error()}); err != nil {
+			w.logger.Error("error writing result to worker", "error", err, "session_id", info.SessionId)
+		}
+		return
+	}
+	defer remoteConn.Close()
+	if err := writeHopResult(conn, &hopResult{EndpointAddress: endpointAddr.String()}); err != nil {
+		w.logger.Error("error writing result to worker", "error", err, "session_id", info.SessionId)
+		return
+	}
+
+	// Closing either side, or the session expiring, ends the connection
+	go func() {
+		<-connCtx.Done()
+		conn.Close()
+		remoteConn.Close()
+	}()
+
+	connWg := new(sync.WaitGroup)
+	connWg.Add(2)
+	go func() {
+		defer connWg.Done()
+		defer connCancel()
+		_, err := io.Copy(conn, remoteConn)
+		w.logger.Debug("copy from endpoint to worker done", "error", err)
+	}()
+	go func() {
+		defer connWg.Done()
+		defer connCancel()
+		_, err := io.Copy(remoteConn, conn)
+		w.logger.Debug("copy from worker to endpoint done", "error", err)
+	}()
+	connWg.Wait()
+}
+
+// certBytes returns the DER bytes of the certificate in certPEM.
+func certBytes(certPEM []byte) []byte {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil
+	}
+	return block.Bytes
+}
+
+func writeHopResult(conn net.Conn, result *hopResult) error {
+	marshaled, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, 2, 2+len(marshaled))
+	binary.BigEndian.PutUint16(buf, uint16(len(marshaled)))
+	_, err = conn.Write(append(buf, marshaled...))
+	return err
+}
+
+func readHopResult(conn net.Conn) (*hopResult, error) {
+	var size uint16
+	if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+		return nil, err
+	}
+	marshaled := make([]byte, size)
+	if _, err := io.ReadFull(conn, marshaled); err != nil {
+		return nil, err
+	}
+	result := new(hopResult)
+	if err := json.Unmarshal(marshaled, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,167 @@
+package worker
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/libs/alpnmux"
+	"github.com/hashicorp/go-hclog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/patrickmn/go-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHopWorker returns a worker serving forwarded connections and the
+// address it listens on.
+func testHopWorker(t *testing.T, name string, wrapper wrapping.Wrapper) (*Worker, string) {
+	t.Helper()
+	logger := hclog.New(&hclog.LoggerOptions{Name: name, Level: hclog.Trace})
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &Worker{
+		conf: &Config{
+			Server: &base.Server{
+				Logger:             logger,
+				WorkerAuthKms:      wrapper,
+				SecureRandomReader: rand.Reader,
+			},
+			RawConfig: &config.Config{
+				Worker: &config.Worker{Name: name},
+			},
+		},
+		logger:       logger,
+		baseContext:  ctx,
+		baseCancel:   cancel,
+		hopInfoCache: cache.New(hopInfoExpiration, hopInfoExpiration),
+		reloadable:   new(atomic.Value),
+	}
+	settings, err := newReloadableSettings(w.conf.RawConfig.Worker)
+	require.NoError(t, err)
+	w.reloadable.Store(settings)
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	mux := alpnmux.New(ln, logger)
+	hopL, err := mux.RegisterProto(workerHopProto, &tls.Config{
+		GetConfigForClient: w.validateHopTls,
+	})
+	require.NoError(t, err)
+	go w.serveHops(hopL)
+	t.Cleanup(func() {
+		cancel()
+		mux.Close()
+	})
+	return w, ln.Addr().String()
+}
+
+// testEchoEndpoint returns the address of a TCP server echoing what it reads.
+func testEchoEndpoint(t *testing.T) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+	return ln.Addr().String()
+}
+
+func TestWorker_dialEndpoint(t *testing.T) {
+	wrapper := db.TestWrapper(t)
+	edge, _ := testHopWorker(t, "edge", wrapper)
+	_, jumpAddr := testHopWorker(t, "jump", wrapper)
+	_, prodAddr := testHopWorker(t, "prod", wrapper)
+	endpoint := testEchoEndpoint(t)
+	expiration := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name string
+		path []string
+	}{
+		{
+			name: "direct",
+		},
+		{
+			name: "one-hop",
+			path: []string{jumpAddr},
+		},
+		{
+			name: "two-hops",
+			path: []string{jumpAddr, prodAddr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			ctx, cancel := context.WithTimeout(context.Background(), hopDialTimeout)
+			defer cancel()
+			conn, endpointAddr, err := edge.dialEndpoint(ctx, "s_1234567890", endpoint, tt.path, expiration)
+			require.NoError(err)
+			defer conn.Close()
+			assert.Equal(endpoint, endpointAddr.String())
+
+			_, err = conn.Write([]byte("hello"))
+			require.NoError(err)
+			got := make([]byte, 5)
+			_, err = io.ReadFull(conn, got)
+			require.NoError(err)
+			assert.Equal("hello", string(got))
+		})
+	}
+
+	t.Run("unreachable-endpoint", func(t *testing.T) {
+		assert := assert.New(t)
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		closed := ln.Addr().String()
+		ln.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), hopDialTimeout)
+		defer cancel()
+		_, _, err = edge.dialEndpoint(ctx, "s_1234567890", closed, []string{jumpAddr, prodAddr}, expiration)
+		assert.Error(err)
+	})
+
+	t.Run("different-worker-auth-kms", func(t *testing.T) {
+		assert := assert.New(t)
+		other, _ := testHopWorker(t, "other", db.TestWrapper(t))
+		ctx, cancel := context.WithTimeout(context.Background(), hopDialTimeout)
+		defer cancel()
+		_, _, err := other.dialEndpoint(ctx, "s_1234567890", endpoint, []string{jumpAddr}, expiration)
+		assert.Error(err)
+	})
+}
+
+func TestWorkerTags(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	tags, err := workerTags(map[string][]string{
+		"region":  {"us-east-1"},
+		"network": {"10.20.0.0/16", "10.10.0.0/16"},
+	})
+	require.NoError(err)
+	var got []string
+	for _, tag := range tags {
+		got = append(got, tag.Key+"="+tag.Value)
+	}
+	assert.Equal([]string{"network=10.10.0.0/16", "network=10.20.0.0/16", "region=us-east-1"}, got)
+
+	_, err = workerTags(map[string][]string{"network": {"10.10.0.0"}})
+	assert.Error(err)
+}
@@ -21,8 +21,9 @@ func (w *Worker) startListeners() error {
 	for _, ln := range w.conf.Listeners {
 		for _, purpose := range ln.Config.Purpose {
 			switch purpose {
-			case "api", "cluster":
-				// We may have this in dev mode; ignore
+			case "api", "cluster", "ops":
+				// We may have this in dev mode, or served by the server
+				// command; ignore
 				continue
 
 			case "proxy":
@@ -75,8 +76,19 @@ func (w *Worker) startListeners() error {
 				return errors.New("could not get tls listener")
 			}
 
+			// Connections forwarded from other workers negotiate their own
+			// proto and are handled outside of the HTTP server
+			ln.Mux.UnregisterProto(workerHopProto)
+			hopL, err := ln.Mux.RegisterProto(workerHopProto, &tls.Config{
+				GetConfigForClient: w.validateHopTls,
+			})
+			if err != nil {
+				return fmt.Errorf("error getting worker hop listener: %w", err)
+			}
+
 			servers = append(servers, func() {
 				go server.Serve(l)
+				go w.serveHops(hopL)
 			})
 		}
 	}
@@ -109,6 +121,9 @@ func (w *Worker) stopListeners() error {
 	var retErr *multierror.Error
 	if !w.conf.RawConfig.DevController {
 		for _, ln := range w.conf.Listeners {
+			if ln.IsOps() {
+				continue
+			}
 			if err := ln.Mux.Close(); err != nil {
 				if _, ok := err.(*os.PathError); ok && ln.Config.Type == "unix" {
 					// The rmListener probably tried to remove the file but it
@@ -0,0 +1,31 @@
+package worker
+
+import (
+	"github.com/armon/go-metrics"
+)
+
+var (
+	activeSessionsKey    = []string{"worker", "sessions", "active"}
+	activeConnectionsKey = []string{"worker", "connections", "active"}
+	proxyBytesKey        = []string{"worker", "proxy", "bytes"}
+)
+
+// recordActivity reports the number of active sessions and connections
+// handled by the worker.
+func (w *Worker) recordActivity(sessions, connections int) {
+	labels := []metrics.Label{{Name: "worker", Value: w.conf.RawConfig.Worker.Name}}
+	metrics.SetGaugeWithLabels(activeSessionsKey, float32(sessions), labels)
+	metrics.SetGaugeWithLabels(activeConnectionsKey, float32(connections), labels)
+}
+
+// recordProxyBytes counts bytes proxied in the given direction, "up" from
+// clients to endpoints or "down" from endpoints to clients.
+func (w *Worker) recordProxyBytes(direction string, n int64) {
+	if n <= 0 {
+		return
+	}
+	metrics.IncrCounterWithLabels(proxyBytesKey, float32(n), []metrics.Label{
+		{Name: "worker", Value: w.conf.RawConfig.Worker.Name},
+		{Name: "direction", Value: direction},
+	})
+}
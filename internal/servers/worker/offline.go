@@ -0,0 +1,262 @@
+package worker
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/boundary/internal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxPendingTransitions bounds the number of connection state changes kept
+// while no controller is reachable. Once reached, no further connections are
+// authorized locally.
+const maxPendingTransitions = 10000
+
+// transitionQueue holds, in order, the connection state changes the worker
+// made on its own while it could not reach a controller. They are sent with
+// every status request until a controller has applied them.
+type transitionQueue struct {
+	sync.Mutex
+	transitions []*pbs.ConnectionTransition
+}
+
+func (q *transitionQueue) push(t *pbs.ConnectionTransition) {
+	q.Lock()
+	defer q.Unlock()
+	q.transitions = append(q.transitions, t)
+}
+
+func (q *transitionQueue) len() int {
+	q.Lock()
+	defer q.Unlock()
+	return len(q.transitions)
+}
+
+// snapshot returns the currently queued transitions.
+func (q *transitionQueue) snapshot() []*pbs.ConnectionTransition {
+	q.Lock()
+	defer q.Unlock()
+	return append([]*pbs.ConnectionTransition(nil), q.transitions...)
+}
+
+// remove drops the first n queued transitions once they have been handled by
+// a controller.
+func (q *transitionQueue) remove(n int) {
+	q.Lock()
+	defer q.Unlock()
+	if n > len(q.transitions) {
+		n = len(q.transitions)
+	}
+	q.transitions = q.transitions[n:]
+}
+
+// controllerUnreachable returns whether err, returned from a call to a
+// controller, means the controller or its database could not be reached
+// rather than that the controller refused the request.
+func controllerUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		// No controller connection at all
+		return true
+	}
+	switch grpcErr.GRPCStatus().Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown:
+		return true
+	}
+	return false
+}
+
+// offlineEnabled returns whether the worker is configured to keep working on
+// its own while no controller is reachable.
+func (w *Worker) offlineEnabled() bool {
+	return w.conf.RawConfig.Worker.ControllerOutageGracePeriodDuration > 0
+}
+
+// offlineAllowed returns whether the worker may authorize connections on its
+// own right now: it is configured to, its last successful status report was
+// within the grace period and there is room to queue the changes.
+func (w *Worker) offlineAllowed() bool {
+	if !w.offlineEnabled() {
+		return false
+	}
+	lastStatus := w.LastStatusSuccess()
+	if lastStatus == nil || time.Since(lastStatus.StatusTime) > w.conf.RawConfig.Worker.ControllerOutageGracePeriodDuration {
+		return false
+	}
+	return w.pendingTransitions.len() < maxPendingTransitions
+}
+
+// offlineSessionTls returns the TLS configuration of a session the worker
+// already handles, to accept further connections for it while no controller
+// is reachable.
+func (w *Worker) offlineSessionTls(sessionId string) (*tls.Config, error) {
+	siRaw, ok := w.sessionInfoMap.Load(sessionId)
+	if !ok {
+		return nil, errors.New("session not known to worker")
+	}
+	si := siRaw.(*sessionInfo)
+	si.RLock()
+	defer si.RUnlock()
+	if err := offlineAuthorizable(si); err != nil {
+		return nil, err
+	}
+	return si.sessionTls, nil
+}
+
+// offlineAuthorizable returns an error if a new connection for si cannot be
+// authorized without a controller. si must be locked.
+func offlineAuthorizable(si *sessionInfo) error {
+	resp := si.lookupSessionResponse
+	switch {
+	case si.status != pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE || resp.GetTofuToken() == "":
+		return errors.New("session is not active")
+	case resp.GetExpiration().AsTime().Before(time.Now()):
+		return errors.New("session is expired")
+	case resp.GetConnectionLimit() != -1 && resp.GetConnectionsLeft() <= 0:
+		return errors.New("session connection limit reached")
+	}
+	return nil
+}
+
+// authorizeConnectionOffline authorizes a connection for si against its
+// cached connection limit and expiration, queueing the authorization for a
+// controller to apply once it is reachable again.
+func (w *Worker) authorizeConnectionOffline(si *sessionInfo) (*connInfo, int32, error) {
+	connectionId, err := db.NewPublicId(session.ConnectionPrefix)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error generating connection id: %w", err)
+	}
+
+	si.Lock()
+	defer si.Unlock()
+	if err := offlineAuthorizable(si); err != nil {
+		return nil, 0, err
+	}
+	resp := si.lookupSessionResponse
+	if resp.GetConnectionLimit() != -1 {
+		resp.ConnectionsLeft--
+	}
+	w.pendingTransitions.push(&pbs.ConnectionTransition{
+		SessionId:    si.id,
+		ConnectionId: connectionId,
+		Status:       pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED,
+		Time:         timestamppb.Now(),
+	})
+	w.logger.Info("authorized connection without a controller", "session_id", si.id, "connection_id", connectionId)
+	return &connInfo{
+		id:     connectionId,
+		status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED,
+		queued: true,
+	}, resp.GetConnectionsLeft(), nil
+}
+
+// queueConnected queues marking the connection as connected for a controller
+// to apply once it is reachable again.
+func (w *Worker) queueConnected(si *sessionInfo, req *pbs.ConnectConnectionRequest) pbs.CONNECTIONSTATUS {
+	si.Lock()
+	defer si.Unlock()
+	if ci, ok := si.connInfoMap[req.GetConnectionId()]; ok {
+		ci.queued = true
+	}
+	w.pendingTransitions.push(&pbs.ConnectionTransition{
+		SessionId:          si.id,
+		ConnectionId:       req.GetConnectionId(),
+		Status:             pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED,
+		Time:               timestamppb.Now(),
+		ClientTcpAddress:   req.GetClientTcpAddress(),
+		ClientTcpPort:      req.GetClientTcpPort(),
+		EndpointTcpAddress: req.GetEndpointTcpAddress(),
+		EndpointTcpPort:    req.GetEndpointTcpPort(),
+	})
+	return pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED
+}
+
+// queueCloses queues closing the connections in closeMap, a map of connection
+// IDs to session IDs, for a controller to apply once it is reachable again.
+// Unless all is set only connections that already have queued changes are
+// queued, as their changes must be applied in order. It returns the
+// connections that were not queued.
+func (w *Worker) queueCloses(closeMap map[string]string, all bool) map[string]string {
+	remaining := make(map[string]string, len(closeMap))
+	for connectionId, sessionId := range closeMap {
+		siRaw, ok := w.sessionInfoMap.Load(sessionId)
+		if !ok {
+			remaining[connectionId] = sessionId
+			continue
+		}
+		si := siRaw.(*sessionInfo)
+		si.Lock()
+		ci, ok := si.connInfoMap[connectionId]
+		switch {
+		case !ok, !all && !ci.queued:
+			remaining[connectionId] = sessionId
+		case ci.closeQueued:
+			// Already queued
+		default:
+			ci.queued = true
+			ci.closeQueued = true
+			w.pendingTransitions.push(&pbs.ConnectionTransition{
+				SessionId:    sessionId,
+				ConnectionId: connectionId,
+				Status:       pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED,
+				Time:         timestamppb.Now(),
+				ClosedReason: session.UnknownReason.String(),
+			})
+		}
+		si.Unlock()
+	}
+	return remaining
+}
+
+// handleTransitionResults updates the connections of the transitions sent in
+// a status request with the results returned by the controller and removes
+// the handled transitions from the queue. Connections whose changes were
+// rejected are closed.
+func (w *Worker) handleTransitionResults(sent []*pbs.ConnectionTransition, results []*pbs.ConnectionTransitionResult) {
+	n := len(results)
+	if n > len(sent) {
+		n = len(sent)
+	}
+	for i, result := range results[:n] {
+		t := sent[i]
+		siRaw, ok := w.sessionInfoMap.Load(t.GetSessionId())
+		if !ok {
+			continue
+		}
+		si := siRaw.(*sessionInfo)
+		si.Lock()
+		ci, ok := si.connInfoMap[t.GetConnectionId()]
+		switch {
+		case !ok:
+		case result.GetRejected():
+			w.logger.Warn("controller rejected connection change made without a controller, closing connection",
+				"session_id", t.GetSessionId(),
+				"connection_id", t.GetConnectionId(),
+				"status", t.GetStatus().String(),
+				"error", result.GetError())
+			if ci.connCancel != nil {
+				ci.connCancel()
+			}
+			ci.status = pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED
+			if ci.closeTime.IsZero() {
+				ci.closeTime = time.Now()
+			}
+		case t.GetStatus() == pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED:
+			ci.status = pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED
+			ci.closeTime = time.Now()
+		}
+		si.Unlock()
+	}
+	w.pendingTransitions.remove(n)
+}
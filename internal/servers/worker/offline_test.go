@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestControllerUnreachable(t *testing.T) {
+	assert := assert.New(t)
+	assert.False(controllerUnreachable(nil))
+	assert.True(controllerUnreachable(errors.New("could not get a controller client")))
+	assert.True(controllerUnreachable(status.Error(codes.Unavailable, "connection refused")))
+	assert.True(controllerUnreachable(fmt.Errorf("error authorizing connection: %w", status.Error(codes.Internal, "db down"))))
+	assert.False(controllerUnreachable(status.Error(codes.PermissionDenied, "connection limit reached")))
+}
+
+func TestWorker_authorizeConnectionOffline(t *testing.T) {
+	newWorker := func(gracePeriod time.Duration, lastStatus time.Time) *Worker {
+		w := &Worker{
+			conf: &Config{
+				RawConfig: &config.Config{
+					Worker: &config.Worker{ControllerOutageGracePeriodDuration: gracePeriod},
+				},
+			},
+			logger:             hclog.New(&hclog.LoggerOptions{Name: "offline", Level: hclog.Trace}),
+			lastStatusSuccess:  new(atomic.Value),
+			sessionInfoMap:     new(sync.Map),
+			pendingTransitions: new(transitionQueue),
+		}
+		w.lastStatusSuccess.Store(&LastStatusInformation{StatusTime: lastStatus})
+		return w
+	}
+	newSession := func(w *Worker, status pbs.SESSIONSTATUS, limit, left int32, expiration time.Time) *sessionInfo {
+		si := &sessionInfo{
+			id:     "s_1234567890",
+			status: status,
+			lookupSessionResponse: &pbs.LookupSessionResponse{
+				TofuToken:       "tofu_1234567890123456789",
+				Expiration:      timestamppb.New(expiration),
+				ConnectionLimit: limit,
+				ConnectionsLeft: left,
+			},
+			connInfoMap: make(map[string]*connInfo),
+		}
+		w.sessionInfoMap.Store(si.id, si)
+		return si
+	}
+	active := pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE
+	inAnHour := time.Now().Add(time.Hour)
+
+	t.Run("allowed", func(t *testing.T) {
+		assert := assert.New(t)
+		assert.False(newWorker(0, time.Now()).offlineAllowed())
+		assert.False(newWorker(time.Minute, time.Now().Add(-2*time.Minute)).offlineAllowed())
+		assert.True(newWorker(time.Minute, time.Now()).offlineAllowed())
+	})
+
+	t.Run("connection-limit", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := newWorker(time.Minute, time.Now())
+		si := newSession(w, active, 2, 1, inAnHour)
+
+		_, err := w.offlineSessionTls(si.id)
+		require.NoError(err)
+		ci, left, err := w.authorizeConnectionOffline(si)
+		require.NoError(err)
+		assert.True(ci.queued)
+		assert.Equal(int32(0), left)
+		assert.Equal(pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED, ci.status)
+
+		_, _, err = w.authorizeConnectionOffline(si)
+		assert.Error(err)
+		_, err = w.offlineSessionTls(si.id)
+		assert.Error(err)
+
+		transitions := w.pendingTransitions.snapshot()
+		require.Len(transitions, 1)
+		assert.Equal(ci.id, transitions[0].ConnectionId)
+		assert.Equal(pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED, transitions[0].Status)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		assert := assert.New(t)
+		w := newWorker(time.Minute, time.Now())
+		si := newSession(w, active, -1, -1, inAnHour)
+		for i := 0; i < 3; i++ {
+			_, left, err := w.authorizeConnectionOffline(si)
+			assert.NoError(err)
+			assert.Equal(int32(-1), left)
+		}
+	})
+
+	t.Run("not-authorizable", func(t *testing.T) {
+		assert := assert.New(t)
+		w := newWorker(time.Minute, time.Now())
+		_, _, err := w.authorizeConnectionOffline(newSession(w, pbs.SESSIONSTATUS_SESSIONSTATUS_CANCELING, -1, -1, inAnHour))
+		assert.Error(err)
+		_, _, err = w.authorizeConnectionOffline(newSession(w, active, -1, -1, time.Now().Add(-time.Minute)))
+		assert.Error(err)
+		assert.Equal(0, w.pendingTransitions.len())
+	})
+
+	t.Run("reconcile", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		w := newWorker(time.Minute, time.Now())
+		si := newSession(w, active, -1, -1, inAnHour)
+		accepted, _, err := w.authorizeConnectionOffline(si)
+		require.NoError(err)
+		rejected, _, err := w.authorizeConnectionOffline(si)
+		require.NoError(err)
+		var rejectedCanceled bool
+		rejected.connCancel = func() { rejectedCanceled = true }
+		si.connInfoMap[accepted.id] = accepted
+		si.connInfoMap[rejected.id] = rejected
+		// Closes of connections with queued changes are queued behind them
+		remaining := w.queueCloses(map[string]string{accepted.id: si.id, "sc_other": si.id}, false)
+		assert.Equal(map[string]string{"sc_other": si.id}, remaining)
+
+		sent := w.pendingTransitions.snapshot()
+		require.Len(sent, 3)
+		w.handleTransitionResults(sent, []*pbs.ConnectionTransitionResult{
+			{ConnectionId: accepted.id, Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED},
+			{ConnectionId: rejected.id, Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_AUTHORIZED, Rejected: true},
+		})
+		assert.True(rejectedCanceled)
+		assert.False(rejected.closeTime.IsZero())
+		assert.True(accepted.closeTime.IsZero())
+		assert.Equal(1, w.pendingTransitions.len())
+
+		sent = w.pendingTransitions.snapshot()
+		w.handleTransitionResults(sent, []*pbs.ConnectionTransitionResult{
+			{ConnectionId: accepted.id, Status: pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CLOSED},
+		})
+		assert.False(accepted.closeTime.IsZero())
+		assert.Equal(0, w.pendingTransitions.len())
+	})
+}
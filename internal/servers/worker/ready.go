@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// readyStatusAge is how recent the last successful status report to a
+// controller must be for the worker to be ready. It matches the time after
+// which controllers stop handing out workers that have not reported.
+const readyStatusAge = 15 * time.Second
+
+// registerReadinessChecks registers the components of the worker reported by
+// the /ready endpoint of ops listeners.
+func (w *Worker) registerReadinessChecks() {
+	w.conf.AddReadinessCheck("worker", func(context.Context) error {
+		switch {
+		case !w.started.Load():
+			return errors.New("not started")
+		case w.Draining():
+			return errors.New("draining")
+		}
+		return nil
+	})
+	w.conf.AddReadinessCheck("worker_kms", func(context.Context) error {
+		if w.conf.WorkerAuthKms == nil {
+			return errors.New("worker-auth kms not loaded")
+		}
+		return nil
+	})
+	w.conf.AddReadinessCheck("controller_status", func(context.Context) error {
+		last := w.LastStatusSuccess()
+		switch {
+		case last == nil:
+			return errors.New("no successful status report to a controller")
+		case time.Since(last.StatusTime) > readyStatusAge:
+			return fmt.Errorf("last successful status report to a controller at %s", last.StatusTime.UTC().Format(time.RFC3339))
+		}
+		return nil
+	})
+}
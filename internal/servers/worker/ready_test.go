@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/cmd/base"
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorker_ReadinessChecks(t *testing.T) {
+	b := base.NewServer(nil)
+	w := &Worker{
+		conf:              &Config{Server: b},
+		logger:            hclog.NewNullLogger(),
+		sessionInfoMap:    new(sync.Map),
+		drained:           make(chan struct{}),
+		lastStatusSuccess: new(atomic.Value),
+	}
+	w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
+	w.registerReadinessChecks()
+	h := b.OpsHandler()
+
+	// ready returns the errors of the components that are not ready
+	ready := func(t *testing.T) map[string]string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ready", nil))
+		var body struct {
+			Components map[string]struct {
+				Error string `json:"error"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		ret := make(map[string]string)
+		for name, c := range body.Components {
+			if c.Error != "" {
+				ret[name] = c.Error
+			}
+		}
+		if len(ret) == 0 {
+			assert.Equal(t, http.StatusOK, rec.Code)
+		} else {
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		}
+		return ret
+	}
+
+	assert.Equal(t, map[string]string{
+		"worker":            "not started",
+		"worker_kms":        "worker-auth kms not loaded",
+		"controller_status": "no successful status report to a controller",
+	}, ready(t))
+
+	w.started.Store(true)
+	b.WorkerAuthKms = aead.NewWrapper(nil)
+	w.lastStatusSuccess.Store(&LastStatusInformation{StatusTime: time.Now().Add(-time.Minute)})
+	got := ready(t)
+	assert.Len(t, got, 1)
+	assert.Contains(t, got["controller_status"], "last successful status report to a controller at")
+
+	w.lastStatusSuccess.Store(&LastStatusInformation{StatusTime: time.Now()})
+	assert.Empty(t, ready(t))
+
+	w.Drain(time.Minute)
+	assert.Equal(t, map[string]string{"worker": "draining"}, ready(t))
+}
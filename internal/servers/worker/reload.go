@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/boundary/sdk/strutil"
+	"github.com/hashicorp/go-hclog"
+	"google.golang.org/grpc/resolver"
+)
+
+// reloadableSettings are the parts of the configuration which can be changed
+// while the worker runs. They are replaced as a whole by Reload, so a
+// snapshot is always consistent.
+type reloadableSettings struct {
+	description string
+	publicAddr  string
+	controllers []string
+	tags        []*servers.ServerTag
+}
+
+func newReloadableSettings(conf *config.Worker) (*reloadableSettings, error) {
+	tags, err := workerTags(conf.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing worker tags: %w", err)
+	}
+	return &reloadableSettings{
+		description: conf.Description,
+		publicAddr:  conf.PublicAddr,
+		controllers: conf.Controllers,
+		tags:        tags,
+	}, nil
+}
+
+func (w *Worker) settings() *reloadableSettings {
+	return w.reloadable.Load().(*reloadableSettings)
+}
+
+// Reload applies the settings of conf which can be changed while the worker
+// runs: its description, public address and tags, which are reported to the
+// controllers with the next status request, and its controllers. If the
+// controllers change, the worker connects to the new addresses right away;
+// like the initial addresses, they are replaced by the addresses the
+// controllers report once a status request succeeds.
+func (w *Worker) Reload(conf *config.Config) error {
+	if conf.Worker == nil {
+		return fmt.Errorf("no worker block in configuration")
+	}
+	s, err := newReloadableSettings(conf.Worker)
+	if err != nil {
+		return err
+	}
+	if w.started.Load() && !strutil.EquivalentSlices(s.controllers, w.settings().controllers) {
+		addrs, err := controllerAddrs(s.controllers, w.logger)
+		if err != nil {
+			return err
+		}
+		w.Resolver().UpdateState(resolver.State{Addresses: addrs})
+	}
+	w.reloadable.Store(s)
+	return nil
+}
+
+// controllerAddrs parses the configured controller addresses, adding the
+// default cluster port to those without one.
+func controllerAddrs(controllers []string, logger hclog.Logger) ([]resolver.Address, error) {
+	addrs := make([]resolver.Address, 0, len(controllers))
+	for _, addr := range controllers {
+		switch {
+		case strings.HasPrefix(addr, "/"):
+			addrs = append(addrs, resolver.Address{Addr: addr})
+		default:
+			host, port, err := net.SplitHostPort(addr)
+			if err != nil && strings.Contains(err.Error(), "missing port in address") {
+				logger.Trace("missing port in controller address, using port 9201", "address", addr)
+				host, port, err = net.SplitHostPort(net.JoinHostPort(addr, "9201"))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error parsing controller address: %w", err)
+			}
+			addrs = append(addrs, resolver.Address{Addr: net.JoinHostPort(host, port)})
+		}
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no controller addresses found")
+	}
+	return addrs, nil
+}
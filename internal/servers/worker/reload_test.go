@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/servers"
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/resolver"
+)
+
+func TestWorker_Reload(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	w := &Worker{
+		logger:     hclog.NewNullLogger(),
+		reloadable: new(atomic.Value),
+	}
+	settings, err := newReloadableSettings(&config.Worker{
+		Description: "first",
+		Controllers: []string{"10.0.0.1"},
+	})
+	require.NoError(err)
+	w.reloadable.Store(settings)
+
+	require.NoError(w.Reload(&config.Config{Worker: &config.Worker{
+		Description: "second",
+		PublicAddr:  "example.com:9202",
+		Controllers: []string{"10.0.0.2"},
+		Tags:        map[string][]string{"type": {"prod", "dev"}},
+	}}))
+	s := w.settings()
+	assert.Equal("second", s.description)
+	assert.Equal("example.com:9202", s.publicAddr)
+	assert.Equal([]string{"10.0.0.2"}, s.controllers)
+	assert.Equal([]*servers.ServerTag{{Key: "type", Value: "dev"}, {Key: "type", Value: "prod"}}, s.tags)
+
+	// Invalid settings leave the current ones in place
+	err = w.Reload(&config.Config{Worker: &config.Worker{
+		Description: "third",
+		Tags:        map[string][]string{servers.WorkerNetworkTag: {"not-a-cidr"}},
+	}})
+	require.Error(err)
+	assert.Equal("second", w.settings().description)
+}
+
+func TestControllerAddrs(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	addrs, err := controllerAddrs([]string{"10.0.0.1", "10.0.0.2:9300", "/var/run/boundary.sock"}, hclog.NewNullLogger())
+	require.NoError(err)
+	assert.Equal([]resolver.Address{
+		{Addr: "10.0.0.1:9201"},
+		{Addr: "10.0.0.2:9300"},
+		{Addr: "/var/run/boundary.sock"},
+	}, addrs)
+
+	_, err = controllerAddrs(nil, hclog.NewNullLogger())
+	assert.Error(err)
+}
@@ -25,6 +25,12 @@ type connInfo struct {
 	connCancel context.CancelFunc
 	status     pbs.CONNECTIONSTATUS
 	closeTime  time.Time
+
+	// queued is set once a change to the connection has been queued while no
+	// controller was reachable; all further changes are then queued too so
+	// they are applied in order
+	queued      bool
+	closeQueued bool
 }
 
 type sessionInfo struct {
@@ -47,6 +53,15 @@ func (w *Worker) getSessionTls(hello *tls.ClientHelloInfo) (*tls.Config, error)
 		return nil, fmt.Errorf("could not find session ID in SNI")
 	}
 
+	// A draining worker only serves further connections of sessions it is
+	// already handling
+	if w.draining.Load() {
+		if _, ok := w.sessionInfoMap.Load(sessionId); !ok {
+			w.logger.Trace("refusing new session while draining", "session_id", sessionId)
+			return nil, errors.New("worker is draining")
+		}
+	}
+
 	rawConn := w.controllerSessionConn.Load()
 	if rawConn == nil {
 		w.logger.Trace("could not get a controller client", "session_id", sessionId)
@@ -68,8 +83,17 @@ func (w *Worker) getSessionTls(hello *tls.ClientHelloInfo) (*tls.Config, error)
 	w.logger.Trace("looking up session", "session_id", sessionId)
 	resp, err := conn.LookupSession(timeoutContext, &pbs.LookupSessionRequest{
 		SessionId: sessionId,
+		WorkerId:  w.conf.RawConfig.Worker.Name,
 	})
 	if err != nil {
+		if controllerUnreachable(err) && w.offlineAllowed() {
+			tlsConf, offlineErr := w.offlineSessionTls(sessionId)
+			if offlineErr == nil {
+				w.logger.Warn("controller unreachable, using cached session information", "session_id", sessionId, "error", err)
+				return tlsConf, nil
+			}
+			w.logger.Trace("cannot use cached session information", "session_id", sessionId, "error", offlineErr)
+		}
 		return nil, fmt.Errorf("error validating session: %w", err)
 	}
 
@@ -230,6 +254,12 @@ func (w *Worker) closeConnection(ctx context.Context, req *pbs.CloseConnectionRe
 func (w *Worker) closeConnections(ctx context.Context, closeMap map[string]string) error {
 	w.logger.Trace("marking connections as closed", "session_and_connection_ids", fmt.Sprintf("%#v", closeMap))
 
+	// Connections with queued changes must be closed through the queue too
+	closeMap = w.queueCloses(closeMap, false)
+	if len(closeMap) == 0 {
+		return nil
+	}
+
 	closeData := make([]*pbs.CloseConnectionRequestData, 0, len(closeMap))
 	for connId := range closeMap {
 		closeData = append(closeData, &pbs.CloseConnectionRequestData{
@@ -243,6 +273,11 @@ func (w *Worker) closeConnections(ctx context.Context, closeMap map[string]strin
 
 	connStatus, err := w.closeConnection(ctx, closeInfo)
 	if err != nil {
+		if controllerUnreachable(err) && w.offlineEnabled() {
+			w.logger.Warn("controller unreachable, queueing connection closes", "error", err)
+			w.queueCloses(closeMap, true)
+			return nil
+		}
 		return err
 	}
 	closedIds := make([]string, 0, len(connStatus.GetCloseResponseData()))
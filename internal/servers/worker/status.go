@@ -48,6 +48,7 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 				// First send info as-is. We'll perform cleanup duties after we
 				// get cancel/job change info back.
 				var activeJobs []*pbs.JobStatus
+				var activeSessions, activeConnections int
 				w.sessionInfoMap.Range(func(key, value interface{}) bool {
 					var jobInfo pbs.SessionJobInfo
 					sessionId := key.(string)
@@ -60,8 +61,14 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 							ConnectionId: k,
 							Status:       v.status,
 						})
+						if v.status == pbs.CONNECTIONSTATUS_CONNECTIONSTATUS_CONNECTED {
+							activeConnections++
+						}
 					}
 					si.RUnlock()
+					if status == pbs.SESSIONSTATUS_SESSIONSTATUS_ACTIVE {
+						activeSessions++
+					}
 					jobInfo.SessionId = sessionId
 					activeJobs = append(activeJobs, &pbs.JobStatus{
 						Job: &pbs.Job{
@@ -77,15 +84,21 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 					})
 					return true
 				})
+				w.recordActivity(activeSessions, activeConnections)
+				transitions := w.pendingTransitions.snapshot()
+				settings := w.settings()
 				client := w.controllerStatusConn.Load().(pbs.ServerCoordinationServiceClient)
 				result, err := client.Status(cancelCtx, &pbs.StatusRequest{
-					Jobs: activeJobs,
+					Jobs:                  activeJobs,
+					ConnectionTransitions: transitions,
 					Worker: &servers.Server{
 						PrivateId:   w.conf.RawConfig.Worker.Name,
 						Name:        w.conf.RawConfig.Worker.Name,
 						Type:        resource.Worker.String(),
-						Description: w.conf.RawConfig.Worker.Description,
-						Address:     w.conf.RawConfig.Worker.PublicAddr,
+						Description: settings.description,
+						Address:     settings.publicAddr,
+						Tags:        settings.tags,
+						Draining:    w.draining.Load(),
 					},
 				})
 				if err != nil {
@@ -107,6 +120,15 @@ func (w *Worker) startStatusTicking(cancelCtx context.Context) {
 					}
 					w.lastStatusSuccess.Store(&LastStatusInformation{StatusResponse: result, StatusTime: time.Now()})
 
+					if len(transitions) > 0 {
+						w.handleTransitionResults(transitions, result.GetConnectionTransitionResults())
+					}
+
+					if result.GetDrain() && !w.draining.Load() {
+						w.logger.Info("controller requested drain")
+						w.Drain(w.conf.RawConfig.Worker.DrainTimeoutDuration)
+					}
+
 					for _, request := range result.GetJobsRequests() {
 						switch request.GetRequestType() {
 						case pbs.CHANGETYPE_CHANGETYPE_UPDATE_STATE:
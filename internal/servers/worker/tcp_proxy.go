@@ -9,12 +9,15 @@ import (
 
 	"nhooyr.io/websocket"
 
+	"github.com/hashicorp/boundary/internal/event"
 	pbs "github.com/hashicorp/boundary/internal/gen/controller/servers/services"
 )
 
 func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAddr, conn *websocket.Conn, si *sessionInfo, connectionId, endpoint string) {
 	si.RLock()
 	sessionId := si.lookupSessionResponse.GetAuthorization().GetSessionId()
+	workerPath := si.lookupSessionResponse.GetWorkerPath()
+	expiration := si.lookupSessionResponse.GetExpiration().AsTime()
 	si.RUnlock()
 
 	sessionUrl, err := url.Parse(endpoint)
@@ -28,16 +31,18 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 		conn.Close(websocket.StatusInternalError, "invalid scheme for type")
 		return
 	}
-	remoteConn, err := net.Dial("tcp", sessionUrl.Host)
+	// If the endpoint can't be reached from here the connection is forwarded
+	// along the path of workers given by the controller
+	dialCtx, dialCancel := context.WithTimeout(connCtx, hopDialTimeout)
+	remoteConn, endpointAddr, err := w.dialEndpoint(dialCtx, sessionId, sessionUrl.Host, workerPath, expiration)
+	dialCancel()
 	if err != nil {
-		w.logger.Error("error dialing endpoint", "error", err, "endpoint", endpoint)
+		w.logger.Error("error dialing endpoint", "error", err, "endpoint", endpoint, "worker_path", workerPath)
 		conn.Close(websocket.StatusInternalError, "endpoint dialing failed")
 		return
 	}
-	// Assert this for better Go 1.11 splice support
-	tcpRemoteConn := remoteConn.(*net.TCPConn)
+	defer remoteConn.Close()
 
-	endpointAddr := tcpRemoteConn.RemoteAddr().(*net.TCPAddr)
 	connectionInfo := &pbs.ConnectConnectionRequest{
 		ConnectionId:       connectionId,
 		ClientTcpAddress:   clientAddr.IP.String(),
@@ -47,7 +52,21 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 		Type:               "tcp",
 	}
 
-	connStatus, err := w.connectConnection(connCtx, connectionInfo)
+	si.RLock()
+	queued := si.connInfoMap[connectionId].queued
+	si.RUnlock()
+
+	var connStatus pbs.CONNECTIONSTATUS
+	if !queued {
+		connStatus, err = w.connectConnection(connCtx, connectionInfo)
+		if err != nil && controllerUnreachable(err) && w.offlineAllowed() {
+			w.logger.Warn("controller unreachable, queueing connection as connected", "connection_id", connectionId, "error", err)
+			queued = true
+		}
+	}
+	if queued {
+		connStatus, err = w.queueConnected(si, connectionInfo), nil
+	}
 	if err != nil {
 		w.logger.Error("error marking connection as connected", "error", err)
 		conn.Close(websocket.StatusInternalError, "failed to mark connection as connected")
@@ -56,6 +75,14 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 	si.Lock()
 	si.connInfoMap[connectionId].status = connStatus
 	si.Unlock()
+	event.Emit(connCtx, event.ConnectionOpenType, map[string]interface{}{
+		"session_id":           sessionId,
+		"connection_id":        connectionId,
+		"client_tcp_address":   connectionInfo.GetClientTcpAddress(),
+		"client_tcp_port":      connectionInfo.GetClientTcpPort(),
+		"endpoint_tcp_address": connectionInfo.GetEndpointTcpAddress(),
+		"endpoint_tcp_port":    connectionInfo.GetEndpointTcpPort(),
+	})
 
 	// Get a wrapped net.Conn so we can use io.Copy
 	netConn := websocket.NetConn(connCtx, conn, websocket.MessageBinary)
@@ -64,14 +91,19 @@ func (w *Worker) handleTcpProxyV1(connCtx context.Context, clientAddr *net.TCPAd
 	connWg.Add(2)
 	go func() {
 		defer connWg.Done()
-		_, err := io.Copy(netConn, tcpRemoteConn)
+		n, err := io.Copy(netConn, remoteConn)
+		w.recordProxyBytes("down", n)
 		w.logger.Debug("copy from client to endpoint done", "error", err)
 	}()
 	go func() {
 		defer connWg.Done()
-		_, err := io.Copy(tcpRemoteConn, netConn)
+		n, err := io.Copy(remoteConn, netConn)
+		w.recordProxyBytes("up", n)
 		w.logger.Debug("copy from endpoint to client done", "error", err)
 	}()
 	connWg.Wait()
-
+	event.Emit(connCtx, event.ConnectionCloseType, map[string]interface{}{
+		"session_id":    sessionId,
+		"connection_id": connectionId,
+	})
 }
@@ -4,13 +4,17 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"net"
+	"sort"
 	"sync"
 	"sync/atomic"
 
 	"github.com/hashicorp/boundary/internal/cmd/config"
+	"github.com/hashicorp/boundary/internal/servers"
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/vault/sdk/helper/base62"
 	"github.com/hashicorp/vault/sdk/helper/mlock"
+	"github.com/patrickmn/go-cache"
 	ua "go.uber.org/atomic"
 	"google.golang.org/grpc/resolver"
 	"google.golang.org/grpc/resolver/manual"
@@ -32,6 +36,22 @@ type Worker struct {
 
 	controllerSessionConn *atomic.Value
 	sessionInfoMap        *sync.Map
+
+	// reloadable holds the *reloadableSettings currently in effect
+	reloadable *atomic.Value
+
+	// hopInfoCache holds the information of forwarded connections from other
+	// workers between the TLS handshake and the connection being accepted
+	hopInfoCache *cache.Cache
+	// activeHops counts connections being forwarded for other workers
+	activeHops ua.Int64
+
+	draining ua.Bool
+	drained  chan struct{}
+
+	// pendingTransitions holds connection changes made while no controller
+	// was reachable
+	pendingTransitions *transitionQueue
 }
 
 func New(conf *Config) (*Worker, error) {
@@ -44,12 +64,17 @@ func New(conf *Config) (*Worker, error) {
 		controllerResolverCleanup: new(atomic.Value),
 		controllerSessionConn:     new(atomic.Value),
 		sessionInfoMap:            new(sync.Map),
+		hopInfoCache:              cache.New(hopInfoExpiration, hopInfoExpiration),
+		drained:                   make(chan struct{}),
+		pendingTransitions:        new(transitionQueue),
+		reloadable:                new(atomic.Value),
 	}
 
 	w.lastStatusSuccess.Store((*LastStatusInformation)(nil))
 	w.started.Store(false)
 	w.controllerResolver.Store((*manual.Resolver)(nil))
 	w.controllerResolverCleanup.Store(func() {})
+	w.registerReadinessChecks()
 
 	if conf.SecureRandomReader == nil {
 		conf.SecureRandomReader = rand.Reader
@@ -65,6 +90,12 @@ func New(conf *Config) (*Worker, error) {
 		}
 	}
 
+	settings, err := newReloadableSettings(conf.RawConfig.Worker)
+	if err != nil {
+		return nil, err
+	}
+	w.reloadable.Store(settings)
+
 	if !conf.RawConfig.DisableMlock {
 		// Ensure our memory usage is locked into physical RAM
 		if err := mlock.LockMemory(); err != nil {
@@ -84,6 +115,29 @@ func New(conf *Config) (*Worker, error) {
 	return w, nil
 }
 
+// workerTags validates the configured tags and returns them sorted by key and
+// value.
+func workerTags(raw map[string][]string) ([]*servers.ServerTag, error) {
+	var tags []*servers.ServerTag
+	for k, vals := range raw {
+		for _, v := range vals {
+			if k == servers.WorkerNetworkTag {
+				if _, _, err := net.ParseCIDR(v); err != nil {
+					return nil, fmt.Errorf("invalid network %q: %w", v, err)
+				}
+			}
+			tags = append(tags, &servers.ServerTag{Key: k, Value: v})
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Key != tags[j].Key {
+			return tags[i].Key < tags[j].Key
+		}
+		return tags[i].Value < tags[j].Value
+	})
+	return tags, nil
+}
+
 func (w *Worker) Start() error {
 	if w.started.Load() {
 		w.logger.Info("already started, skipping")
@@ -0,0 +1,104 @@
+package servers
+
+import (
+	"errors"
+	"net"
+	"sort"
+)
+
+// WorkerNetworkTag is the tag key workers use to report the networks, in CIDR
+// notation, they can reach directly. A worker reporting no networks is
+// assumed to be able to reach any address.
+const WorkerNetworkTag = "network"
+
+// ErrNoWorkerPath is returned by WorkerPath when no chain of workers can reach
+// an endpoint.
+var ErrNoWorkerPath = errors.New("no path of workers reaches the endpoint")
+
+// WorkerPath returns the workers, in order, a connection received by the
+// worker named from must be forwarded through to reach the host of endpoint,
+// which is given as host:port. The last worker in the returned path dials the
+// endpoint. A nil path is returned if from can reach the endpoint itself or
+// is not one of workers.
+//
+// A worker can forward a connection to another worker if it can reach the
+// other worker's address. The shortest path is returned; ties are broken by
+// worker name.
+func WorkerPath(workers []*Server, from, endpoint string) ([]*Server, error) {
+	byName := make(map[string]*Server, len(workers))
+	sorted := make([]*Server, 0, len(workers))
+	for _, w := range workers {
+		byName[w.Name] = w
+		sorted = append(sorted, w)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	start, ok := byName[from]
+	if !ok {
+		return nil, nil
+	}
+	endpointHost := hostOf(endpoint)
+	if canReach(start, endpointHost) {
+		return nil, nil
+	}
+
+	// Breadth first search from the starting worker
+	prev := map[string]*Server{start.Name: nil}
+	queue := []*Server{start}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, next := range sorted {
+			if _, seen := prev[next.Name]; seen || next.Address == "" {
+				continue
+			}
+			if !canReach(cur, hostOf(next.Address)) {
+				continue
+			}
+			prev[next.Name] = cur
+			if canReach(next, endpointHost) {
+				var path []*Server
+				for s := next; s != start; s = prev[s.Name] {
+					path = append([]*Server{s}, path...)
+				}
+				return path, nil
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil, ErrNoWorkerPath
+}
+
+// canReach reports whether worker can reach host directly.
+func canReach(worker *Server, host string) bool {
+	var networks []*net.IPNet
+	for _, t := range worker.GetTags() {
+		if t.GetKey() != WorkerNetworkTag {
+			continue
+		}
+		if _, n, err := net.ParseCIDR(t.GetValue()); err == nil {
+			networks = append(networks, n)
+		}
+	}
+	if len(networks) == 0 {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, n := range networks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostOf returns the host of addr, which may or may not include a port.
+func hostOf(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -0,0 +1,99 @@
+package servers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerPath(t *testing.T) {
+	t.Parallel()
+	worker := func(name, address string, networks ...string) *Server {
+		s := &Server{Name: name, Address: address}
+		for _, n := range networks {
+			s.Tags = append(s.Tags, &ServerTag{Key: WorkerNetworkTag, Value: n})
+		}
+		return s
+	}
+	edge := worker("edge", "203.0.113.10:9202", "203.0.113.0/24", "192.168.1.0/24")
+	jump := worker("jump", "192.168.1.10:9202", "192.168.1.0/24", "10.10.0.0/16")
+	prod := worker("prod", "10.10.0.5:9202", "10.10.0.0/16", "10.20.0.0/16")
+	open := worker("open", "198.51.100.7:9202")
+	isolated := worker("isolated", "172.16.0.1:9202", "172.16.0.0/24")
+
+	tests := []struct {
+		name     string
+		workers  []*Server
+		from     string
+		endpoint string
+		want     []string
+		wantErr  error
+	}{
+		{
+			name:     "direct",
+			workers:  []*Server{edge, jump, prod},
+			from:     "edge",
+			endpoint: "192.168.1.20:22",
+		},
+		{
+			name:     "untagged-worker-reaches-anything",
+			workers:  []*Server{open, jump},
+			from:     "open",
+			endpoint: "10.10.0.20:22",
+		},
+		{
+			name:     "unknown-worker",
+			workers:  []*Server{edge},
+			from:     "other",
+			endpoint: "10.10.0.20:22",
+		},
+		{
+			name:     "one-hop",
+			workers:  []*Server{edge, jump, prod},
+			from:     "edge",
+			endpoint: "10.10.0.20:22",
+			want:     []string{"jump"},
+		},
+		{
+			name:     "two-hops",
+			workers:  []*Server{prod, jump, edge},
+			from:     "edge",
+			endpoint: "10.20.0.20:5432",
+			want:     []string{"jump", "prod"},
+		},
+		{
+			name:     "hostname-endpoint",
+			workers:  []*Server{edge, jump, open},
+			from:     "edge",
+			endpoint: "db.internal:5432",
+			wantErr:  ErrNoWorkerPath,
+		},
+		{
+			name:     "no-path",
+			workers:  []*Server{edge, jump, prod, isolated},
+			from:     "isolated",
+			endpoint: "10.10.0.20:22",
+			wantErr:  ErrNoWorkerPath,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert, require := assert.New(t), require.New(t)
+			got, err := WorkerPath(tt.workers, tt.from, tt.endpoint)
+			if tt.wantErr != nil {
+				require.Error(err)
+				assert.Equal(tt.wantErr, err)
+				return
+			}
+			require.NoError(err)
+			var names []string
+			for _, s := range got {
+				names = append(names, s.Name)
+			}
+			assert.Equal(tt.want, names)
+		})
+	}
+}
@@ -26,6 +26,8 @@ type options struct {
 	withTestTofu       []byte
 	withListingConvert bool
 	withSessionIds     []string
+	withConnectionId   string
+	withAfterId        string
 }
 
 func getDefaultOptions() options {
@@ -84,8 +86,26 @@ func WithSessionIds(ids ...string) Option {
 	}
 }
 
+// WithConnectionId allows specifying the id of a connection being authorized,
+// such as one a worker authorized on its own while no controller was
+// reachable.
+func WithConnectionId(id string) Option {
+	return func(o *options) {
+		o.withConnectionId = id
+	}
+}
+
 func withListingConvert(withListingConvert bool) Option {
 	return func(o *options) {
 		o.withListingConvert = withListingConvert
 	}
 }
+
+// WithAfterId provides an option to list only the items whose id sorts after
+// id. Lists are ordered by id so it can be used to list items a page at a
+// time.
+func WithAfterId(id string) Option {
+	return func(o *options) {
+		o.withAfterId = id
+	}
+}
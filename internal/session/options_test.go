@@ -77,4 +77,18 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withSessionIds = []string{"s_1", "s_2", "s_3"}
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithConnectionId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithConnectionId("sc_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withConnectionId = "sc_1234567890"
+		assert.Equal(opts, testOpts)
+	})
+	t.Run("WithAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAfterId("s_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withAfterId = "s_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 }
@@ -145,13 +145,12 @@ from
 	session_connection_limit, session_connection_count;	
 `
 	sessionList = `
-select * 
+select *
 from
-	(select public_id from session %s) s,
+	(select public_id from session s %s order by public_id %s) s,
 	session_with_state ss
-where 
-	s.public_id = ss.public_id 
-	%s
+where
+	s.public_id = ss.public_id
 %s
 `
 
@@ -231,5 +230,23 @@ where
                	end_time is null
     )
 )
+`
+
+	rewrapSessionQuery = `
+update session
+   set tofu_token = $1,
+       key_id = $2
+ where public_id = $3
+   and key_id = $4;
+`
+
+	countActiveSessionsQuery = `
+select count(*)
+  from session
+ where scope_id = $1
+   and create_time >= $2
+   and create_time < $3
+   and termination_reason is null
+   and expiration_time > now();
 `
 )
@@ -186,6 +186,10 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		}
 		where = append(where, fmt.Sprintf("s.public_id in(%s)", strings.Join(idsInClause, ",")))
 	}
+	if opts.withAfterId != "" {
+		inClauseCnt += 1
+		where, args = append(where, fmt.Sprintf("s.public_id > $%d", inClauseCnt)), append(args, opts.withAfterId)
+	}
 
 	var limit string
 	switch {
@@ -197,16 +201,19 @@ func (r *Repository) ListSessions(ctx context.Context, opt ...Option) ([]*Sessio
 		limit = fmt.Sprintf("limit %d", opts.withLimit)
 	}
 
+	// Rows are grouped into sessions by public id so they must be ordered
+	// by it unless another order is requested.
+	order := "order by s.public_id"
 	if opts.withOrder != "" {
-		opts.withOrder = fmt.Sprintf("order by %s", opts.withOrder)
+		order = fmt.Sprintf("order by %s", opts.withOrder)
 	}
 
 	var whereClause string
 	if len(where) > 0 {
-		whereClause = " and " + strings.Join(where, " and")
+		whereClause = "where " + strings.Join(where, " and ")
 	}
 	q := sessionList
-	query := fmt.Sprintf(q, limit, whereClause, opts.withOrder)
+	query := fmt.Sprintf(q, whereClause, limit, order)
 
 	rows, err := r.reader.Query(ctx, query, args)
 	if err != nil {
@@ -365,20 +372,28 @@ func (r *Repository) TerminateCompletedSessions(ctx context.Context) (int, error
 // * number of connections already created is less than session.ConnectionLimit
 // If authorization is success, it creates/stores a new connection in the repo
 // and returns it, along with it's states.  If the authorization fails, it
-// an error of ErrInvalidStateForOperation.
-func (r *Repository) AuthorizeConnection(ctx context.Context, sessionId string) (*Connection, []*ConnectionState, *ConnectionAuthzSummary, error) {
+// an error of ErrInvalidStateForOperation. Supports the WithConnectionId option
+// to use an existing connection id rather than generating one.
+func (r *Repository) AuthorizeConnection(ctx context.Context, sessionId string, opt ...Option) (*Connection, []*ConnectionState, *ConnectionAuthzSummary, error) {
 	if sessionId == "" {
 		return nil, nil, nil, status.Errorf(codes.FailedPrecondition, "authorize connection: missing session id: %v", errors.ErrInvalidParameter)
 	}
-	connectionId, err := newConnectionId()
-	if err != nil {
-		return nil, nil, nil, status.Errorf(codes.Internal, "authorize connection: %v", err)
+	opts := getOpts(opt...)
+	connectionId := opts.withConnectionId
+	if connectionId == "" {
+		var err error
+		connectionId, err = newConnectionId()
+		if err != nil {
+			return nil, nil, nil, status.Errorf(codes.Internal, "authorize connection: %v", err)
+		}
+	} else if !strings.HasPrefix(connectionId, ConnectionPrefix+"_") {
+		return nil, nil, nil, status.Errorf(codes.InvalidArgument, "authorize connection: invalid connection id %q: %v", connectionId, errors.ErrInvalidParameter)
 	}
 
 	connection := AllocConnection()
 	connection.PublicId = connectionId
 	var connectionStates []*ConnectionState
-	_, err = r.writer.DoTx(
+	_, err := r.writer.DoTx(
 		ctx,
 		db.StdRetryCnt,
 		db.ExpBackoff{},
@@ -0,0 +1,65 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn(defaultSessionTableName, kms.KeyPurposeDatabase, sessionRewrapFn)
+	kms.RegisterIssuedCountFn("session certificates", kms.KeyPurposeSessions, sessionCertificateCountFn)
+}
+
+// sessionRewrapFn re-encrypts the TOFU tokens of sessions, which are
+// encrypted with the database key of their scope.
+func sessionRewrapFn(ctx context.Context, keyVersionIds []string, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, limit int) (int, error) {
+	var sessions []*Session
+	if err := r.SearchWhere(ctx, &sessions, "key_id in (?) and tofu_token is not null", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap sessions: %w", err)
+	}
+	var updated int
+	for _, s := range sessions {
+		oldKeyId := s.KeyId
+		if err := s.decrypt(ctx, wrapper); err != nil {
+			return updated, fmt.Errorf("rewrap sessions: %s: %w", s.PublicId, err)
+		}
+		if err := s.encrypt(ctx, wrapper); err != nil {
+			return updated, fmt.Errorf("rewrap sessions: %s: %w", s.PublicId, err)
+		}
+		rowsUpdated, err := w.Exec(ctx, rewrapSessionQuery, []interface{}{s.CtTofuToken, s.KeyId, s.PublicId, oldKeyId})
+		if err != nil {
+			return updated, fmt.Errorf("rewrap sessions: unable to update %s: %w", s.PublicId, err)
+		}
+		updated += rowsUpdated
+	}
+	return updated, nil
+}
+
+// sessionCertificateCountFn counts the sessions which were authorized in the
+// time window and are neither terminated nor expired. The certificates of the
+// sessions can't be re-issued, as their clients already hold them: the private
+// keys of the certificates keep being derived from the session key version
+// which was current when each session was authorized, see
+// DeriveCertificateKey.
+func sessionCertificateCountFn(ctx context.Context, r db.Reader, scopeId string, issuedAfter, issuedBefore time.Time) (int64, error) {
+	rows, err := r.Query(ctx, countActiveSessionsQuery, []interface{}{scopeId, issuedAfter, issuedBefore})
+	if err != nil {
+		return 0, fmt.Errorf("count session certificates: %w", err)
+	}
+	defer rows.Close()
+	var count int64
+	for rows.Next() {
+		if err := rows.Scan(&count); err != nil {
+			return 0, fmt.Errorf("count session certificates: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("count session certificates: %w", err)
+	}
+	return count, nil
+}
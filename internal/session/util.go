@@ -1,9 +1,12 @@
 package session
 
 import (
+	"bytes"
 	"crypto/ed25519"
 	"crypto/sha256"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"io"
 
 	wrapping "github.com/hashicorp/go-kms-wrapping"
@@ -35,3 +38,48 @@ func DeriveED25519Key(wrapper wrapping.Wrapper, userId, jobId string) (ed25519.P
 	}
 	return ed25519.GenerateKey(limitedReader)
 }
+
+// ErrCertificateKeyNotFound is returned by DeriveCertificateKey when none of
+// the session key versions it tried issued the certificate.
+var ErrCertificateKeyNotFound = errors.New("session certificate was not issued with any of the session key versions")
+
+// DeriveCertificateKey derives the private key of a session certificate. The
+// certificate was issued with the version of the scope's session key which was
+// current when the session was authorized: the current version of wrapper is
+// tried first, then the versions in keyVersionIds, which are only needed for
+// sessions authorized before the session key was rotated.
+func DeriveCertificateKey(wrapper wrapping.Wrapper, keyVersionIds []string, userId, jobId string, certificate []byte) (ed25519.PrivateKey, error) {
+	cert, err := x509.ParseCertificate(certificate)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing session certificate: %w", err)
+	}
+	pubKey, ok := cert.PublicKey.(ed25519.PublicKey)
+	if !ok {
+		return nil, errors.New("session certificate does not have an ed25519 public key")
+	}
+	derivedPubKey, privKey, err := DeriveED25519Key(wrapper, userId, jobId)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Equal(pubKey, derivedPubKey) {
+		return privKey, nil
+	}
+	multi, ok := wrapper.(*multiwrapper.MultiWrapper)
+	if !ok {
+		return nil, ErrCertificateKeyNotFound
+	}
+	for _, id := range keyVersionIds {
+		w := multi.WrapperForKeyID(id)
+		if w == nil {
+			continue
+		}
+		derivedPubKey, privKey, err := DeriveED25519Key(w, userId, jobId)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(pubKey, derivedPubKey) {
+			return privKey, nil
+		}
+	}
+	return nil, ErrCertificateKeyNotFound
+}
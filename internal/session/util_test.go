@@ -0,0 +1,67 @@
+package session
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/aead"
+	"github.com/hashicorp/go-kms-wrapping/wrappers/multiwrapper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSessionKeyVersion(t *testing.T, keyId string) wrapping.Wrapper {
+	t.Helper()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	w := aead.NewWrapper(nil)
+	_, err = w.SetConfig(map[string]string{"key_id": keyId})
+	require.NoError(t, err)
+	require.NoError(t, w.SetAESGCMKeyBytes(key))
+	return w
+}
+
+func TestDeriveCertificateKey(t *testing.T) {
+	oldVersion := testSessionKeyVersion(t, "kskv_old")
+	newVersion := testSessionKeyVersion(t, "kskv_new")
+	multi := multiwrapper.NewMultiWrapper(newVersion)
+	multi.AddWrapper(oldVersion)
+
+	const userId, jobId = "u_1234567890", "s_1234567890"
+	exp := time.Now().Add(time.Hour)
+	newPrivKey, newCertificate, err := newCert(newVersion, userId, jobId, exp)
+	require.NoError(t, err)
+	oldPrivKey, oldCertificate, err := newCert(oldVersion, userId, jobId, exp)
+	require.NoError(t, err)
+
+	t.Run("current-version", func(t *testing.T) {
+		privKey, err := DeriveCertificateKey(multi, nil, userId, jobId, newCertificate)
+		require.NoError(t, err)
+		assert.Equal(t, newPrivKey, privKey)
+	})
+	t.Run("older-version-not-tried", func(t *testing.T) {
+		_, err := DeriveCertificateKey(multi, nil, userId, jobId, oldCertificate)
+		assert.True(t, errors.Is(err, ErrCertificateKeyNotFound))
+	})
+	t.Run("older-version", func(t *testing.T) {
+		privKey, err := DeriveCertificateKey(multi, []string{"kskv_new", "kskv_old"}, userId, jobId, oldCertificate)
+		require.NoError(t, err)
+		assert.Equal(t, oldPrivKey, privKey)
+	})
+	t.Run("unknown-version", func(t *testing.T) {
+		_, err := DeriveCertificateKey(multi, []string{"kskv_unknown"}, userId, jobId, oldCertificate)
+		assert.True(t, errors.Is(err, ErrCertificateKeyNotFound))
+	})
+	t.Run("other-job", func(t *testing.T) {
+		_, err := DeriveCertificateKey(multi, []string{"kskv_new", "kskv_old"}, userId, "s_0987654321", oldCertificate)
+		assert.True(t, errors.Is(err, ErrCertificateKeyNotFound))
+	})
+	t.Run("invalid-certificate", func(t *testing.T) {
+		_, err := DeriveCertificateKey(multi, nil, userId, jobId, []byte("not a certificate"))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,75 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const warehouseDimensionsQuery = `
+select t.target_id, t.target_session_max_seconds, t.target_session_connection_limit, t.current_row_indicator,
+       s.scope_id, s.scope_name, s.parent_scope_type, s.current_row_indicator
+  from wh_session_accumulating_fact f
+  join wh_target_dimension t
+    on t.id = f.target_id
+  join wh_scope_dimension s
+    on s.id = f.project_id
+ where f.session_id = $1
+`
+
+func TestWarehouse_targetAndScopeDimensions(t *testing.T) {
+	t.Parallel()
+	assert, require := assert.New(t), require.New(t)
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	type dimensions struct {
+		targetId, targetCurrent, scopeId, scopeName, parentType, scopeCurrent string
+		maxSeconds, connectionLimit                                          int
+	}
+	lookup := func(sessionId string) dimensions {
+		t.Helper()
+		rows, err := rw.Query(ctx, warehouseDimensionsQuery, []interface{}{sessionId})
+		require.NoError(err)
+		defer rows.Close()
+		require.True(rows.Next())
+		var d dimensions
+		require.NoError(rows.Scan(&d.targetId, &d.maxSeconds, &d.connectionLimit, &d.targetCurrent,
+			&d.scopeId, &d.scopeName, &d.parentType, &d.scopeCurrent))
+		return d
+	}
+
+	composedOf := TestSessionParams(t, conn, wrapper, iamRepo)
+	s1 := TestSession(t, conn, wrapper, composedOf)
+	got := lookup(s1.PublicId)
+	assert.Equal(composedOf.TargetId, got.targetId)
+	assert.Equal(int(composedOf.ConnectionLimit), got.connectionLimit)
+	assert.Equal("Current", got.targetCurrent)
+	assert.Equal(composedOf.ScopeId, got.scopeId)
+	assert.Equal("org", got.parentType)
+	assert.Equal("Current", got.scopeCurrent)
+
+	// Renaming the project adds a new version of its dimension row, the
+	// first session keeps the previous one.
+	proj, err := iamRepo.LookupScope(ctx, composedOf.ScopeId)
+	require.NoError(err)
+	proj.Name = "renamed"
+	_, _, err = iamRepo.UpdateScope(ctx, proj, proj.Version, []string{"Name"})
+	require.NoError(err)
+	s2 := TestSession(t, conn, wrapper, composedOf)
+
+	got = lookup(s2.PublicId)
+	assert.Equal("renamed", got.scopeName)
+	assert.Equal("Current", got.scopeCurrent)
+	got = lookup(s1.PublicId)
+	assert.NotEqual("renamed", got.scopeName)
+	assert.Equal("Expired", got.scopeCurrent)
+	assert.Equal("Current", got.targetCurrent, "The target did not change")
+}
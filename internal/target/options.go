@@ -28,6 +28,7 @@ type options struct {
 	withSessionMaxSeconds      uint32
 	withSessionConnectionLimit int32
 	withPublicId               string
+	withAfterId                string
 }
 
 func getDefaultOptions() options {
@@ -130,3 +131,12 @@ func WithPublicId(id string) Option {
 		o.withPublicId = id
 	}
 }
+
+// WithAfterId provides an option to list only the items whose id sorts after
+// id. Lists are ordered by id so it can be used to list items a page at a
+// time.
+func WithAfterId(id string) Option {
+	return func(o *options) {
+		o.withAfterId = id
+	}
+}
@@ -97,4 +97,11 @@ func Test_GetOpts(t *testing.T) {
 		testOpts.withHostSets = []string{"alice", "bob"}
 		assert.Equal(opts, testOpts)
 	})
+	t.Run("WithAfterId", func(t *testing.T) {
+		assert := assert.New(t)
+		opts := getOpts(WithAfterId("ttcp_1234567890"))
+		testOpts := getDefaultOptions()
+		testOpts.withAfterId = "ttcp_1234567890"
+		assert.Equal(opts, testOpts)
+	})
 }
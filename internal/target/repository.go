@@ -162,6 +162,9 @@ func (r *Repository) ListTargets(ctx context.Context, opt ...Option) ([]Target,
 	if opts.withTargetType != nil {
 		where, args = append(where, "type = ?"), append(args, opts.withTargetType.String())
 	}
+	if opts.withAfterId != "" {
+		where, args = append(where, "public_id > ?"), append(args, opts.withAfterId)
+	}
 
 	var foundTargets []*targetView
 	err := r.list(ctx, &foundTargets, strings.Join(where, " and "), args, opt...)
@@ -191,7 +194,7 @@ func (r *Repository) list(ctx context.Context, resources interface{}, where stri
 		// non-zero signals an override of the default limit for the repo.
 		limit = opts.withLimit
 	}
-	dbOpts = append(dbOpts, db.WithLimit(limit))
+	dbOpts = append(dbOpts, db.WithLimit(limit), db.WithOrder("public_id"))
 	return r.reader.SearchWhere(ctx, resources, where, args, dbOpts...)
 }
 
@@ -75,6 +75,42 @@ func TestAction(t *testing.T) {
 			action: Deauthenticate,
 			want:   "deauthenticate",
 		},
+		{
+			action: ImportHosts,
+			want:   "import-hosts",
+		},
+		{
+			action: ExportHosts,
+			want:   "export-hosts",
+		},
+		{
+			action: Drain,
+			want:   "drain",
+		},
+		{
+			action: ListDeliveries,
+			want:   "list-deliveries",
+		},
+		{
+			action: RotateKeys,
+			want:   "rotate-keys",
+		},
+		{
+			action: RewrapStatus,
+			want:   "rewrap-status",
+		},
+		{
+			action: ListKeyVersions,
+			want:   "list-key-versions",
+		},
+		{
+			action: DestroyKeyVersion,
+			want:   "destroy-key-version",
+		},
+		{
+			action: ReadOplog,
+			want:   "read-oplog",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.want, func(t *testing.T) {
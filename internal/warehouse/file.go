@@ -0,0 +1,259 @@
+package warehouse
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// Format is the format of the exported files.
+type Format string
+
+const (
+	FormatParquet Format = "parquet"
+	FormatCsv     Format = "csv"
+)
+
+// manifestName is the name of the manifest file in the export directory.
+const manifestName = "manifest.json"
+
+// Manifest describes the files exported to an export directory. It is
+// written last by each export, so the files it lists are always complete.
+type Manifest struct {
+	// HighWaterMark is the high-water mark of the last export. The next
+	// export exports the rows written by transactions with an ID at or above
+	// it.
+	HighWaterMark uint64 `json:"high_water_mark"`
+	// Exports are the exports to the directory, oldest first.
+	Exports []*Export `json:"exports"`
+}
+
+// Export describes the files written by an export. It includes the rows
+// inserted or updated by the transactions with an ID from FromMark
+// (inclusive) to ToMark (exclusive). A row updated after it was exported is
+// exported again, so consumers should keep the row most recently exported
+// for each primary key.
+type Export struct {
+	CreateTime time.Time `json:"create_time"`
+	Format     Format    `json:"format"`
+	FromMark   uint64    `json:"from_mark"`
+	ToMark     uint64    `json:"to_mark"`
+	// Files are the exported files, one per table with exported rows.
+	Files []*File `json:"files"`
+}
+
+// File is an exported file of a table. Path is relative to the export
+// directory, with forward slashes.
+type File struct {
+	Table    string `json:"table"`
+	Path     string `json:"path"`
+	RowCount int    `json:"row_count"`
+}
+
+// column is a column of a warehouse table.
+type column struct {
+	name     string
+	dataType string
+}
+
+// selectExpr returns the expression selecting the column as text in the
+// representation the file format expects.
+func (c column) selectExpr(f Format) string {
+	n := `"` + c.name + `"`
+	switch {
+	case f == FormatParquet && strings.HasPrefix(c.dataType, "timestamp"):
+		// Milliseconds since the epoch. Infinite timestamps are exported as
+		// null.
+		return fmt.Sprintf("case when isfinite(%[1]s) then floor(extract(epoch from %[1]s) * 1000)::bigint::text end", n)
+	case f == FormatParquet && c.dataType == "date":
+		// Days since the epoch.
+		return fmt.Sprintf("case when isfinite(%[1]s) then (%[1]s - date '1970-01-01')::text end", n)
+	case f == FormatParquet && strings.HasPrefix(c.dataType, "time "):
+		// Milliseconds since midnight.
+		return fmt.Sprintf("floor(extract(epoch from %s::time) * 1000)::int::text", n)
+	case f == FormatCsv && c.dataType == "timestamp with time zone":
+		return fmt.Sprintf(`case when isfinite(%[1]s) then to_char(%[1]s at time zone 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"') else %[1]s::text end`, n)
+	}
+	return n + "::text"
+}
+
+// parquetTag returns the parquet-go tag of the column. All the columns of
+// the files are optional.
+func (c column) parquetTag() string {
+	var typ string
+	switch {
+	case c.dataType == "smallint" || c.dataType == "integer":
+		typ = "INT32"
+	case c.dataType == "bigint":
+		typ = "INT64"
+	case c.dataType == "boolean":
+		typ = "BOOLEAN"
+	case c.dataType == "real" || c.dataType == "double precision" || c.dataType == "numeric":
+		typ = "DOUBLE"
+	case strings.HasPrefix(c.dataType, "timestamp"):
+		typ = "TIMESTAMP_MILLIS"
+	case c.dataType == "date":
+		typ = "DATE"
+	case strings.HasPrefix(c.dataType, "time "):
+		typ = "TIME_MILLIS"
+	default:
+		typ = "UTF8"
+	}
+	return fmt.Sprintf("name=%s, type=%s", c.name, typ)
+}
+
+// tableFile is an exported file being written. It is written to a temporary
+// file which is renamed when it is closed.
+type tableFile struct {
+	path string
+	file *os.File
+	buf  *bufio.Writer
+	csv  *csv.Writer
+	pq   *writer.CSVWriter
+}
+
+func createTableFile(path string, f Format, columns []column) (*tableFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("unable to create export directory: %w", err)
+	}
+	file, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create export file: %w", err)
+	}
+	t := &tableFile{path: path, file: file}
+	switch f {
+	case FormatCsv:
+		t.buf = bufio.NewWriter(file)
+		t.csv = csv.NewWriter(t.buf)
+		header := make([]string, 0, len(columns))
+		for _, c := range columns {
+			header = append(header, c.name)
+		}
+		if err := t.csv.Write(header); err != nil {
+			t.abort()
+			return nil, fmt.Errorf("unable to write export file: %w", err)
+		}
+	default:
+		md := make([]string, 0, len(columns))
+		for _, c := range columns {
+			md = append(md, c.parquetTag())
+		}
+		t.pq, err = writer.NewCSVWriterFromWriter(md, file, 1)
+		if err != nil {
+			t.abort()
+			return nil, fmt.Errorf("unable to create parquet writer: %w", err)
+		}
+	}
+	return t, nil
+}
+
+// write writes a row. A nil value is null. In CSV files null and empty text
+// are both written as an empty field.
+func (t *tableFile) write(values []*string) error {
+	if t.csv != nil {
+		record := make([]string, len(values))
+		for i, v := range values {
+			if v != nil {
+				record[i] = *v
+			}
+		}
+		if err := t.csv.Write(record); err != nil {
+			return fmt.Errorf("unable to write export file: %w", err)
+		}
+		return nil
+	}
+	if err := t.pq.WriteString(values); err != nil {
+		return fmt.Errorf("unable to write export file: %w", err)
+	}
+	return nil
+}
+
+// close flushes and syncs the file, and renames it to its final path.
+func (t *tableFile) close() error {
+	if t.csv != nil {
+		t.csv.Flush()
+		if err := t.csv.Error(); err != nil {
+			t.abort()
+			return fmt.Errorf("unable to write export file: %w", err)
+		}
+		if err := t.buf.Flush(); err != nil {
+			t.abort()
+			return fmt.Errorf("unable to write export file: %w", err)
+		}
+	} else if err := t.pq.WriteStop(); err != nil {
+		t.abort()
+		return fmt.Errorf("unable to write export file: %w", err)
+	}
+	if err := t.file.Sync(); err != nil {
+		t.abort()
+		return fmt.Errorf("unable to sync export file: %w", err)
+	}
+	if err := t.file.Close(); err != nil {
+		os.Remove(t.file.Name())
+		return fmt.Errorf("unable to write export file: %w", err)
+	}
+	if err := os.Rename(t.file.Name(), t.path); err != nil {
+		return fmt.Errorf("unable to write export file: %w", err)
+	}
+	return nil
+}
+
+// abort removes the temporary file.
+func (t *tableFile) abort() {
+	t.file.Close()
+	os.Remove(t.file.Name())
+}
+
+// readManifest reads the manifest of the export directory. It returns an
+// empty manifest if the directory has none.
+func readManifest(dir string) (*Manifest, error) {
+	b, err := ioutil.ReadFile(filepath.Join(dir, manifestName))
+	switch {
+	case os.IsNotExist(err):
+		return &Manifest{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("unable to read manifest: %w", err)
+	}
+	m := &Manifest{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("unable to decode manifest: %w", err)
+	}
+	return m, nil
+}
+
+// writeManifest writes the manifest to a temporary file first, so a
+// manifest file is always complete.
+func writeManifest(dir string, m *Manifest) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode manifest: %w", err)
+	}
+	path := filepath.Join(dir, manifestName)
+	tmp, err := os.OpenFile(path+".tmp", os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create manifest: %w", err)
+	}
+	if _, err := tmp.Write(append(b, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to write manifest: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("unable to sync manifest: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write manifest: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("unable to write manifest: %w", err)
+	}
+	return nil
+}
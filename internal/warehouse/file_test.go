@@ -0,0 +1,149 @@
+package warehouse
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+func Test_column(t *testing.T) {
+	tests := []struct {
+		col         column
+		wantTag     string
+		wantParquet string
+		wantCsv     string
+	}{
+		{
+			col:         column{name: "host_id", dataType: "text"},
+			wantTag:     "name=host_id, type=UTF8",
+			wantParquet: `"host_id"::text`,
+			wantCsv:     `"host_id"::text`,
+		},
+		{
+			col:         column{name: "bytes_up", dataType: "bigint"},
+			wantTag:     "name=bytes_up, type=INT64",
+			wantParquet: `"bytes_up"::text`,
+			wantCsv:     `"bytes_up"::text`,
+		},
+		{
+			col:         column{name: "session_pending_time", dataType: "timestamp with time zone"},
+			wantTag:     "name=session_pending_time, type=TIMESTAMP_MILLIS",
+			wantParquet: `case when isfinite("session_pending_time") then floor(extract(epoch from "session_pending_time") * 1000)::bigint::text end`,
+			wantCsv:     `case when isfinite("session_pending_time") then to_char("session_pending_time" at time zone 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS.US"Z"') else "session_pending_time"::text end`,
+		},
+		{
+			col:         column{name: "date_actual", dataType: "date"},
+			wantTag:     "name=date_actual, type=DATE",
+			wantParquet: `case when isfinite("date_actual") then ("date_actual" - date '1970-01-01')::text end`,
+			wantCsv:     `"date_actual"::text`,
+		},
+		{
+			col:         column{name: "time_no_zone", dataType: "time without time zone"},
+			wantTag:     "name=time_no_zone, type=TIME_MILLIS",
+			wantParquet: `floor(extract(epoch from "time_no_zone"::time) * 1000)::int::text`,
+			wantCsv:     `"time_no_zone"::text`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.col.name, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(tt.wantTag, tt.col.parquetTag())
+			assert.Equal(tt.wantParquet, tt.col.selectExpr(FormatParquet))
+			assert.Equal(tt.wantCsv, tt.col.selectExpr(FormatCsv))
+		})
+	}
+}
+
+func Test_tableFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "warehouse-export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	columns := []column{
+		{name: "id", dataType: "text"},
+		{name: "count", dataType: "bigint"},
+		{name: "flag", dataType: "boolean"},
+		{name: "time", dataType: "timestamp with time zone"},
+	}
+	str := func(s string) *string { return &s }
+	rows := [][]*string{
+		{str("a"), str("1"), str("true"), str("1600000000000")},
+		{str("b,c"), str("2"), str("false"), nil},
+	}
+	write := func(t *testing.T, path string, f Format) {
+		t.Helper()
+		tf, err := createTableFile(path, f, columns)
+		require.NoError(t, err)
+		for _, r := range rows {
+			require.NoError(t, tf.write(r))
+		}
+		require.NoError(t, tf.close())
+		assert.NoFileExists(t, path+".tmp")
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		path := filepath.Join(dir, "t", "t-0-10.csv")
+		write(t, path, FormatCsv)
+		b, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "id,count,flag,time\na,1,true,1600000000000\n\"b,c\",2,false,\n", string(b))
+	})
+
+	t.Run("parquet", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		path := filepath.Join(dir, "t", "t-0-10.parquet")
+		write(t, path, FormatParquet)
+
+		type row struct {
+			Id    *string `parquet:"name=id, type=UTF8, repetitiontype=OPTIONAL"`
+			Count *int64  `parquet:"name=count, type=INT64, repetitiontype=OPTIONAL"`
+			Flag  *bool   `parquet:"name=flag, type=BOOLEAN, repetitiontype=OPTIONAL"`
+			Time  *int64  `parquet:"name=time, type=TIMESTAMP_MILLIS, repetitiontype=OPTIONAL"`
+		}
+		fr, err := local.NewLocalFileReader(path)
+		require.NoError(err)
+		defer fr.Close()
+		pr, err := reader.NewParquetReader(fr, new(row), 1)
+		require.NoError(err)
+		defer pr.ReadStop()
+		require.Equal(int64(2), pr.GetNumRows())
+		got := make([]row, 2)
+		require.NoError(pr.Read(&got))
+		i64 := func(i int64) *int64 { return &i }
+		b := func(b bool) *bool { return &b }
+		assert.Equal([]row{
+			{Id: str("a"), Count: i64(1), Flag: b(true), Time: i64(1600000000000)},
+			{Id: str("b,c"), Count: i64(2), Flag: b(false)},
+		}, got)
+	})
+}
+
+func Test_manifest(t *testing.T) {
+	assert, require := assert.New(t), require.New(t)
+	dir, err := ioutil.TempDir("", "warehouse-export")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	m, err := readManifest(dir)
+	require.NoError(err)
+	assert.Equal(&Manifest{}, m)
+
+	m.HighWaterMark = 10
+	m.Exports = append(m.Exports, &Export{
+		Format:   FormatCsv,
+		FromMark: 0,
+		ToMark:   10,
+		Files:    []*File{{Table: "wh_host_dimension", Path: "wh_host_dimension/wh_host_dimension-0-10.csv", RowCount: 3}},
+	})
+	require.NoError(writeManifest(dir, m))
+	assert.NoFileExists(filepath.Join(dir, manifestName+".tmp"))
+	got, err := readManifest(dir)
+	require.NoError(err)
+	assert.Equal(m, got)
+}
@@ -0,0 +1,32 @@
+package warehouse
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withFormat Format
+}
+
+func getDefaultOptions() options {
+	return options{
+		withFormat: FormatParquet,
+	}
+}
+
+// WithFormat provides an option to set the format of the exported files. The
+// default is FormatParquet.
+func WithFormat(f Format) Option {
+	return func(o *options) {
+		o.withFormat = f
+	}
+}
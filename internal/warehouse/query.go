@@ -0,0 +1,35 @@
+package warehouse
+
+const (
+	// highWaterMarkQuery returns the ID of the oldest transaction still
+	// running. All the rows with a lower row_transaction_id were written by
+	// transactions which are finished.
+	highWaterMarkQuery = `
+select txid_snapshot_xmin(txid_current_snapshot());
+`
+
+	// columnsQuery returns the columns of the warehouse tables. The data type
+	// of a column of a domain type is the base type of the domain.
+	columnsQuery = `
+select c.table_name, c.column_name, c.data_type
+  from information_schema.columns c
+  join information_schema.tables t
+    on t.table_schema = c.table_schema
+   and t.table_name   = c.table_name
+ where c.table_schema = current_schema()
+   and t.table_type   = 'BASE TABLE'
+   and c.table_name like 'wh\_%'
+   and c.column_name != 'row_transaction_id'
+ order by c.table_name, c.ordinal_position;
+`
+
+	// exportQuery selects the rows of a table written by the transactions
+	// between two high-water marks. The %s verbs are the select list and the
+	// table name.
+	exportQuery = `
+select %s
+  from %s
+ where row_transaction_id >= ?
+   and row_transaction_id <  ?;
+`
+)
@@ -0,0 +1,227 @@
+// Package warehouse exports the data warehouse tables to files, so they can
+// be loaded into an external data warehouse or analytics tool. Each export
+// writes the rows inserted or updated since the previous export to the
+// export directory, one Parquet or CSV file per table, and records the files
+// in the manifest of the directory.
+package warehouse
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// lockName is the name of the file created in the export directory while an
+// export is running.
+const lockName = ".export.lock"
+
+// Repository exports the data warehouse tables.
+type Repository struct {
+	reader db.Reader
+}
+
+// NewRepository creates a new warehouse Repository.
+func NewRepository(r db.Reader) (*Repository, error) {
+	if r == nil {
+		return nil, stderrors.New("error creating db repository with nil reader")
+	}
+	return &Repository{reader: r}, nil
+}
+
+// Export exports the rows of the warehouse tables inserted or updated since
+// the previous export to dir. The rows are exported up to a high-water mark,
+// the ID of the oldest transaction still running, which is recorded in the
+// manifest of dir and is where the next export starts. The first export to a
+// directory exports all the rows. The manifest is written last and the new
+// Export is returned. If no rows were written since the previous export, no
+// files are written and nil is returned. Supports the options: WithFormat
+// which sets the format of the exported files.
+//
+// Only one export to a directory can run at a time. A lock file is created
+// in dir while the export runs; if an export is interrupted, the lock file
+// must be removed before exporting to dir again.
+func (r *Repository) Export(ctx context.Context, dir string, opt ...Option) (*Export, error) {
+	opts := getOpts(opt...)
+	switch {
+	case dir == "":
+		return nil, fmt.Errorf("export warehouse: missing export dir: %w", errors.ErrInvalidParameter)
+	case opts.withFormat != FormatParquet && opts.withFormat != FormatCsv:
+		return nil, fmt.Errorf("export warehouse: unknown format %q: %w", opts.withFormat, errors.ErrInvalidParameter)
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("export warehouse: unable to create export directory: %w", err)
+	}
+	lock, err := os.OpenFile(filepath.Join(dir, lockName), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		if os.IsExist(err) {
+			return nil, fmt.Errorf("export warehouse: another export to %s is running, or was interrupted and %s must be removed", dir, lockName)
+		}
+		return nil, fmt.Errorf("export warehouse: unable to lock export directory: %w", err)
+	}
+	lock.Close()
+	defer os.Remove(lock.Name())
+
+	m, err := readManifest(dir)
+	if err != nil {
+		return nil, fmt.Errorf("export warehouse: %w", err)
+	}
+	mark, err := r.highWaterMark(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("export warehouse: %w", err)
+	}
+	if mark <= m.HighWaterMark {
+		return nil, nil
+	}
+	tables, columns, err := r.columns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("export warehouse: %w", err)
+	}
+
+	e := &Export{
+		CreateTime: time.Now().UTC(),
+		Format:     opts.withFormat,
+		FromMark:   m.HighWaterMark,
+		ToMark:     mark,
+	}
+	for _, table := range tables {
+		f, err := r.exportTable(ctx, dir, table, columns[table], e)
+		if err != nil {
+			return nil, fmt.Errorf("export warehouse: %w", err)
+		}
+		if f != nil {
+			e.Files = append(e.Files, f)
+		}
+	}
+
+	// The high-water mark advances even if no rows were exported, so the
+	// next export does not scan the same transactions again.
+	m.HighWaterMark = mark
+	if len(e.Files) > 0 {
+		m.Exports = append(m.Exports, e)
+	}
+	if err := writeManifest(dir, m); err != nil {
+		return nil, fmt.Errorf("export warehouse: %w", err)
+	}
+	if len(e.Files) == 0 {
+		return nil, nil
+	}
+	return e, nil
+}
+
+func (r *Repository) highWaterMark(ctx context.Context) (uint64, error) {
+	rows, err := r.reader.Query(ctx, highWaterMarkQuery, nil)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read high-water mark: %w", err)
+	}
+	defer rows.Close()
+	var mark uint64
+	if rows.Next() {
+		if err := rows.Scan(&mark); err != nil {
+			return 0, fmt.Errorf("unable to read high-water mark: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("unable to read high-water mark: %w", err)
+	}
+	return mark, nil
+}
+
+// columns returns the names of the warehouse tables, in alphabetical order,
+// and their columns.
+func (r *Repository) columns(ctx context.Context) ([]string, map[string][]column, error) {
+	rows, err := r.reader.Query(ctx, columnsQuery, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read warehouse columns: %w", err)
+	}
+	defer rows.Close()
+	var tables []string
+	columns := map[string][]column{}
+	for rows.Next() {
+		var table string
+		var c column
+		if err := rows.Scan(&table, &c.name, &c.dataType); err != nil {
+			return nil, nil, fmt.Errorf("unable to read warehouse columns: %w", err)
+		}
+		if _, ok := columns[table]; !ok {
+			tables = append(tables, table)
+		}
+		columns[table] = append(columns[table], c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("unable to read warehouse columns: %w", err)
+	}
+	return tables, columns, nil
+}
+
+// exportTable exports the rows of table written between the marks of e to a
+// new file. If there are no rows, no file is written and nil is returned.
+func (r *Repository) exportTable(ctx context.Context, dir, table string, columns []column, e *Export) (*File, error) {
+	exprs := make([]string, 0, len(columns))
+	for _, c := range columns {
+		exprs = append(exprs, c.selectExpr(e.Format))
+	}
+	query := fmt.Sprintf(exportQuery, strings.Join(exprs, ", "), `"`+table+`"`)
+	rows, err := r.reader.Query(ctx, query, []interface{}{e.FromMark, e.ToMark})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	f := &File{
+		Table: table,
+		Path:  path.Join(table, fmt.Sprintf("%s-%d-%d.%s", table, e.FromMark, e.ToMark, e.Format)),
+	}
+	var tf *tableFile
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			if tf != nil {
+				tf.abort()
+			}
+			return nil, fmt.Errorf("unable to read %s: %w", table, err)
+		}
+		if tf == nil {
+			if tf, err = createTableFile(filepath.Join(dir, filepath.FromSlash(f.Path)), e.Format, columns); err != nil {
+				return nil, err
+			}
+		}
+		row := make([]*string, len(values))
+		for i := range values {
+			if values[i].Valid {
+				row[i] = &values[i].String
+			}
+		}
+		if err := tf.write(row); err != nil {
+			tf.abort()
+			return nil, err
+		}
+		f.RowCount++
+	}
+	if err := rows.Err(); err != nil {
+		if tf != nil {
+			tf.abort()
+		}
+		return nil, fmt.Errorf("unable to read %s: %w", table, err)
+	}
+	if tf == nil {
+		return nil, nil
+	}
+	if err := tf.close(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
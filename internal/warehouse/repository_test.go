@@ -0,0 +1,108 @@
+package warehouse_test
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/hashicorp/boundary/internal/warehouse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Export(t *testing.T) {
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	ctx := context.Background()
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	s := session.TestDefaultSession(t, conn, wrapper, iamRepo)
+
+	dir, err := ioutil.TempDir("", "warehouse-export")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	repo, err := warehouse.NewRepository(rw)
+	require.NoError(t, err)
+
+	fileOf := func(e *warehouse.Export, table string) *warehouse.File {
+		for _, f := range e.Files {
+			if f.Table == table {
+				return f
+			}
+		}
+		return nil
+	}
+	readCsv := func(t *testing.T, f *warehouse.File) [][]string {
+		t.Helper()
+		file, err := os.Open(filepath.Join(dir, filepath.FromSlash(f.Path)))
+		require.NoError(t, err)
+		defer file.Close()
+		records, err := csv.NewReader(file).ReadAll()
+		require.NoError(t, err)
+		return records
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := repo.Export(ctx, "")
+		assert.Error(t, err)
+		_, err = repo.Export(ctx, dir, warehouse.WithFormat("xml"))
+		assert.Error(t, err)
+	})
+
+	var first *warehouse.Export
+	t.Run("first", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		e, err := repo.Export(ctx, dir, warehouse.WithFormat(warehouse.FormatCsv))
+		require.NoError(err)
+		require.NotNil(e)
+		assert.Equal(uint64(0), e.FromMark)
+		assert.True(e.ToMark > 0)
+
+		f := fileOf(e, "wh_session_accumulating_fact")
+		require.NotNil(f)
+		assert.Equal(1, f.RowCount)
+		records := readCsv(t, f)
+		require.Len(records, 2)
+		assert.Equal("session_id", records[0][0])
+		assert.NotContains(records[0], "row_transaction_id")
+		assert.Equal(s.PublicId, records[1][0])
+		require.NotNil(fileOf(e, "wh_time_of_day_dimension"))
+		first = e
+	})
+
+	t.Run("nothing-new", func(t *testing.T) {
+		e, err := repo.Export(ctx, dir, warehouse.WithFormat(warehouse.FormatCsv))
+		require.NoError(t, err)
+		assert.Nil(t, e)
+	})
+
+	t.Run("updated", func(t *testing.T) {
+		assert, require := assert.New(t), require.New(t)
+		require.NotNil(first)
+		session.TestState(t, conn, s.PublicId, session.StatusCanceling)
+		e, err := repo.Export(ctx, dir)
+		require.NoError(err)
+		require.NotNil(e)
+		assert.Equal(warehouse.FormatParquet, e.Format)
+		assert.True(e.FromMark >= first.ToMark)
+		require.Len(e.Files, 1)
+		assert.Equal("wh_session_accumulating_fact", e.Files[0].Table)
+		assert.Equal(1, e.Files[0].RowCount)
+		assert.FileExists(filepath.Join(dir, filepath.FromSlash(e.Files[0].Path)))
+
+		b, err := ioutil.ReadFile(filepath.Join(dir, "manifest.json"))
+		require.NoError(err)
+		var m warehouse.Manifest
+		require.NoError(json.Unmarshal(b, &m))
+		assert.Equal(e.ToMark, m.HighWaterMark)
+		assert.Len(m.Exports, 2)
+	})
+}
@@ -0,0 +1,180 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The headers sent with each delivery. The signature is the hex encoded
+// HMAC-SHA256, keyed with the webhook's secret, of the timestamp header, a
+// period and the body, prefixed with "sha256=". Receivers should recompute it
+// and reject deliveries with old timestamps.
+const (
+	EventTypeHeader  = "X-Boundary-Event"
+	DeliveryIdHeader = "X-Boundary-Delivery"
+	TimestampHeader  = "X-Boundary-Timestamp"
+	SignatureHeader  = "X-Boundary-Signature"
+)
+
+const (
+	// MaxDeliveryAttempts is the number of attempts after which a delivery
+	// which hasn't been accepted by its webhook fails.
+	MaxDeliveryAttempts = 8
+
+	minRetryDelay = 30 * time.Second
+	maxRetryDelay = time.Hour
+
+	deliveryTimeout   = 10 * time.Second
+	deliveryBatchSize = 20
+	// deliveryLease is long enough for a batch, which is attempted
+	// concurrently, to be attempted and recorded.
+	deliveryLease = 2 * time.Minute
+	// maxResponseBytes of a response body are read, so that the connection
+	// can be reused.
+	maxResponseBytes = 64 * 1024
+)
+
+// A Deliverer posts the pending deliveries in the outbox to their webhooks.
+// Any number of controllers can deliver from the same outbox; deliveries are
+// leased to a deliverer while they are attempted.
+type Deliverer struct {
+	repo   *Repository
+	client *http.Client
+}
+
+// NewDeliverer creates a new Deliverer. WithHttpClient overrides the client
+// deliveries are posted with, which by default times out after ten seconds
+// and does not follow redirects.
+func NewDeliverer(repo *Repository, opt ...Option) (*Deliverer, error) {
+	if repo == nil {
+		return nil, fmt.Errorf("new deliverer: nil repository")
+	}
+	opts := getOpts(opt...)
+	client := opts.withHttpClient
+	if client == nil {
+		client = &http.Client{
+			Timeout: deliveryTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+	return &Deliverer{
+		repo:   repo,
+		client: client,
+	}, nil
+}
+
+// DeliverPending attempts every pending delivery which is due, returning the
+// number of deliveries attempted.
+func (d *Deliverer) DeliverPending(ctx context.Context) (int, error) {
+	var attempted int
+	for {
+		claimed, leased, err := d.repo.claimDeliveries(ctx, deliveryBatchSize, deliveryLease)
+		if err != nil {
+			return attempted, err
+		}
+		// Deliveries whose secrets can't be decrypted have already had a
+		// failed attempt recorded
+		attempted += leased - len(claimed)
+		if err := d.attempt(ctx, claimed); err != nil {
+			return attempted, err
+		}
+		attempted += len(claimed)
+		if leased < deliveryBatchSize || ctx.Err() != nil {
+			return attempted, nil
+		}
+	}
+}
+
+// attempt posts each of the claimed deliveries concurrently and records the
+// outcomes, returning the first error recording them.
+func (d *Deliverer) attempt(ctx context.Context, claimed []*pendingDelivery) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for _, p := range claimed {
+		wg.Add(1)
+		go func(p *pendingDelivery) {
+			defer wg.Done()
+			statusCode, err := d.post(ctx, p, time.Now())
+			state, retryAfter := DeliverySucceeded, time.Duration(0)
+			if err != nil {
+				state, retryAfter = nextAttempt(p.Attempts + 1)
+			}
+			if err := d.repo.recordAttempt(ctx, p.PublicId, state, retryAfter, statusCode, err); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(p)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// post sends a delivery to its webhook, returning the status code of the
+// response, if any, and an error unless the status was 2xx.
+func (d *Deliverer) post(ctx context.Context, p *pendingDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.Url, strings.NewReader(p.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("error creating request: %w", err)
+	}
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Boundary-Webhook")
+	req.Header.Set(EventTypeHeader, p.EventType)
+	req.Header.Set(DeliveryIdHeader, p.PublicId)
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, signature(p.Secret, timestamp, []byte(p.Payload)))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, maxResponseBytes))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %q", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// signature returns the value of the signature header of a delivery of body
+// at timestamp.
+func signature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// nextAttempt returns the state of a delivery which has failed attempts
+// times and, if it is still pending, how long to wait before the next
+// attempt. The wait doubles after each attempt, up to an hour.
+func nextAttempt(attempts uint32) (DeliveryState, time.Duration) {
+	if attempts >= MaxDeliveryAttempts {
+		return DeliveryFailed, 0
+	}
+	delay := minRetryDelay
+	for i := uint32(1); i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return DeliveryPending, delay
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignature(t *testing.T) {
+	t.Parallel()
+	// echo -n '1604311200.{"a":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=1c588623aaaf14779cd5b43b83e9f08c9d71af800bab311f2988c102e835c689",
+		signature("secret", "1604311200", []byte(`{"a":1}`)))
+}
+
+func TestNextAttempt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		attempts  uint32
+		wantState DeliveryState
+		wantDelay time.Duration
+	}{
+		{attempts: 1, wantState: DeliveryPending, wantDelay: 30 * time.Second},
+		{attempts: 2, wantState: DeliveryPending, wantDelay: time.Minute},
+		{attempts: 3, wantState: DeliveryPending, wantDelay: 2 * time.Minute},
+		{attempts: 7, wantState: DeliveryPending, wantDelay: 32 * time.Minute},
+		{attempts: MaxDeliveryAttempts, wantState: DeliveryFailed},
+		{attempts: MaxDeliveryAttempts + 1, wantState: DeliveryFailed},
+	}
+	for _, tt := range tests {
+		state, delay := nextAttempt(tt.attempts)
+		assert.Equal(t, tt.wantState, state, "attempts %d", tt.attempts)
+		assert.Equal(t, tt.wantDelay, delay, "attempts %d", tt.attempts)
+	}
+}
+
+func TestDeliverer_post(t *testing.T) {
+	t.Parallel()
+	p := &pendingDelivery{
+		PublicId:  "whd_0123456789",
+		WebhookId: "wh_1234567890",
+		EventType: SessionAuthorized,
+		Payload:   `{"event_type":"session.authorized"}`,
+		Secret:    "secret",
+	}
+	now := time.Unix(1604311200, 0)
+
+	var got *http.Request
+	var gotBody string
+	status := http.StatusNoContent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		got, gotBody = r, string(body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+	p.Url = srv.URL + "/hook"
+
+	d, err := NewDeliverer(&Repository{})
+	require.NoError(t, err)
+
+	code, err := d.post(context.Background(), p, now)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, code)
+	assert.Equal(t, http.MethodPost, got.Method)
+	assert.Equal(t, "/hook", got.URL.Path)
+	assert.Equal(t, p.Payload, gotBody)
+	assert.Equal(t, "application/json", got.Header.Get("Content-Type"))
+	assert.Equal(t, SessionAuthorized, got.Header.Get(EventTypeHeader))
+	assert.Equal(t, p.PublicId, got.Header.Get(DeliveryIdHeader))
+	assert.Equal(t, "1604311200", got.Header.Get(TimestampHeader))
+	assert.Equal(t, signature("secret", "1604311200", []byte(p.Payload)), got.Header.Get(SignatureHeader))
+
+	status = http.StatusInternalServerError
+	code, err = d.post(context.Background(), p, now)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, code)
+
+	// Redirects are not followed
+	status = http.StatusFound
+	code, err = d.post(context.Background(), p, now)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusFound, code)
+
+	// No response
+	srv.Close()
+	code, err = d.post(context.Background(), p, now)
+	require.Error(t, err)
+	assert.Equal(t, 0, code)
+}
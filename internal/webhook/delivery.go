@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"github.com/hashicorp/boundary/internal/webhook/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultDeliveryTableName = "webhook_delivery"
+)
+
+// DeliveryState is the state of a delivery.
+type DeliveryState string
+
+const (
+	// DeliveryPending deliveries have not been attempted yet, or their last
+	// attempt failed and they will be retried.
+	DeliveryPending DeliveryState = "pending"
+	// DeliverySucceeded deliveries were accepted by the webhook.
+	DeliverySucceeded DeliveryState = "succeeded"
+	// DeliveryFailed deliveries were not accepted by the webhook after the
+	// maximum number of attempts and will not be retried.
+	DeliveryFailed DeliveryState = "failed"
+)
+
+// String returns the string representation of the delivery state.
+func (s DeliveryState) String() string {
+	return string(s)
+}
+
+// ValidDeliveryState returns true if s is a known delivery state.
+func ValidDeliveryState(s string) bool {
+	switch DeliveryState(s) {
+	case DeliveryPending, DeliverySucceeded, DeliveryFailed:
+		return true
+	}
+	return false
+}
+
+// A Delivery is an event queued for delivery to a webhook, along with the
+// outcome of the last attempt to deliver it. Deliveries are created by the
+// database in the transaction which changes the state of a session or
+// connection.
+type Delivery struct {
+	*store.Delivery
+	tableName string `gorm:"-"`
+}
+
+// Clone creates a clone of the Delivery
+func (d *Delivery) Clone() interface{} {
+	cp := proto.Clone(d.Delivery)
+	return &Delivery{
+		Delivery: cp.(*store.Delivery),
+	}
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (d *Delivery) TableName() string {
+	if d.tableName != "" {
+		return d.tableName
+	}
+	return DefaultDeliveryTableName
+}
+
+// SetTableName sets the tablename. If the caller attempts to set the name to
+// "" the name will be reset to the default name.
+func (d *Delivery) SetTableName(n string) {
+	d.tableName = n
+}
@@ -0,0 +1,23 @@
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+)
+
+const (
+	WebhookPrefix = "wh"
+
+	// DeliveryPrefix is the prefix of the IDs of deliveries, which are
+	// generated by the database when an event is queued.
+	DeliveryPrefix = "whd"
+)
+
+func newWebhookId() (string, error) {
+	id, err := db.NewPublicId(WebhookPrefix)
+	if err != nil {
+		return "", fmt.Errorf("new webhook id: %w", err)
+	}
+	return id, nil
+}
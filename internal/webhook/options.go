@@ -0,0 +1,99 @@
+package webhook
+
+import "net/http"
+
+// getOpts - iterate the inbound Options and return a struct
+func getOpts(opt ...Option) options {
+	opts := getDefaultOptions()
+	for _, o := range opt {
+		o(&opts)
+	}
+	return opts
+}
+
+// Option - how Options are passed as arguments
+type Option func(*options)
+
+// options = how options are represented
+type options struct {
+	withName        string
+	withDescription string
+	withSecret      string
+	withEventTypes  []string
+	withLimit       int
+	withState       DeliveryState
+	withHttpClient  *http.Client
+	withAfterId     string
+}
+
+func getDefaultOptions() options {
+	return options{
+		withName:        "",
+		withDescription: "",
+		withSecret:      "",
+		withEventTypes:  nil,
+		withLimit:       0,
+		withState:       "",
+		withHttpClient:  nil,
+	}
+}
+
+// WithDescription provides an optional description
+func WithDescription(desc string) Option {
+	return func(o *options) {
+		o.withDescription = desc
+	}
+}
+
+// WithName provides an optional name
+func WithName(name string) Option {
+	return func(o *options) {
+		o.withName = name
+	}
+}
+
+// WithSecret provides the secret used to sign deliveries
+func WithSecret(secret string) Option {
+	return func(o *options) {
+		o.withSecret = secret
+	}
+}
+
+// WithEventTypes provides the event types a webhook is subscribed to
+func WithEventTypes(eventTypes []string) Option {
+	return func(o *options) {
+		o.withEventTypes = eventTypes
+	}
+}
+
+// WithLimit provides an option to provide a limit.  Intentionally allowing
+// negative integers.   If WithLimit < 0, then unlimited results are returned.
+// If WithLimit == 0, then default limits are used for results.
+func WithLimit(limit int) Option {
+	return func(o *options) {
+		o.withLimit = limit
+	}
+}
+
+// WithState provides an option to only list deliveries in a state
+func WithState(s DeliveryState) Option {
+	return func(o *options) {
+		o.withState = s
+	}
+}
+
+// WithHttpClient provides the client a Deliverer posts events with
+func WithHttpClient(c *http.Client) Option {
+	return func(o *options) {
+		o.withHttpClient = c
+	}
+}
+
+// WithAfterId provides an option to list only the items whose id sorts after
+// id. Lists are ordered by id so it can be used to list items a page at a
+// time.
+func WithAfterId(id string) Option {
+	return func(o *options) {
+		o.withAfterId = id
+	}
+}
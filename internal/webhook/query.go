@@ -0,0 +1,48 @@
+package webhook
+
+const (
+	// claimDeliveriesQuery leases up to $1 due pending deliveries for $2
+	// seconds by moving their next attempt time into the future, so other
+	// controllers skip them while they are being attempted. It returns them
+	// along with the url and encrypted secret of their webhooks.
+	claimDeliveriesQuery = `
+with claimed as (
+  select public_id
+    from webhook_delivery
+   where state = 'pending'
+     and next_attempt_time <= now()
+   order by next_attempt_time
+   limit $1
+     for update skip locked
+)
+update webhook_delivery d
+   set next_attempt_time = now() + interval '1 second' * $2::double precision
+  from claimed c, webhook w
+ where d.public_id = c.public_id
+   and w.public_id = d.webhook_id
+returning d.public_id, d.webhook_id, d.event_type, d.payload, d.attempts,
+          w.url, w.secret, w.key_id, w.scope_id;
+`
+
+	// recordDeliveryAttemptQuery records the outcome of an attempt of
+	// delivery $1, setting its state to $2 and scheduling the next attempt of
+	// a pending delivery $3 seconds from now.
+	recordDeliveryAttemptQuery = `
+update webhook_delivery
+   set state = $2,
+       attempts = attempts + 1,
+       last_attempt_time = now(),
+       next_attempt_time = now() + interval '1 second' * $3::double precision,
+       last_status_code = $4,
+       last_error = $5
+ where public_id = $1;
+`
+
+	rewrapWebhookQuery = `
+update webhook
+   set secret = $1,
+       key_id = $2
+ where public_id = $3
+   and key_id = $4;
+`
+)
@@ -0,0 +1,465 @@
+package webhook
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/boundary/internal/db"
+	dbcommon "github.com/hashicorp/boundary/internal/db/common"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/oplog"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+// unlimited is used to load every event type of the webhooks being returned,
+// regardless of the repository's default limit.
+const unlimited = -1
+
+// Repository is the webhook database repository
+type Repository struct {
+	reader db.Reader
+	writer db.Writer
+	kms    *kms.Kms
+
+	// defaultLimit provides a default for limiting the number of results returned from the repo
+	defaultLimit int
+}
+
+// NewRepository creates a new webhook Repository. Supports the options: WithLimit
+// which sets a default limit on results returned by repo operations.
+func NewRepository(r db.Reader, w db.Writer, kms *kms.Kms, opt ...Option) (*Repository, error) {
+	if r == nil {
+		return nil, stderrors.New("error creating db repository with nil reader")
+	}
+	if w == nil {
+		return nil, stderrors.New("error creating db repository with nil writer")
+	}
+	if kms == nil {
+		return nil, stderrors.New("error creating db repository with nil kms")
+	}
+	opts := getOpts(opt...)
+	if opts.withLimit == 0 {
+		// zero signals the boundary defaults should be used.
+		opts.withLimit = db.DefaultLimit
+	}
+	return &Repository{
+		reader:       r,
+		writer:       w,
+		kms:          kms,
+		defaultLimit: opts.withLimit,
+	}, nil
+}
+
+// CreateWebhook inserts the webhook and its event types into the repository
+// and returns the new webhook. The webhook's secret is encrypted with the
+// database key of its scope and is not returned. No options are currently
+// supported.
+func (r *Repository) CreateWebhook(ctx context.Context, webhook *Webhook, opt ...Option) (*Webhook, error) {
+	if webhook == nil || webhook.Webhook == nil {
+		return nil, fmt.Errorf("create webhook: missing webhook: %w", errors.ErrInvalidParameter)
+	}
+	if webhook.PublicId != "" {
+		return nil, fmt.Errorf("create webhook: public id not empty: %w", errors.ErrInvalidParameter)
+	}
+	if webhook.ScopeId == "" {
+		return nil, fmt.Errorf("create webhook: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	if webhook.Url == "" {
+		return nil, fmt.Errorf("create webhook: missing url: %w", errors.ErrInvalidParameter)
+	}
+	if webhook.Secret == "" {
+		return nil, fmt.Errorf("create webhook: missing secret: %w", errors.ErrInvalidParameter)
+	}
+	eventTypes, err := normalizeEventTypes(webhook.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+	if len(eventTypes) == 0 {
+		return nil, fmt.Errorf("create webhook: missing event types: %w", errors.ErrInvalidParameter)
+	}
+
+	w := webhook.Clone().(*Webhook)
+	if w.PublicId, err = newWebhookId(); err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+	w.EventTypes = eventTypes
+
+	databaseWrapper, err := r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeDatabase)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook: unable to get database wrapper: %w", err)
+	}
+	if err := w.encrypt(ctx, databaseWrapper); err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook: unable to get oplog wrapper: %w", err)
+	}
+	newEventTypes, err := newWebhookEventTypes(w.PublicId, eventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("create webhook: %w", err)
+	}
+
+	metadata := w.oplog(oplog.OpType_OP_TYPE_CREATE)
+	var returnedWebhook *Webhook
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, dbw db.Writer) error {
+			ticket, err := dbw.GetTicket(w)
+			if err != nil {
+				return fmt.Errorf("unable to get ticket: %w", err)
+			}
+			msgs := make([]*oplog.Message, 0, 1+len(newEventTypes))
+			var webhookOplogMsg oplog.Message
+			returnedWebhook = w.Clone().(*Webhook)
+			if err := dbw.Create(ctx, returnedWebhook, db.NewOplogMsg(&webhookOplogMsg)); err != nil {
+				return err
+			}
+			msgs = append(msgs, &webhookOplogMsg)
+			eventTypeOplogMsgs := make([]*oplog.Message, 0, len(newEventTypes))
+			if err := dbw.CreateItems(ctx, newEventTypes, db.NewOplogMsgs(&eventTypeOplogMsgs)); err != nil {
+				return fmt.Errorf("unable to add event types: %w", err)
+			}
+			msgs = append(msgs, eventTypeOplogMsgs...)
+			if err := dbw.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return fmt.Errorf("unable to write oplog: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, fmt.Errorf("create webhook: webhook %s already exists in scope %s: %w", w.Name, w.ScopeId, errors.ErrNotUnique)
+		}
+		return nil, fmt.Errorf("create webhook: %w for %s", err, w.PublicId)
+	}
+	return returnedWebhook, nil
+}
+
+// LookupWebhook will look up a webhook and its event types in the
+// repository. If the webhook is not found, it will return nil, nil. The
+// webhook's secret is not returned. No options are currently supported.
+func (r *Repository) LookupWebhook(ctx context.Context, publicId string, opt ...Option) (*Webhook, error) {
+	if publicId == "" {
+		return nil, fmt.Errorf("lookup webhook: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	w := allocWebhook()
+	w.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, &w); err != nil {
+		if errors.Is(err, errors.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup webhook: failed %w for %s", err, publicId)
+	}
+	if err := loadEventTypes(ctx, r.reader, &w); err != nil {
+		return nil, fmt.Errorf("lookup webhook: %w", err)
+	}
+	w.CtSecret = nil
+	return &w, nil
+}
+
+// ListWebhooks lists the webhooks, and their event types, in a scope.
+// Supports the WithLimit option.
+func (r *Repository) ListWebhooks(ctx context.Context, withScopeId string, opt ...Option) ([]*Webhook, error) {
+	if withScopeId == "" {
+		return nil, fmt.Errorf("list webhooks: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	where, args := "scope_id = ?", []interface{}{withScopeId}
+	if opts := getOpts(opt...); opts.withAfterId != "" {
+		where, args = where+" and public_id > ?", append(args, opts.withAfterId)
+	}
+	var webhooks []*Webhook
+	if err := r.list(ctx, &webhooks, where, args, opt...); err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+	if err := loadEventTypes(ctx, r.reader, webhooks...); err != nil {
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+	for _, w := range webhooks {
+		w.CtSecret = nil
+	}
+	return webhooks, nil
+}
+
+// list will return a listing of resources and honor the WithLimit option or the
+// repo defaultLimit
+func (r *Repository) list(ctx context.Context, resources interface{}, where string, args []interface{}, opt ...Option) error {
+	opts := getOpts(opt...)
+	limit := r.defaultLimit
+	var dbOpts []db.Option
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	dbOpts = append(dbOpts, db.WithLimit(limit), db.WithOrder("public_id"))
+	return r.reader.SearchWhere(ctx, resources, where, args, dbOpts...)
+}
+
+// UpdateWebhook will update a webhook in the repository and return the
+// written webhook. fieldMaskPaths provides field_mask.proto paths for fields
+// that should be updated. Fields will be set to NULL if the field is a zero
+// value and included in fieldMask. Name, Description, Url, Secret and
+// EventTypes are the updatable fields; Url and Secret cannot be unset and
+// EventTypes cannot be emptied. If no updatable fields are included in the
+// fieldMaskPaths, then an error is returned.
+func (r *Repository) UpdateWebhook(ctx context.Context, webhook *Webhook, version uint32, fieldMaskPaths []string, opt ...Option) (*Webhook, int, error) {
+	if webhook == nil || webhook.Webhook == nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update webhook: missing webhook: %w", errors.ErrInvalidParameter)
+	}
+	if webhook.PublicId == "" {
+		return nil, db.NoRowsAffected, fmt.Errorf("update webhook: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if version == 0 {
+		return nil, db.NoRowsAffected, fmt.Errorf("update webhook: version cannot be zero: %w", errors.ErrInvalidParameter)
+	}
+	var updateSecret, updateEventTypes bool
+	columnPaths := make([]string, 0, len(fieldMaskPaths))
+	for _, f := range fieldMaskPaths {
+		switch {
+		case strings.EqualFold("name", f):
+		case strings.EqualFold("description", f):
+		case strings.EqualFold("url", f):
+			if webhook.Url == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update webhook: url cannot be unset: %w", errors.ErrInvalidParameter)
+			}
+			if err := ValidUrl(webhook.Url); err != nil {
+				return nil, db.NoRowsAffected, fmt.Errorf("update webhook: invalid url %q: %v: %w", webhook.Url, err, errors.ErrInvalidParameter)
+			}
+		case strings.EqualFold("secret", f):
+			if webhook.Secret == "" {
+				return nil, db.NoRowsAffected, fmt.Errorf("update webhook: secret cannot be unset: %w", errors.ErrInvalidParameter)
+			}
+			updateSecret = true
+			continue
+		case strings.EqualFold("eventtypes", f):
+			updateEventTypes = true
+			continue
+		default:
+			return nil, db.NoRowsAffected, fmt.Errorf("update webhook: field: %s: %w", f, errors.ErrInvalidFieldMask)
+		}
+		columnPaths = append(columnPaths, f)
+	}
+	var eventTypes []string
+	if updateEventTypes {
+		var err error
+		if eventTypes, err = normalizeEventTypes(webhook.EventTypes); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update webhook: %w", err)
+		}
+		if len(eventTypes) == 0 {
+			return nil, db.NoRowsAffected, fmt.Errorf("update webhook: event types cannot be emptied: %w", errors.ErrInvalidParameter)
+		}
+	}
+
+	dbMask, nullFields := dbcommon.BuildUpdatePaths(
+		map[string]interface{}{
+			"Name":        webhook.Name,
+			"Description": webhook.Description,
+			"Url":         webhook.Url,
+		},
+		columnPaths,
+		nil,
+	)
+	if len(dbMask) == 0 && len(nullFields) == 0 && !updateSecret && !updateEventTypes {
+		return nil, db.NoRowsAffected, fmt.Errorf("update webhook: %w", errors.ErrEmptyFieldMask)
+	}
+
+	current := allocWebhook()
+	current.PublicId = webhook.PublicId
+	if err := r.reader.LookupByPublicId(ctx, &current); err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update webhook: failed %w for %s", err, webhook.PublicId)
+	}
+
+	w := webhook.Clone().(*Webhook)
+	w.ScopeId = current.ScopeId
+	w.EventTypes = nil
+	if updateSecret {
+		databaseWrapper, err := r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeDatabase)
+		if err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update webhook: unable to get database wrapper: %w", err)
+		}
+		if err := w.encrypt(ctx, databaseWrapper); err != nil {
+			return nil, db.NoRowsAffected, fmt.Errorf("update webhook: %w", err)
+		}
+		dbMask = append(dbMask, "CtSecret", "KeyId")
+	} else {
+		w.Secret = ""
+	}
+	if len(dbMask) == 0 && len(nullFields) == 0 {
+		// Only the event types are changing; bump the version of the
+		// webhook so the change is visible to optimistic locking.
+		w.Version = version + 1
+		dbMask = append(dbMask, "Version")
+	}
+
+	oplogWrapper, err := r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return nil, db.NoRowsAffected, fmt.Errorf("update webhook: unable to get oplog wrapper: %w", err)
+	}
+	metadata := w.oplog(oplog.OpType_OP_TYPE_UPDATE)
+
+	var returnedWebhook *Webhook
+	var rowsUpdated int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(reader db.Reader, dbw db.Writer) error {
+			ticket, err := dbw.GetTicket(w)
+			if err != nil {
+				return fmt.Errorf("unable to get ticket: %w", err)
+			}
+			msgs := make([]*oplog.Message, 0, 1)
+			var webhookOplogMsg oplog.Message
+			returnedWebhook = w.Clone().(*Webhook)
+			rowsUpdated, err = dbw.Update(ctx, returnedWebhook, dbMask, nullFields, db.NewOplogMsg(&webhookOplogMsg), db.WithVersion(&version))
+			if err != nil {
+				return err
+			}
+			if rowsUpdated > 1 {
+				// return err, which will result in a rollback of the update
+				return fmt.Errorf("error more than 1 webhook would have been updated: %w", errors.ErrMultipleRecords)
+			}
+			if rowsUpdated == 0 {
+				return nil
+			}
+			msgs = append(msgs, &webhookOplogMsg)
+			if updateEventTypes {
+				eventTypeMsgs, err := replaceEventTypes(ctx, reader, dbw, w.PublicId, eventTypes)
+				if err != nil {
+					return err
+				}
+				msgs = append(msgs, eventTypeMsgs...)
+			}
+			if err := dbw.WriteOplogEntryWith(ctx, oplogWrapper, ticket, metadata, msgs); err != nil {
+				return fmt.Errorf("unable to write oplog: %w", err)
+			}
+			return loadEventTypes(ctx, reader, returnedWebhook)
+		},
+	)
+	if err != nil {
+		if errors.IsUniqueError(err) {
+			return nil, db.NoRowsAffected, fmt.Errorf("update webhook: webhook %s already exists in scope %s: %w", webhook.Name, w.ScopeId, errors.ErrNotUnique)
+		}
+		return nil, db.NoRowsAffected, fmt.Errorf("update webhook: %w for %s", err, webhook.PublicId)
+	}
+	returnedWebhook.CtSecret = nil
+	return returnedWebhook, rowsUpdated, nil
+}
+
+// DeleteWebhook will delete a webhook, along with its event types and
+// deliveries, from the repository.
+func (r *Repository) DeleteWebhook(ctx context.Context, publicId string, opt ...Option) (int, error) {
+	if publicId == "" {
+		return db.NoRowsAffected, fmt.Errorf("delete webhook: missing public id %w", errors.ErrInvalidParameter)
+	}
+	w := allocWebhook()
+	w.PublicId = publicId
+	if err := r.reader.LookupByPublicId(ctx, &w); err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete webhook: failed %w for %s", err, publicId)
+	}
+	oplogWrapper, err := r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeOplog)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete webhook: unable to get oplog wrapper: %w", err)
+	}
+	metadata := w.oplog(oplog.OpType_OP_TYPE_DELETE)
+
+	var rowsDeleted int
+	_, err = r.writer.DoTx(
+		ctx,
+		db.StdRetryCnt,
+		db.ExpBackoff{},
+		func(_ db.Reader, dbw db.Writer) error {
+			deleteWebhook := allocWebhook()
+			deleteWebhook.PublicId = publicId
+			rowsDeleted, err = dbw.Delete(
+				ctx,
+				&deleteWebhook,
+				db.WithOplog(oplogWrapper, metadata),
+			)
+			if err == nil && rowsDeleted > 1 {
+				// return err, which will result in a rollback of the delete
+				return errors.ErrMultipleRecords
+			}
+			return err
+		},
+	)
+	if err != nil {
+		return db.NoRowsAffected, fmt.Errorf("delete webhook: %w for %s", err, publicId)
+	}
+	return rowsDeleted, nil
+}
+
+// secretWrapper returns the wrapper to decrypt the secret of w.
+func (r *Repository) secretWrapper(ctx context.Context, w *Webhook) (wrapping.Wrapper, error) {
+	return r.kms.GetWrapper(ctx, w.ScopeId, kms.KeyPurposeDatabase, kms.WithKeyId(w.KeyId))
+}
+
+func newWebhookEventTypes(webhookId string, eventTypes []string) ([]interface{}, error) {
+	items := make([]interface{}, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		et, err := NewWebhookEventType(webhookId, t)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, et)
+	}
+	return items, nil
+}
+
+// replaceEventTypes replaces the event types of webhookId with eventTypes,
+// returning the oplog messages of the changes.
+func replaceEventTypes(ctx context.Context, reader db.Reader, w db.Writer, webhookId string, eventTypes []string) ([]*oplog.Message, error) {
+	var current []*WebhookEventType
+	if err := reader.SearchWhere(ctx, &current, "webhook_id = ?", []interface{}{webhookId}, db.WithLimit(unlimited)); err != nil {
+		return nil, fmt.Errorf("unable to get event types: %w", err)
+	}
+	var msgs []*oplog.Message
+	if len(current) > 0 {
+		deleteItems := make([]interface{}, 0, len(current))
+		for _, et := range current {
+			deleteItems = append(deleteItems, et)
+		}
+		deleteMsgs := make([]*oplog.Message, 0, len(deleteItems))
+		if _, err := w.DeleteItems(ctx, deleteItems, db.NewOplogMsgs(&deleteMsgs)); err != nil {
+			return nil, fmt.Errorf("unable to delete event types: %w", err)
+		}
+		msgs = append(msgs, deleteMsgs...)
+	}
+	createItems, err := newWebhookEventTypes(webhookId, eventTypes)
+	if err != nil {
+		return nil, err
+	}
+	createMsgs := make([]*oplog.Message, 0, len(createItems))
+	if err := w.CreateItems(ctx, createItems, db.NewOplogMsgs(&createMsgs)); err != nil {
+		return nil, fmt.Errorf("unable to create event types: %w", err)
+	}
+	return append(msgs, createMsgs...), nil
+}
+
+// loadEventTypes sets the EventTypes of each of webhooks from the repository.
+func loadEventTypes(ctx context.Context, reader db.Reader, webhooks ...*Webhook) error {
+	if len(webhooks) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(webhooks))
+	for _, w := range webhooks {
+		ids = append(ids, w.PublicId)
+	}
+	var eventTypes []*WebhookEventType
+	if err := reader.SearchWhere(ctx, &eventTypes, "webhook_id in (?)", []interface{}{ids}, db.WithLimit(unlimited)); err != nil {
+		return fmt.Errorf("load event types: %w", err)
+	}
+	byWebhook := make(map[string][]string)
+	for _, et := range eventTypes {
+		byWebhook[et.WebhookId] = append(byWebhook[et.WebhookId], et.EventType)
+	}
+	for _, w := range webhooks {
+		w.EventTypes, _ = normalizeEventTypes(byWebhook[w.PublicId])
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+)
+
+// ListDeliveries lists the deliveries of a webhook, most recent first.
+// Supports the WithState and WithLimit options.
+func (r *Repository) ListDeliveries(ctx context.Context, webhookId string, opt ...Option) ([]*Delivery, error) {
+	if webhookId == "" {
+		return nil, fmt.Errorf("list deliveries: missing webhook id: %w", errors.ErrInvalidParameter)
+	}
+	opts := getOpts(opt...)
+	where, args := "webhook_id = ?", []interface{}{webhookId}
+	if opts.withState != "" {
+		if !ValidDeliveryState(opts.withState.String()) {
+			return nil, fmt.Errorf("list deliveries: unknown state %q: %w", opts.withState, errors.ErrInvalidParameter)
+		}
+		where, args = where+" and state = ?", append(args, opts.withState.String())
+	}
+	limit := r.defaultLimit
+	if opts.withLimit != 0 {
+		// non-zero signals an override of the default limit for the repo.
+		limit = opts.withLimit
+	}
+	var deliveries []*Delivery
+	if err := r.reader.SearchWhere(ctx, &deliveries, where, args, db.WithLimit(limit), db.WithOrder("create_time desc")); err != nil {
+		return nil, fmt.Errorf("list deliveries: %w", err)
+	}
+	return deliveries, nil
+}
+
+// pendingDelivery is a delivery leased for an attempt, along with the
+// webhook it is posted to.
+type pendingDelivery struct {
+	PublicId  string
+	WebhookId string
+	EventType string
+	Payload   string
+	Attempts  uint32
+	Url       string
+	CtSecret  []byte `gorm:"column:secret"`
+	KeyId     string
+	ScopeId   string
+
+	// Secret is the decrypted secret of the webhook
+	Secret string `gorm:"-"`
+}
+
+// claimDeliveries leases up to limit pending deliveries which are due for an
+// attempt, decrypting the secrets of their webhooks. Leased deliveries are
+// not returned by other calls until lease has passed, unless their attempt
+// is recorded first. A failed attempt is recorded for each delivery whose
+// webhook secret can't be decrypted, and only the other deliveries are
+// returned, along with the number of deliveries leased.
+func (r *Repository) claimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*pendingDelivery, int, error) {
+	rows, err := r.reader.Query(ctx, claimDeliveriesQuery, []interface{}{limit, lease.Seconds()})
+	if err != nil {
+		return nil, 0, fmt.Errorf("claim deliveries: query failed: %w", err)
+	}
+	defer rows.Close()
+
+	var leased []*pendingDelivery
+	for rows.Next() {
+		var p pendingDelivery
+		if err := r.reader.ScanRows(rows, &p); err != nil {
+			return nil, 0, fmt.Errorf("claim deliveries: scan row failed: %w", err)
+		}
+		leased = append(leased, &p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("claim deliveries: %w", err)
+	}
+
+	claimed := make([]*pendingDelivery, 0, len(leased))
+	for _, p := range leased {
+		if err := r.decryptSecret(ctx, p); err != nil {
+			state, retryAfter := nextAttempt(p.Attempts + 1)
+			if err := r.recordAttempt(ctx, p.PublicId, state, retryAfter, 0, err); err != nil {
+				return nil, 0, fmt.Errorf("claim deliveries: %w", err)
+			}
+			continue
+		}
+		claimed = append(claimed, p)
+	}
+	return claimed, len(leased), nil
+}
+
+// decryptSecret sets the secret of the webhook of the delivery p.
+func (r *Repository) decryptSecret(ctx context.Context, p *pendingDelivery) error {
+	w := allocWebhook()
+	w.PublicId = p.WebhookId
+	w.ScopeId = p.ScopeId
+	w.KeyId = p.KeyId
+	w.CtSecret = p.CtSecret
+	wrapper, err := r.secretWrapper(ctx, &w)
+	if err != nil {
+		return fmt.Errorf("unable to get database wrapper for webhook %s: %w", p.WebhookId, err)
+	}
+	if err := w.decrypt(ctx, wrapper); err != nil {
+		return fmt.Errorf("webhook %s: %w", p.WebhookId, err)
+	}
+	p.Secret = w.Secret
+	return nil
+}
+
+// recordAttempt records the outcome of an attempt of a delivery. A status
+// code of zero means no response was received.
+func (r *Repository) recordAttempt(ctx context.Context, deliveryId string, state DeliveryState, retryAfter time.Duration, statusCode int, attemptErr error) error {
+	var code, lastErr interface{}
+	if statusCode != 0 {
+		code = statusCode
+	}
+	if attemptErr != nil {
+		lastErr = attemptErr.Error()
+	}
+	rowsUpdated, err := r.writer.Exec(ctx, recordDeliveryAttemptQuery, []interface{}{deliveryId, state.String(), retryAfter.Seconds(), code, lastErr})
+	if err != nil {
+		return fmt.Errorf("record delivery attempt: %w", err)
+	}
+	if rowsUpdated != 1 {
+		return fmt.Errorf("record delivery attempt: %d rows updated for %s", rowsUpdated, deliveryId)
+	}
+	return nil
+}
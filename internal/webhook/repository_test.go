@@ -0,0 +1,206 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/iam"
+	"github.com/hashicorp/boundary/internal/kms"
+	"github.com/hashicorp/boundary/internal/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository_Webhook(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	testKms := kms.TestKms(t, conn, wrapper)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	org, _ := iam.TestScopes(t, iamRepo)
+	ctx := context.Background()
+
+	repo, err := NewRepository(rw, rw, testKms)
+	require.NoError(t, err)
+
+	in, err := NewWebhook(org.PublicId, "https://hooks.example.com/a", WithName("a"), WithSecret("s3cr3t"), WithEventTypes([]string{ConnectionOpened, SessionAuthorized}))
+	require.NoError(t, err)
+	w, err := repo.CreateWebhook(ctx, in)
+	require.NoError(t, err)
+	assert.Empty(t, w.Secret)
+	assert.NotEmpty(t, w.KeyId)
+	assert.Equal(t, []string{ConnectionOpened, SessionAuthorized}, w.EventTypes)
+
+	// The secret is encrypted
+	stored := allocWebhook()
+	stored.PublicId = w.PublicId
+	require.NoError(t, rw.LookupByPublicId(ctx, &stored))
+	assert.NotContains(t, string(stored.CtSecret), "s3cr3t")
+	databaseWrapper, err := repo.secretWrapper(ctx, &stored)
+	require.NoError(t, err)
+	require.NoError(t, stored.decrypt(ctx, databaseWrapper))
+	assert.Equal(t, "s3cr3t", stored.Secret)
+
+	_, err = repo.CreateWebhook(ctx, in)
+	assert.True(t, errors.Is(err, errors.ErrNotUnique))
+
+	noEvents, err := NewWebhook(org.PublicId, "https://hooks.example.com/b", WithSecret("s"))
+	require.NoError(t, err)
+	_, err = repo.CreateWebhook(ctx, noEvents)
+	assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+
+	got, err := repo.LookupWebhook(ctx, w.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, w.EventTypes, got.EventTypes)
+	assert.Empty(t, got.CtSecret)
+
+	update := allocWebhook()
+	update.PublicId = w.PublicId
+	update.EventTypes = []string{SessionTerminated}
+	got, rows, err := repo.UpdateWebhook(ctx, &update, w.Version, []string{"EventTypes"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rows)
+	assert.Equal(t, []string{SessionTerminated}, got.EventTypes)
+	assert.Equal(t, w.Version+1, got.Version)
+
+	update.Url = "http://127.0.0.1:9999/hook"
+	update.Secret = "rotated"
+	got, rows, err = repo.UpdateWebhook(ctx, &update, got.Version, []string{"Url", "Secret"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rows)
+	assert.Equal(t, "http://127.0.0.1:9999/hook", got.Url)
+	assert.Empty(t, got.Secret)
+
+	update.Url = ""
+	_, _, err = repo.UpdateWebhook(ctx, &update, got.Version, []string{"Url"})
+	assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+
+	list, err := repo.ListWebhooks(ctx, org.PublicId)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, []string{SessionTerminated}, list[0].EventTypes)
+
+	rows, err = repo.DeleteWebhook(ctx, w.PublicId)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rows)
+	got, err = repo.LookupWebhook(ctx, w.PublicId)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestDeliverer_DeliverPending(t *testing.T) {
+	t.Parallel()
+	conn, _ := db.TestSetup(t, "postgres")
+	rw := db.New(conn)
+	wrapper := db.TestWrapper(t)
+	iamRepo := iam.TestRepo(t, conn, wrapper)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	received := map[string][]string{}
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, signature("secret", r.Header.Get(TimestampHeader), body), r.Header.Get(SignatureHeader))
+		var payload struct {
+			EventType string `json:"event_type"`
+		}
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, r.Header.Get(EventTypeHeader), payload.EventType)
+		received[r.URL.Path] = append(received[r.URL.Path], payload.EventType)
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	composedOf := session.TestSessionParams(t, conn, wrapper, iamRepo)
+	proj, err := iamRepo.LookupScope(ctx, composedOf.ScopeId)
+	require.NoError(t, err)
+	_, otherProj := iam.TestScopes(t, iamRepo)
+
+	projHook := TestWebhook(t, conn, wrapper, proj.PublicId, srv.URL+"/project", WithEventTypes([]string{SessionAuthorized, SessionActivated}))
+	TestWebhook(t, conn, wrapper, proj.ParentId, srv.URL+"/org", WithEventTypes([]string{SessionActivated}))
+	globalHook := TestWebhook(t, conn, wrapper, "global", srv.URL+"/global", WithEventTypes([]string{SessionTerminated}))
+	TestWebhook(t, conn, wrapper, otherProj.PublicId, srv.URL+"/other")
+
+	s := session.TestSession(t, conn, wrapper, composedOf)
+	session.TestState(t, conn, s.PublicId, session.StatusActive)
+
+	repo, err := NewRepository(rw, rw, kms.TestKms(t, conn, wrapper))
+	require.NoError(t, err)
+	d, err := NewDeliverer(repo)
+	require.NoError(t, err)
+
+	attempted, err := d.DeliverPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempted)
+	assert.Equal(t, map[string][]string{
+		"/project": {SessionAuthorized, SessionActivated},
+		"/org":     {SessionActivated},
+	}, received)
+
+	deliveries, err := repo.ListDeliveries(ctx, projHook.PublicId)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	for _, dl := range deliveries {
+		assert.Equal(t, DeliverySucceeded.String(), dl.State)
+		assert.Equal(t, uint32(1), dl.Attempts)
+		assert.Equal(t, int32(http.StatusOK), dl.LastStatusCode)
+	}
+
+	// Failed attempts are retried later
+	mu.Lock()
+	fail = true
+	mu.Unlock()
+	session.TestState(t, conn, s.PublicId, session.StatusTerminated)
+	attempted, err = d.DeliverPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, attempted)
+	attempted, err = d.DeliverPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, attempted)
+
+	pending, err := repo.ListDeliveries(ctx, globalHook.PublicId, WithState(DeliveryPending))
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, SessionTerminated, pending[0].EventType)
+	assert.Equal(t, uint32(1), pending[0].Attempts)
+	assert.Equal(t, int32(http.StatusServiceUnavailable), pending[0].LastStatusCode)
+	assert.NotEmpty(t, pending[0].LastError)
+
+	// A webhook whose secret can't be decrypted fails its own deliveries
+	// without holding up the others
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+	_, err = rw.Exec(ctx, "update webhook set secret = $1 where public_id = $2", []interface{}{[]byte("undecryptable"), projHook.PublicId})
+	require.NoError(t, err)
+	s = session.TestSession(t, conn, wrapper, composedOf)
+	session.TestState(t, conn, s.PublicId, session.StatusActive)
+	attempted, err = d.DeliverPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempted)
+	assert.Equal(t, []string{SessionActivated, SessionActivated}, received["/org"])
+	assert.Len(t, received["/project"], 2)
+
+	pending, err = repo.ListDeliveries(ctx, projHook.PublicId, WithState(DeliveryPending))
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	for _, dl := range pending {
+		assert.Equal(t, uint32(1), dl.Attempts)
+		assert.Zero(t, dl.LastStatusCode)
+		assert.Contains(t, dl.LastError, projHook.PublicId)
+	}
+}
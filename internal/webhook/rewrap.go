@@ -0,0 +1,38 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+)
+
+func init() {
+	kms.RegisterTableRewrapFn(DefaultWebhookTableName, kms.KeyPurposeDatabase, webhookRewrapFn)
+}
+
+// webhookRewrapFn re-encrypts the secrets of webhooks.
+func webhookRewrapFn(ctx context.Context, keyVersionIds []string, r db.Reader, w db.Writer, wrapper wrapping.Wrapper, limit int) (int, error) {
+	var webhooks []*Webhook
+	if err := r.SearchWhere(ctx, &webhooks, "key_id in (?)", []interface{}{keyVersionIds}, db.WithLimit(limit)); err != nil {
+		return 0, fmt.Errorf("rewrap webhooks: %w", err)
+	}
+	var updated int
+	for _, wh := range webhooks {
+		oldKeyId := wh.KeyId
+		if err := wh.decrypt(ctx, wrapper); err != nil {
+			return updated, fmt.Errorf("rewrap webhooks: %s: %w", wh.PublicId, err)
+		}
+		if err := wh.encrypt(ctx, wrapper); err != nil {
+			return updated, fmt.Errorf("rewrap webhooks: %s: %w", wh.PublicId, err)
+		}
+		rowsUpdated, err := w.Exec(ctx, rewrapWebhookQuery, []interface{}{wh.CtSecret, wh.KeyId, wh.PublicId, oldKeyId})
+		if err != nil {
+			return updated, fmt.Errorf("rewrap webhooks: unable to update %s: %w", wh.PublicId, err)
+		}
+		updated += rowsUpdated
+	}
+	return updated, nil
+}
@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/kms"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/jinzhu/gorm"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWebhook creates a webhook in the repository. Unless they are provided
+// with WithSecret and WithEventTypes, the webhook's secret is "secret" and it
+// is subscribed to every event type.
+func TestWebhook(t *testing.T, conn *gorm.DB, wrapper wrapping.Wrapper, scopeId, url string, opt ...Option) *Webhook {
+	t.Helper()
+	require := require.New(t)
+	opts := getOpts(opt...)
+	if opts.withSecret == "" {
+		opt = append(opt, WithSecret("secret"))
+	}
+	if len(opts.withEventTypes) == 0 {
+		opt = append(opt, WithEventTypes(EventTypes))
+	}
+	rw := db.New(conn)
+	repo, err := NewRepository(rw, rw, kms.TestKms(t, conn, wrapper))
+	require.NoError(err)
+	w, err := NewWebhook(scopeId, url, opt...)
+	require.NoError(err)
+	w, err = repo.CreateWebhook(context.Background(), w)
+	require.NoError(err)
+	return w
+}
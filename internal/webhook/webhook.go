@@ -0,0 +1,215 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/webhook/store"
+	wrapping "github.com/hashicorp/go-kms-wrapping"
+	"github.com/hashicorp/go-kms-wrapping/structwrapping"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultWebhookTableName = "webhook"
+)
+
+// The session lifecycle events a webhook can subscribe to. They are queued for
+// delivery by the database as the states of sessions and connections change.
+const (
+	SessionAuthorized = "session.authorized"
+	SessionActivated  = "session.activated"
+	SessionCanceled   = "session.canceled"
+	SessionTerminated = "session.terminated"
+	ConnectionOpened  = "connection.opened"
+	ConnectionClosed  = "connection.closed"
+)
+
+// EventTypes lists every event type a webhook can subscribe to.
+var EventTypes = []string{
+	SessionAuthorized,
+	SessionActivated,
+	SessionCanceled,
+	SessionTerminated,
+	ConnectionOpened,
+	ConnectionClosed,
+}
+
+// ValidEventType returns true if t is a known event type.
+func ValidEventType(t string) bool {
+	for _, et := range EventTypes {
+		if et == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidUrl returns an error if u isn't an absolute http or https URL.
+func ValidUrl(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https")
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("missing host")
+	}
+	return nil
+}
+
+// A Webhook is a subscription to the session lifecycle events of a scope,
+// which are posted to its URL and signed with its secret.
+type Webhook struct {
+	*store.Webhook
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*Webhook)(nil)
+var _ oplog.ReplayableMessage = (*Webhook)(nil)
+
+// NewWebhook creates a new in memory webhook. WithName, WithDescription,
+// WithSecret and WithEventTypes options are supported.
+func NewWebhook(scopeId, url string, opt ...Option) (*Webhook, error) {
+	opts := getOpts(opt...)
+	if scopeId == "" {
+		return nil, fmt.Errorf("new webhook: missing scope id: %w", errors.ErrInvalidParameter)
+	}
+	if url == "" {
+		return nil, fmt.Errorf("new webhook: missing url: %w", errors.ErrInvalidParameter)
+	}
+	if err := ValidUrl(url); err != nil {
+		return nil, fmt.Errorf("new webhook: invalid url %q: %v: %w", url, err, errors.ErrInvalidParameter)
+	}
+	eventTypes, err := normalizeEventTypes(opts.withEventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("new webhook: %w", err)
+	}
+	w := &Webhook{
+		Webhook: &store.Webhook{
+			ScopeId:     scopeId,
+			Url:         url,
+			Name:        opts.withName,
+			Description: opts.withDescription,
+			Secret:      opts.withSecret,
+			EventTypes:  eventTypes,
+		},
+	}
+	return w, nil
+}
+
+// normalizeEventTypes validates and sorts event types, removing duplicates.
+func normalizeEventTypes(in []string) ([]string, error) {
+	if len(in) == 0 {
+		return nil, nil
+	}
+	seen := make(map[string]bool, len(in))
+	out := make([]string, 0, len(in))
+	for _, t := range in {
+		if !ValidEventType(t) {
+			return nil, fmt.Errorf("unknown event type %q: %w", t, errors.ErrInvalidParameter)
+		}
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// allocWebhook will allocate a webhook
+func allocWebhook() Webhook {
+	return Webhook{
+		Webhook: &store.Webhook{},
+	}
+}
+
+// Clone creates a clone of the Webhook
+func (w *Webhook) Clone() interface{} {
+	cp := proto.Clone(w.Webhook)
+	return &Webhook{
+		Webhook: cp.(*store.Webhook),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the webhook
+// before it's written.
+func (w *Webhook) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if w.PublicId == "" {
+		return fmt.Errorf("webhook vet for write: missing public id: %w", errors.ErrInvalidParameter)
+	}
+	if w.Secret != "" {
+		return fmt.Errorf("webhook vet for write: secret is not encrypted: %w", errors.ErrInvalidParameter)
+	}
+	if opType == db.CreateOp {
+		if w.ScopeId == "" {
+			return fmt.Errorf("webhook vet for write: missing scope id: %w", errors.ErrInvalidParameter)
+		}
+		if w.Url == "" {
+			return fmt.Errorf("webhook vet for write: missing url: %w", errors.ErrInvalidParameter)
+		}
+		if len(w.CtSecret) == 0 {
+			return fmt.Errorf("webhook vet for write: missing secret: %w", errors.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (w *Webhook) TableName() string {
+	if w.tableName != "" {
+		return w.tableName
+	}
+	return DefaultWebhookTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (w *Webhook) SetTableName(n string) {
+	w.tableName = n
+}
+
+func (w *Webhook) oplog(op oplog.OpType) oplog.Metadata {
+	metadata := oplog.Metadata{
+		"resource-public-id": []string{w.PublicId},
+		"resource-type":      []string{"webhook"},
+		"op-type":            []string{op.String()},
+	}
+	if w.ScopeId != "" {
+		metadata["scope-id"] = []string{w.ScopeId}
+	}
+	return metadata
+}
+
+// encrypt the webhook's secret using the provided cipher (wrapping.Wrapper)
+// and clear the plain text secret, so it is neither written to the database
+// nor to the oplog.
+func (w *Webhook) encrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.Webhook directly
+	if err := structwrapping.WrapStruct(ctx, cipher, w.Webhook, nil); err != nil {
+		return fmt.Errorf("error encrypting webhook secret: %w", err)
+	}
+	w.KeyId = cipher.KeyID()
+	w.Secret = ""
+	return nil
+}
+
+// decrypt will decrypt the webhook's secret using the provided cipher
+// (wrapping.Wrapper)
+func (w *Webhook) decrypt(ctx context.Context, cipher wrapping.Wrapper) error {
+	// structwrapping doesn't support embedding, so we'll pass in the store.Webhook directly
+	if err := structwrapping.UnwrapStruct(ctx, cipher, w.Webhook, nil); err != nil {
+		return fmt.Errorf("error decrypting webhook secret: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/boundary/internal/db"
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/hashicorp/boundary/internal/oplog"
+	"github.com/hashicorp/boundary/internal/webhook/store"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultWebhookEventTypeTableName = "webhook_event_type"
+)
+
+// WebhookEventType is an event type a webhook is subscribed to.
+type WebhookEventType struct {
+	*store.WebhookEventType
+	tableName string `gorm:"-"`
+}
+
+var _ db.VetForWriter = (*WebhookEventType)(nil)
+var _ oplog.ReplayableMessage = (*WebhookEventType)(nil)
+
+// NewWebhookEventType creates a new in memory webhook event type. No options
+// are currently supported.
+func NewWebhookEventType(webhookId, eventType string, opt ...Option) (*WebhookEventType, error) {
+	if webhookId == "" {
+		return nil, fmt.Errorf("new webhook event type: missing webhook id: %w", errors.ErrInvalidParameter)
+	}
+	if !ValidEventType(eventType) {
+		return nil, fmt.Errorf("new webhook event type: unknown event type %q: %w", eventType, errors.ErrInvalidParameter)
+	}
+	return &WebhookEventType{
+		WebhookEventType: &store.WebhookEventType{
+			WebhookId: webhookId,
+			EventType: eventType,
+		},
+	}, nil
+}
+
+// Clone creates a clone of the webhook event type
+func (t *WebhookEventType) Clone() interface{} {
+	cp := proto.Clone(t.WebhookEventType)
+	return &WebhookEventType{
+		WebhookEventType: cp.(*store.WebhookEventType),
+	}
+}
+
+// VetForWrite implements db.VetForWrite() interface and validates the webhook
+// event type before it's written.
+func (t *WebhookEventType) VetForWrite(ctx context.Context, r db.Reader, opType db.OpType, opt ...db.Option) error {
+	if opType == db.CreateOp {
+		if t.WebhookId == "" {
+			return fmt.Errorf("webhook event type: vet for write: missing webhook id: %w", errors.ErrInvalidParameter)
+		}
+		if t.EventType == "" {
+			return fmt.Errorf("webhook event type: vet for write: missing event type: %w", errors.ErrInvalidParameter)
+		}
+	}
+	return nil
+}
+
+// TableName returns the tablename to override the default gorm table name
+func (t *WebhookEventType) TableName() string {
+	if t.tableName != "" {
+		return t.tableName
+	}
+	return DefaultWebhookEventTypeTableName
+}
+
+// SetTableName sets the tablename and satisfies the ReplayableMessage
+// interface. If the caller attempts to set the name to "" the name will be
+// reset to the default name.
+func (t *WebhookEventType) SetTableName(n string) {
+	t.tableName = n
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/hashicorp/boundary/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWebhook(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		scopeId string
+		url     string
+		opts    []Option
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			scopeId: "p_1234567890",
+			url:     "https://hooks.example.com/boundary",
+			opts:    []Option{WithName("slack"), WithSecret("secret"), WithEventTypes([]string{SessionTerminated, SessionAuthorized, SessionAuthorized})},
+			want:    []string{SessionAuthorized, SessionTerminated},
+		},
+		{
+			name:    "local-http",
+			scopeId: "global",
+			url:     "http://127.0.0.1:8080",
+		},
+		{
+			name:    "missing-scope",
+			url:     "https://hooks.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "missing-url",
+			scopeId: "global",
+			wantErr: true,
+		},
+		{
+			name:    "bad-scheme",
+			scopeId: "global",
+			url:     "ftp://hooks.example.com",
+			wantErr: true,
+		},
+		{
+			name:    "no-host",
+			scopeId: "global",
+			url:     "https:///path",
+			wantErr: true,
+		},
+		{
+			name:    "unknown-event-type",
+			scopeId: "global",
+			url:     "https://hooks.example.com",
+			opts:    []Option{WithEventTypes([]string{"session.created"})},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewWebhook(tt.scopeId, tt.url, tt.opts...)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, errors.ErrInvalidParameter))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.scopeId, got.ScopeId)
+			assert.Equal(t, tt.url, got.Url)
+			assert.Equal(t, tt.want, got.EventTypes)
+		})
+	}
+}
@@ -75,6 +75,8 @@ func main() {
 		session,
 		target,
 		user,
+		webhook,
+		worker,
 	)
 
 	fileContents, err := ioutil.ReadFile(permsFile)
@@ -505,7 +507,23 @@ var hostCatalog = &Resource{
 				"ID":   "<id>",
 				"Type": "host-catalog",
 			},
-			Actions: rudActions("a host catalog", false),
+			Actions: append(
+				rudActions("a host catalog", false),
+				&Action{
+					Name:        "import-hosts",
+					Description: "Create or update hosts in a host catalog in bulk",
+					Examples: []string{
+						"id=<id>;actions=import-hosts",
+					},
+				},
+				&Action{
+					Name:        "export-hosts",
+					Description: "Export all hosts in a host catalog",
+					Examples: []string{
+						"id=<id>;actions=export-hosts",
+					},
+				},
+			),
 		},
 	},
 }
@@ -642,7 +660,44 @@ var scope = &Resource{
 				"ID":   "<id>",
 				"Type": "scope",
 			},
-			Actions: rudActions("a scope", false),
+			Actions: append(
+				rudActions("a scope", false),
+				&Action{
+					Name:        "rotate-keys",
+					Description: "Rotate the keys of a scope and re-encrypt its data with the new keys",
+					Examples: []string{
+						"id=<id>;actions=rotate-keys",
+					},
+				},
+				&Action{
+					Name:        "rewrap-status",
+					Description: "Read the status of the re-encryption of the data of a scope",
+					Examples: []string{
+						"id=<id>;actions=rewrap-status",
+					},
+				},
+				&Action{
+					Name:        "list-key-versions",
+					Description: "List the versions of the keys of a scope",
+					Examples: []string{
+						"id=<id>;actions=list-key-versions",
+					},
+				},
+				&Action{
+					Name:        "destroy-key-version",
+					Description: "Destroy a version of a key of a scope",
+					Examples: []string{
+						"id=<id>;actions=destroy-key-version",
+					},
+				},
+				&Action{
+					Name:        "read-oplog",
+					Description: "Read the decrypted operation log of a scope",
+					Examples: []string{
+						"id=<id>;actions=read-oplog",
+					},
+				},
+			),
 		},
 	},
 }
@@ -788,3 +843,79 @@ var user = &Resource{
 		},
 	},
 }
+
+var webhook = &Resource{
+	Type:   "Webhook",
+	Scopes: append(iamScopes, infraScope...),
+	Endpoints: []*Endpoint{
+		{
+			Path: "/webhooks",
+			Params: map[string]string{
+				"Type": "webhook",
+			},
+			Actions: clActions("a webhook"),
+		},
+		{
+			Path: "/webhooks/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "webhook",
+			},
+			Actions: append(
+				rudActions("a webhook", false),
+				&Action{
+					Name:        "list-deliveries",
+					Description: "List the deliveries of a webhook",
+					Examples: []string{
+						"id=<id>;actions=list-deliveries",
+					},
+				},
+			),
+		},
+	},
+}
+
+var worker = &Resource{
+	Type:   "Worker",
+	Scopes: []string{"Global"},
+	Endpoints: []*Endpoint{
+		{
+			Path: "/workers",
+			Params: map[string]string{
+				"Type": "worker",
+			},
+			Actions: []*Action{
+				{
+					Name:        "list",
+					Description: "List workers",
+					Examples: []string{
+						"type=worker;actions=list",
+					},
+				},
+			},
+		},
+		{
+			Path: "/workers/<id>",
+			Params: map[string]string{
+				"ID":   "<id>",
+				"Type": "worker",
+			},
+			Actions: []*Action{
+				{
+					Name:        "read",
+					Description: "Read a worker",
+					Examples: []string{
+						"id=<id>;actions=read",
+					},
+				},
+				{
+					Name:        "drain",
+					Description: "Drain a worker",
+					Examples: []string{
+						"id=<id>;actions=drain",
+					},
+				},
+			},
+		},
+	},
+}
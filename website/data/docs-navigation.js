@@ -44,6 +44,7 @@ export default [
           'targets',
           'roles',
           'users',
+          'webhooks',
         ],
       },
     ],
@@ -80,6 +81,7 @@ export default [
       },
       'controller',
       'worker',
+      'events',
     ],
   },
   {
@@ -90,6 +92,7 @@ export default [
       'manage-users-groups',
       'manage-roles',
       'manage-sessions',
+      'manage-declaratively',
     ],
   },
   '---',